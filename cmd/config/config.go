@@ -5,15 +5,26 @@ import (
 	// для парсинга пути к миграциям
 	_ "github.com/golang-migrate/migrate/v4/source/file"
 	"github.com/jmoiron/sqlx"
+	"internshipPVZ/internal/domain/model"
+	"log"
 	"os"
+	"strconv"
 )
 
 // NewAppConfig конструктор
 func NewAppConfig() *AppConfig {
+	profile, err := model.NewProfile(os.Getenv("APP_PROFILE"))
+	if err != nil {
+		log.Fatalf("AppConfig initialization failed: %v", err)
+	}
 	return &AppConfig{
-		appPort:   os.Getenv("SERVER_PORT"),
-		logLevel:  os.Getenv("LOG_LEVEL"),
-		jwtSecret: os.Getenv("JWT_SECRET"),
+		profile:  profile,
+		appPort:  os.Getenv("SERVER_PORT"),
+		logLevel: os.Getenv("LOG_LEVEL"),
+		jwt: &JWT{
+			keysDir:     os.Getenv("JWT_KEYS_DIR"),
+			activeKeyID: os.Getenv("JWT_ACTIVE_KEY_ID"),
+		},
 		db: &DB{
 			host:         os.Getenv("DATABASE_HOST"),
 			port:         os.Getenv("DATABASE_PORT"),
@@ -25,10 +36,33 @@ func NewAppConfig() *AppConfig {
 		prometheus: &Prometheus{
 			port: os.Getenv("PROM_PORT"),
 		},
+		notifier: &Notifier{
+			outboxFile: os.Getenv("NOTIFIER_OUTBOX_FILE"),
+		},
 		grpc: &GRPC{
 			port: os.Getenv("GRPC_PORT"),
 		},
+		passwordHash: &PasswordHash{
+			algorithm:         os.Getenv("PASSWORD_HASH_ALGORITHM"),
+			argon2Memory:      uint32(parseUintEnv("ARGON2_MEMORY_KIB", 32)),
+			argon2Iterations:  uint32(parseUintEnv("ARGON2_ITERATIONS", 32)),
+			argon2Parallelism: uint8(parseUintEnv("ARGON2_PARALLELISM", 8)),
+			bcryptCost:        int(parseUintEnv("BCRYPT_COST", 8)),
+		},
+	}
+}
+
+// parseUintEnv читает беззнаковое число из переменной окружения, пустое значение это 0
+func parseUintEnv(name string, bitSize int) uint64 {
+	value := os.Getenv(name)
+	if value == "" {
+		return 0
+	}
+	parsed, err := strconv.ParseUint(value, 10, bitSize)
+	if err != nil {
+		log.Fatalf("AppConfig initialization failed: invalid %s: %v", name, err)
 	}
+	return parsed
 }
 
 // Prometheus конфиг
@@ -41,6 +75,26 @@ type GRPC struct {
 	port string
 }
 
+// Notifier конфиг отправки уведомлений
+type Notifier struct {
+	outboxFile string
+}
+
+// PasswordHash конфиг хэширования паролей, нулевые значения заменяются значениями по умолчанию
+type PasswordHash struct {
+	algorithm         string
+	argon2Memory      uint32
+	argon2Iterations  uint32
+	argon2Parallelism uint8
+	bcryptCost        int
+}
+
+// JWT конфиг ключей подписи
+type JWT struct {
+	keysDir     string
+	activeKeyID string
+}
+
 // DB конфиг
 type DB struct {
 	host         string
@@ -54,12 +108,20 @@ type DB struct {
 
 // AppConfig конфиг
 type AppConfig struct {
-	appPort    string
-	logLevel   string
-	db         *DB
-	grpc       *GRPC
-	jwtSecret  string
-	prometheus *Prometheus
+	profile      model.Profile
+	appPort      string
+	logLevel     string
+	db           *DB
+	grpc         *GRPC
+	jwt          *JWT
+	prometheus   *Prometheus
+	notifier     *Notifier
+	passwordHash *PasswordHash
+}
+
+// GetProfile возвращает профиль развёртывания.
+func (ac *AppConfig) GetProfile() model.Profile {
+	return ac.profile
 }
 
 // GetAppPort возвращает порт приложения.
@@ -122,7 +184,42 @@ func (ac *AppConfig) GetMigrationDir() string {
 	return ac.db.migrationDir
 }
 
-// GetJWTSecret возвращает секретный ключ JWT.
-func (ac *AppConfig) GetJWTSecret() string {
-	return ac.jwtSecret
+// GetJWTKeysDir возвращает директорию с PEM ключами подписи JWT.
+func (ac *AppConfig) GetJWTKeysDir() string {
+	return ac.jwt.keysDir
+}
+
+// GetJWTActiveKeyID возвращает kid ключа, которым подписываются новые токены.
+func (ac *AppConfig) GetJWTActiveKeyID() string {
+	return ac.jwt.activeKeyID
+}
+
+// GetNotifierOutboxFile возвращает файл, в который пишутся уведомления вместо отправки.
+func (ac *AppConfig) GetNotifierOutboxFile() string {
+	return ac.notifier.outboxFile
+}
+
+// GetPasswordHashAlgorithm возвращает алгоритм хэширования новых паролей.
+func (ac *AppConfig) GetPasswordHashAlgorithm() string {
+	return ac.passwordHash.algorithm
+}
+
+// GetArgon2Memory возвращает память argon2id в КиБ.
+func (ac *AppConfig) GetArgon2Memory() uint32 {
+	return ac.passwordHash.argon2Memory
+}
+
+// GetArgon2Iterations возвращает число итераций argon2id.
+func (ac *AppConfig) GetArgon2Iterations() uint32 {
+	return ac.passwordHash.argon2Iterations
+}
+
+// GetArgon2Parallelism возвращает число потоков argon2id.
+func (ac *AppConfig) GetArgon2Parallelism() uint8 {
+	return ac.passwordHash.argon2Parallelism
+}
+
+// GetBcryptCost возвращает cost для bcrypt.
+func (ac *AppConfig) GetBcryptCost() int {
+	return ac.passwordHash.bcryptCost
 }
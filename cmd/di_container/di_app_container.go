@@ -49,7 +49,8 @@ type AppConfig interface {
 	SetDbConnection(conn *sqlx.DB)
 	GetDbConnection() *sqlx.DB
 	GetMigrationDir() string
-	GetJWTSecret() string
+	GetJWTKeysDir() string
+	GetJWTActiveKeyID() string
 }
 
 // HttpServer интерфейс для HTTP сервера.
@@ -77,6 +78,11 @@ func ModuleConfig() fx.Option {
 		fx.Provide(fx.Annotate(
 			config.NewAppConfig,
 			fx.As(new(service.JWTConfig)),
+			fx.As(new(service.NotifierConfig)),
+			fx.As(new(service.HashConfig)),
+			fx.As(new(http.ProfileConfig)),
+			fx.As(new(grpc.ProfileConfig)),
+			fx.As(new(handlers.ProfileConfig)),
 			fx.As(new(repository.Config)),
 			fx.As(new(AppConfig)),
 			fx.As(new(service.LoggerConfig)))),
@@ -87,13 +93,25 @@ func ModuleConfig() fx.Option {
 		fx.Provide(fx.Annotate(
 			service.NewJWTService,
 			fx.As(new(usecase.JWTService)),
-			fx.As(new(http.JWTService)))),
+			fx.As(new(handlers.SigningKeyProvider)),
+			fx.As(new(http.JWTService)),
+			fx.As(new(handler.JWTService)))),
 		fx.Provide(fx.Annotate(
 			service.NewHashService,
 			fx.As(new(usecase.HashService)))),
+		fx.Provide(fx.Annotate(
+			service.NewRefreshTokenService,
+			fx.As(new(usecase.RefreshTokenService)))),
+		fx.Provide(fx.Annotate(
+			service.NewRoleAuthorizer,
+			fx.As(new(usecase.Authorizer)))),
+		fx.Provide(fx.Annotate(
+			service.NewFileNotifier,
+			fx.As(new(usecase.Notifier)))),
 		fx.Provide(fx.Annotate(
 			service.NewSlogLogger,
 			fx.As(new(usecase.Logger)),
+			fx.As(new(service.NotifierLogger)),
 			fx.As(new(handlers.Logger)))),
 	)
 }
@@ -114,13 +132,48 @@ func Module() fx.Option {
 		fx.Provide(fx.Annotate(
 			repository.NewReceptionRepo,
 			fx.As(new(repo.ReceptionRepo)))),
+		fx.Provide(fx.Annotate(
+			repository.NewTxManager,
+			fx.As(new(repo.TxManager)))),
+		fx.Provide(fx.Annotate(
+			repository.NewCityRepo,
+			fx.As(new(repo.CityRepo)))),
+		fx.Provide(fx.Annotate(
+			repository.NewProductTypeRepo,
+			fx.As(new(repo.ProductTypeRepo)))),
+		fx.Provide(fx.Annotate(
+			repository.NewPVZAssignmentRepo,
+			fx.As(new(repo.PVZAssignmentRepo)))),
+		fx.Provide(fx.Annotate(
+			repository.NewSessionRepo,
+			fx.As(new(repo.SessionRepo)))),
+		fx.Provide(fx.Annotate(
+			repository.NewLoginAttemptRepo,
+			fx.As(new(repo.LoginAttemptRepo)))),
+		fx.Provide(fx.Annotate(
+			repository.NewPasswordResetTokenRepo,
+			fx.As(new(repo.PasswordResetTokenRepo)))),
+		fx.Provide(fx.Annotate(
+			repository.NewModeratorInviteRepo,
+			fx.As(new(repo.ModeratorInviteRepo)))),
+		fx.Provide(fx.Annotate(
+			repository.NewServiceAccountRepo,
+			fx.As(new(repo.ServiceAccountRepo)))),
 		// Регистрируем юзкейсы
 		fx.Provide(fx.Annotate(
 			usecase.NewUseCaseAuth,
-			fx.As(new(handlers.AuthUseCase)))),
+			fx.As(new(handlers.AuthUseCase)),
+			fx.As(new(http.SessionChecker)),
+			fx.As(new(handler.SessionChecker)))),
 		fx.Provide(fx.Annotate(
 			usecase.NewUseCaseCreatePVZ,
 			fx.As(new(handlers.CreatePVZUseCase)))),
+		fx.Provide(fx.Annotate(
+			usecase.NewUseCaseUpdatePVZ,
+			fx.As(new(handlers.UpdatePVZUseCase)))),
+		fx.Provide(fx.Annotate(
+			usecase.NewUseCaseArchivePVZ,
+			fx.As(new(handlers.ArchivePVZUseCase)))),
 		fx.Provide(fx.Annotate(
 			usecase.NewUseCaseGetPvz,
 			fx.As(new(handlers.GetPVZUseCase)),
@@ -137,6 +190,50 @@ func Module() fx.Option {
 		fx.Provide(fx.Annotate(
 			usecase.NewUseCaseCloseReception,
 			fx.As(new(handlers.CloseReceptionUseCase)))),
+		fx.Provide(fx.Annotate(
+			usecase.NewUseCaseCancelReception,
+			fx.As(new(handlers.CancelReceptionUseCase)))),
+		fx.Provide(fx.Annotate(
+			usecase.NewUseCasePauseReception,
+			fx.As(new(handlers.PauseReceptionUseCase)))),
+		fx.Provide(fx.Annotate(
+			usecase.NewUseCaseResumeReception,
+			fx.As(new(handlers.ResumeReceptionUseCase)))),
+		fx.Provide(fx.Annotate(
+			usecase.NewUseCaseStoreProduct,
+			fx.As(new(handlers.StoreProductUseCase)))),
+		fx.Provide(fx.Annotate(
+			usecase.NewUseCaseIssueProduct,
+			fx.As(new(handlers.IssueProductUseCase)))),
+		fx.Provide(fx.Annotate(
+			usecase.NewUseCaseReturnProduct,
+			fx.As(new(handlers.ReturnProductUseCase)))),
+		fx.Provide(fx.Annotate(
+			usecase.NewUseCaseGetInventory,
+			fx.As(new(handlers.GetInventoryUseCase)))),
+		fx.Provide(fx.Annotate(
+			usecase.NewUseCaseManageCity,
+			fx.As(new(handlers.CityUseCase)),
+			fx.As(new(handler.CityUseCase)))),
+		fx.Provide(fx.Annotate(
+			usecase.NewUseCaseManageProductType,
+			fx.As(new(handlers.ProductTypeUseCase)))),
+		fx.Provide(fx.Annotate(
+			usecase.NewUseCaseManagePVZAssignment,
+			fx.As(new(handlers.PVZAssignmentUseCase)))),
+		fx.Provide(fx.Annotate(
+			usecase.NewUseCaseManagePassword,
+			fx.As(new(handlers.PasswordUseCase)))),
+		fx.Provide(fx.Annotate(
+			usecase.NewUseCaseManageUsers,
+			fx.As(new(handlers.UserUseCase)))),
+		fx.Provide(fx.Annotate(
+			usecase.NewUseCaseManageModeratorInvite,
+			fx.As(new(handlers.ModeratorInviteUseCase)))),
+		fx.Provide(fx.Annotate(
+			usecase.NewUseCaseManageServiceAccounts,
+			fx.As(new(handlers.ServiceAccountUseCase)),
+			fx.As(new(http.APIKeyChecker)))),
 		// Регистрируем HTTP хэндлеры
 		fx.Provide(fx.Annotate(
 			handlers.NewProductController,
@@ -150,6 +247,33 @@ func Module() fx.Option {
 		fx.Provide(fx.Annotate(
 			handlers.NewReceptionController,
 			fx.As(new(http.ReceptionController)))),
+		fx.Provide(fx.Annotate(
+			handlers.NewCityController,
+			fx.As(new(http.CityController)))),
+		fx.Provide(fx.Annotate(
+			handlers.NewProductTypeController,
+			fx.As(new(http.ProductTypeController)))),
+		fx.Provide(fx.Annotate(
+			handlers.NewPVZAssignmentController,
+			fx.As(new(http.PVZAssignmentController)))),
+		fx.Provide(fx.Annotate(
+			handlers.NewPasswordController,
+			fx.As(new(http.PasswordController)))),
+		fx.Provide(fx.Annotate(
+			handlers.NewUserController,
+			fx.As(new(http.UserController)))),
+		fx.Provide(fx.Annotate(
+			handlers.NewModeratorInviteController,
+			fx.As(new(http.ModeratorInviteController)))),
+		fx.Provide(fx.Annotate(
+			handlers.NewServiceAccountController,
+			fx.As(new(http.ServiceAccountController)))),
+		fx.Provide(fx.Annotate(
+			handlers.NewJWKSController,
+			fx.As(new(http.JWKSController)))),
+		fx.Provide(fx.Annotate(
+			handlers.NewOpenAPIController,
+			fx.As(new(http.OpenAPIController)))),
 		// Регистрируем HTTP сервер приложения
 		fx.Provide(fx.Annotate(
 			http.NewHTTPServer,
@@ -0,0 +1,88 @@
+// Package main это утилита проверки данных перед миграцией 018_referential_integrity.
+// Печатает приёмки без ПВЗ, продукты без приёмки и строки с неизвестными статусами,
+// с -fix удаляет висячие строки. Код выхода 1, если после работы нарушения остались
+package main
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"internshipPVZ/cmd/config"
+	"internshipPVZ/cmd/initdb"
+	"internshipPVZ/internal/domain/repository/dao"
+	"internshipPVZ/internal/repository"
+	"log"
+	"os"
+	"strings"
+	"time"
+)
+
+func main() {
+	fix := flag.Bool("fix", false, "удалить приёмки без ПВЗ и продукты без приёмки")
+	flag.Parse()
+
+	cfg := config.NewAppConfig()
+	dbConn, err := initdb.NewDBConnection(cfg)
+	if err != nil {
+		log.Fatalf(err.Error())
+	}
+	defer dbConn.Close()
+	cfg.SetDbConnection(dbConn)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Minute)
+	defer cancel()
+
+	integrityRepo := repository.NewIntegrityRepo(cfg)
+	violations, err := integrityRepo.FindViolations(ctx)
+	if err != nil {
+		log.Fatalf("failed to check data integrity: %v", err)
+	}
+	printViolations(violations)
+
+	if *fix && hasFixable(violations) {
+		var receptions, products int64
+		err = repository.NewTxManager(cfg).WithinTx(ctx, func(ctx context.Context) error {
+			var err error
+			receptions, products, err = integrityRepo.DeleteOrphans(ctx)
+			return err
+		})
+		if err != nil {
+			log.Fatalf("failed to delete orphaned rows: %v", err)
+		}
+		fmt.Printf("deleted %d receptions and %d products\n", receptions, products)
+
+		if violations, err = integrityRepo.FindViolations(ctx); err != nil {
+			log.Fatalf("failed to check data integrity: %v", err)
+		}
+		printViolations(violations)
+	}
+
+	for _, violation := range violations {
+		if violation.Count > 0 {
+			os.Exit(1)
+		}
+	}
+}
+
+func printViolations(violations []*dao.IntegrityViolation) {
+	for _, violation := range violations {
+		if violation.Count == 0 {
+			fmt.Printf("%s: ok\n", violation.Check)
+			continue
+		}
+		hint := "fix manually"
+		if violation.Fixable {
+			hint = "fixable with -fix"
+		}
+		fmt.Printf("%s: %d (%s), e.g. %s\n", violation.Check, violation.Count, hint, strings.Join(violation.SampleIDs, ", "))
+	}
+}
+
+func hasFixable(violations []*dao.IntegrityViolation) bool {
+	for _, violation := range violations {
+		if violation.Fixable && violation.Count > 0 {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,100 @@
+// Package main это утилита для ротации ключей подписи JWT.
+//
+// Ротация:
+//  1. go run ./cmd/jwtkeys -dir $JWT_KEYS_DIR — новый ключ сразу попадает в /.well-known/jwks.json,
+//     но токены им ещё не подписываются;
+//  2. после перезапуска реплик и истечения кэша JWKS (5 минут) выставить JWT_ACTIVE_KEY_ID=<новый kid>
+//     и снова перезапустить реплики;
+//  3. когда истекут токены, подписанные старым ключом (15 минут), убрать его приватную часть
+//     через -retire <старый kid> или удалить файл.
+package main
+
+import (
+	"crypto"
+	"crypto/ed25519"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/x509"
+	"encoding/pem"
+	"flag"
+	"fmt"
+	"github.com/golang-jwt/jwt/v5"
+	"log"
+	"os"
+	"path/filepath"
+	"time"
+)
+
+func main() {
+	dir := flag.String("dir", os.Getenv("JWT_KEYS_DIR"), "директория ключей подписи")
+	alg := flag.String("alg", "EdDSA", "алгоритм нового ключа: EdDSA или RS256")
+	kid := flag.String("kid", time.Now().UTC().Format("20060102-150405"), "kid нового ключа, он же имя файла")
+	retire := flag.String("retire", "", "kid ключа, у которого нужно оставить только публичную часть")
+	flag.Parse()
+
+	if *dir == "" {
+		log.Fatalf("keys directory is not set, use -dir or JWT_KEYS_DIR")
+	}
+	if *retire != "" {
+		if err := retireKey(filepath.Join(*dir, *retire+".pem")); err != nil {
+			log.Fatalf("failed to retire key %s: %v", *retire, err)
+		}
+		fmt.Println(*retire)
+		return
+	}
+	if err := generateKey(filepath.Join(*dir, *kid+".pem"), *alg); err != nil {
+		log.Fatalf("failed to generate key: %v", err)
+	}
+	fmt.Println(*kid)
+}
+
+func generateKey(path, alg string) error {
+	var privateKey crypto.PrivateKey
+	var err error
+	switch alg {
+	case jwt.SigningMethodEdDSA.Alg():
+		_, privateKey, err = ed25519.GenerateKey(rand.Reader)
+	case jwt.SigningMethodRS256.Alg():
+		privateKey, err = rsa.GenerateKey(rand.Reader, 3072)
+	default:
+		return fmt.Errorf("unsupported algorithm %q", alg)
+	}
+	if err != nil {
+		return err
+	}
+	der, err := x509.MarshalPKCS8PrivateKey(privateKey)
+	if err != nil {
+		return err
+	}
+	// O_EXCL не даёт случайно перезаписать действующий ключ
+	file, err := os.OpenFile(path, os.O_WRONLY|os.O_CREATE|os.O_EXCL, 0o600)
+	if err != nil {
+		return err
+	}
+	defer file.Close()
+	return pem.Encode(file, &pem.Block{Type: "PRIVATE KEY", Bytes: der})
+}
+
+func retireKey(path string) error {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return err
+	}
+	block, _ := pem.Decode(data)
+	if block == nil || block.Type != "PRIVATE KEY" {
+		return fmt.Errorf("%s is not a PKCS8 private key", path)
+	}
+	privateKey, err := x509.ParsePKCS8PrivateKey(block.Bytes)
+	if err != nil {
+		return err
+	}
+	signer, ok := privateKey.(crypto.Signer)
+	if !ok {
+		return fmt.Errorf("%s has unsupported key type", path)
+	}
+	der, err := x509.MarshalPKIXPublicKey(signer.Public())
+	if err != nil {
+		return err
+	}
+	return os.WriteFile(path, pem.EncodeToMemory(&pem.Block{Type: "PUBLIC KEY", Bytes: der}), 0o644)
+}
@@ -0,0 +1,47 @@
+// Package main это утилита для создания первого модератора организации.
+// Остальные модераторы регистрируются через /register по приглашениям из /moderator-invites
+package main
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"internshipPVZ/cmd/config"
+	"internshipPVZ/cmd/initdb"
+	"internshipPVZ/internal/domain/service"
+	"internshipPVZ/internal/repository"
+	"internshipPVZ/internal/usecase"
+	"log"
+	"os"
+	"time"
+)
+
+func main() {
+	email := flag.String("email", "", "email модератора")
+	password := flag.String("password", os.Getenv("MODERATOR_PASSWORD"), "пароль модератора, лучше передавать через MODERATOR_PASSWORD")
+	organizationID := flag.String("organization", "", "ID организации, по умолчанию основная сеть")
+	flag.Parse()
+
+	cfg := config.NewAppConfig()
+	dbConn, err := initdb.NewDBConnection(cfg)
+	if err != nil {
+		log.Fatalf(err.Error())
+	}
+	defer dbConn.Close()
+	cfg.SetDbConnection(dbConn)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	bootstrap := usecase.NewUseCaseBootstrapModerator(
+		repository.NewUserRepo(cfg),
+		service.NewHashService(cfg),
+		service.NewSlogLogger(cfg),
+	)
+	user, err := bootstrap.Execute(ctx, *email, *password, *organizationID)
+	if err != nil {
+		log.Fatalf("failed to create moderator: %v", err)
+	}
+	// выводим только ID, как и утилита заведения организаций
+	fmt.Println(user.Id)
+}
@@ -0,0 +1,43 @@
+// Package main это утилита для заведения организаций (партнёрских сетей ПВЗ)
+package main
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"internshipPVZ/cmd/config"
+	"internshipPVZ/cmd/initdb"
+	"internshipPVZ/internal/domain/repository/dao"
+	"internshipPVZ/internal/repository"
+	"log"
+	"strings"
+	"time"
+)
+
+func main() {
+	name := flag.String("name", "", "название организации")
+	flag.Parse()
+
+	orgName := strings.TrimSpace(*name)
+	if orgName == "" || len([]rune(orgName)) > 100 {
+		log.Fatalf("organization name must be from 1 to 100 characters")
+	}
+
+	cfg := config.NewAppConfig()
+	dbConn, err := initdb.NewDBConnection(cfg)
+	if err != nil {
+		log.Fatalf(err.Error())
+	}
+	defer dbConn.Close()
+	cfg.SetDbConnection(dbConn)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	organization := &dao.Organization{Name: orgName}
+	if err := repository.NewOrganizationRepo(cfg).Create(ctx, organization); err != nil {
+		log.Fatalf("failed to create organization: %v", err)
+	}
+	// выводим только ID, чтобы его было удобно подставить в /register
+	fmt.Println(organization.ID)
+}
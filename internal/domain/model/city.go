@@ -0,0 +1,17 @@
+// Package model это доменные сущности и типы
+package model
+
+import (
+	"internshipPVZ/internal/domain/repository/dao"
+)
+
+// City сущность города из справочника
+type City struct {
+	ID   int32
+	Name string
+}
+
+// ToDao преобразует сущность города в DAO объект.
+func (c City) ToDao() *dao.City {
+	return &dao.City{ID: c.ID, Name: c.Name}
+}
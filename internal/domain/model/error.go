@@ -17,4 +17,51 @@ const (
 	ErrInvalidProductType         string = "missing or invalid product type"
 	ErrInternal                   string = "internal server error"
 	ErrUserWithEmailAlreadyExists string = "user with email already exists"
+	ErrInvalidCityID              string = "missing or invalid city ID"
+	ErrCityNotFound               string = "city not found"
+	ErrCityAlreadyExists          string = "city already exists"
+	ErrCityIsInUse                string = "city is used by existing PVZ"
+	ErrInvalidProductTypeID       string = "missing or invalid product type ID"
+	ErrProductTypeNotFound        string = "product type not found"
+	ErrProductTypeAlreadyExists   string = "product type already exists"
+	ErrProductTypeDeactivated     string = "product type is deactivated"
+	ErrPVZNotFound                string = "PVZ not found"
+	ErrInvalidPVZName             string = "PVZ name should be at most 100 characters long"
+	ErrInvalidPVZAddress          string = "PVZ address should be at most 255 characters long"
+	ErrInvalidCoordinates         string = "latitude and longitude should be set together and be in range"
+	ErrInvalidWorkingHours        string = "working hours should have unique weekdays and HH:MM opening time before closing time"
+	ErrInvalidBarcode             string = "barcode should be a valid EAN-13 or Code128 value"
+	ErrInvalidSKU                 string = "SKU should be at most 64 characters long"
+	ErrInvalidOrderID             string = "order ID should be at most 64 characters long"
+	ErrDuplicateBarcode           string = "product with this barcode is already in the reception"
+	ErrInvalidReceptionTransition string = "reception status transition is not allowed"
+	ErrInvalidProductID           string = "missing or invalid product ID"
+	ErrProductNotFound            string = "product not found"
+	ErrInvalidProductTransition   string = "product status transition is not allowed"
+	ErrReceptionNotClosed         string = "product reception is not closed yet"
+	ErrPVZArchived                string = "PVZ is archived"
+	ErrPVZHasUnfinishedReception  string = "PVZ has an unfinished reception"
+	ErrInvalidUserID              string = "missing or invalid user ID"
+	ErrUserNotFound               string = "user not found"
+	ErrAssignmentAlreadyExists    string = "user is already assigned to PVZ"
+	ErrAssignmentNotFound         string = "user is not assigned to PVZ"
+	ErrInvalidOrganizationID      string = "missing or invalid organization ID"
+	ErrOrganizationNotFound       string = "organization not found"
+	ErrOrganizationAlreadyExists  string = "organization already exists"
+	ErrInvalidRefreshToken        string = "invalid or expired refresh token"
+	ErrTooManyLoginAttempts       string = "too many failed login attempts, try again later"
+	ErrWrongCurrentPassword       string = "current password is wrong"
+	ErrInvalidResetToken          string = "invalid, used or expired password reset token"
+	ErrUserDeactivated            string = "user is deactivated"
+	ErrCannotManageSelf           string = "moderator cannot change role or status of own account"
+	ErrModeratorInviteRequired    string = "moderator registration requires an invite code"
+	ErrInvalidInviteCode          string = "invalid, used or expired invite code"
+	ErrModeratorAlreadyExists     string = "organization already has a moderator"
+	ErrInvalidServiceAccountID    string = "missing or invalid service account ID"
+	ErrInvalidServiceAccountName  string = "service account name should be 1 to 100 characters long"
+	ErrInvalidAPIKeyScope         string = "API key scopes should be non-empty and known"
+	ErrServiceAccountNotFound     string = "service account not found"
+	ErrInvalidPVZCursor           string = "invalid PVZ list cursor"
+	ErrInvalidDateFilter          string = "startDate and endDate should be dates in YYYY-MM-DD format"
+	ErrInvalidPVZListMode         string = "PVZ list mode should be all or withReceptions"
 )
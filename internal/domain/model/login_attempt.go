@@ -0,0 +1,50 @@
+// Package model это доменные сущности и типы
+package model
+
+import "time"
+
+// LoginAttemptScope по какому признаку считаются неудачные попытки входа
+type LoginAttemptScope string
+
+// признаки для подсчёта неудачных попыток входа
+const (
+	LoginAttemptScopeEmail LoginAttemptScope = "email"
+	LoginAttemptScopeIP    LoginAttemptScope = "ip"
+)
+
+// LoginThrottlePolicy правила блокировки входа после неудачных попыток
+type LoginThrottlePolicy struct {
+	// FreeAttempts сколько неудачных попыток подряд проходят без блокировки
+	FreeAttempts int
+	// BaseLockout блокировка после первой сверх бесплатных неудачи, дальше удваивается
+	BaseLockout time.Duration
+	// MaxLockout предел блокировки
+	MaxLockout time.Duration
+	// ResetAfter через сколько без неудач счётчик начинается заново
+	ResetAfter time.Duration
+}
+
+// Lockout возвращает длительность блокировки после failures неудачных попыток подряд
+func (p LoginThrottlePolicy) Lockout(failures int) time.Duration {
+	if failures < p.FreeAttempts {
+		return 0
+	}
+	lockout := p.BaseLockout
+	for i := p.FreeAttempts; i < failures; i++ {
+		lockout *= 2
+		if lockout >= p.MaxLockout {
+			return p.MaxLockout
+		}
+	}
+	return lockout
+}
+
+// LoginLockedError вход временно заблокирован, повторить можно через RetryAfter
+type LoginLockedError struct {
+	RetryAfter time.Duration
+}
+
+// Error возвращает текст ошибки, как у остальных ошибок домена
+func (e *LoginLockedError) Error() string {
+	return ErrTooManyLoginAttempts
+}
@@ -0,0 +1,11 @@
+// Package model это доменные сущности и типы
+package model
+
+import "time"
+
+// PasswordResetNotification письмо со ссылкой на сброс пароля
+type PasswordResetNotification struct {
+	Email     string
+	Token     string
+	ExpiresAt time.Time
+}
@@ -0,0 +1,37 @@
+// Package model это доменные сущности и типы
+package model
+
+import (
+	"context"
+	"github.com/google/uuid"
+	"internshipPVZ/internal/domain/repository/dao"
+	"time"
+)
+
+// DefaultOrganizationID организация, которой принадлежат данные, созданные до появления организаций
+const DefaultOrganizationID = "5e0c9a4d-2f3b-4c8a-9b1d-7e6f5a4c3b2a"
+
+// Organization сущность организации, владеющей сетью ПВЗ
+type Organization struct {
+	ID        uuid.UUID
+	Name      string
+	CreatedAt time.Time
+}
+
+// ToDao преобразует сущность организации в DAO объект.
+func (o Organization) ToDao() *dao.Organization {
+	return &dao.Organization{ID: o.ID.String(), Name: o.Name, CreatedAt: o.CreatedAt}
+}
+
+type organizationIDKey struct{}
+
+// ContextWithOrganizationID кладёт в контекст организацию, которой ограничены запросы к репозиториям
+func ContextWithOrganizationID(ctx context.Context, organizationID string) context.Context {
+	return context.WithValue(ctx, organizationIDKey{}, organizationID)
+}
+
+// OrganizationIDFromContext достаёт организацию из контекста, ok ложно, если организации нет
+func OrganizationIDFromContext(ctx context.Context) (organizationID string, ok bool) {
+	organizationID, ok = ctx.Value(organizationIDKey{}).(string)
+	return organizationID, ok && organizationID != ""
+}
@@ -0,0 +1,68 @@
+// Package model это доменные сущности и типы
+package model
+
+// Permission действие, на которое у роли может быть право
+type Permission string
+
+// права пользователей
+const (
+	PermissionCreatePVZ             Permission = "pvz:create"
+	PermissionReadPVZ               Permission = "pvz:read"
+	PermissionUpdatePVZ             Permission = "pvz:update"
+	PermissionArchivePVZ            Permission = "pvz:archive"
+	PermissionReadInventory         Permission = "inventory:read"
+	PermissionManageAssignments     Permission = "assignments:manage"
+	PermissionOpenReception         Permission = "receptions:open"
+	PermissionCloseReception        Permission = "receptions:close"
+	PermissionPauseReception        Permission = "receptions:pause"
+	PermissionResumeReception       Permission = "receptions:resume"
+	PermissionCancelReception       Permission = "receptions:cancel"
+	PermissionAddProduct            Permission = "products:add"
+	PermissionDeleteProduct         Permission = "products:delete"
+	PermissionStoreProduct          Permission = "products:store"
+	PermissionIssueProduct          Permission = "products:issue"
+	PermissionReturnProduct         Permission = "products:return"
+	PermissionReadCities            Permission = "cities:read"
+	PermissionManageCities          Permission = "cities:manage"
+	PermissionReadProductTypes      Permission = "product_types:read"
+	PermissionManageProductTypes    Permission = "product_types:manage"
+	PermissionInviteModerator       Permission = "moderator_invites:create"
+	PermissionManageUsers           Permission = "users:manage"
+	PermissionManageServiceAccounts Permission = "service_accounts:manage"
+)
+
+// RolePermissions права каждой роли. Новая роль получает права здесь, а не в каждом юзкейсе
+func RolePermissions() map[Role][]Permission {
+	return map[Role][]Permission{
+		RoleEmployee: {
+			PermissionReadPVZ,
+			PermissionReadInventory,
+			PermissionOpenReception,
+			PermissionCloseReception,
+			PermissionPauseReception,
+			PermissionResumeReception,
+			PermissionCancelReception,
+			PermissionAddProduct,
+			PermissionDeleteProduct,
+			PermissionStoreProduct,
+			PermissionIssueProduct,
+			PermissionReturnProduct,
+			PermissionReadProductTypes,
+		},
+		RoleModerator: {
+			PermissionCreatePVZ,
+			PermissionReadPVZ,
+			PermissionUpdatePVZ,
+			PermissionArchivePVZ,
+			PermissionReadInventory,
+			PermissionManageAssignments,
+			PermissionReadCities,
+			PermissionManageCities,
+			PermissionReadProductTypes,
+			PermissionManageProductTypes,
+			PermissionInviteModerator,
+			PermissionManageUsers,
+			PermissionManageServiceAccounts,
+		},
+	}
+}
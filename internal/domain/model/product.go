@@ -2,58 +2,112 @@
 package model
 
 import (
+	"database/sql"
 	"github.com/google/uuid"
 	"internshipPVZ/internal/domain/repository/dao"
 	"time"
 )
 
-// ProductType тип продукта
-type ProductType string
+// ProductType тип продукта из справочника типов продуктов
+type ProductType struct {
+	ID     int16
+	Name   string
+	Active bool
+}
+
+// ToDao преобразует тип продукта в DAO объект.
+func (p ProductType) ToDao() *dao.ProductType {
+	return &dao.ProductType{ID: p.ID, Name: p.Name, Active: p.Active}
+}
+
+// ProductStatus статус продукта в ПВЗ
+type ProductStatus string
 
-// типы продуктов
+// статусы продуктов
 const (
-	ProductElectronics ProductType = "электроника"
-	ProductClothes     ProductType = "одежда"
-	ProductShoes       ProductType = "обувь"
-	ProductDefault     ProductType = ""
+	ProductReceived ProductStatus = "received"
+	ProductStored   ProductStatus = "stored"
+	ProductIssued   ProductStatus = "issued"
+	ProductReturned ProductStatus = "returned"
 )
 
-// Get возвращает строковое представление типа продукта.
-func (p ProductType) Get() string {
-	return string(p)
+// productTransitions допустимые переходы между статусами продукта, возврат отправителю финален
+var productTransitions = map[ProductStatus][]ProductStatus{
+	ProductReceived: {ProductStored},
+	ProductStored:   {ProductIssued, ProductReturned},
+	ProductIssued:   {ProductReturned},
+}
+
+// OnHandProductStatuses статусы продуктов, которые физически находятся в ПВЗ
+var OnHandProductStatuses = []ProductStatus{ProductReceived, ProductStored}
+
+// Get возвращает строковое представление статуса продукта.
+func (ps ProductStatus) Get() string {
+	return string(ps)
 }
 
-// ToInt возвращает целочисленное представление типа продукта.
-func (p ProductType) ToInt() int16 {
-	mapp := map[ProductType]int16{
-		ProductElectronics: 0,
-		ProductShoes:       1,
-		ProductClothes:     2,
-		ProductDefault:     3,
+// ToInt возвращает целочисленное представление статуса продукта.
+func (ps ProductStatus) ToInt() int8 {
+	mapp := map[ProductStatus]int8{
+		ProductReceived: 0,
+		ProductStored:   1,
+		ProductIssued:   2,
+		ProductReturned: 3,
 	}
-	return mapp[p]
+	return mapp[ps]
 }
 
-// NewProductType конструктор для создания типа продукта из целочисленного значения.
-func NewProductType(num int16) ProductType {
-	mapp := map[int16]ProductType{
-		0: ProductElectronics,
-		1: ProductShoes,
-		2: ProductClothes,
-		3: ProductDefault,
+// NewProductStatus конструктор для создания статуса продукта из целочисленного значения.
+func NewProductStatus(num int8) ProductStatus {
+	mapp := map[int8]ProductStatus{
+		0: ProductReceived,
+		1: ProductStored,
+		2: ProductIssued,
+		3: ProductReturned,
 	}
 	return mapp[num]
 }
 
+// CanTransitionTo проверяет, допускает ли жизненный цикл продукта переход в next.
+func (ps ProductStatus) CanTransitionTo(next ProductStatus) bool {
+	for _, allowed := range productTransitions[ps] {
+		if allowed == next {
+			return true
+		}
+	}
+	return false
+}
+
 // Product сущность продукта
 type Product struct {
 	ID          uuid.UUID
 	DateTime    time.Time
 	ReceptionID uuid.UUID
 	Type        ProductType
+	Barcode     string
+	SKU         string
+	OrderID     string
+	AddedBy     uuid.UUID
+	Status      ProductStatus
 }
 
 // ToDao преобразует сущность продукта в DAO объект.
 func (p Product) ToDao() *dao.Product {
-	return &dao.Product{DateTime: p.DateTime, Type: p.Type.ToInt(), ReceptionID: p.ReceptionID.String()}
+	return &dao.Product{
+		DateTime:    p.DateTime,
+		Type:        p.Type.ID,
+		ReceptionID: p.ReceptionID.String(),
+		Barcode:     toNullString(p.Barcode),
+		SKU:         toNullString(p.SKU),
+		OrderID:     toNullString(p.OrderID),
+		AddedBy:     toNullUUID(p.AddedBy),
+		Status:      p.Status.ToInt(),
+	}
+}
+
+func toNullString(value string) sql.NullString {
+	if value == "" {
+		return sql.NullString{}
+	}
+	return sql.NullString{String: value, Valid: true}
 }
@@ -0,0 +1,36 @@
+// Package model это доменные сущности и типы
+package model
+
+import "fmt"
+
+// Profile профиль развёртывания приложения
+type Profile string
+
+// профили развёртывания
+const (
+	ProfileDev  Profile = "dev"
+	ProfileTest Profile = "test"
+	ProfileProd Profile = "prod"
+)
+
+// NewProfile разбирает профиль из конфига, пустое значение означает prod
+func NewProfile(profile string) (Profile, error) {
+	switch Profile(profile) {
+	case ProfileDev, ProfileTest:
+		return Profile(profile), nil
+	case ProfileProd, "":
+		return ProfileProd, nil
+	default:
+		return "", fmt.Errorf("unknown profile %q, expected dev, test or prod", profile)
+	}
+}
+
+// DummyLoginEnabled доступен ли /dummyLogin, выдающий токен любой роли без пароля
+func (p Profile) DummyLoginEnabled() bool {
+	return p != ProfileProd
+}
+
+// EphemeralKeysAllowed можно ли подписывать токены временным ключом, который теряется при перезапуске
+func (p Profile) EphemeralKeysAllowed() bool {
+	return p != ProfileProd
+}
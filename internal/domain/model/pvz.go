@@ -2,57 +2,73 @@
 package model
 
 import (
+	"database/sql"
+	"errors"
 	"github.com/google/uuid"
 	"internshipPVZ/internal/domain/repository/dao"
 	"time"
 )
 
-// City тип города
-type City string
-
-// названия городов
-const (
-	CityMoscow  City = "Москва"
-	CitySPB     City = "Санкт-Петербург"
-	CityKazan   City = "Казань"
-	CityDefault City = ""
-)
-
-// Get возвращает строковое представление города.
-func (c City) Get() string {
-	return string(c)
-}
-
-// ToInt возвращает целочисленное представление города.
-func (c City) ToInt() int32 {
-	mapp := map[City]int32{
-		CitySPB:     0,
-		CityKazan:   1,
-		CityMoscow:  2,
-		CityDefault: 3,
-	}
-	return mapp[c]
-}
-
-// NewCity конструктор для создания типа города из целочисленного значения.
-func NewCity(num int32) City {
-	mapp := map[int32]City{
-		0: CitySPB,
-		1: CityKazan,
-		2: CityMoscow,
-		3: CityDefault,
-	}
-	return mapp[num]
-}
-
 // PVZ сущность пункта выдачи заказов (ПВЗ)
 type PVZ struct {
 	ID               uuid.UUID
 	RegistrationDate time.Time
 	City             City
+	Name             string
+	Address          string
+	Latitude         *float64
+	Longitude        *float64
+	WorkingHours     []OpeningHours
+	ArchivedAt       time.Time
+	ArchivedBy       uuid.UUID
 }
 
 // ToDao преобразует сущность ПВЗ в DAO объект.
 func (pvz PVZ) ToDao() *dao.PVZ {
-	return &dao.PVZ{ID: pvz.ID.String(), RegistrationDate: pvz.RegistrationDate, City: pvz.City.ToInt()}
+	workingHours := make([]dao.OpeningHours, 0, len(pvz.WorkingHours))
+	for _, hours := range pvz.WorkingHours {
+		workingHours = append(workingHours, hours.ToDao())
+	}
+	return &dao.PVZ{
+		ID:               pvz.ID.String(),
+		RegistrationDate: pvz.RegistrationDate,
+		City:             pvz.City.ID,
+		Name:             pvz.Name,
+		Address:          pvz.Address,
+		Latitude:         toNullFloat64(pvz.Latitude),
+		Longitude:        toNullFloat64(pvz.Longitude),
+		WorkingHours:     workingHours,
+		ArchivedAt:       toNullTime(pvz.ArchivedAt),
+		ArchivedBy:       toNullUUID(pvz.ArchivedBy),
+	}
+}
+
+func toNullFloat64(value *float64) sql.NullFloat64 {
+	if value == nil {
+		return sql.NullFloat64{}
+	}
+	return sql.NullFloat64{Float64: *value, Valid: true}
+}
+
+// PVZListMode как фильтр по датам приёмок влияет на состав списка ПВЗ
+type PVZListMode string
+
+// режимы списка ПВЗ
+const (
+	// PVZListAll все ПВЗ, у каждого только приёмки из диапазона
+	PVZListAll PVZListMode = "all"
+	// PVZListWithReceptions только ПВЗ, у которых есть приёмки в диапазоне
+	PVZListWithReceptions PVZListMode = "withReceptions"
+)
+
+// NewPVZListMode пустой режим означает PVZListAll
+func NewPVZListMode(mode string) (PVZListMode, error) {
+	switch PVZListMode(mode) {
+	case "", PVZListAll:
+		return PVZListAll, nil
+	case PVZListWithReceptions:
+		return PVZListWithReceptions, nil
+	default:
+		return "", errors.New(ErrInvalidPVZListMode)
+	}
 }
@@ -0,0 +1,26 @@
+// Package model это доменные сущности и типы
+package model
+
+import (
+	"github.com/google/uuid"
+	"internshipPVZ/internal/domain/repository/dao"
+	"time"
+)
+
+// PVZAssignment закрепление сотрудника за ПВЗ
+type PVZAssignment struct {
+	UserID     uuid.UUID
+	PVZID      uuid.UUID
+	AssignedBy uuid.UUID
+	AssignedAt time.Time
+}
+
+// ToDao преобразует закрепление сотрудника в DAO объект.
+func (a PVZAssignment) ToDao() *dao.PVZAssignment {
+	return &dao.PVZAssignment{
+		UserID:     a.UserID.String(),
+		PVZID:      a.PVZID.String(),
+		AssignedBy: toNullUUID(a.AssignedBy),
+		AssignedAt: a.AssignedAt,
+	}
+}
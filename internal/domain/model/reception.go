@@ -2,6 +2,7 @@
 package model
 
 import (
+	"database/sql"
 	"github.com/google/uuid"
 	"internshipPVZ/internal/domain/repository/dao"
 	"time"
@@ -14,8 +15,19 @@ type ReceptionStatus string
 const (
 	ReceptionInProgress ReceptionStatus = "in_progress"
 	ReceptionClosed     ReceptionStatus = "close"
+	ReceptionCancelled  ReceptionStatus = "cancelled"
+	ReceptionPaused     ReceptionStatus = "paused"
 )
 
+// receptionTransitions допустимые переходы между статусами приёмки, закрытая и отменённая приёмки финальны
+var receptionTransitions = map[ReceptionStatus][]ReceptionStatus{
+	ReceptionInProgress: {ReceptionClosed, ReceptionCancelled, ReceptionPaused},
+	ReceptionPaused:     {ReceptionInProgress, ReceptionCancelled},
+}
+
+// UnfinishedReceptionStatuses статусы, при которых у ПВЗ нельзя открыть новую приёмку
+var UnfinishedReceptionStatuses = []ReceptionStatus{ReceptionInProgress, ReceptionPaused}
+
 // Get возвращает строковое представление статуса приёмки.
 func (rs ReceptionStatus) Get() string {
 	return string(rs)
@@ -26,15 +38,34 @@ func (rs ReceptionStatus) ToInt() int8 {
 	mapp := map[ReceptionStatus]int8{
 		ReceptionInProgress: 0,
 		ReceptionClosed:     1,
+		ReceptionCancelled:  2,
+		ReceptionPaused:     3,
 	}
 	return mapp[rs]
 }
 
+// IsFinal возвращает true для статусов, из которых нет переходов.
+func (rs ReceptionStatus) IsFinal() bool {
+	return rs == ReceptionClosed || rs == ReceptionCancelled
+}
+
+// CanTransitionTo проверяет, допускает ли автомат статусов переход в next.
+func (rs ReceptionStatus) CanTransitionTo(next ReceptionStatus) bool {
+	for _, allowed := range receptionTransitions[rs] {
+		if allowed == next {
+			return true
+		}
+	}
+	return false
+}
+
 // NewReceptionStatus конструктор для создания статуса приёмки из целочисленного значения.
 func NewReceptionStatus(num int8) ReceptionStatus {
 	mapp := map[int8]ReceptionStatus{
 		0: ReceptionInProgress,
 		1: ReceptionClosed,
+		2: ReceptionCancelled,
+		3: ReceptionPaused,
 	}
 	return mapp[num]
 }
@@ -45,9 +76,34 @@ type Reception struct {
 	PVZID    uuid.UUID
 	DateTime time.Time
 	Status   ReceptionStatus
+	OpenedBy uuid.UUID
+	ClosedBy uuid.UUID
+	ClosedAt time.Time
 }
 
 // ToDao преобразует сущность приёмки в DAO объект.
 func (r Reception) ToDao() *dao.Reception {
-	return &dao.Reception{ID: r.ID.String(), PVZID: r.PVZID.String(), DateTime: r.DateTime, Status: r.Status.ToInt()}
+	return &dao.Reception{
+		ID:       r.ID.String(),
+		PVZID:    r.PVZID.String(),
+		DateTime: r.DateTime,
+		Status:   r.Status.ToInt(),
+		OpenedBy: toNullUUID(r.OpenedBy),
+		ClosedBy: toNullUUID(r.ClosedBy),
+		ClosedAt: toNullTime(r.ClosedAt),
+	}
+}
+
+func toNullUUID(value uuid.UUID) sql.NullString {
+	if value == uuid.Nil {
+		return sql.NullString{}
+	}
+	return sql.NullString{String: value.String(), Valid: true}
+}
+
+func toNullTime(value time.Time) sql.NullTime {
+	if value.IsZero() {
+		return sql.NullTime{}
+	}
+	return sql.NullTime{Time: value, Valid: true}
 }
@@ -0,0 +1,56 @@
+// Package model это доменные сущности и типы
+package model
+
+import "context"
+
+// APIKeyScope действие, разрешённое ключу сервисного аккаунта
+type APIKeyScope string
+
+// области действия ключей сервисных аккаунтов
+const (
+	ScopeReadPVZ        APIKeyScope = "pvz:read"
+	ScopeOpenReception  APIKeyScope = "receptions:open"
+	ScopeCloseReception APIKeyScope = "receptions:close"
+	ScopeAddProduct     APIKeyScope = "products:add"
+	ScopeDeleteProduct  APIKeyScope = "products:delete"
+)
+
+// NewAPIKeyScope конструктор области действия, ok ложно для неизвестной области
+func NewAPIKeyScope(scope string) (APIKeyScope, bool) {
+	switch s := APIKeyScope(scope); s {
+	case ScopeReadPVZ, ScopeOpenReception, ScopeCloseReception, ScopeAddProduct, ScopeDeleteProduct:
+		return s, true
+	default:
+		return "", false
+	}
+}
+
+// ServiceAccountClaims данные сервисного аккаунта, найденного по API-ключу
+type ServiceAccountClaims struct {
+	ID             string
+	OrganizationID string
+	Scopes         []APIKeyScope
+}
+
+// HasScope проверяет, выдана ли ключу область действия
+func (c ServiceAccountClaims) HasScope(scope APIKeyScope) bool {
+	for _, s := range c.Scopes {
+		if s == scope {
+			return true
+		}
+	}
+	return false
+}
+
+type serviceAccountIDKey struct{}
+
+// ContextWithServiceAccountID помечает запрос как выполняемый сервисным аккаунтом
+func ContextWithServiceAccountID(ctx context.Context, serviceAccountID string) context.Context {
+	return context.WithValue(ctx, serviceAccountIDKey{}, serviceAccountID)
+}
+
+// ServiceAccountIDFromContext достаёт сервисный аккаунт из контекста, ok ложно для запросов пользователей
+func ServiceAccountIDFromContext(ctx context.Context) (serviceAccountID string, ok bool) {
+	serviceAccountID, ok = ctx.Value(serviceAccountIDKey{}).(string)
+	return serviceAccountID, ok && serviceAccountID != ""
+}
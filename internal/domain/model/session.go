@@ -0,0 +1,11 @@
+// Package model это доменные сущности и типы
+package model
+
+import "time"
+
+// AuthTokens токены, выдаваемые при входе и при обновлении сессии
+type AuthTokens struct {
+	AccessToken      string
+	RefreshToken     string
+	RefreshExpiresAt time.Time
+}
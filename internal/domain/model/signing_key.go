@@ -0,0 +1,11 @@
+// Package model это доменные сущности и типы
+package model
+
+import "crypto"
+
+// SigningPublicKey публичная часть ключа подписи токенов, публикуется в JWKS
+type SigningPublicKey struct {
+	ID        string
+	Algorithm string
+	Key       crypto.PublicKey
+}
@@ -43,13 +43,22 @@ func NewUserRole(num int8) Role {
 
 // User сущность пользователя
 type User struct {
-	ID       uuid.UUID
-	Email    string
-	Password string
-	Role     Role
+	ID             uuid.UUID
+	Email          string
+	Password       string
+	Role           Role
+	OrganizationID uuid.UUID
 }
 
 // ToDao преобразует сущность пользователя в DAO объект.
 func (u User) ToDao() *dao.User {
-	return &dao.User{ID: u.ID.String(), Email: u.Email, Role: u.Role.ToInt(), Password: u.Password}
+	return &dao.User{ID: u.ID.String(), Email: u.Email, Role: u.Role.ToInt(), Password: u.Password, OrganizationID: u.OrganizationID.String()}
+}
+
+// UserClaims данные пользователя из токена доступа
+type UserClaims struct {
+	UserID         string
+	Role           string
+	OrganizationID string
+	SessionID      string
 }
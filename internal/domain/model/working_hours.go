@@ -0,0 +1,65 @@
+// Package model это доменные сущности и типы
+package model
+
+import (
+	"internshipPVZ/internal/domain/repository/dao"
+)
+
+// Weekday день недели в графике работы ПВЗ
+type Weekday string
+
+// дни недели
+const (
+	WeekdayMonday    Weekday = "monday"
+	WeekdayTuesday   Weekday = "tuesday"
+	WeekdayWednesday Weekday = "wednesday"
+	WeekdayThursday  Weekday = "thursday"
+	WeekdayFriday    Weekday = "friday"
+	WeekdaySaturday  Weekday = "saturday"
+	WeekdaySunday    Weekday = "sunday"
+)
+
+// Get возвращает строковое представление дня недели.
+func (w Weekday) Get() string {
+	return string(w)
+}
+
+// ToInt возвращает номер дня недели (1 - понедельник), 0 для неизвестного дня.
+func (w Weekday) ToInt() int8 {
+	mapp := map[Weekday]int8{
+		WeekdayMonday:    1,
+		WeekdayTuesday:   2,
+		WeekdayWednesday: 3,
+		WeekdayThursday:  4,
+		WeekdayFriday:    5,
+		WeekdaySaturday:  6,
+		WeekdaySunday:    7,
+	}
+	return mapp[w]
+}
+
+// NewWeekday конструктор для создания дня недели из номера.
+func NewWeekday(num int8) Weekday {
+	mapp := map[int8]Weekday{
+		1: WeekdayMonday,
+		2: WeekdayTuesday,
+		3: WeekdayWednesday,
+		4: WeekdayThursday,
+		5: WeekdayFriday,
+		6: WeekdaySaturday,
+		7: WeekdaySunday,
+	}
+	return mapp[num]
+}
+
+// OpeningHours часы работы ПВЗ в один день недели, время в формате HH:MM
+type OpeningHours struct {
+	Weekday Weekday
+	Opens   string
+	Closes  string
+}
+
+// ToDao преобразует часы работы в DAO объект.
+func (o OpeningHours) ToDao() dao.OpeningHours {
+	return dao.OpeningHours{Weekday: o.Weekday.ToInt(), Opens: o.Opens, Closes: o.Closes}
+}
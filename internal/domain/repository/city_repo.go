@@ -0,0 +1,18 @@
+// Package repository это интерфейсы репозиториев
+package repository
+
+import (
+	"context"
+	"internshipPVZ/internal/domain/repository/dao"
+)
+
+// CityRepo репозиторий
+type CityRepo interface {
+	// Create добавляет city id в dao
+	Create(ctx context.Context, city *dao.City) error
+	GetAll(ctx context.Context) ([]*dao.City, error)
+	// FindByName возвращает nil, если города нет в справочнике
+	FindByName(ctx context.Context, name string) (*dao.City, error)
+	Update(ctx context.Context, city *dao.City) error
+	Delete(ctx context.Context, id int32) error
+}
@@ -0,0 +1,8 @@
+// Package dao это dao для общения с репозиториями
+package dao
+
+// City dao
+type City struct {
+	ID   int32
+	Name string
+}
@@ -0,0 +1,11 @@
+// Package dao это dao для общения с репозиториями
+package dao
+
+// IntegrityViolation нарушение целостности, найденное одной проверкой
+type IntegrityViolation struct {
+	Check string
+	// Fixable нарушение исправляется удалением строк, остальные нужно разбирать вручную
+	Fixable   bool
+	Count     int
+	SampleIDs []string
+}
@@ -0,0 +1,16 @@
+// Package dao это dao для общения с репозиториями
+package dao
+
+import (
+	"database/sql"
+	"time"
+)
+
+// LoginAttempt dao
+type LoginAttempt struct {
+	Scope         string
+	Key           string
+	Failures      int
+	LastFailureAt time.Time
+	LockedUntil   sql.NullTime
+}
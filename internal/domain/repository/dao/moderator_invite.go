@@ -0,0 +1,18 @@
+// Package dao это dao для общения с репозиториями
+package dao
+
+import (
+	"database/sql"
+	"time"
+)
+
+// ModeratorInvite dao
+type ModeratorInvite struct {
+	ID             string
+	OrganizationID string
+	CodeHash       string
+	CreatedBy      string
+	CreatedAt      time.Time
+	ExpiresAt      time.Time
+	UsedAt         sql.NullTime
+}
@@ -0,0 +1,11 @@
+// Package dao это dao для общения с репозиториями
+package dao
+
+import "time"
+
+// Organization dao
+type Organization struct {
+	ID        string
+	Name      string
+	CreatedAt time.Time
+}
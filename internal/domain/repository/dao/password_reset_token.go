@@ -0,0 +1,17 @@
+// Package dao это dao для общения с репозиториями
+package dao
+
+import (
+	"database/sql"
+	"time"
+)
+
+// PasswordResetToken dao
+type PasswordResetToken struct {
+	ID        string
+	UserID    string
+	TokenHash string
+	CreatedAt time.Time
+	ExpiresAt time.Time
+	UsedAt    sql.NullTime
+}
@@ -2,6 +2,7 @@
 package dao
 
 import (
+	"database/sql"
 	"time"
 )
 
@@ -11,4 +12,9 @@ type Product struct {
 	DateTime    time.Time
 	ReceptionID string
 	Type        int16
+	Barcode     sql.NullString
+	SKU         sql.NullString
+	OrderID     sql.NullString
+	AddedBy     sql.NullString
+	Status      int8
 }
@@ -0,0 +1,9 @@
+// Package dao это dao для общения с репозиториями
+package dao
+
+// ProductType dao
+type ProductType struct {
+	ID     int16
+	Name   string
+	Active bool
+}
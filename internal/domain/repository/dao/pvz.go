@@ -11,17 +11,64 @@ type PVZ struct {
 	ID               string
 	RegistrationDate time.Time
 	City             int32
+	Name             string
+	Address          string
+	Latitude         sql.NullFloat64
+	Longitude        sql.NullFloat64
+	WorkingHours     []OpeningHours
+	ArchivedAt       sql.NullTime
+	ArchivedBy       sql.NullString
 }
 
-// PVZList dao
+// OpeningHours dao, хранится в pvz.working_hours как JSON
+type OpeningHours struct {
+	Weekday int8   `json:"weekday"`
+	Opens   string `json:"opens"`
+	Closes  string `json:"closes"`
+}
+
+// PVZList dao, строка ПВЗ с приёмкой и товаром. У ПВЗ без приёмок в диапазоне поля приёмки пустые
 type PVZList struct {
 	PvzID             string
 	RegistrationDate  time.Time
 	City              int32
-	ReceptionID       string
-	ReceptionDateTime time.Time
-	Status            int8
+	Name              string
+	Address           string
+	Latitude          sql.NullFloat64
+	Longitude         sql.NullFloat64
+	WorkingHours      []OpeningHours
+	ArchivedAt        sql.NullTime
+	ReceptionID       sql.NullString
+	ReceptionDateTime sql.NullTime
+	Status            sql.NullInt16
+	OpenedBy          sql.NullString
+	ClosedBy          sql.NullString
+	ClosedAt          sql.NullTime
 	ProductID         sql.NullString
 	ProductDateTime   sql.NullTime
 	Type              sql.NullInt16
+	Barcode           sql.NullString
+	SKU               sql.NullString
+	OrderID           sql.NullString
+	AddedBy           sql.NullString
+	ProductStatus     sql.NullInt16
+}
+
+// PVZCursor позиция в списке ПВЗ, упорядоченном по дате регистрации и ID
+type PVZCursor struct {
+	RegistrationDate time.Time
+	ID               string
+}
+
+// PVZListFilter параметры выборки списка ПВЗ с приёмками.
+// StartDate и EndDate отбирают приёмки, а при OnlyWithReceptions и сами ПВЗ: остаются только ПВЗ с приёмками в диапазоне.
+// Если задан After, страница начинается сразу после него и Page не учитывается
+type PVZListFilter struct {
+	StartDate          string
+	EndDate            string
+	OnlyWithReceptions bool
+	Page               int
+	Limit              int
+	After              *PVZCursor
+	IncludeArchived    bool
 }
@@ -0,0 +1,15 @@
+// Package dao это dao для общения с репозиториями
+package dao
+
+import (
+	"database/sql"
+	"time"
+)
+
+// PVZAssignment dao
+type PVZAssignment struct {
+	UserID     string
+	PVZID      string
+	AssignedBy sql.NullString
+	AssignedAt time.Time
+}
@@ -2,6 +2,7 @@
 package dao
 
 import (
+	"database/sql"
 	"time"
 )
 
@@ -11,4 +12,7 @@ type Reception struct {
 	PVZID    string
 	DateTime time.Time
 	Status   int8
+	OpenedBy sql.NullString
+	ClosedBy sql.NullString
+	ClosedAt sql.NullTime
 }
@@ -0,0 +1,19 @@
+// Package dao это dao для общения с репозиториями
+package dao
+
+import (
+	"database/sql"
+	"time"
+)
+
+// ServiceAccount dao
+type ServiceAccount struct {
+	ID             string
+	OrganizationID string
+	Name           string
+	KeyHash        string
+	Scopes         []string
+	CreatedBy      string
+	CreatedAt      time.Time
+	RevokedAt      sql.NullTime
+}
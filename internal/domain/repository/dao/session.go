@@ -0,0 +1,18 @@
+// Package dao это dao для общения с репозиториями
+package dao
+
+import (
+	"database/sql"
+	"time"
+)
+
+// Session dao
+type Session struct {
+	ID                string
+	UserID            string
+	RefreshTokenHash  string
+	PreviousTokenHash sql.NullString
+	CreatedAt         time.Time
+	ExpiresAt         time.Time
+	RevokedAt         sql.NullTime
+}
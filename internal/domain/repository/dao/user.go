@@ -1,10 +1,14 @@
 // Package dao это dao для общения с репозиториями
 package dao
 
+import "database/sql"
+
 // User dao
 type User struct {
-	ID       string
-	Email    string
-	Password string
-	Role     int8
+	ID             string
+	Email          string
+	Password       string
+	Role           int8
+	OrganizationID string
+	DeactivatedAt  sql.NullTime
 }
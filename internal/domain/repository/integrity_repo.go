@@ -0,0 +1,16 @@
+// Package repository это интерфейсы репозиториев
+package repository
+
+import (
+	"context"
+	"internshipPVZ/internal/domain/repository/dao"
+)
+
+// IntegrityRepo проверки данных перед миграцией ограничений целостности.
+// Запросы идут по всем организациям, репозиторий используется только утилитой cmd/integrity
+type IntegrityRepo interface {
+	// FindViolations возвращает результат каждой проверки, в том числе с нулевым Count
+	FindViolations(ctx context.Context) ([]*dao.IntegrityViolation, error)
+	// DeleteOrphans удаляет продукты без приёмки и приёмки без ПВЗ вместе с их продуктами
+	DeleteOrphans(ctx context.Context) (receptions, products int64, err error)
+}
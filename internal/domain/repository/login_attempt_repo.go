@@ -0,0 +1,20 @@
+// Package repository это интерфейсы репозиториев
+package repository
+
+import (
+	"context"
+	"internshipPVZ/internal/domain/repository/dao"
+	"time"
+)
+
+// LoginAttemptRepo репозиторий неудачных попыток входа, хранится в БД, чтобы счётчики были общими для всех реплик
+type LoginAttemptRepo interface {
+	// Find возвращает nil, если неудачных попыток по ключу не было
+	Find(ctx context.Context, scope, key string) (*dao.LoginAttempt, error)
+	// RegisterFailure атомарно увеличивает счётчик неудачных попыток.
+	// Счётчик, последняя неудача которого была раньше resetBefore, начинается заново
+	RegisterFailure(ctx context.Context, scope, key string, now, resetBefore time.Time) (*dao.LoginAttempt, error)
+	// Lock блокирует вход до until, более длинную блокировку не сокращает
+	Lock(ctx context.Context, scope, key string, until time.Time) error
+	Reset(ctx context.Context, scope, key string) error
+}
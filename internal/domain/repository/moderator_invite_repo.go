@@ -0,0 +1,18 @@
+// Package repository это интерфейсы репозиториев
+package repository
+
+import (
+	"context"
+	"internshipPVZ/internal/domain/repository/dao"
+	"time"
+)
+
+// ModeratorInviteRepo репозиторий одноразовых приглашений модераторов
+type ModeratorInviteRepo interface {
+	// Create добавляет invite id и организацию из контекста в dao
+	Create(ctx context.Context, invite *dao.ModeratorInvite) error
+	// Consume помечает неиспользованное и неистёкшее приглашение использованным, возвращает nil, если такого нет
+	Consume(ctx context.Context, codeHash string, now time.Time) (*dao.ModeratorInvite, error)
+	// Release снова делает приглашение доступным, если регистрация по нему не удалась
+	Release(ctx context.Context, id string) error
+}
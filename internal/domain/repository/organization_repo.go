@@ -0,0 +1,15 @@
+// Package repository это интерфейсы репозиториев
+package repository
+
+import (
+	"context"
+	"internshipPVZ/internal/domain/repository/dao"
+)
+
+// OrganizationRepo репозиторий
+type OrganizationRepo interface {
+	// Create добавляет organization id и created_at в dao
+	Create(ctx context.Context, organization *dao.Organization) error
+	// FindByID возвращает nil, если организации нет
+	FindByID(ctx context.Context, id string) (*dao.Organization, error)
+}
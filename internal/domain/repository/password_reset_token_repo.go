@@ -0,0 +1,18 @@
+// Package repository это интерфейсы репозиториев
+package repository
+
+import (
+	"context"
+	"internshipPVZ/internal/domain/repository/dao"
+	"time"
+)
+
+// PasswordResetTokenRepo репозиторий одноразовых токенов сброса пароля
+type PasswordResetTokenRepo interface {
+	// Create добавляет token id в dao
+	Create(ctx context.Context, token *dao.PasswordResetToken) error
+	// Consume помечает неиспользованный и неистёкший токен использованным, возвращает nil, если такого нет
+	Consume(ctx context.Context, tokenHash string, now time.Time) (*dao.PasswordResetToken, error)
+	// DeleteByUser удаляет все токены пользователя
+	DeleteByUser(ctx context.Context, userID string) error
+}
@@ -11,5 +11,12 @@ type ProductRepo interface {
 	// Add добавляет product id в dao
 	Add(ctx context.Context, product *dao.Product) error
 	CountProducts(ctx context.Context, receptionID string) (int, error)
+	// DeleteLastFromReception удаляет только из приёмки in_progress, иначе ErrNoActiveReception
 	DeleteLastFromReception(ctx context.Context, receptionID string) error
+	// FindByID возвращает nil, если продукта нет
+	FindByID(ctx context.Context, id string) (*dao.Product, error)
+	// ChangeStatus возвращает ErrInvalidProductTransition, если статус продукта уже не равен from
+	ChangeStatus(ctx context.Context, product *dao.Product, from int8) error
+	// GetInventory возвращает продукты ПВЗ с одним из productStatuses из приёмок с одним из receptionStatuses
+	GetInventory(ctx context.Context, pvzID string, productStatuses, receptionStatuses []int8) ([]*dao.Product, error)
 }
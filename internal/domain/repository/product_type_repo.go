@@ -0,0 +1,20 @@
+// Package repository это интерфейсы репозиториев
+package repository
+
+import (
+	"context"
+	"internshipPVZ/internal/domain/repository/dao"
+)
+
+// ProductTypeRepo репозиторий
+type ProductTypeRepo interface {
+	// Create добавляет product type id в dao
+	Create(ctx context.Context, productType *dao.ProductType) error
+	GetAll(ctx context.Context) ([]*dao.ProductType, error)
+	// FindByName возвращает nil, если типа нет в справочнике
+	FindByName(ctx context.Context, name string) (*dao.ProductType, error)
+	// Rename заполняет active в dao
+	Rename(ctx context.Context, productType *dao.ProductType) error
+	// Deactivate заполняет name в dao
+	Deactivate(ctx context.Context, productType *dao.ProductType) error
+}
@@ -0,0 +1,16 @@
+// Package repository это интерфейсы репозиториев
+package repository
+
+import (
+	"context"
+	"internshipPVZ/internal/domain/repository/dao"
+)
+
+// PVZAssignmentRepo репозиторий
+type PVZAssignmentRepo interface {
+	Assign(ctx context.Context, assignment *dao.PVZAssignment) error
+	// Unassign возвращает ErrAssignmentNotFound, если сотрудник не закреплён за ПВЗ
+	Unassign(ctx context.Context, userID, pvzID string) error
+	GetByPVZ(ctx context.Context, pvzID string) ([]*dao.PVZAssignment, error)
+	CheckIfAssigned(ctx context.Context, userID, pvzID string) (bool, error)
+}
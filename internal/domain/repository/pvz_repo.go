@@ -10,7 +10,18 @@ import (
 type PVZRepo interface {
 	// Create добавляет pvz id в dao
 	Create(ctx context.Context, pvz *dao.PVZ) error
-	GetAllWithFilter(ctx context.Context, startDate, endDate string, page, limit int) ([]*dao.PVZList, error)
-	Get(ctx context.Context) ([]*dao.PVZ, error)
+	// GetAllWithFilter и Get возвращают архивные ПВЗ, только если includeArchived.
+	// Оба упорядочены по дате регистрации и ID и отдают курсор следующей страницы, nil на последней
+	GetAllWithFilter(ctx context.Context, filter dao.PVZListFilter) ([]*dao.PVZList, *dao.PVZCursor, error)
+	// Get при limit == 0 возвращает все ПВЗ без пагинации
+	Get(ctx context.Context, after *dao.PVZCursor, limit int, includeArchived bool) ([]*dao.PVZ, *dao.PVZCursor, error)
 	CheckIfExists(ctx context.Context, id string) (bool, error)
+	// FindByID возвращает nil, если ПВЗ не найден
+	FindByID(ctx context.Context, id string) (*dao.PVZ, error)
+	// FindByIDForUpdate как FindByID, но блокирует строку ПВЗ до конца транзакции TxManager.
+	// Так приёмки и продукты одного ПВЗ меняются последовательно
+	FindByIDForUpdate(ctx context.Context, id string) (*dao.PVZ, error)
+	Update(ctx context.Context, pvz *dao.PVZ) error
+	// Archive возвращает ErrPVZArchived, если ПВЗ уже в архиве
+	Archive(ctx context.Context, pvz *dao.PVZ) error
 }
@@ -8,10 +8,14 @@ import (
 
 // ReceptionRepo репозиторий
 type ReceptionRepo interface {
-	// Create добавляет reception id в dao
+	// Create добавляет reception id в dao, возвращает ErrReceptionAlreadyOpened, если у ПВЗ уже есть незавершённая приёмка
 	Create(ctx context.Context, reception *dao.Reception) error
 	// FindOpened добавляет reception id в dao если reception существует
 	FindOpened(ctx context.Context, reception *dao.Reception) error
-	CheckIfExists(ctx context.Context, reception *dao.Reception) (bool, error)
-	Close(ctx context.Context, rec *dao.Reception) error
+	// FindLast добавляет id, date_time и status последней приёмки ПВЗ с одним из статусов, если она существует
+	FindLast(ctx context.Context, reception *dao.Reception, statuses ...int8) error
+	// ChangeStatus возвращает ErrInvalidReceptionTransition, если статус приёмки уже не равен from
+	ChangeStatus(ctx context.Context, reception *dao.Reception, from int8) error
+	// FindByID возвращает nil, если приёмки нет
+	FindByID(ctx context.Context, id string) (*dao.Reception, error)
 }
@@ -0,0 +1,20 @@
+// Package repository это интерфейсы репозиториев
+package repository
+
+import (
+	"context"
+	"internshipPVZ/internal/domain/repository/dao"
+	"time"
+)
+
+// ServiceAccountRepo репозиторий сервисных аккаунтов с API-ключами
+type ServiceAccountRepo interface {
+	// Create добавляет id и организацию из контекста в dao
+	Create(ctx context.Context, account *dao.ServiceAccount) error
+	// List возвращает сервисные аккаунты организации из контекста, включая отозванные
+	List(ctx context.Context) ([]*dao.ServiceAccount, error)
+	// Revoke отзывает ключ аккаунта организации из контекста, повторный отзыв не меняет дату
+	Revoke(ctx context.Context, id string, now time.Time) (*dao.ServiceAccount, error)
+	// FindActiveByKeyHash возвращает nil, если ключа нет или он отозван
+	FindActiveByKeyHash(ctx context.Context, keyHash string) (*dao.ServiceAccount, error)
+}
@@ -0,0 +1,22 @@
+// Package repository это интерфейсы репозиториев
+package repository
+
+import (
+	"context"
+	"internshipPVZ/internal/domain/repository/dao"
+	"time"
+)
+
+// SessionRepo репозиторий сессий пользователей
+type SessionRepo interface {
+	// Create добавляет session id в dao
+	Create(ctx context.Context, session *dao.Session) error
+	// Rotate заменяет refresh токен активной сессии, возвращает nil, если сессии с таким токеном нет
+	Rotate(ctx context.Context, tokenHash, newTokenHash string, expiresAt, now time.Time) (*dao.Session, error)
+	// RevokeByPreviousToken отзывает сессию, предыдущий refresh токен которой предъявили повторно
+	RevokeByPreviousToken(ctx context.Context, tokenHash string, now time.Time) (bool, error)
+	Revoke(ctx context.Context, sessionID string, now time.Time) error
+	// RevokeByUser отзывает все сессии пользователя, кроме exceptSessionID (пустой отзывает все)
+	RevokeByUser(ctx context.Context, userID, exceptSessionID string, now time.Time) error
+	IsActive(ctx context.Context, sessionID string) (bool, error)
+}
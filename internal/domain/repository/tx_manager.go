@@ -0,0 +1,13 @@
+// Package repository это интерфейсы репозиториев
+package repository
+
+import (
+	"context"
+)
+
+// TxManager выполняет вызовы репозиториев в одной транзакции
+type TxManager interface {
+	// WithinTx выполняет fn в транзакции: репозитории, вызванные с переданным в fn контекстом, работают в ней.
+	// Если fn вернул ошибку, транзакция откатывается, вложенный вызов присоединяется к внешней транзакции
+	WithinTx(ctx context.Context, fn func(ctx context.Context) error) error
+}
@@ -4,6 +4,7 @@ package repository
 import (
 	"context"
 	"internshipPVZ/internal/domain/repository/dao"
+	"time"
 )
 
 // UserRepo репозиторий
@@ -11,4 +12,17 @@ type UserRepo interface {
 	// Create добавляет user id в dao
 	Create(ctx context.Context, user *dao.User) error
 	FindByEmail(ctx context.Context, email string) (*dao.User, error)
+	// FindByID возвращает nil, если пользователя нет
+	FindByID(ctx context.Context, id string) (*dao.User, error)
+	UpdatePassword(ctx context.Context, id, passwordHash string) error
+	// List возвращает страницу пользователей организации из контекста, search ищет по части email
+	List(ctx context.Context, search string, page, limit int) ([]*dao.User, error)
+	// UpdateRole меняет роль пользователя организации из контекста
+	UpdateRole(ctx context.Context, id string, role int8) (*dao.User, error)
+	// Deactivate закрывает вход пользователю организации из контекста, повторная деактивация не меняет дату
+	Deactivate(ctx context.Context, id string, now time.Time) (*dao.User, error)
+	// Reactivate возвращает вход пользователю организации из контекста
+	Reactivate(ctx context.Context, id string) (*dao.User, error)
+	// CheckIfRoleExists проверяет, есть ли в организации хотя бы один пользователь с ролью
+	CheckIfRoleExists(ctx context.Context, organizationID string, role int8) (bool, error)
 }
@@ -0,0 +1,114 @@
+// Package service это вспомогательные сервисы
+package service
+
+import (
+	"crypto/rand"
+	"crypto/subtle"
+	"encoding/base64"
+	"fmt"
+	"golang.org/x/crypto/argon2"
+	"strings"
+)
+
+// параметры argon2id по умолчанию, рекомендация RFC 9106 для ограниченной памяти
+const (
+	defaultArgon2Memory      uint32 = 64 * 1024
+	defaultArgon2Iterations  uint32 = 3
+	defaultArgon2Parallelism uint8  = 2
+	argon2SaltLength                = 16
+	argon2KeyLength                 = 32
+)
+
+// Argon2Hasher хэширует пароли argon2id, хэш записывается как
+// $argon2id$v=19$m=<память в КиБ>,t=<итерации>,p=<потоки>$<соль>$<хэш>
+type Argon2Hasher struct {
+	params argon2Params
+}
+
+type argon2Params struct {
+	memory      uint32
+	iterations  uint32
+	parallelism uint8
+}
+
+// NewArgon2Hasher конструктор, нулевые параметры заменяются значениями по умолчанию
+func NewArgon2Hasher(memory, iterations uint32, parallelism uint8) *Argon2Hasher {
+	if memory == 0 {
+		memory = defaultArgon2Memory
+	}
+	if iterations == 0 {
+		iterations = defaultArgon2Iterations
+	}
+	if parallelism == 0 {
+		parallelism = defaultArgon2Parallelism
+	}
+	return &Argon2Hasher{params: argon2Params{memory: memory, iterations: iterations, parallelism: parallelism}}
+}
+
+// IDs идентификатор argon2id в PHC строке
+func (h *Argon2Hasher) IDs() []string {
+	return []string{PasswordHashArgon2id}
+}
+
+// Hash хэширует пароль со случайной солью
+func (h *Argon2Hasher) Hash(password string) (string, error) {
+	salt := make([]byte, argon2SaltLength)
+	if _, err := rand.Read(salt); err != nil {
+		return "", err
+	}
+	key := argon2.IDKey([]byte(password), salt, h.params.iterations, h.params.memory, h.params.parallelism, argon2KeyLength)
+	return fmt.Sprintf("$%s$v=%d$m=%d,t=%d,p=%d$%s$%s",
+		PasswordHashArgon2id, argon2.Version,
+		h.params.memory, h.params.iterations, h.params.parallelism,
+		base64.RawStdEncoding.EncodeToString(salt),
+		base64.RawStdEncoding.EncodeToString(key)), nil
+}
+
+// Verify пересчитывает хэш с параметрами и солью из encoded и сравнивает за постоянное время
+func (h *Argon2Hasher) Verify(password, encoded string) bool {
+	params, salt, key, err := decodeArgon2Hash(encoded)
+	if err != nil {
+		return false
+	}
+	actual := argon2.IDKey([]byte(password), salt, params.iterations, params.memory, params.parallelism, uint32(len(key)))
+	return subtle.ConstantTimeCompare(actual, key) == 1
+}
+
+// NeedsRehash сравнивает параметры хэша с текущими
+func (h *Argon2Hasher) NeedsRehash(encoded string) bool {
+	params, _, key, err := decodeArgon2Hash(encoded)
+	if err != nil {
+		return true
+	}
+	return params != h.params || len(key) != argon2KeyLength
+}
+
+func decodeArgon2Hash(encoded string) (argon2Params, []byte, []byte, error) {
+	var params argon2Params
+	parts := strings.Split(encoded, "$")
+	if len(parts) != 6 || parts[0] != "" || parts[1] != PasswordHashArgon2id {
+		return params, nil, nil, fmt.Errorf("invalid argon2id hash format")
+	}
+	var version int
+	if _, err := fmt.Sscanf(parts[2], "v=%d", &version); err != nil {
+		return params, nil, nil, err
+	}
+	if version != argon2.Version {
+		return params, nil, nil, fmt.Errorf("unsupported argon2 version %d", version)
+	}
+	if _, err := fmt.Sscanf(parts[3], "m=%d,t=%d,p=%d", &params.memory, &params.iterations, &params.parallelism); err != nil {
+		return params, nil, nil, err
+	}
+	salt, err := base64.RawStdEncoding.DecodeString(parts[4])
+	if err != nil {
+		return params, nil, nil, err
+	}
+	key, err := base64.RawStdEncoding.DecodeString(parts[5])
+	if err != nil {
+		return params, nil, nil, err
+	}
+	if len(key) == 0 || params.iterations == 0 || params.parallelism == 0 {
+		return params, nil, nil, fmt.Errorf("invalid argon2id parameters")
+	}
+	return params, salt, key, nil
+}
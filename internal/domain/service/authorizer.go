@@ -0,0 +1,28 @@
+// Package service это вспомогательные сервисы
+package service
+
+import "internshipPVZ/internal/domain/model"
+
+// RoleAuthorizer проверяет права по таблице model.RolePermissions
+type RoleAuthorizer struct {
+	permissions map[model.Role]map[model.Permission]struct{}
+}
+
+// NewRoleAuthorizer конструктор
+func NewRoleAuthorizer() *RoleAuthorizer {
+	permissions := make(map[model.Role]map[model.Permission]struct{})
+	for role, granted := range model.RolePermissions() {
+		set := make(map[model.Permission]struct{}, len(granted))
+		for _, permission := range granted {
+			set[permission] = struct{}{}
+		}
+		permissions[role] = set
+	}
+	return &RoleAuthorizer{permissions: permissions}
+}
+
+// HasPermission проверяет, есть ли у роли право; у неизвестной роли прав нет
+func (a *RoleAuthorizer) HasPermission(role model.Role, permission model.Permission) bool {
+	_, ok := a.permissions[role][permission]
+	return ok
+}
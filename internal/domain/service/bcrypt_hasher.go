@@ -0,0 +1,44 @@
+// Package service это вспомогательные сервисы
+package service
+
+import (
+	"golang.org/x/crypto/bcrypt"
+)
+
+// BcryptHasher хэширует пароли bcrypt, которым посчитаны хэши, созданные до перехода на argon2id
+type BcryptHasher struct {
+	cost int
+}
+
+// NewBcryptHasher конструктор, cost вне допустимого диапазона заменяется bcrypt.DefaultCost
+func NewBcryptHasher(cost int) *BcryptHasher {
+	if cost < bcrypt.MinCost || cost > bcrypt.MaxCost {
+		cost = bcrypt.DefaultCost
+	}
+	return &BcryptHasher{cost: cost}
+}
+
+// IDs версии bcrypt в PHC строке
+func (h *BcryptHasher) IDs() []string {
+	return []string{"2a", "2b", "2y"}
+}
+
+// Hash хэширует пароль
+func (h *BcryptHasher) Hash(password string) (string, error) {
+	hashedPassword, err := bcrypt.GenerateFromPassword([]byte(password), h.cost)
+	if err != nil {
+		return "", err
+	}
+	return string(hashedPassword), nil
+}
+
+// Verify сравнивает пароль с хэшем
+func (h *BcryptHasher) Verify(password, encoded string) bool {
+	return bcrypt.CompareHashAndPassword([]byte(encoded), []byte(password)) == nil
+}
+
+// NeedsRehash сравнивает cost хэша с текущим
+func (h *BcryptHasher) NeedsRehash(encoded string) bool {
+	cost, err := bcrypt.Cost([]byte(encoded))
+	return err != nil || cost != h.cost
+}
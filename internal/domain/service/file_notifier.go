@@ -0,0 +1,76 @@
+// Package service это вспомогательные сервисы
+package service
+
+import (
+	"context"
+	"encoding/json"
+	"internshipPVZ/internal/domain/model"
+	"os"
+	"sync"
+	"time"
+)
+
+// NotifierConfig интерфейс конфигурации отправки уведомлений
+type NotifierConfig interface {
+	GetNotifierOutboxFile() string
+}
+
+// NotifierLogger логгер, в который пишутся уведомления, если файл не задан
+type NotifierLogger interface {
+	Info(msg string, args ...any)
+}
+
+// FileNotifier вместо отправки писем дописывает уведомления строками JSON в файл,
+// а без файла пишет их в лог. Подходит для разработки и стендов без SMTP сервера
+type FileNotifier struct {
+	outboxFile string
+	logger     NotifierLogger
+	mu         sync.Mutex
+}
+
+// fileNotification строка файла с уведомлениями
+type fileNotification struct {
+	Type      string    `json:"type"`
+	Email     string    `json:"email"`
+	Token     string    `json:"token"`
+	ExpiresAt time.Time `json:"expires_at"`
+}
+
+// NewFileNotifier конструктор для создания нового экземпляра FileNotifier
+func NewFileNotifier(config NotifierConfig, logger NotifierLogger) *FileNotifier {
+	return &FileNotifier{outboxFile: config.GetNotifierOutboxFile(), logger: logger}
+}
+
+// SendPasswordReset доставляет токен сброса пароля
+func (n *FileNotifier) SendPasswordReset(_ context.Context, notification model.PasswordResetNotification) error {
+	if n.outboxFile == "" {
+		n.logger.Info("password reset requested",
+			"notifier", "FileNotifier",
+			"email", notification.Email,
+			"token", notification.Token,
+			"expires_at", notification.ExpiresAt)
+		return nil
+	}
+
+	line, err := json.Marshal(fileNotification{
+		Type:      "password_reset",
+		Email:     notification.Email,
+		Token:     notification.Token,
+		ExpiresAt: notification.ExpiresAt,
+	})
+	if err != nil {
+		return err
+	}
+
+	n.mu.Lock()
+	defer n.mu.Unlock()
+	file, err := os.OpenFile(n.outboxFile, os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0o600)
+	if err != nil {
+		return err
+	}
+	if _, err := file.Write(append(line, '\n')); err != nil {
+		file.Close()
+		return err
+	}
+	return file.Close()
+}
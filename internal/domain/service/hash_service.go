@@ -2,30 +2,107 @@
 package service
 
 import (
-	"golang.org/x/crypto/bcrypt"
+	"fmt"
+	"log"
+	"strings"
 )
 
-// HashService для паролей
-type HashService struct{}
+// алгоритмы хэширования паролей, значения PASSWORD_HASH_ALGORITHM
+const (
+	PasswordHashArgon2id = "argon2id"
+	PasswordHashBcrypt   = "bcrypt"
+)
+
+// HashConfig параметры хэширования паролей, нулевые значения заменяются значениями по умолчанию
+type HashConfig interface {
+	GetPasswordHashAlgorithm() string
+	GetArgon2Memory() uint32
+	GetArgon2Iterations() uint32
+	GetArgon2Parallelism() uint8
+	GetBcryptCost() int
+}
+
+// PasswordHasher алгоритм хэширования паролей.
+// Хэши хранятся в формате PHC ($<id>$...), поэтому хэши разных алгоритмов лежат в одной колонке
+type PasswordHasher interface {
+	// IDs идентификаторы алгоритма в PHC строке, по ним выбирается алгоритм для проверки
+	IDs() []string
+	Hash(password string) (string, error)
+	Verify(password, encoded string) bool
+	// NeedsRehash проверяет, отличаются ли параметры хэша от текущих
+	NeedsRehash(encoded string) bool
+}
+
+// HashService для паролей: новые хэши считает текущим алгоритмом, проверяет хэши всех известных
+type HashService struct {
+	current PasswordHasher
+	hashers map[string]PasswordHasher
+}
 
 // NewHashService конструктор для создания нового экземпляра HashService.
-func NewHashService() HashService {
-	return HashService{}
+func NewHashService(config HashConfig) *HashService {
+	if config == nil {
+		log.Fatalf("HashService initialization failed: config is nil")
+	}
+	argon2id := NewArgon2Hasher(config.GetArgon2Memory(), config.GetArgon2Iterations(), config.GetArgon2Parallelism())
+	bcrypt := NewBcryptHasher(config.GetBcryptCost())
+
+	var current PasswordHasher
+	switch config.GetPasswordHashAlgorithm() {
+	case "", PasswordHashArgon2id:
+		current = argon2id
+	case PasswordHashBcrypt:
+		current = bcrypt
+	default:
+		log.Fatalf("HashService initialization failed: unknown password hash algorithm %q", config.GetPasswordHashAlgorithm())
+	}
+	return newHashService(current, argon2id, bcrypt)
 }
 
-// HashPassword хеширует пароль.
-// Возвращает хешированный пароль и ошибку, если таковая возникла.
-func (hs HashService) HashPassword(password string) (string, error) {
-	hashedPassword, err := bcrypt.GenerateFromPassword([]byte(password), bcrypt.DefaultCost)
-	if err != nil {
-		return "", err
+func newHashService(current PasswordHasher, hashers ...PasswordHasher) *HashService {
+	hs := &HashService{current: current, hashers: make(map[string]PasswordHasher)}
+	for _, hasher := range hashers {
+		for _, id := range hasher.IDs() {
+			hs.hashers[id] = hasher
+		}
 	}
-	return string(hashedPassword), nil
+	return hs
+}
+
+// HashPassword хеширует пароль текущим алгоритмом.
+// Возвращает хешированный пароль и ошибку, если таковая возникла.
+func (hs *HashService) HashPassword(password string) (string, error) {
+	return hs.current.Hash(password)
 }
 
 // HashAndComparePassword хеширует пароль и сравнивает его с хешированным паролем.
 // Возвращает true, если пароли совпадают, и false в противном случае.
-func (hs HashService) HashAndComparePassword(password, hash string) bool {
-	err := bcrypt.CompareHashAndPassword([]byte(hash), []byte(password))
-	return err == nil
+func (hs *HashService) HashAndComparePassword(password, hash string) bool {
+	hasher, err := hs.hasherFor(hash)
+	if err != nil {
+		return false
+	}
+	return hasher.Verify(password, hash)
+}
+
+// NeedsRehash сообщает, что хэш посчитан другим алгоритмом или с другими параметрами и его стоит пересчитать
+func (hs *HashService) NeedsRehash(hash string) bool {
+	hasher, err := hs.hasherFor(hash)
+	if err != nil {
+		return true
+	}
+	return hasher != hs.current || hasher.NeedsRehash(hash)
+}
+
+// hasherFor выбирает алгоритм по идентификатору из PHC строки
+func (hs *HashService) hasherFor(hash string) (PasswordHasher, error) {
+	parts := strings.SplitN(hash, "$", 3)
+	if len(parts) < 3 || parts[0] != "" {
+		return nil, fmt.Errorf("hash is not in PHC format")
+	}
+	hasher, ok := hs.hashers[parts[1]]
+	if !ok {
+		return nil, fmt.Errorf("unknown password hash algorithm %q", parts[1])
+	}
+	return hasher, nil
 }
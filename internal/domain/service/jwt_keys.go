@@ -0,0 +1,129 @@
+// Package service это вспомогательные сервисы
+package service
+
+import (
+	"crypto"
+	"crypto/ed25519"
+	"crypto/rand"
+	"crypto/rsa"
+	"encoding/hex"
+	"encoding/pem"
+	"errors"
+	"fmt"
+	"github.com/golang-jwt/jwt/v5"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+// jwtKeyFileExt расширение файлов ключей, имя файла без расширения служит kid
+const jwtKeyFileExt = ".pem"
+
+// signingKey ключ подписи JWT. У выведенных из оборота ключей может не быть приватной части,
+// тогда ключ только проверяет подпись ещё не истёкших токенов
+type signingKey struct {
+	id         string
+	method     jwt.SigningMethod
+	privateKey crypto.Signer
+	publicKey  crypto.PublicKey
+}
+
+// keySet ключи подписи, active подписывает новые токены
+type keySet struct {
+	active *signingKey
+	keys   map[string]*signingKey
+	order  []string
+}
+
+// loadKeySet читает все PEM ключи из директории, активный ключ должен быть среди них и иметь приватную часть
+func loadKeySet(dir, activeKeyID string) (*keySet, error) {
+	paths, err := filepath.Glob(filepath.Join(dir, "*"+jwtKeyFileExt))
+	if err != nil {
+		return nil, err
+	}
+	set := &keySet{keys: make(map[string]*signingKey, len(paths))}
+	for _, path := range paths {
+		data, err := os.ReadFile(path)
+		if err != nil {
+			return nil, err
+		}
+		id := strings.TrimSuffix(filepath.Base(path), jwtKeyFileExt)
+		key, err := parsePEMKey(id, data)
+		if err != nil {
+			return nil, fmt.Errorf("key %s: %w", id, err)
+		}
+		set.keys[id] = key
+		set.order = append(set.order, id)
+	}
+	active, ok := set.keys[activeKeyID]
+	if !ok {
+		return nil, fmt.Errorf("active key %q not found in %s", activeKeyID, dir)
+	}
+	if active.privateKey == nil {
+		return nil, fmt.Errorf("active key %q has no private part", activeKeyID)
+	}
+	set.active = active
+	return set, nil
+}
+
+// newEphemeralKeySet создаёт набор из одного случайного Ed25519 ключа, токены не переживут перезапуск
+func newEphemeralKeySet() (*keySet, error) {
+	publicKey, privateKey, err := ed25519.GenerateKey(rand.Reader)
+	if err != nil {
+		return nil, err
+	}
+	suffix := make([]byte, 4)
+	if _, err = rand.Read(suffix); err != nil {
+		return nil, err
+	}
+	key := &signingKey{
+		id:         "ephemeral-" + hex.EncodeToString(suffix),
+		method:     jwt.SigningMethodEdDSA,
+		privateKey: privateKey,
+		publicKey:  publicKey,
+	}
+	return &keySet{active: key, keys: map[string]*signingKey{key.id: key}, order: []string{key.id}}, nil
+}
+
+// parsePEMKey разбирает приватный или публичный RSA/Ed25519 ключ, алгоритм определяется типом ключа
+func parsePEMKey(id string, data []byte) (*signingKey, error) {
+	block, _ := pem.Decode(data)
+	if block == nil {
+		return nil, errors.New("no PEM block found")
+	}
+	key := &signingKey{id: id}
+	if strings.Contains(block.Type, "PRIVATE KEY") {
+		if rsaKey, err := jwt.ParseRSAPrivateKeyFromPEM(data); err == nil {
+			key.privateKey = rsaKey
+		} else if edKey, err := jwt.ParseEdPrivateKeyFromPEM(data); err == nil {
+			signer, ok := edKey.(ed25519.PrivateKey)
+			if !ok {
+				return nil, errors.New("unsupported Edwards curve private key, expected Ed25519")
+			}
+			key.privateKey = signer
+		} else {
+			return nil, errors.New("unsupported private key, expected RSA or Ed25519")
+		}
+		key.publicKey = key.privateKey.Public()
+	} else {
+		if rsaKey, err := jwt.ParseRSAPublicKeyFromPEM(data); err == nil {
+			key.publicKey = rsaKey
+		} else if edKey, err := jwt.ParseEdPublicKeyFromPEM(data); err == nil {
+			key.publicKey = edKey
+		} else {
+			return nil, errors.New("unsupported public key, expected RSA or Ed25519")
+		}
+	}
+	switch publicKey := key.publicKey.(type) {
+	case *rsa.PublicKey:
+		if publicKey.N.BitLen() < 2048 {
+			return nil, errors.New("RSA key should be at least 2048 bits")
+		}
+		key.method = jwt.SigningMethodRS256
+	case ed25519.PublicKey:
+		key.method = jwt.SigningMethodEdDSA
+	default:
+		return nil, errors.New("unsupported key type")
+	}
+	return key, nil
+}
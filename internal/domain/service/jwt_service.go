@@ -2,63 +2,120 @@
 package service
 
 import (
+	"errors"
+	"fmt"
 	"github.com/golang-jwt/jwt/v5"
+	"internshipPVZ/internal/domain/model"
 	"log"
 	"time"
 )
 
 // CustomClaims данные из токена
 type CustomClaims struct {
-	Role string `json:"role"`
+	UserID         string `json:"user_id"`
+	Role           string `json:"role"`
+	OrganizationID string `json:"organization_id"`
+	SessionID      string `json:"sid,omitempty"`
 	jwt.RegisteredClaims
 }
 
-// JWTService для создания и валидации токенов
+// accessTokenTTL время жизни токена доступа, дальше его нужно обновить по refresh токену
+const accessTokenTTL = 15 * time.Minute
+
+// JWTService для создания и валидации токенов.
+// Токены подписываются асимметричным ключом (RS256 или EdDSA), в заголовке kid указывает ключ подписи
 type JWTService struct {
-	secretKey string
+	keys *keySet
 }
 
 // JWTConfig интерфейс конфигурации JWT
 type JWTConfig interface {
-	GetJWTSecret() string
+	GetProfile() model.Profile
+	GetJWTKeysDir() string
+	GetJWTActiveKeyID() string
 }
 
 // NewJWTService конструктор для создания нового экземпляра JWTService
 func NewJWTService(jwtConfig JWTConfig) JWTService {
-	secretKey := jwtConfig.GetJWTSecret()
-	if secretKey == "" {
-		log.Fatalf("JWTService initialization failed: secret key is empty")
+	if jwtConfig.GetJWTKeysDir() == "" {
+		if !jwtConfig.GetProfile().EphemeralKeysAllowed() {
+			log.Fatalf("JWTService initialization failed: JWT_KEYS_DIR is required in %s profile", jwtConfig.GetProfile())
+		}
+		keys, err := newEphemeralKeySet()
+		if err != nil {
+			log.Fatalf("JWTService initialization failed: %v", err)
+		}
+		log.Printf("JWTService: JWT_KEYS_DIR is empty, tokens are signed with ephemeral key %s", keys.active.id)
+		return JWTService{keys: keys}
+	}
+	keys, err := loadKeySet(jwtConfig.GetJWTKeysDir(), jwtConfig.GetJWTActiveKeyID())
+	if err != nil {
+		log.Fatalf("JWTService initialization failed: %v", err)
 	}
-	return JWTService{secretKey: secretKey}
+	return JWTService{keys: keys}
 }
 
-// GenerateToken генерирует JWT токен с указанной ролью
-func (s JWTService) GenerateToken(role string) (string, error) {
+// GenerateToken генерирует JWT токен с указанными ID пользователя, ролью, организацией и сессией.
+// Пустой sessionID означает токен без сессии, его нельзя отозвать
+func (s JWTService) GenerateToken(userID, role, organizationID, sessionID string) (string, error) {
 	claims := &CustomClaims{
-		Role: role,
+		UserID:         userID,
+		Role:           role,
+		OrganizationID: organizationID,
+		SessionID:      sessionID,
 		RegisteredClaims: jwt.RegisteredClaims{
-			ExpiresAt: jwt.NewNumericDate(time.Now().Add(24 * time.Hour)),
+			ExpiresAt: jwt.NewNumericDate(time.Now().Add(accessTokenTTL)),
 		},
 	}
-	token := jwt.NewWithClaims(jwt.SigningMethodHS256, claims)
-	return token.SignedString([]byte(s.secretKey))
+	token := jwt.NewWithClaims(s.keys.active.method, claims)
+	token.Header["kid"] = s.keys.active.id
+	return token.SignedString(s.keys.active.privateKey)
 }
 
-// ValidateToken валидирует JWT токен
+// ValidateToken валидирует JWT токен ключом из заголовка kid
 func (s JWTService) ValidateToken(tokenString string) (*jwt.Token, error) {
-	return jwt.ParseWithClaims(tokenString, &CustomClaims{}, func(_ *jwt.Token) (interface{}, error) {
-		return []byte(s.secretKey), nil
-	})
+	return jwt.ParseWithClaims(tokenString, &CustomClaims{}, s.verificationKey,
+		jwt.WithValidMethods([]string{jwt.SigningMethodRS256.Alg(), jwt.SigningMethodEdDSA.Alg()}))
 }
 
-// GetClaims извлекает данные из JWT токена
-func (s JWTService) GetClaims(tokenString string) (string, error) {
+// GetClaims извлекает данные из JWT токена, токен без организации считается невалидным
+func (s JWTService) GetClaims(tokenString string) (*model.UserClaims, error) {
 	token, err := s.ValidateToken(tokenString)
 	if err != nil {
-		return "", err
+		return nil, err
 	}
 	if claims, ok := token.Claims.(*CustomClaims); ok && token.Valid {
-		return claims.Role, nil
+		if claims.OrganizationID == "" {
+			return nil, errors.New("invalid token claims: organization_id is missing")
+		}
+		return &model.UserClaims{
+			UserID:         claims.UserID,
+			Role:           claims.Role,
+			OrganizationID: claims.OrganizationID,
+			SessionID:      claims.SessionID,
+		}, nil
+	}
+	return nil, errors.New("invalid token claims")
+}
+
+// PublicKeys возвращает публичные части всех ключей, которыми могут быть подписаны действующие токены
+func (s JWTService) PublicKeys() []model.SigningPublicKey {
+	keys := make([]model.SigningPublicKey, 0, len(s.keys.order))
+	for _, id := range s.keys.order {
+		key := s.keys.keys[id]
+		keys = append(keys, model.SigningPublicKey{ID: key.id, Algorithm: key.method.Alg(), Key: key.publicKey})
+	}
+	return keys
+}
+
+func (s JWTService) verificationKey(token *jwt.Token) (interface{}, error) {
+	id, _ := token.Header["kid"].(string)
+	key, ok := s.keys.keys[id]
+	if !ok {
+		return nil, fmt.Errorf("unknown signing key %q", id)
+	}
+	if token.Method.Alg() != key.method.Alg() {
+		return nil, fmt.Errorf("signing method %s does not match key %q", token.Method.Alg(), id)
 	}
-	return "", err
+	return key.publicKey, nil
 }
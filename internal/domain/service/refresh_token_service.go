@@ -0,0 +1,36 @@
+// Package service это вспомогательные сервисы
+package service
+
+import (
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+)
+
+// refreshTokenSize размер refresh токена в байтах до кодирования
+const refreshTokenSize = 32
+
+// RefreshTokenService для выпуска refresh токенов
+type RefreshTokenService struct{}
+
+// NewRefreshTokenService конструктор для создания нового экземпляра RefreshTokenService
+func NewRefreshTokenService() RefreshTokenService {
+	return RefreshTokenService{}
+}
+
+// GenerateRefreshToken генерирует случайный непрозрачный refresh токен
+func (s RefreshTokenService) GenerateRefreshToken() (string, error) {
+	buf := make([]byte, refreshTokenSize)
+	if _, err := rand.Read(buf); err != nil {
+		return "", err
+	}
+	return base64.RawURLEncoding.EncodeToString(buf), nil
+}
+
+// HashRefreshToken возвращает хэш refresh токена, в БД хранится только он.
+// Токен случайный и длинный, поэтому медленный хэш как для паролей не нужен
+func (s RefreshTokenService) HashRefreshToken(token string) string {
+	sum := sha256.Sum256([]byte(token))
+	return hex.EncodeToString(sum[:])
+}
@@ -0,0 +1,72 @@
+// Package handler это хэндлер grpc
+package handler
+
+import (
+	"context"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+	"internshipPVZ/internal/domain/model"
+	"strings"
+)
+
+type userRoleKey struct{}
+
+// JWTService токены
+type JWTService interface {
+	GetClaims(tokenString string) (*model.UserClaims, error)
+}
+
+// SessionChecker проверка отзыва сессий
+type SessionChecker interface {
+	IsSessionActive(ctx context.Context, sessionID string) (bool, error)
+}
+
+// AuthInterceptor проверяет токен из метаданных "authorization" и кладёт роль и организацию в контекст.
+// Токены отозванных сессий отклоняются, токены без сессии принимаются, только если sessionlessTokensAllowed.
+// Методы из publicMethods вызываются без токена.
+func AuthInterceptor(
+	jwtService JWTService,
+	sessionChecker SessionChecker,
+	sessionlessTokensAllowed bool,
+	publicMethods ...string,
+) grpc.UnaryServerInterceptor {
+	public := make(map[string]struct{}, len(publicMethods))
+	for _, method := range publicMethods {
+		public[method] = struct{}{}
+	}
+	return func(ctx context.Context, req any, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (any, error) {
+		if _, ok := public[info.FullMethod]; ok {
+			return handler(ctx, req)
+		}
+		md, ok := metadata.FromIncomingContext(ctx)
+		if !ok || len(md.Get("authorization")) == 0 {
+			return nil, status.Error(codes.Unauthenticated, model.ErrAccessDenied)
+		}
+		tokenString := strings.TrimPrefix(md.Get("authorization")[0], "Bearer ")
+		claims, err := jwtService.GetClaims(tokenString)
+		if err != nil {
+			return nil, status.Error(codes.Unauthenticated, model.ErrAccessDenied)
+		}
+		if claims.SessionID == "" && !sessionlessTokensAllowed {
+			return nil, status.Error(codes.Unauthenticated, model.ErrAccessDenied)
+		}
+		if claims.SessionID != "" {
+			active, err := sessionChecker.IsSessionActive(ctx, claims.SessionID)
+			if err != nil {
+				return nil, status.Error(codes.Internal, model.ErrInternal)
+			}
+			if !active {
+				return nil, status.Error(codes.Unauthenticated, model.ErrAccessDenied)
+			}
+		}
+		ctx = model.ContextWithOrganizationID(ctx, claims.OrganizationID)
+		return handler(context.WithValue(ctx, userRoleKey{}, claims.Role), req)
+	}
+}
+
+func getUserRoleFromContext(ctx context.Context) string {
+	userRole, _ := ctx.Value(userRoleKey{}).(string)
+	return userRole
+}
@@ -0,0 +1,104 @@
+// Package handler это хэндлер grpc
+package handler
+
+import (
+	"context"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"internshipPVZ/internal/domain/model"
+	pb "internshipPVZ/internal/grpc/models"
+	"internshipPVZ/internal/http/onlymodels"
+)
+
+// CityUseCase --
+type CityUseCase interface {
+	GetAll(ctx context.Context, userRole string) ([]onlymodels.City, error)
+	Create(ctx context.Context, request *onlymodels.City, userRole string) (*onlymodels.City, error)
+	Update(ctx context.Context, cityID int32, request *onlymodels.City, userRole string) (*onlymodels.City, error)
+	Delete(ctx context.Context, cityID int32, userRole string) error
+}
+
+// CityServiceServer grpc сервис справочника городов
+type CityServiceServer struct {
+	pb.UnimplementedCityServiceServer
+	cityUseCase CityUseCase
+}
+
+// NewCityServiceServer конструктор
+func NewCityServiceServer(uc CityUseCase) *CityServiceServer {
+	return &CityServiceServer{cityUseCase: uc}
+}
+
+// ListCities возвращает справочник городов
+func (s *CityServiceServer) ListCities(ctx context.Context, _ *pb.ListCitiesRequest) (*pb.ListCitiesResponse, error) {
+	contWithTimeout, cancel := context.WithTimeout(ctx, cancelContextTime)
+	defer cancel()
+
+	cities, err := s.cityUseCase.GetAll(contWithTimeout, getUserRoleFromContext(ctx))
+	if err != nil {
+		return nil, cityErrorStatus(err)
+	}
+	resp := &pb.ListCitiesResponse{Cities: make([]*pb.City, 0, len(cities))}
+	for i := range cities {
+		resp.Cities = append(resp.Cities, cityDtoToGrpc(&cities[i]))
+	}
+	return resp, nil
+}
+
+// CreateCity добавляет город в справочник
+func (s *CityServiceServer) CreateCity(ctx context.Context, req *pb.CreateCityRequest) (*pb.City, error) {
+	contWithTimeout, cancel := context.WithTimeout(ctx, cancelContextTime)
+	defer cancel()
+
+	city, err := s.cityUseCase.Create(contWithTimeout, &onlymodels.City{Name: req.GetName()}, getUserRoleFromContext(ctx))
+	if err != nil {
+		return nil, cityErrorStatus(err)
+	}
+	return cityDtoToGrpc(city), nil
+}
+
+// UpdateCity переименовывает город
+func (s *CityServiceServer) UpdateCity(ctx context.Context, req *pb.UpdateCityRequest) (*pb.City, error) {
+	contWithTimeout, cancel := context.WithTimeout(ctx, cancelContextTime)
+	defer cancel()
+
+	city, err := s.cityUseCase.Update(contWithTimeout, req.GetId(), &onlymodels.City{Name: req.GetName()}, getUserRoleFromContext(ctx))
+	if err != nil {
+		return nil, cityErrorStatus(err)
+	}
+	return cityDtoToGrpc(city), nil
+}
+
+// DeleteCity удаляет город из справочника
+func (s *CityServiceServer) DeleteCity(ctx context.Context, req *pb.DeleteCityRequest) (*pb.DeleteCityResponse, error) {
+	contWithTimeout, cancel := context.WithTimeout(ctx, cancelContextTime)
+	defer cancel()
+
+	if err := s.cityUseCase.Delete(contWithTimeout, req.GetId(), getUserRoleFromContext(ctx)); err != nil {
+		return nil, cityErrorStatus(err)
+	}
+	return &pb.DeleteCityResponse{}, nil
+}
+
+func cityDtoToGrpc(city *onlymodels.City) *pb.City {
+	result := &pb.City{Name: city.Name}
+	if city.Id != nil {
+		result.Id = *city.Id
+	}
+	return result
+}
+
+func cityErrorStatus(err error) error {
+	switch err.Error() {
+	case model.ErrAccessDenied, model.ErrInvalidRole:
+		return status.Error(codes.PermissionDenied, err.Error())
+	case model.ErrCityNotFound:
+		return status.Error(codes.NotFound, err.Error())
+	case model.ErrCityAlreadyExists, model.ErrCityIsInUse:
+		return status.Error(codes.FailedPrecondition, err.Error())
+	case model.ErrInternal:
+		return status.Error(codes.Internal, err.Error())
+	default:
+		return status.Error(codes.InvalidArgument, err.Error())
+	}
+}
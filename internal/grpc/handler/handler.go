@@ -3,6 +3,9 @@ package handler
 
 import (
 	"context"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"internshipPVZ/internal/domain/model"
 	pb "internshipPVZ/internal/grpc/models"
 	"time"
 )
@@ -13,7 +16,7 @@ const (
 
 // GetPvzUseCase --
 type GetPvzUseCase interface {
-	Get(ctx context.Context) *pb.GetPVZListResponse
+	Get(ctx context.Context, cursor string, limit int, includeArchived bool) (*pb.GetPVZListResponse, error)
 }
 
 // PVZServiceServer grpc сервис
@@ -28,11 +31,17 @@ func NewPVZServiceServer(uc GetPvzUseCase) *PVZServiceServer {
 }
 
 // GetPVZList возвращает лист пвз
-func (s *PVZServiceServer) GetPVZList(ctx context.Context, _ *pb.GetPVZListRequest) (*pb.GetPVZListResponse, error) {
+func (s *PVZServiceServer) GetPVZList(ctx context.Context, req *pb.GetPVZListRequest) (*pb.GetPVZListResponse, error) {
 	contWithTimeout, cancel := context.WithTimeout(ctx, cancelContextTime)
 	defer cancel()
 
-	resp := s.getPVZUseCase.Get(contWithTimeout)
+	resp, err := s.getPVZUseCase.Get(contWithTimeout, req.GetCursor(), int(req.GetLimit()), req.GetIncludeArchived())
+	if err != nil {
+		if err.Error() == model.ErrInvalidPVZCursor {
+			return nil, status.Error(codes.InvalidArgument, err.Error())
+		}
+		return nil, status.Error(codes.Internal, err.Error())
+	}
 
 	return resp, nil
 }
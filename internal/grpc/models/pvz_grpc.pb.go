@@ -120,3 +120,224 @@ var PVZService_ServiceDesc = grpc.ServiceDesc{
 	Streams:  []grpc.StreamDesc{},
 	Metadata: "pvz.proto",
 }
+
+const (
+	CityService_ListCities_FullMethodName = "/pvz.v1.CityService/ListCities"
+	CityService_CreateCity_FullMethodName = "/pvz.v1.CityService/CreateCity"
+	CityService_UpdateCity_FullMethodName = "/pvz.v1.CityService/UpdateCity"
+	CityService_DeleteCity_FullMethodName = "/pvz.v1.CityService/DeleteCity"
+)
+
+// CityServiceClient is the client API for CityService service.
+//
+// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://pkg.go.dev/google.golang.org/grpc/?tab=doc#ClientConn.NewStream.
+//
+// CityService справочник городов, доступен только модераторам
+type CityServiceClient interface {
+	ListCities(ctx context.Context, in *ListCitiesRequest, opts ...grpc.CallOption) (*ListCitiesResponse, error)
+	CreateCity(ctx context.Context, in *CreateCityRequest, opts ...grpc.CallOption) (*City, error)
+	UpdateCity(ctx context.Context, in *UpdateCityRequest, opts ...grpc.CallOption) (*City, error)
+	DeleteCity(ctx context.Context, in *DeleteCityRequest, opts ...grpc.CallOption) (*DeleteCityResponse, error)
+}
+
+type cityServiceClient struct {
+	cc grpc.ClientConnInterface
+}
+
+// NewCityServiceClient конструктор
+func NewCityServiceClient(cc grpc.ClientConnInterface) CityServiceClient {
+	return &cityServiceClient{cc}
+}
+
+func (c *cityServiceClient) ListCities(ctx context.Context, in *ListCitiesRequest, opts ...grpc.CallOption) (*ListCitiesResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ListCitiesResponse)
+	err := c.cc.Invoke(ctx, CityService_ListCities_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *cityServiceClient) CreateCity(ctx context.Context, in *CreateCityRequest, opts ...grpc.CallOption) (*City, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(City)
+	err := c.cc.Invoke(ctx, CityService_CreateCity_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *cityServiceClient) UpdateCity(ctx context.Context, in *UpdateCityRequest, opts ...grpc.CallOption) (*City, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(City)
+	err := c.cc.Invoke(ctx, CityService_UpdateCity_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *cityServiceClient) DeleteCity(ctx context.Context, in *DeleteCityRequest, opts ...grpc.CallOption) (*DeleteCityResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(DeleteCityResponse)
+	err := c.cc.Invoke(ctx, CityService_DeleteCity_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// CityServiceServer is the server API for CityService service.
+// All implementations must embed UnimplementedCityServiceServer
+// for forward compatibility.
+//
+// CityService справочник городов, доступен только модераторам
+type CityServiceServer interface {
+	ListCities(context.Context, *ListCitiesRequest) (*ListCitiesResponse, error)
+	CreateCity(context.Context, *CreateCityRequest) (*City, error)
+	UpdateCity(context.Context, *UpdateCityRequest) (*City, error)
+	DeleteCity(context.Context, *DeleteCityRequest) (*DeleteCityResponse, error)
+	mustEmbedUnimplementedCityServiceServer()
+}
+
+// UnimplementedCityServiceServer must be embedded to have
+// forward compatible implementations.
+//
+// NOTE: this should be embedded by value instead of pointer to avoid a nil
+// pointer dereference when methods are called.
+type UnimplementedCityServiceServer struct{}
+
+func (UnimplementedCityServiceServer) ListCities(context.Context, *ListCitiesRequest) (*ListCitiesResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListCities not implemented")
+}
+func (UnimplementedCityServiceServer) CreateCity(context.Context, *CreateCityRequest) (*City, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method CreateCity not implemented")
+}
+func (UnimplementedCityServiceServer) UpdateCity(context.Context, *UpdateCityRequest) (*City, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method UpdateCity not implemented")
+}
+func (UnimplementedCityServiceServer) DeleteCity(context.Context, *DeleteCityRequest) (*DeleteCityResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method DeleteCity not implemented")
+}
+func (UnimplementedCityServiceServer) mustEmbedUnimplementedCityServiceServer() {}
+func (UnimplementedCityServiceServer) testEmbeddedByValue()                     {}
+
+// UnsafeCityServiceServer may be embedded to opt out of forward compatibility for this service.
+// Use of this interface is not recommended, as added methods to CityServiceServer will
+// result in compilation errors.
+type UnsafeCityServiceServer interface {
+	mustEmbedUnimplementedCityServiceServer()
+}
+
+func RegisterCityServiceServer(s grpc.ServiceRegistrar, srv CityServiceServer) {
+	// If the following call pancis, it indicates UnimplementedCityServiceServer was
+	// embedded by pointer and is nil.  This will cause panics if an
+	// unimplemented method is ever invoked, so we test this at initialization
+	// time to prevent it from happening at runtime later due to I/O.
+	if t, ok := srv.(interface{ testEmbeddedByValue() }); ok {
+		t.testEmbeddedByValue()
+	}
+	s.RegisterService(&CityService_ServiceDesc, srv)
+}
+
+func _CityService_ListCities_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListCitiesRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(CityServiceServer).ListCities(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: CityService_ListCities_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(CityServiceServer).ListCities(ctx, req.(*ListCitiesRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _CityService_CreateCity_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CreateCityRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(CityServiceServer).CreateCity(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: CityService_CreateCity_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(CityServiceServer).CreateCity(ctx, req.(*CreateCityRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _CityService_UpdateCity_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(UpdateCityRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(CityServiceServer).UpdateCity(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: CityService_UpdateCity_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(CityServiceServer).UpdateCity(ctx, req.(*UpdateCityRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _CityService_DeleteCity_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(DeleteCityRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(CityServiceServer).DeleteCity(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: CityService_DeleteCity_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(CityServiceServer).DeleteCity(ctx, req.(*DeleteCityRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+// CityService_ServiceDesc is the grpc.ServiceDesc for CityService service.
+// It's only intended for direct use with grpc.RegisterService,
+// and not to be introspected or modified (even as a copy)
+var CityService_ServiceDesc = grpc.ServiceDesc{
+	ServiceName: "pvz.v1.CityService",
+	HandlerType: (*CityServiceServer)(nil),
+	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "ListCities",
+			Handler:    _CityService_ListCities_Handler,
+		},
+		{
+			MethodName: "CreateCity",
+			Handler:    _CityService_CreateCity_Handler,
+		},
+		{
+			MethodName: "UpdateCity",
+			Handler:    _CityService_UpdateCity_Handler,
+		},
+		{
+			MethodName: "DeleteCity",
+			Handler:    _CityService_DeleteCity_Handler,
+		},
+	},
+	Streams:  []grpc.StreamDesc{},
+	Metadata: "pvz.proto",
+}
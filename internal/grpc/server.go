@@ -4,6 +4,7 @@ package grpc
 import (
 	"google.golang.org/grpc"
 	"google.golang.org/grpc/reflection"
+	"internshipPVZ/internal/domain/model"
 	"internshipPVZ/internal/grpc/handler"
 	pb "internshipPVZ/internal/grpc/models"
 	"log"
@@ -16,14 +17,40 @@ type Server struct {
 	s   *grpc.Server
 }
 
+// ProfileConfig профиль развёртывания
+type ProfileConfig interface {
+	GetProfile() model.Profile
+}
+
 // NewServer конструктор
-func NewServer(uc handler.GetPvzUseCase) *Server {
+func NewServer(
+	uc handler.GetPvzUseCase,
+	cityUC handler.CityUseCase,
+	jwtService handler.JWTService,
+	sessionChecker handler.SessionChecker,
+	profileConfig ProfileConfig,
+) *Server {
 	if uc == nil {
 		log.Fatalf("NewServer initialization failed: GetPvzUseCase is nil")
 	}
-	s := grpc.NewServer()
+	if cityUC == nil {
+		log.Fatalf("NewServer initialization failed: CityUseCase is nil")
+	}
+	if jwtService == nil {
+		log.Fatalf("NewServer initialization failed: JWTService is nil")
+	}
+	if sessionChecker == nil {
+		log.Fatalf("NewServer initialization failed: SessionChecker is nil")
+	}
+	if profileConfig == nil {
+		log.Fatalf("NewServer initialization failed: ProfileConfig is nil")
+	}
+	// GetPVZList больше не публичный: список ПВЗ ограничен организацией из токена
+	sessionlessTokensAllowed := profileConfig.GetProfile().DummyLoginEnabled()
+	s := grpc.NewServer(grpc.UnaryInterceptor(handler.AuthInterceptor(jwtService, sessionChecker, sessionlessTokensAllowed)))
 	reflection.Register(s)
 	pb.RegisterPVZServiceServer(s, handler.NewPVZServiceServer(uc))
+	pb.RegisterCityServiceServer(s, handler.NewCityServiceServer(cityUC))
 	return &Server{s: s}
 }
 
@@ -3,17 +3,26 @@ package handlers
 
 import (
 	"context"
+	"errors"
 	"github.com/gofiber/fiber/v2"
 	"internshipPVZ/internal/domain/model"
 	"internshipPVZ/internal/http/onlymodels"
 	"log"
+	"math"
+	"strconv"
+	"time"
 )
 
+// refreshTokenCookie cookie с refresh токеном, доступна только эндпоинтам /token/*
+const refreshTokenCookie = "refresh_token"
+
 // AuthUseCase интерфейс для аутентификации
 type AuthUseCase interface {
 	DummyLogin(ctx context.Context, request *onlymodels.PostDummyLoginJSONBody) (string, error)
 	Register(ctx context.Context, request *onlymodels.PostRegisterJSONBody) (*onlymodels.User, error)
-	Login(ctx context.Context, request *onlymodels.PostLoginJSONBody) (string, error)
+	Login(ctx context.Context, request *onlymodels.PostLoginJSONBody, clientIP string) (*model.AuthTokens, error)
+	Refresh(ctx context.Context, refreshToken string) (*model.AuthTokens, error)
+	Logout(ctx context.Context, sessionID string) error
 }
 
 // AuthController контроллер для аутентификации
@@ -43,7 +52,7 @@ func (c *AuthController) DummyLogin(ctx *fiber.Ctx) error {
 	if err := ctx.BodyParser(&req); err != nil {
 		return ctx.Status(fiber.StatusBadRequest).JSON(onlymodels.Error{Message: model.ErrInvalidRole})
 	}
-	contWithTimeout, cancel := context.WithTimeout(context.Background(), cancelContextTime)
+	contWithTimeout, cancel := context.WithTimeout(ctx.UserContext(), cancelContextTime)
 	defer cancel()
 	token, err := c.authUsecase.DummyLogin(contWithTimeout, &req)
 	if err != nil {
@@ -62,10 +71,13 @@ func (c *AuthController) Register(ctx *fiber.Ctx) error {
 	if err := ctx.BodyParser(&req); err != nil {
 		return ctx.Status(fiber.StatusBadRequest).JSON(onlymodels.Error{Message: model.ErrInvalidRequest})
 	}
-	contWithTimeout, cancel := context.WithTimeout(context.Background(), cancelContextTime)
+	contWithTimeout, cancel := context.WithTimeout(ctx.UserContext(), cancelContextTime)
 	defer cancel()
 	user, err := c.authUsecase.Register(contWithTimeout, &req)
 	if err != nil {
+		if err.Error() == model.ErrModeratorInviteRequired {
+			return ctx.Status(fiber.StatusForbidden).JSON(onlymodels.Error{Message: err.Error()})
+		}
 		return ctx.Status(fiber.StatusBadRequest).JSON(onlymodels.Error{Message: err.Error()})
 	}
 	return ctx.Status(fiber.StatusCreated).JSON(user)
@@ -81,11 +93,91 @@ func (c *AuthController) Login(ctx *fiber.Ctx) error {
 	if err := ctx.BodyParser(&req); err != nil {
 		return ctx.Status(fiber.StatusBadRequest).JSON(onlymodels.Error{Message: model.ErrInvalidRequest})
 	}
-	contWithTimeout, cancel := context.WithTimeout(context.Background(), cancelContextTime)
+	contWithTimeout, cancel := context.WithTimeout(ctx.UserContext(), cancelContextTime)
 	defer cancel()
-	token, err := c.authUsecase.Login(contWithTimeout, &req)
+	tokens, err := c.authUsecase.Login(contWithTimeout, &req, ctx.IP())
 	if err != nil {
+		var locked *model.LoginLockedError
+		if errors.As(err, &locked) {
+			ctx.Set(fiber.HeaderRetryAfter, strconv.Itoa(int(math.Ceil(locked.RetryAfter.Seconds()))))
+			return ctx.Status(fiber.StatusTooManyRequests).JSON(onlymodels.Error{Message: err.Error()})
+		}
+		if err.Error() == model.ErrUserDeactivated {
+			return ctx.Status(fiber.StatusForbidden).JSON(onlymodels.Error{Message: err.Error()})
+		}
 		return ctx.Status(fiber.StatusUnauthorized).JSON(onlymodels.Error{Message: err.Error()})
 	}
-	return ctx.JSON(token)
+	setRefreshTokenCookie(ctx, tokens.RefreshToken, tokens.RefreshExpiresAt)
+	return ctx.JSON(tokens.AccessToken)
+}
+
+// RefreshToken обрабатывает запрос на обновление токена доступа
+func (c *AuthController) RefreshToken(ctx *fiber.Ctx) error {
+	if ctx == nil {
+		c.logger.Error("received nil ctx", "controller", "AuthController", "method", "RefreshToken", "error")
+		return ctx.Status(fiber.StatusBadRequest).JSON(onlymodels.Error{Message: model.ErrInternal})
+	}
+	var req onlymodels.PostTokenRefreshJSONBody
+	if len(ctx.Body()) > 0 {
+		if err := ctx.BodyParser(&req); err != nil {
+			return ctx.Status(fiber.StatusBadRequest).JSON(onlymodels.Error{Message: model.ErrInvalidRequest})
+		}
+	}
+	refreshToken := ctx.Cookies(refreshTokenCookie)
+	if req.RefreshToken != nil {
+		refreshToken = *req.RefreshToken
+	}
+	contWithTimeout, cancel := context.WithTimeout(ctx.UserContext(), cancelContextTime)
+	defer cancel()
+	tokens, err := c.authUsecase.Refresh(contWithTimeout, refreshToken)
+	if err != nil {
+		if err.Error() == model.ErrInvalidRefreshToken {
+			clearRefreshTokenCookie(ctx)
+			return ctx.Status(fiber.StatusUnauthorized).JSON(onlymodels.Error{Message: err.Error()})
+		}
+		return ctx.Status(fiber.StatusBadRequest).JSON(onlymodels.Error{Message: err.Error()})
+	}
+	setRefreshTokenCookie(ctx, tokens.RefreshToken, tokens.RefreshExpiresAt)
+	return ctx.JSON(tokens.AccessToken)
+}
+
+// Logout обрабатывает запрос на завершение сессии
+func (c *AuthController) Logout(ctx *fiber.Ctx) error {
+	if ctx == nil {
+		c.logger.Error("received nil ctx", "controller", "AuthController", "method", "Logout", "error")
+		return ctx.Status(fiber.StatusBadRequest).JSON(onlymodels.Error{Message: model.ErrInternal})
+	}
+	sessionID := getSessionIDFromContext(ctx)
+	contWithTimeout, cancel := context.WithTimeout(ctx.UserContext(), cancelContextTime)
+	defer cancel()
+	err := c.authUsecase.Logout(contWithTimeout, sessionID)
+	if err != nil {
+		if err.Error() == model.ErrAccessDenied {
+			return ctx.Status(fiber.StatusForbidden).JSON(onlymodels.Error{Message: err.Error()})
+		}
+		return ctx.Status(fiber.StatusBadRequest).JSON(onlymodels.Error{Message: err.Error()})
+	}
+	clearRefreshTokenCookie(ctx)
+	return ctx.SendStatus(fiber.StatusOK)
+}
+
+func setRefreshTokenCookie(ctx *fiber.Ctx, refreshToken string, expiresAt time.Time) {
+	ctx.Cookie(&fiber.Cookie{
+		Name:     refreshTokenCookie,
+		Value:    refreshToken,
+		Path:     "/token",
+		Expires:  expiresAt,
+		HTTPOnly: true,
+		SameSite: fiber.CookieSameSiteStrictMode,
+	})
+}
+
+func clearRefreshTokenCookie(ctx *fiber.Ctx) {
+	ctx.Cookie(&fiber.Cookie{
+		Name:     refreshTokenCookie,
+		Path:     "/token",
+		Expires:  time.Unix(0, 0),
+		HTTPOnly: true,
+		SameSite: fiber.CookieSameSiteStrictMode,
+	})
 }
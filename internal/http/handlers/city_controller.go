@@ -0,0 +1,138 @@
+// Package handlers это http хэндлеры
+package handlers
+
+import (
+	"context"
+	"github.com/gofiber/fiber/v2"
+	"internshipPVZ/internal/domain/model"
+	"internshipPVZ/internal/http/onlymodels"
+	"log"
+	"math"
+)
+
+// CityUseCase интерфейс для управления справочником городов
+type CityUseCase interface {
+	GetAll(ctx context.Context, userRole string) ([]onlymodels.City, error)
+	Create(ctx context.Context, request *onlymodels.City, userRole string) (*onlymodels.City, error)
+	Update(ctx context.Context, cityID int32, request *onlymodels.City, userRole string) (*onlymodels.City, error)
+	Delete(ctx context.Context, cityID int32, userRole string) error
+}
+
+// CityController контроллер для управления справочником городов
+type CityController struct {
+	cityUseCase CityUseCase
+	logger      Logger
+}
+
+// NewCityController конструктор для создания нового экземпляра CityController
+func NewCityController(cityUseCase CityUseCase, logger Logger) *CityController {
+	if cityUseCase == nil {
+		log.Fatalf("CityController initialization failed: cityUseCase is nil")
+	}
+	if logger == nil {
+		log.Fatalf("CityController initialization failed: logger is nil")
+	}
+	return &CityController{cityUseCase: cityUseCase, logger: logger}
+}
+
+// GetCities обрабатывает запрос на получение справочника городов
+func (c *CityController) GetCities(ctx *fiber.Ctx) error {
+	if ctx == nil {
+		c.logger.Error("received nil ctx", "controller", "CityController", "method", "GetCities", "error")
+		return ctx.Status(fiber.StatusBadRequest).JSON(onlymodels.Error{Message: model.ErrInternal})
+	}
+	userRole := getUserRoleFromContext(ctx)
+	contWithTimeout, cancel := context.WithTimeout(ctx.UserContext(), cancelContextTime)
+	defer cancel()
+	cities, err := c.cityUseCase.GetAll(contWithTimeout, userRole)
+	if err != nil {
+		return cityErrorResponse(ctx, err)
+	}
+	return ctx.JSON(cities)
+}
+
+// CreateCity обрабатывает запрос на добавление города
+func (c *CityController) CreateCity(ctx *fiber.Ctx) error {
+	if ctx == nil {
+		c.logger.Error("received nil ctx", "controller", "CityController", "method", "CreateCity", "error")
+		return ctx.Status(fiber.StatusBadRequest).JSON(onlymodels.Error{Message: model.ErrInternal})
+	}
+	userRole := getUserRoleFromContext(ctx)
+	var req onlymodels.City
+	if err := ctx.BodyParser(&req); err != nil {
+		return ctx.Status(fiber.StatusBadRequest).JSON(onlymodels.Error{Message: model.ErrInvalidRequest})
+	}
+	contWithTimeout, cancel := context.WithTimeout(ctx.UserContext(), cancelContextTime)
+	defer cancel()
+	city, err := c.cityUseCase.Create(contWithTimeout, &req, userRole)
+	if err != nil {
+		return cityErrorResponse(ctx, err)
+	}
+	return ctx.Status(fiber.StatusCreated).JSON(city)
+}
+
+// UpdateCity обрабатывает запрос на переименование города
+func (c *CityController) UpdateCity(ctx *fiber.Ctx) error {
+	if ctx == nil {
+		c.logger.Error("received nil ctx", "controller", "CityController", "method", "UpdateCity", "error")
+		return ctx.Status(fiber.StatusBadRequest).JSON(onlymodels.Error{Message: model.ErrInternal})
+	}
+	userRole := getUserRoleFromContext(ctx)
+	cityID, err := getCityIDFromParams(ctx)
+	if err != nil {
+		return ctx.Status(fiber.StatusBadRequest).JSON(onlymodels.Error{Message: model.ErrInvalidCityID})
+	}
+	var req onlymodels.City
+	if err := ctx.BodyParser(&req); err != nil {
+		return ctx.Status(fiber.StatusBadRequest).JSON(onlymodels.Error{Message: model.ErrInvalidRequest})
+	}
+	contWithTimeout, cancel := context.WithTimeout(ctx.UserContext(), cancelContextTime)
+	defer cancel()
+	city, err := c.cityUseCase.Update(contWithTimeout, cityID, &req, userRole)
+	if err != nil {
+		return cityErrorResponse(ctx, err)
+	}
+	return ctx.JSON(city)
+}
+
+// DeleteCity обрабатывает запрос на удаление города
+func (c *CityController) DeleteCity(ctx *fiber.Ctx) error {
+	if ctx == nil {
+		c.logger.Error("received nil ctx", "controller", "CityController", "method", "DeleteCity", "error")
+		return ctx.Status(fiber.StatusBadRequest).JSON(onlymodels.Error{Message: model.ErrInternal})
+	}
+	userRole := getUserRoleFromContext(ctx)
+	cityID, err := getCityIDFromParams(ctx)
+	if err != nil {
+		return ctx.Status(fiber.StatusBadRequest).JSON(onlymodels.Error{Message: model.ErrInvalidCityID})
+	}
+	contWithTimeout, cancel := context.WithTimeout(ctx.UserContext(), cancelContextTime)
+	defer cancel()
+	err = c.cityUseCase.Delete(contWithTimeout, cityID, userRole)
+	if err != nil {
+		return cityErrorResponse(ctx, err)
+	}
+	return ctx.SendStatus(fiber.StatusOK)
+}
+
+func getCityIDFromParams(ctx *fiber.Ctx) (int32, error) {
+	cityID, err := ctx.ParamsInt("cityId")
+	if err != nil {
+		return 0, err
+	}
+	if cityID < 0 || cityID > math.MaxInt32 {
+		return 0, fiber.ErrBadRequest
+	}
+	return int32(cityID), nil
+}
+
+func cityErrorResponse(ctx *fiber.Ctx, err error) error {
+	switch err.Error() {
+	case model.ErrAccessDenied:
+		return ctx.Status(fiber.StatusForbidden).JSON(onlymodels.Error{Message: err.Error()})
+	case model.ErrCityNotFound:
+		return ctx.Status(fiber.StatusNotFound).JSON(onlymodels.Error{Message: err.Error()})
+	default:
+		return ctx.Status(fiber.StatusBadRequest).JSON(onlymodels.Error{Message: err.Error()})
+	}
+}
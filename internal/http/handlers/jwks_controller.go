@@ -0,0 +1,87 @@
+// Package handlers это http хэндлеры
+package handlers
+
+import (
+	"crypto/ed25519"
+	"crypto/rsa"
+	"encoding/base64"
+	"github.com/gofiber/fiber/v2"
+	"internshipPVZ/internal/domain/model"
+	"internshipPVZ/internal/http/onlymodels"
+	"log"
+	"math/big"
+)
+
+// jwksCacheControl сколько клиенты могут кэшировать набор ключей.
+// Новый ключ нужно опубликовать хотя бы на это время раньше, чем он начнёт подписывать токены
+const jwksCacheControl = "public, max-age=300"
+
+// SigningKeyProvider источник публичных ключей подписи токенов
+type SigningKeyProvider interface {
+	PublicKeys() []model.SigningPublicKey
+}
+
+// JWKSController контроллер для публикации ключей проверки подписи токенов
+type JWKSController struct {
+	keyProvider SigningKeyProvider
+	logger      Logger
+}
+
+// NewJWKSController конструктор для создания нового экземпляра JWKSController
+func NewJWKSController(keyProvider SigningKeyProvider, logger Logger) *JWKSController {
+	if keyProvider == nil {
+		log.Fatalf("JWKSController initialization failed: keyProvider is nil")
+	}
+	if logger == nil {
+		log.Fatalf("JWKSController initialization failed: logger is nil")
+	}
+	return &JWKSController{keyProvider: keyProvider, logger: logger}
+}
+
+// GetJWKS обрабатывает запрос на получение набора публичных ключей
+func (c *JWKSController) GetJWKS(ctx *fiber.Ctx) error {
+	if ctx == nil {
+		c.logger.Error("received nil ctx", "controller", "JWKSController", "method", "GetJWKS", "error")
+		return ctx.Status(fiber.StatusBadRequest).JSON(onlymodels.Error{Message: model.ErrInternal})
+	}
+	keys := c.keyProvider.PublicKeys()
+	set := onlymodels.JWKSet{Keys: make([]onlymodels.JWK, 0, len(keys))}
+	for _, key := range keys {
+		jwk, ok := publicKeyToJWK(key)
+		if !ok {
+			c.logger.Error("unsupported signing key type",
+				"controller", "JWKSController",
+				"method", "GetJWKS",
+				"kid", key.ID)
+			continue
+		}
+		set.Keys = append(set.Keys, jwk)
+	}
+	ctx.Set(fiber.HeaderCacheControl, jwksCacheControl)
+	return ctx.JSON(set)
+}
+
+func publicKeyToJWK(key model.SigningPublicKey) (onlymodels.JWK, bool) {
+	jwk := onlymodels.JWK{
+		Kid: key.ID,
+		Use: onlymodels.Sig,
+		Alg: onlymodels.JWKAlg(key.Algorithm),
+	}
+	switch publicKey := key.Key.(type) {
+	case *rsa.PublicKey:
+		n := base64.RawURLEncoding.EncodeToString(publicKey.N.Bytes())
+		e := base64.RawURLEncoding.EncodeToString(big.NewInt(int64(publicKey.E)).Bytes())
+		jwk.Kty = onlymodels.RSA
+		jwk.N = &n
+		jwk.E = &e
+	case ed25519.PublicKey:
+		x := base64.RawURLEncoding.EncodeToString(publicKey)
+		crv := onlymodels.Ed25519
+		jwk.Kty = onlymodels.OKP
+		jwk.Crv = &crv
+		jwk.X = &x
+	default:
+		return jwk, false
+	}
+	return jwk, true
+}
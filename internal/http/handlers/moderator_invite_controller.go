@@ -0,0 +1,52 @@
+// Package handlers это http хэндлеры
+package handlers
+
+import (
+	"context"
+	"github.com/gofiber/fiber/v2"
+	"internshipPVZ/internal/domain/model"
+	"internshipPVZ/internal/http/onlymodels"
+	"log"
+)
+
+// ModeratorInviteUseCase интерфейс для выдачи приглашений модераторов
+type ModeratorInviteUseCase interface {
+	Create(ctx context.Context, userID, userRole string) (*onlymodels.ModeratorInvite, error)
+}
+
+// ModeratorInviteController контроллер для выдачи приглашений модераторов
+type ModeratorInviteController struct {
+	inviteUseCase ModeratorInviteUseCase
+	logger        Logger
+}
+
+// NewModeratorInviteController конструктор для создания нового экземпляра ModeratorInviteController
+func NewModeratorInviteController(inviteUseCase ModeratorInviteUseCase, logger Logger) *ModeratorInviteController {
+	if inviteUseCase == nil {
+		log.Fatalf("ModeratorInviteController initialization failed: inviteUseCase is nil")
+	}
+	if logger == nil {
+		log.Fatalf("ModeratorInviteController initialization failed: logger is nil")
+	}
+	return &ModeratorInviteController{inviteUseCase: inviteUseCase, logger: logger}
+}
+
+// CreateInvite обрабатывает запрос на выдачу приглашения модератора
+func (c *ModeratorInviteController) CreateInvite(ctx *fiber.Ctx) error {
+	if ctx == nil {
+		c.logger.Error("received nil ctx", "controller", "ModeratorInviteController", "method", "CreateInvite", "error")
+		return ctx.Status(fiber.StatusBadRequest).JSON(onlymodels.Error{Message: model.ErrInternal})
+	}
+	userRole := getUserRoleFromContext(ctx)
+	userID := getUserIDFromContext(ctx)
+	contWithTimeout, cancel := context.WithTimeout(ctx.UserContext(), cancelContextTime)
+	defer cancel()
+	invite, err := c.inviteUseCase.Create(contWithTimeout, userID, userRole)
+	if err != nil {
+		if err.Error() == model.ErrAccessDenied {
+			return ctx.Status(fiber.StatusForbidden).JSON(onlymodels.Error{Message: err.Error()})
+		}
+		return ctx.Status(fiber.StatusBadRequest).JSON(onlymodels.Error{Message: err.Error()})
+	}
+	return ctx.Status(fiber.StatusCreated).JSON(invite)
+}
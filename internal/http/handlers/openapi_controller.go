@@ -0,0 +1,84 @@
+// Package handlers это http хэндлеры
+package handlers
+
+import (
+	"bytes"
+	"github.com/gofiber/fiber/v2"
+	"gopkg.in/yaml.v3"
+	"internshipPVZ"
+	"internshipPVZ/internal/domain/model"
+	"internshipPVZ/internal/http/onlymodels"
+	"log"
+)
+
+// ProfileConfig профиль развёртывания
+type ProfileConfig interface {
+	GetProfile() model.Profile
+}
+
+// OpenAPIController контроллер, отдающий спецификацию API текущего профиля
+type OpenAPIController struct {
+	spec   []byte
+	logger Logger
+}
+
+// NewOpenAPIController конструктор для создания нового экземпляра OpenAPIController.
+// Эндпоинты, выключенные профилем, вырезаются из спецификации один раз при старте
+func NewOpenAPIController(profileConfig ProfileConfig, logger Logger) *OpenAPIController {
+	if profileConfig == nil {
+		log.Fatalf("OpenAPIController initialization failed: profileConfig is nil")
+	}
+	if logger == nil {
+		log.Fatalf("OpenAPIController initialization failed: logger is nil")
+	}
+	spec := internshipPVZ.OpenAPISpec
+	if !profileConfig.GetProfile().DummyLoginEnabled() {
+		var err error
+		spec, err = removeOpenAPIPath(spec, "/dummyLogin")
+		if err != nil {
+			log.Fatalf("OpenAPIController initialization failed: %v", err)
+		}
+	}
+	return &OpenAPIController{spec: spec, logger: logger}
+}
+
+// GetSpec обрабатывает запрос на получение спецификации API
+func (c *OpenAPIController) GetSpec(ctx *fiber.Ctx) error {
+	if ctx == nil {
+		c.logger.Error("received nil ctx", "controller", "OpenAPIController", "method", "GetSpec", "error")
+		return ctx.Status(fiber.StatusBadRequest).JSON(onlymodels.Error{Message: model.ErrInternal})
+	}
+	ctx.Set(fiber.HeaderContentType, "application/yaml")
+	return ctx.Send(c.spec)
+}
+
+// removeOpenAPIPath удаляет путь из раздела paths, сохраняя порядок и комментарии остальной спецификации
+func removeOpenAPIPath(spec []byte, path string) ([]byte, error) {
+	var doc yaml.Node
+	if err := yaml.Unmarshal(spec, &doc); err != nil {
+		return nil, err
+	}
+	if len(doc.Content) == 0 {
+		return spec, nil
+	}
+	root := doc.Content[0]
+	for i := 0; i+1 < len(root.Content); i += 2 {
+		if root.Content[i].Value != "paths" {
+			continue
+		}
+		paths := root.Content[i+1]
+		for j := 0; j+1 < len(paths.Content); j += 2 {
+			if paths.Content[j].Value == path {
+				paths.Content = append(paths.Content[:j], paths.Content[j+2:]...)
+				break
+			}
+		}
+	}
+	var buf bytes.Buffer
+	encoder := yaml.NewEncoder(&buf)
+	encoder.SetIndent(2)
+	if err := encoder.Encode(&doc); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
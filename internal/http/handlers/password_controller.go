@@ -0,0 +1,104 @@
+// Package handlers это http хэндлеры
+package handlers
+
+import (
+	"context"
+	"github.com/gofiber/fiber/v2"
+	"internshipPVZ/internal/domain/model"
+	"internshipPVZ/internal/http/onlymodels"
+	"log"
+)
+
+// PasswordUseCase интерфейс для смены и сброса пароля
+type PasswordUseCase interface {
+	Change(ctx context.Context, userID, sessionID string, request *onlymodels.PostPasswordChangeJSONBody) error
+	RequestReset(ctx context.Context, request *onlymodels.PostPasswordResetRequestJSONBody) error
+	Reset(ctx context.Context, request *onlymodels.PostPasswordResetJSONBody) error
+}
+
+// PasswordController контроллер для смены и сброса пароля
+type PasswordController struct {
+	passwordUseCase PasswordUseCase
+	logger          Logger
+}
+
+// NewPasswordController конструктор для создания нового экземпляра PasswordController
+func NewPasswordController(passwordUseCase PasswordUseCase, logger Logger) *PasswordController {
+	if passwordUseCase == nil {
+		log.Fatalf("PasswordController initialization failed: passwordUseCase is nil")
+	}
+	if logger == nil {
+		log.Fatalf("PasswordController initialization failed: logger is nil")
+	}
+	return &PasswordController{passwordUseCase: passwordUseCase, logger: logger}
+}
+
+// ChangePassword обрабатывает запрос на смену пароля текущего пользователя
+func (c *PasswordController) ChangePassword(ctx *fiber.Ctx) error {
+	if ctx == nil {
+		c.logger.Error("received nil ctx", "controller", "PasswordController", "method", "ChangePassword", "error")
+		return ctx.Status(fiber.StatusBadRequest).JSON(onlymodels.Error{Message: model.ErrInternal})
+	}
+	userID := getUserIDFromContext(ctx)
+	sessionID := getSessionIDFromContext(ctx)
+	var req onlymodels.PostPasswordChangeJSONBody
+	if err := ctx.BodyParser(&req); err != nil {
+		return ctx.Status(fiber.StatusBadRequest).JSON(onlymodels.Error{Message: model.ErrInvalidRequest})
+	}
+	contWithTimeout, cancel := context.WithTimeout(ctx.UserContext(), cancelContextTime)
+	defer cancel()
+	err := c.passwordUseCase.Change(contWithTimeout, userID, sessionID, &req)
+	if err != nil {
+		return passwordErrorResponse(ctx, err)
+	}
+	return ctx.SendStatus(fiber.StatusOK)
+}
+
+// RequestPasswordReset обрабатывает запрос на отправку токена сброса пароля
+func (c *PasswordController) RequestPasswordReset(ctx *fiber.Ctx) error {
+	if ctx == nil {
+		c.logger.Error("received nil ctx", "controller", "PasswordController", "method", "RequestPasswordReset", "error")
+		return ctx.Status(fiber.StatusBadRequest).JSON(onlymodels.Error{Message: model.ErrInternal})
+	}
+	var req onlymodels.PostPasswordResetRequestJSONBody
+	if err := ctx.BodyParser(&req); err != nil {
+		return ctx.Status(fiber.StatusBadRequest).JSON(onlymodels.Error{Message: model.ErrInvalidRequest})
+	}
+	contWithTimeout, cancel := context.WithTimeout(ctx.UserContext(), cancelContextTime)
+	defer cancel()
+	err := c.passwordUseCase.RequestReset(contWithTimeout, &req)
+	if err != nil {
+		return passwordErrorResponse(ctx, err)
+	}
+	return ctx.SendStatus(fiber.StatusOK)
+}
+
+// ResetPassword обрабатывает запрос на установку нового пароля по токену сброса
+func (c *PasswordController) ResetPassword(ctx *fiber.Ctx) error {
+	if ctx == nil {
+		c.logger.Error("received nil ctx", "controller", "PasswordController", "method", "ResetPassword", "error")
+		return ctx.Status(fiber.StatusBadRequest).JSON(onlymodels.Error{Message: model.ErrInternal})
+	}
+	var req onlymodels.PostPasswordResetJSONBody
+	if err := ctx.BodyParser(&req); err != nil {
+		return ctx.Status(fiber.StatusBadRequest).JSON(onlymodels.Error{Message: model.ErrInvalidRequest})
+	}
+	contWithTimeout, cancel := context.WithTimeout(ctx.UserContext(), cancelContextTime)
+	defer cancel()
+	err := c.passwordUseCase.Reset(contWithTimeout, &req)
+	if err != nil {
+		return passwordErrorResponse(ctx, err)
+	}
+	return ctx.SendStatus(fiber.StatusOK)
+}
+
+func passwordErrorResponse(ctx *fiber.Ctx, err error) error {
+	switch err.Error() {
+	case model.ErrAccessDenied:
+		return ctx.Status(fiber.StatusForbidden).JSON(onlymodels.Error{Message: err.Error()})
+	case model.ErrUserNotFound:
+		return ctx.Status(fiber.StatusNotFound).JSON(onlymodels.Error{Message: err.Error()})
+	default:
+		return ctx.Status(fiber.StatusBadRequest).JSON(onlymodels.Error{Message: err.Error()})
+	}
+}
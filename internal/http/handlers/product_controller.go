@@ -4,6 +4,7 @@ package handlers
 import (
 	"context"
 	"github.com/gofiber/fiber/v2"
+	"github.com/google/uuid"
 	"internshipPVZ/internal/domain/model"
 	"internshipPVZ/internal/http/onlymodels"
 	"log"
@@ -11,24 +12,79 @@ import (
 
 // AddProductUsecase интерфейс для добавления продукта
 type AddProductUsecase interface {
-	Execute(ctx context.Context, request *onlymodels.PostProductsJSONBody, userRole string) (*onlymodels.Product, error)
+	Execute(ctx context.Context, request *onlymodels.PostProductsJSONBody, userID, userRole string) (*onlymodels.Product, error)
+}
+
+// StoreProductUseCase интерфейс для перевода продукта на хранение
+type StoreProductUseCase interface {
+	Execute(ctx context.Context, productID uuid.UUID, userID, userRole string) (*onlymodels.Product, error)
+}
+
+// IssueProductUseCase интерфейс для выдачи продукта покупателю
+type IssueProductUseCase interface {
+	Execute(ctx context.Context, productID uuid.UUID, userID, userRole string) (*onlymodels.Product, error)
+}
+
+// ReturnProductUseCase интерфейс для возврата продукта отправителю
+type ReturnProductUseCase interface {
+	Execute(ctx context.Context, productID uuid.UUID, userID, userRole string) (*onlymodels.Product, error)
+}
+
+// GetInventoryUseCase интерфейс для получения продуктов, находящихся в ПВЗ
+type GetInventoryUseCase interface {
+	Execute(ctx context.Context, PVZID uuid.UUID, userRole string) ([]onlymodels.Product, error)
+}
+
+// productStatusUseCase общий интерфейс юзкейсов смены статуса продукта
+type productStatusUseCase interface {
+	Execute(ctx context.Context, productID uuid.UUID, userID, userRole string) (*onlymodels.Product, error)
 }
 
 // ProductController контроллер для управления продуктами
 type ProductController struct {
-	addProductUsecase AddProductUsecase
-	logger            Logger
+	addProductUsecase    AddProductUsecase
+	storeProductUseCase  StoreProductUseCase
+	issueProductUseCase  IssueProductUseCase
+	returnProductUseCase ReturnProductUseCase
+	getInventoryUseCase  GetInventoryUseCase
+	logger               Logger
 }
 
 // NewProductController конструктор для создания нового экземпляра ProductController
-func NewProductController(addProductUsecase AddProductUsecase, logger Logger) *ProductController {
+func NewProductController(
+	addProductUsecase AddProductUsecase,
+	storeProductUseCase StoreProductUseCase,
+	issueProductUseCase IssueProductUseCase,
+	returnProductUseCase ReturnProductUseCase,
+	getInventoryUseCase GetInventoryUseCase,
+	logger Logger,
+) *ProductController {
 	if addProductUsecase == nil {
 		log.Fatalf("ProductController initialization failed: addProductUsecase is nil")
 	}
+	if storeProductUseCase == nil {
+		log.Fatalf("ProductController initialization failed: storeProductUseCase is nil")
+	}
+	if issueProductUseCase == nil {
+		log.Fatalf("ProductController initialization failed: issueProductUseCase is nil")
+	}
+	if returnProductUseCase == nil {
+		log.Fatalf("ProductController initialization failed: returnProductUseCase is nil")
+	}
+	if getInventoryUseCase == nil {
+		log.Fatalf("ProductController initialization failed: getInventoryUseCase is nil")
+	}
 	if logger == nil {
 		log.Fatalf("ProductController initialization failed: logger is nil")
 	}
-	return &ProductController{addProductUsecase: addProductUsecase, logger: logger}
+	return &ProductController{
+		addProductUsecase:    addProductUsecase,
+		storeProductUseCase:  storeProductUseCase,
+		issueProductUseCase:  issueProductUseCase,
+		returnProductUseCase: returnProductUseCase,
+		getInventoryUseCase:  getInventoryUseCase,
+		logger:               logger,
+	}
 }
 
 // CreateProduct обрабатывает запрос на создание продукта
@@ -38,14 +94,15 @@ func (c *ProductController) CreateProduct(ctx *fiber.Ctx) error {
 		return ctx.Status(fiber.StatusBadRequest).JSON(onlymodels.Error{Message: model.ErrInternal})
 	}
 	UserRole := getUserRoleFromContext(ctx)
+	userID := getUserIDFromContext(ctx)
 	var req onlymodels.PostProductsJSONBody
 	if err := ctx.BodyParser(&req); err != nil {
 		return ctx.Status(fiber.StatusBadRequest).JSON(onlymodels.Error{Message: model.ErrInvalidPVZID})
 	}
-	contWithTimeout, cancel := context.WithTimeout(context.Background(), cancelContextTime)
+	contWithTimeout, cancel := context.WithTimeout(ctx.UserContext(), cancelContextTime)
 	defer cancel()
 
-	product, err := c.addProductUsecase.Execute(contWithTimeout, &req, UserRole)
+	product, err := c.addProductUsecase.Execute(contWithTimeout, &req, userID, UserRole)
 	if err != nil {
 		if err.Error() == model.ErrAccessDenied {
 			return ctx.Status(fiber.StatusForbidden).JSON(onlymodels.Error{Message: err.Error()})
@@ -55,3 +112,80 @@ func (c *ProductController) CreateProduct(ctx *fiber.Ctx) error {
 
 	return ctx.Status(fiber.StatusCreated).JSON(product)
 }
+
+// StoreProduct обрабатывает запрос на перевод продукта на хранение
+func (c *ProductController) StoreProduct(ctx *fiber.Ctx) error {
+	if ctx == nil {
+		c.logger.Error("received nil ctx", "controller", "ProductController", "method", "StoreProduct", "error")
+		return ctx.Status(fiber.StatusBadRequest).JSON(onlymodels.Error{Message: model.ErrInternal})
+	}
+	return c.changeProductStatus(ctx, c.storeProductUseCase)
+}
+
+// IssueProduct обрабатывает запрос на выдачу продукта покупателю
+func (c *ProductController) IssueProduct(ctx *fiber.Ctx) error {
+	if ctx == nil {
+		c.logger.Error("received nil ctx", "controller", "ProductController", "method", "IssueProduct", "error")
+		return ctx.Status(fiber.StatusBadRequest).JSON(onlymodels.Error{Message: model.ErrInternal})
+	}
+	return c.changeProductStatus(ctx, c.issueProductUseCase)
+}
+
+// ReturnProduct обрабатывает запрос на возврат продукта отправителю
+func (c *ProductController) ReturnProduct(ctx *fiber.Ctx) error {
+	if ctx == nil {
+		c.logger.Error("received nil ctx", "controller", "ProductController", "method", "ReturnProduct", "error")
+		return ctx.Status(fiber.StatusBadRequest).JSON(onlymodels.Error{Message: model.ErrInternal})
+	}
+	return c.changeProductStatus(ctx, c.returnProductUseCase)
+}
+
+// GetInventory обрабатывает запрос на получение продуктов, находящихся в ПВЗ
+func (c *ProductController) GetInventory(ctx *fiber.Ctx) error {
+	if ctx == nil {
+		c.logger.Error("received nil ctx", "controller", "ProductController", "method", "GetInventory", "error")
+		return ctx.Status(fiber.StatusBadRequest).JSON(onlymodels.Error{Message: model.ErrInternal})
+	}
+	userRole := getUserRoleFromContext(ctx)
+	pvzID, err := uuid.Parse(ctx.Params("pvzId"))
+	if err != nil {
+		return ctx.Status(fiber.StatusBadRequest).JSON(onlymodels.Error{Message: model.ErrInvalidPVZID})
+	}
+	contWithTimeout, cancel := context.WithTimeout(ctx.UserContext(), cancelContextTime)
+	defer cancel()
+	products, err := c.getInventoryUseCase.Execute(contWithTimeout, pvzID, userRole)
+	if err != nil {
+		return productTransitionErrorResponse(ctx, err)
+	}
+	return ctx.JSON(products)
+}
+
+// changeProductStatus общий разбор запроса на смену статуса продукта, usecase определяет целевой статус
+func (c *ProductController) changeProductStatus(ctx *fiber.Ctx, usecase productStatusUseCase) error {
+	userRole := getUserRoleFromContext(ctx)
+	userID := getUserIDFromContext(ctx)
+	productID, err := uuid.Parse(ctx.Params("productId"))
+	if err != nil {
+		return ctx.Status(fiber.StatusBadRequest).JSON(onlymodels.Error{Message: model.ErrInvalidProductID})
+	}
+	contWithTimeout, cancel := context.WithTimeout(ctx.UserContext(), cancelContextTime)
+	defer cancel()
+	product, err := usecase.Execute(contWithTimeout, productID, userID, userRole)
+	if err != nil {
+		return productTransitionErrorResponse(ctx, err)
+	}
+	return ctx.JSON(product)
+}
+
+func productTransitionErrorResponse(ctx *fiber.Ctx, err error) error {
+	switch err.Error() {
+	case model.ErrAccessDenied:
+		return ctx.Status(fiber.StatusForbidden).JSON(onlymodels.Error{Message: err.Error()})
+	case model.ErrProductNotFound:
+		return ctx.Status(fiber.StatusNotFound).JSON(onlymodels.Error{Message: err.Error()})
+	case model.ErrInvalidProductTransition, model.ErrReceptionNotClosed:
+		return ctx.Status(fiber.StatusConflict).JSON(onlymodels.Error{Message: err.Error()})
+	default:
+		return ctx.Status(fiber.StatusBadRequest).JSON(onlymodels.Error{Message: err.Error()})
+	}
+}
@@ -0,0 +1,138 @@
+// Package handlers это http хэндлеры
+package handlers
+
+import (
+	"context"
+	"github.com/gofiber/fiber/v2"
+	"internshipPVZ/internal/domain/model"
+	"internshipPVZ/internal/http/onlymodels"
+	"log"
+	"math"
+)
+
+// ProductTypeUseCase интерфейс для управления справочником типов товаров
+type ProductTypeUseCase interface {
+	GetAll(ctx context.Context, userRole string) ([]onlymodels.ProductType, error)
+	Create(ctx context.Context, request *onlymodels.ProductType, userRole string) (*onlymodels.ProductType, error)
+	Rename(ctx context.Context, productTypeID int16, request *onlymodels.ProductType, userRole string) (*onlymodels.ProductType, error)
+	Deactivate(ctx context.Context, productTypeID int16, userRole string) (*onlymodels.ProductType, error)
+}
+
+// ProductTypeController контроллер для управления справочником типов товаров
+type ProductTypeController struct {
+	productTypeUseCase ProductTypeUseCase
+	logger             Logger
+}
+
+// NewProductTypeController конструктор для создания нового экземпляра ProductTypeController
+func NewProductTypeController(productTypeUseCase ProductTypeUseCase, logger Logger) *ProductTypeController {
+	if productTypeUseCase == nil {
+		log.Fatalf("ProductTypeController initialization failed: productTypeUseCase is nil")
+	}
+	if logger == nil {
+		log.Fatalf("ProductTypeController initialization failed: logger is nil")
+	}
+	return &ProductTypeController{productTypeUseCase: productTypeUseCase, logger: logger}
+}
+
+// GetProductTypes обрабатывает запрос на получение справочника типов товаров
+func (c *ProductTypeController) GetProductTypes(ctx *fiber.Ctx) error {
+	if ctx == nil {
+		c.logger.Error("received nil ctx", "controller", "ProductTypeController", "method", "GetProductTypes", "error")
+		return ctx.Status(fiber.StatusBadRequest).JSON(onlymodels.Error{Message: model.ErrInternal})
+	}
+	userRole := getUserRoleFromContext(ctx)
+	contWithTimeout, cancel := context.WithTimeout(ctx.UserContext(), cancelContextTime)
+	defer cancel()
+	productTypes, err := c.productTypeUseCase.GetAll(contWithTimeout, userRole)
+	if err != nil {
+		return productTypeErrorResponse(ctx, err)
+	}
+	return ctx.JSON(productTypes)
+}
+
+// CreateProductType обрабатывает запрос на добавление типа товара
+func (c *ProductTypeController) CreateProductType(ctx *fiber.Ctx) error {
+	if ctx == nil {
+		c.logger.Error("received nil ctx", "controller", "ProductTypeController", "method", "CreateProductType", "error")
+		return ctx.Status(fiber.StatusBadRequest).JSON(onlymodels.Error{Message: model.ErrInternal})
+	}
+	userRole := getUserRoleFromContext(ctx)
+	var req onlymodels.ProductType
+	if err := ctx.BodyParser(&req); err != nil {
+		return ctx.Status(fiber.StatusBadRequest).JSON(onlymodels.Error{Message: model.ErrInvalidRequest})
+	}
+	contWithTimeout, cancel := context.WithTimeout(ctx.UserContext(), cancelContextTime)
+	defer cancel()
+	productType, err := c.productTypeUseCase.Create(contWithTimeout, &req, userRole)
+	if err != nil {
+		return productTypeErrorResponse(ctx, err)
+	}
+	return ctx.Status(fiber.StatusCreated).JSON(productType)
+}
+
+// RenameProductType обрабатывает запрос на переименование типа товара
+func (c *ProductTypeController) RenameProductType(ctx *fiber.Ctx) error {
+	if ctx == nil {
+		c.logger.Error("received nil ctx", "controller", "ProductTypeController", "method", "RenameProductType", "error")
+		return ctx.Status(fiber.StatusBadRequest).JSON(onlymodels.Error{Message: model.ErrInternal})
+	}
+	userRole := getUserRoleFromContext(ctx)
+	productTypeID, err := getProductTypeIDFromParams(ctx)
+	if err != nil {
+		return ctx.Status(fiber.StatusBadRequest).JSON(onlymodels.Error{Message: model.ErrInvalidProductTypeID})
+	}
+	var req onlymodels.ProductType
+	if err := ctx.BodyParser(&req); err != nil {
+		return ctx.Status(fiber.StatusBadRequest).JSON(onlymodels.Error{Message: model.ErrInvalidRequest})
+	}
+	contWithTimeout, cancel := context.WithTimeout(ctx.UserContext(), cancelContextTime)
+	defer cancel()
+	productType, err := c.productTypeUseCase.Rename(contWithTimeout, productTypeID, &req, userRole)
+	if err != nil {
+		return productTypeErrorResponse(ctx, err)
+	}
+	return ctx.JSON(productType)
+}
+
+// DeactivateProductType обрабатывает запрос на деактивацию типа товара
+func (c *ProductTypeController) DeactivateProductType(ctx *fiber.Ctx) error {
+	if ctx == nil {
+		c.logger.Error("received nil ctx", "controller", "ProductTypeController", "method", "DeactivateProductType", "error")
+		return ctx.Status(fiber.StatusBadRequest).JSON(onlymodels.Error{Message: model.ErrInternal})
+	}
+	userRole := getUserRoleFromContext(ctx)
+	productTypeID, err := getProductTypeIDFromParams(ctx)
+	if err != nil {
+		return ctx.Status(fiber.StatusBadRequest).JSON(onlymodels.Error{Message: model.ErrInvalidProductTypeID})
+	}
+	contWithTimeout, cancel := context.WithTimeout(ctx.UserContext(), cancelContextTime)
+	defer cancel()
+	productType, err := c.productTypeUseCase.Deactivate(contWithTimeout, productTypeID, userRole)
+	if err != nil {
+		return productTypeErrorResponse(ctx, err)
+	}
+	return ctx.JSON(productType)
+}
+
+func getProductTypeIDFromParams(ctx *fiber.Ctx) (int16, error) {
+	productTypeID, err := ctx.ParamsInt("typeId")
+	if err != nil {
+		return 0, err
+	}
+	if productTypeID < 0 || productTypeID > math.MaxInt16 {
+		return 0, fiber.ErrBadRequest
+	}
+	return int16(productTypeID), nil
+}
+
+func productTypeErrorResponse(ctx *fiber.Ctx, err error) error {
+	switch err.Error() {
+	case model.ErrAccessDenied:
+		return ctx.Status(fiber.StatusForbidden).JSON(onlymodels.Error{Message: err.Error()})
+	case model.ErrProductTypeNotFound:
+		return ctx.Status(fiber.StatusNotFound).JSON(onlymodels.Error{Message: err.Error()})
+	default:
+		return ctx.Status(fiber.StatusBadRequest).JSON(onlymodels.Error{Message: err.Error()})
+	}
+}
@@ -0,0 +1,117 @@
+// Package handlers это http хэндлеры
+package handlers
+
+import (
+	"context"
+	"github.com/gofiber/fiber/v2"
+	"github.com/google/uuid"
+	"internshipPVZ/internal/domain/model"
+	"internshipPVZ/internal/http/onlymodels"
+	"log"
+)
+
+// PVZAssignmentUseCase интерфейс для управления закреплением сотрудников за ПВЗ
+type PVZAssignmentUseCase interface {
+	GetByPVZ(ctx context.Context, PVZID uuid.UUID, userRole string) ([]onlymodels.PVZAssignment, error)
+	Assign(ctx context.Context, PVZID uuid.UUID, request *onlymodels.PVZAssignment, userID, userRole string) (*onlymodels.PVZAssignment, error)
+	Unassign(ctx context.Context, PVZID, employeeID uuid.UUID, userRole string) error
+}
+
+// PVZAssignmentController контроллер для управления закреплением сотрудников за ПВЗ
+type PVZAssignmentController struct {
+	assignmentUseCase PVZAssignmentUseCase
+	logger            Logger
+}
+
+// NewPVZAssignmentController конструктор для создания нового экземпляра PVZAssignmentController
+func NewPVZAssignmentController(assignmentUseCase PVZAssignmentUseCase, logger Logger) *PVZAssignmentController {
+	if assignmentUseCase == nil {
+		log.Fatalf("PVZAssignmentController initialization failed: assignmentUseCase is nil")
+	}
+	if logger == nil {
+		log.Fatalf("PVZAssignmentController initialization failed: logger is nil")
+	}
+	return &PVZAssignmentController{assignmentUseCase: assignmentUseCase, logger: logger}
+}
+
+// GetAssignments обрабатывает запрос на получение сотрудников, закреплённых за ПВЗ
+func (c *PVZAssignmentController) GetAssignments(ctx *fiber.Ctx) error {
+	if ctx == nil {
+		c.logger.Error("received nil ctx", "controller", "PVZAssignmentController", "method", "GetAssignments", "error")
+		return ctx.Status(fiber.StatusBadRequest).JSON(onlymodels.Error{Message: model.ErrInternal})
+	}
+	userRole := getUserRoleFromContext(ctx)
+	pvzID, err := uuid.Parse(ctx.Params("pvzId"))
+	if err != nil {
+		return ctx.Status(fiber.StatusBadRequest).JSON(onlymodels.Error{Message: model.ErrInvalidPVZID})
+	}
+	contWithTimeout, cancel := context.WithTimeout(ctx.UserContext(), cancelContextTime)
+	defer cancel()
+	assignments, err := c.assignmentUseCase.GetByPVZ(contWithTimeout, pvzID, userRole)
+	if err != nil {
+		return pvzAssignmentErrorResponse(ctx, err)
+	}
+	return ctx.JSON(assignments)
+}
+
+// AssignEmployee обрабатывает запрос на закрепление сотрудника за ПВЗ
+func (c *PVZAssignmentController) AssignEmployee(ctx *fiber.Ctx) error {
+	if ctx == nil {
+		c.logger.Error("received nil ctx", "controller", "PVZAssignmentController", "method", "AssignEmployee", "error")
+		return ctx.Status(fiber.StatusBadRequest).JSON(onlymodels.Error{Message: model.ErrInternal})
+	}
+	userRole := getUserRoleFromContext(ctx)
+	userID := getUserIDFromContext(ctx)
+	pvzID, err := uuid.Parse(ctx.Params("pvzId"))
+	if err != nil {
+		return ctx.Status(fiber.StatusBadRequest).JSON(onlymodels.Error{Message: model.ErrInvalidPVZID})
+	}
+	var req onlymodels.PVZAssignment
+	if err := ctx.BodyParser(&req); err != nil {
+		return ctx.Status(fiber.StatusBadRequest).JSON(onlymodels.Error{Message: model.ErrInvalidRequest})
+	}
+	contWithTimeout, cancel := context.WithTimeout(ctx.UserContext(), cancelContextTime)
+	defer cancel()
+	assignment, err := c.assignmentUseCase.Assign(contWithTimeout, pvzID, &req, userID, userRole)
+	if err != nil {
+		return pvzAssignmentErrorResponse(ctx, err)
+	}
+	return ctx.Status(fiber.StatusCreated).JSON(assignment)
+}
+
+// UnassignEmployee обрабатывает запрос на открепление сотрудника от ПВЗ
+func (c *PVZAssignmentController) UnassignEmployee(ctx *fiber.Ctx) error {
+	if ctx == nil {
+		c.logger.Error("received nil ctx", "controller", "PVZAssignmentController", "method", "UnassignEmployee", "error")
+		return ctx.Status(fiber.StatusBadRequest).JSON(onlymodels.Error{Message: model.ErrInternal})
+	}
+	userRole := getUserRoleFromContext(ctx)
+	pvzID, err := uuid.Parse(ctx.Params("pvzId"))
+	if err != nil {
+		return ctx.Status(fiber.StatusBadRequest).JSON(onlymodels.Error{Message: model.ErrInvalidPVZID})
+	}
+	employeeID, err := uuid.Parse(ctx.Params("userId"))
+	if err != nil {
+		return ctx.Status(fiber.StatusBadRequest).JSON(onlymodels.Error{Message: model.ErrInvalidUserID})
+	}
+	contWithTimeout, cancel := context.WithTimeout(ctx.UserContext(), cancelContextTime)
+	defer cancel()
+	err = c.assignmentUseCase.Unassign(contWithTimeout, pvzID, employeeID, userRole)
+	if err != nil {
+		return pvzAssignmentErrorResponse(ctx, err)
+	}
+	return ctx.SendStatus(fiber.StatusOK)
+}
+
+func pvzAssignmentErrorResponse(ctx *fiber.Ctx, err error) error {
+	switch err.Error() {
+	case model.ErrAccessDenied:
+		return ctx.Status(fiber.StatusForbidden).JSON(onlymodels.Error{Message: err.Error()})
+	case model.ErrPVZNotFound, model.ErrUserNotFound, model.ErrAssignmentNotFound:
+		return ctx.Status(fiber.StatusNotFound).JSON(onlymodels.Error{Message: err.Error()})
+	case model.ErrAssignmentAlreadyExists, model.ErrPVZArchived:
+		return ctx.Status(fiber.StatusConflict).JSON(onlymodels.Error{Message: err.Error()})
+	default:
+		return ctx.Status(fiber.StatusBadRequest).JSON(onlymodels.Error{Message: err.Error()})
+	}
+}
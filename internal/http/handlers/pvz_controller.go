@@ -10,24 +10,37 @@ import (
 	"log"
 )
 
+// headerNextCursor курсор следующей страницы списка ПВЗ. Тело ответа остаётся массивом ради старых клиентов
+const headerNextCursor = "X-Next-Cursor"
+
 // CreatePVZUseCase интерфейс для создания ПВЗ
 type CreatePVZUseCase interface {
 	Execute(ctx context.Context, request *onlymodels.PVZ, userRole string) (*onlymodels.PVZ, error)
 }
 
+// UpdatePVZUseCase интерфейс для изменения профиля ПВЗ
+type UpdatePVZUseCase interface {
+	Execute(ctx context.Context, PVZID uuid.UUID, request *onlymodels.PVZUpdate, userRole string) (*onlymodels.PVZ, error)
+}
+
+// ArchivePVZUseCase интерфейс для переноса ПВЗ в архив
+type ArchivePVZUseCase interface {
+	Execute(ctx context.Context, PVZID uuid.UUID, userID, userRole string) (*onlymodels.PVZ, error)
+}
+
 // DeleteProductUseCase интерфейс для удаления продукта
 type DeleteProductUseCase interface {
-	Execute(ctx context.Context, PVZID uuid.UUID, userRole string) error
+	Execute(ctx context.Context, PVZID uuid.UUID, userID, userRole string) error
 }
 
 // CloseReceptionUseCase интерфейс для закрытия приёмки
 type CloseReceptionUseCase interface {
-	Execute(ctx context.Context, PVZID uuid.UUID, userRole string) (*onlymodels.Reception, error)
+	Execute(ctx context.Context, PVZID uuid.UUID, userID, userRole string) (*onlymodels.Reception, error)
 }
 
 // GetPVZUseCase интерфейс для получения списка ПВЗ
 type GetPVZUseCase interface {
-	GetFiltered(ctx context.Context, startDate, endDate string, page, limit int, userRole string) *onlymodels.GetFilteredResponse
+	GetFiltered(ctx context.Context, startDate, endDate, mode string, page, limit int, cursor string, includeArchived bool, userRole string) (*onlymodels.GetFilteredResponse, string, error)
 }
 
 // PVZController контроллер для управления ПВЗ
@@ -35,6 +48,8 @@ type PVZController struct {
 	closeReceptionUseCase CloseReceptionUseCase
 	deleteProductUseCase  DeleteProductUseCase
 	createPVZUseCase      CreatePVZUseCase
+	updatePVZUseCase      UpdatePVZUseCase
+	archivePVZUseCase     ArchivePVZUseCase
 	getPVZUseCase         GetPVZUseCase
 	logger                Logger
 }
@@ -43,6 +58,8 @@ type PVZController struct {
 func NewPVZController(
 	deleteProductUseCase DeleteProductUseCase,
 	createPVZUseCase CreatePVZUseCase,
+	updatePVZUseCase UpdatePVZUseCase,
+	archivePVZUseCase ArchivePVZUseCase,
 	closeReceptionUseCase CloseReceptionUseCase,
 	getPVZUseCase GetPVZUseCase,
 	logger Logger,
@@ -53,6 +70,12 @@ func NewPVZController(
 	if createPVZUseCase == nil {
 		log.Fatalf("PVZController initialization failed: createPVZUseCase is nil")
 	}
+	if updatePVZUseCase == nil {
+		log.Fatalf("PVZController initialization failed: updatePVZUseCase is nil")
+	}
+	if archivePVZUseCase == nil {
+		log.Fatalf("PVZController initialization failed: archivePVZUseCase is nil")
+	}
 	if closeReceptionUseCase == nil {
 		log.Fatalf("PVZController initialization failed: closeReceptionUseCase is nil")
 	}
@@ -65,6 +88,8 @@ func NewPVZController(
 	return &PVZController{
 		deleteProductUseCase:  deleteProductUseCase,
 		createPVZUseCase:      createPVZUseCase,
+		updatePVZUseCase:      updatePVZUseCase,
+		archivePVZUseCase:     archivePVZUseCase,
 		closeReceptionUseCase: closeReceptionUseCase,
 		getPVZUseCase:         getPVZUseCase,
 		logger:                logger,
@@ -82,7 +107,7 @@ func (c *PVZController) CreatePVZ(ctx *fiber.Ctx) error {
 	if err := ctx.BodyParser(&req); err != nil {
 		return ctx.Status(fiber.StatusBadRequest).JSON(onlymodels.Error{Message: model.ErrInvalidRequest})
 	}
-	contWithTimeout, cancel := context.WithTimeout(context.Background(), cancelContextTime)
+	contWithTimeout, cancel := context.WithTimeout(ctx.UserContext(), cancelContextTime)
 	defer cancel()
 	pvz, err := c.createPVZUseCase.Execute(contWithTimeout, &req, userRole)
 	if err != nil {
@@ -94,6 +119,67 @@ func (c *PVZController) CreatePVZ(ctx *fiber.Ctx) error {
 	return ctx.Status(fiber.StatusCreated).JSON(pvz)
 }
 
+// UpdatePVZ обрабатывает запрос на изменение профиля ПВЗ
+func (c *PVZController) UpdatePVZ(ctx *fiber.Ctx) error {
+	if ctx == nil {
+		c.logger.Error("received nil ctx", "controller", "PVZController", "method", "UpdatePVZ", "error")
+		return ctx.Status(fiber.StatusBadRequest).JSON(onlymodels.Error{Message: model.ErrInternal})
+	}
+	userRole := getUserRoleFromContext(ctx)
+	pvzID, err := uuid.Parse(ctx.Params("pvzId"))
+	if err != nil {
+		return ctx.Status(fiber.StatusBadRequest).JSON(onlymodels.Error{Message: model.ErrInvalidPVZID})
+	}
+	var req onlymodels.PVZUpdate
+	if err := ctx.BodyParser(&req); err != nil {
+		return ctx.Status(fiber.StatusBadRequest).JSON(onlymodels.Error{Message: model.ErrInvalidRequest})
+	}
+	contWithTimeout, cancel := context.WithTimeout(ctx.UserContext(), cancelContextTime)
+	defer cancel()
+	pvz, err := c.updatePVZUseCase.Execute(contWithTimeout, pvzID, &req, userRole)
+	if err != nil {
+		switch err.Error() {
+		case model.ErrAccessDenied:
+			return ctx.Status(fiber.StatusForbidden).JSON(onlymodels.Error{Message: err.Error()})
+		case model.ErrPVZNotFound:
+			return ctx.Status(fiber.StatusNotFound).JSON(onlymodels.Error{Message: err.Error()})
+		default:
+			return ctx.Status(fiber.StatusBadRequest).JSON(onlymodels.Error{Message: err.Error()})
+		}
+	}
+	return ctx.JSON(pvz)
+}
+
+// ArchivePVZ обрабатывает запрос на перенос ПВЗ в архив
+func (c *PVZController) ArchivePVZ(ctx *fiber.Ctx) error {
+	if ctx == nil {
+		c.logger.Error("received nil ctx", "controller", "PVZController", "method", "ArchivePVZ", "error")
+		return ctx.Status(fiber.StatusBadRequest).JSON(onlymodels.Error{Message: model.ErrInternal})
+	}
+	userRole := getUserRoleFromContext(ctx)
+	userID := getUserIDFromContext(ctx)
+	pvzID, err := uuid.Parse(ctx.Params("pvzId"))
+	if err != nil {
+		return ctx.Status(fiber.StatusBadRequest).JSON(onlymodels.Error{Message: model.ErrInvalidPVZID})
+	}
+	contWithTimeout, cancel := context.WithTimeout(ctx.UserContext(), cancelContextTime)
+	defer cancel()
+	pvz, err := c.archivePVZUseCase.Execute(contWithTimeout, pvzID, userID, userRole)
+	if err != nil {
+		switch err.Error() {
+		case model.ErrAccessDenied:
+			return ctx.Status(fiber.StatusForbidden).JSON(onlymodels.Error{Message: err.Error()})
+		case model.ErrPVZNotFound:
+			return ctx.Status(fiber.StatusNotFound).JSON(onlymodels.Error{Message: err.Error()})
+		case model.ErrPVZArchived, model.ErrPVZHasUnfinishedReception:
+			return ctx.Status(fiber.StatusConflict).JSON(onlymodels.Error{Message: err.Error()})
+		default:
+			return ctx.Status(fiber.StatusBadRequest).JSON(onlymodels.Error{Message: err.Error()})
+		}
+	}
+	return ctx.JSON(pvz)
+}
+
 // GetPVZs обрабатывает запрос на получение списка ПВЗ
 func (c *PVZController) GetPVZs(ctx *fiber.Ctx) error {
 	if ctx == nil {
@@ -103,11 +189,23 @@ func (c *PVZController) GetPVZs(ctx *fiber.Ctx) error {
 	userRole := getUserRoleFromContext(ctx)
 	startDate := ctx.Query("startDate")
 	endDate := ctx.Query("endDate")
+	mode := ctx.Query("mode")
 	page := ctx.QueryInt("page")
 	limit := ctx.QueryInt("limit")
-	contWithTimeout, cancel := context.WithTimeout(context.Background(), cancelContextTime)
+	cursor := ctx.Query("cursor")
+	includeArchived := ctx.QueryBool("includeArchived")
+	contWithTimeout, cancel := context.WithTimeout(ctx.UserContext(), cancelContextTime)
 	defer cancel()
-	pvzs := c.getPVZUseCase.GetFiltered(contWithTimeout, startDate, endDate, page, limit, userRole)
+	pvzs, nextCursor, err := c.getPVZUseCase.GetFiltered(contWithTimeout, startDate, endDate, mode, page, limit, cursor, includeArchived, userRole)
+	if err != nil {
+		if err.Error() == model.ErrAccessDenied {
+			return ctx.Status(fiber.StatusForbidden).JSON(onlymodels.Error{Message: err.Error()})
+		}
+		return ctx.Status(fiber.StatusBadRequest).JSON(onlymodels.Error{Message: err.Error()})
+	}
+	if nextCursor != "" {
+		ctx.Set(headerNextCursor, nextCursor)
+	}
 	return ctx.JSON(pvzs)
 }
 
@@ -118,18 +216,16 @@ func (c *PVZController) CloseLastReception(ctx *fiber.Ctx) error {
 		return ctx.Status(fiber.StatusBadRequest).JSON(onlymodels.Error{Message: model.ErrInternal})
 	}
 	userRole := getUserRoleFromContext(ctx)
+	userID := getUserIDFromContext(ctx)
 	pvzID, err := uuid.Parse(ctx.Params("pvzId"))
 	if err != nil {
 		return ctx.Status(fiber.StatusBadRequest).JSON(onlymodels.Error{Message: model.ErrInvalidPVZID})
 	}
-	contWithTimeout, cancel := context.WithTimeout(context.Background(), cancelContextTime)
+	contWithTimeout, cancel := context.WithTimeout(ctx.UserContext(), cancelContextTime)
 	defer cancel()
-	reception, err := c.closeReceptionUseCase.Execute(contWithTimeout, pvzID, userRole)
+	reception, err := c.closeReceptionUseCase.Execute(contWithTimeout, pvzID, userID, userRole)
 	if err != nil {
-		if err.Error() == model.ErrAccessDenied {
-			return ctx.Status(fiber.StatusForbidden).JSON(onlymodels.Error{Message: err.Error()})
-		}
-		return ctx.Status(fiber.StatusBadRequest).JSON(onlymodels.Error{Message: err.Error()})
+		return receptionTransitionErrorResponse(ctx, err)
 	}
 	return ctx.JSON(reception)
 }
@@ -141,13 +237,14 @@ func (c *PVZController) DeleteLastProduct(ctx *fiber.Ctx) error {
 		return ctx.Status(fiber.StatusBadRequest).JSON(onlymodels.Error{Message: model.ErrInternal})
 	}
 	userRole := getUserRoleFromContext(ctx)
+	userID := getUserIDFromContext(ctx)
 	pvzID, err := uuid.Parse(ctx.Params("pvzId"))
 	if err != nil {
 		return ctx.Status(fiber.StatusBadRequest).JSON(onlymodels.Error{Message: model.ErrInvalidPVZID})
 	}
-	contWithTimeout, cancel := context.WithTimeout(context.Background(), cancelContextTime)
+	contWithTimeout, cancel := context.WithTimeout(ctx.UserContext(), cancelContextTime)
 	defer cancel()
-	err = c.deleteProductUseCase.Execute(contWithTimeout, pvzID, userRole)
+	err = c.deleteProductUseCase.Execute(contWithTimeout, pvzID, userID, userRole)
 	if err != nil {
 		if err.Error() == model.ErrAccessDenied {
 			return ctx.Status(fiber.StatusForbidden).JSON(onlymodels.Error{Message: err.Error()})
@@ -12,24 +12,63 @@ import (
 
 // OpenReceptionUseCase интерфейс для открытия приёмки
 type OpenReceptionUseCase interface {
-	Execute(ctx context.Context, PVZID uuid.UUID, userRole string) (*onlymodels.Reception, error)
+	Execute(ctx context.Context, PVZID uuid.UUID, userID, userRole string) (*onlymodels.Reception, error)
+}
+
+// CancelReceptionUseCase интерфейс для отмены приёмки
+type CancelReceptionUseCase interface {
+	Execute(ctx context.Context, PVZID uuid.UUID, userID, userRole string) (*onlymodels.Reception, error)
+}
+
+// PauseReceptionUseCase интерфейс для приостановки приёмки
+type PauseReceptionUseCase interface {
+	Execute(ctx context.Context, PVZID uuid.UUID, userID, userRole string) (*onlymodels.Reception, error)
+}
+
+// ResumeReceptionUseCase интерфейс для возобновления приёмки
+type ResumeReceptionUseCase interface {
+	Execute(ctx context.Context, PVZID uuid.UUID, userID, userRole string) (*onlymodels.Reception, error)
 }
 
 // ReceptionController контроллер для управления приёмками
 type ReceptionController struct {
-	openReceptionUseCase OpenReceptionUseCase
-	logger               Logger
+	openReceptionUseCase   OpenReceptionUseCase
+	cancelReceptionUseCase CancelReceptionUseCase
+	pauseReceptionUseCase  PauseReceptionUseCase
+	resumeReceptionUseCase ResumeReceptionUseCase
+	logger                 Logger
 }
 
 // NewReceptionController конструктор для создания нового экземпляра ReceptionController
-func NewReceptionController(openReceptionUseCase OpenReceptionUseCase, logger Logger) *ReceptionController {
+func NewReceptionController(
+	openReceptionUseCase OpenReceptionUseCase,
+	cancelReceptionUseCase CancelReceptionUseCase,
+	pauseReceptionUseCase PauseReceptionUseCase,
+	resumeReceptionUseCase ResumeReceptionUseCase,
+	logger Logger,
+) *ReceptionController {
 	if openReceptionUseCase == nil {
 		log.Fatalf("ReceptionController initialization failed: openReceptionUseCase is nil")
 	}
+	if cancelReceptionUseCase == nil {
+		log.Fatalf("ReceptionController initialization failed: cancelReceptionUseCase is nil")
+	}
+	if pauseReceptionUseCase == nil {
+		log.Fatalf("ReceptionController initialization failed: pauseReceptionUseCase is nil")
+	}
+	if resumeReceptionUseCase == nil {
+		log.Fatalf("ReceptionController initialization failed: resumeReceptionUseCase is nil")
+	}
 	if logger == nil {
 		log.Fatalf("ReceptionController initialization failed: logger is nil")
 	}
-	return &ReceptionController{openReceptionUseCase: openReceptionUseCase, logger: logger}
+	return &ReceptionController{
+		openReceptionUseCase:   openReceptionUseCase,
+		cancelReceptionUseCase: cancelReceptionUseCase,
+		pauseReceptionUseCase:  pauseReceptionUseCase,
+		resumeReceptionUseCase: resumeReceptionUseCase,
+		logger:                 logger,
+	}
 }
 
 // CreateReception обрабатывает запрос на создание приёмки
@@ -39,13 +78,14 @@ func (c *ReceptionController) CreateReception(ctx *fiber.Ctx) error {
 		return ctx.Status(fiber.StatusBadRequest).JSON(onlymodels.Error{Message: model.ErrInternal})
 	}
 	userRole := getUserRoleFromContext(ctx)
+	userID := getUserIDFromContext(ctx)
 	var req onlymodels.PostReceptionsJSONBody
 	if err := ctx.BodyParser(&req); err != nil {
 		return ctx.Status(fiber.StatusBadRequest).JSON(onlymodels.Error{Message: model.ErrInvalidPVZID})
 	}
-	contWithTimeout, cancel := context.WithTimeout(context.Background(), cancelContextTime)
+	contWithTimeout, cancel := context.WithTimeout(ctx.UserContext(), cancelContextTime)
 	defer cancel()
-	reception, err := c.openReceptionUseCase.Execute(contWithTimeout, req.PvzId, userRole)
+	reception, err := c.openReceptionUseCase.Execute(contWithTimeout, req.PvzId, userID, userRole)
 	if err != nil {
 		if err.Error() == model.ErrAccessDenied {
 			return ctx.Status(fiber.StatusForbidden).JSON(onlymodels.Error{Message: err.Error()})
@@ -54,3 +94,77 @@ func (c *ReceptionController) CreateReception(ctx *fiber.Ctx) error {
 	}
 	return ctx.Status(fiber.StatusCreated).JSON(reception)
 }
+
+// CancelLastReception обрабатывает запрос на отмену последней незавершённой приёмки
+func (c *ReceptionController) CancelLastReception(ctx *fiber.Ctx) error {
+	if ctx == nil {
+		c.logger.Error("received nil ctx", "controller", "ReceptionController", "method", "CancelLastReception", "error")
+		return ctx.Status(fiber.StatusBadRequest).JSON(onlymodels.Error{Message: model.ErrInternal})
+	}
+	userRole := getUserRoleFromContext(ctx)
+	userID := getUserIDFromContext(ctx)
+	pvzID, err := uuid.Parse(ctx.Params("pvzId"))
+	if err != nil {
+		return ctx.Status(fiber.StatusBadRequest).JSON(onlymodels.Error{Message: model.ErrInvalidPVZID})
+	}
+	contWithTimeout, cancel := context.WithTimeout(ctx.UserContext(), cancelContextTime)
+	defer cancel()
+	reception, err := c.cancelReceptionUseCase.Execute(contWithTimeout, pvzID, userID, userRole)
+	if err != nil {
+		return receptionTransitionErrorResponse(ctx, err)
+	}
+	return ctx.JSON(reception)
+}
+
+// PauseLastReception обрабатывает запрос на приостановку открытой приёмки
+func (c *ReceptionController) PauseLastReception(ctx *fiber.Ctx) error {
+	if ctx == nil {
+		c.logger.Error("received nil ctx", "controller", "ReceptionController", "method", "PauseLastReception", "error")
+		return ctx.Status(fiber.StatusBadRequest).JSON(onlymodels.Error{Message: model.ErrInternal})
+	}
+	userRole := getUserRoleFromContext(ctx)
+	userID := getUserIDFromContext(ctx)
+	pvzID, err := uuid.Parse(ctx.Params("pvzId"))
+	if err != nil {
+		return ctx.Status(fiber.StatusBadRequest).JSON(onlymodels.Error{Message: model.ErrInvalidPVZID})
+	}
+	contWithTimeout, cancel := context.WithTimeout(ctx.UserContext(), cancelContextTime)
+	defer cancel()
+	reception, err := c.pauseReceptionUseCase.Execute(contWithTimeout, pvzID, userID, userRole)
+	if err != nil {
+		return receptionTransitionErrorResponse(ctx, err)
+	}
+	return ctx.JSON(reception)
+}
+
+// ResumeLastReception обрабатывает запрос на возобновление приостановленной приёмки
+func (c *ReceptionController) ResumeLastReception(ctx *fiber.Ctx) error {
+	if ctx == nil {
+		c.logger.Error("received nil ctx", "controller", "ReceptionController", "method", "ResumeLastReception", "error")
+		return ctx.Status(fiber.StatusBadRequest).JSON(onlymodels.Error{Message: model.ErrInternal})
+	}
+	userRole := getUserRoleFromContext(ctx)
+	userID := getUserIDFromContext(ctx)
+	pvzID, err := uuid.Parse(ctx.Params("pvzId"))
+	if err != nil {
+		return ctx.Status(fiber.StatusBadRequest).JSON(onlymodels.Error{Message: model.ErrInvalidPVZID})
+	}
+	contWithTimeout, cancel := context.WithTimeout(ctx.UserContext(), cancelContextTime)
+	defer cancel()
+	reception, err := c.resumeReceptionUseCase.Execute(contWithTimeout, pvzID, userID, userRole)
+	if err != nil {
+		return receptionTransitionErrorResponse(ctx, err)
+	}
+	return ctx.JSON(reception)
+}
+
+func receptionTransitionErrorResponse(ctx *fiber.Ctx, err error) error {
+	switch err.Error() {
+	case model.ErrAccessDenied:
+		return ctx.Status(fiber.StatusForbidden).JSON(onlymodels.Error{Message: err.Error()})
+	case model.ErrInvalidReceptionTransition:
+		return ctx.Status(fiber.StatusConflict).JSON(onlymodels.Error{Message: err.Error()})
+	default:
+		return ctx.Status(fiber.StatusBadRequest).JSON(onlymodels.Error{Message: err.Error()})
+	}
+}
@@ -0,0 +1,103 @@
+// Package handlers это http хэндлеры
+package handlers
+
+import (
+	"context"
+	"github.com/gofiber/fiber/v2"
+	"github.com/google/uuid"
+	"internshipPVZ/internal/domain/model"
+	"internshipPVZ/internal/http/onlymodels"
+	"log"
+)
+
+// ServiceAccountUseCase интерфейс для управления сервисными аккаунтами
+type ServiceAccountUseCase interface {
+	Create(ctx context.Context, request *onlymodels.PostServiceAccountsJSONBody, userID, userRole string) (*onlymodels.ServiceAccount, error)
+	List(ctx context.Context, userRole string) ([]onlymodels.ServiceAccount, error)
+	Revoke(ctx context.Context, accountID uuid.UUID, userRole string) (*onlymodels.ServiceAccount, error)
+}
+
+// ServiceAccountController контроллер для управления сервисными аккаунтами
+type ServiceAccountController struct {
+	accountUseCase ServiceAccountUseCase
+	logger         Logger
+}
+
+// NewServiceAccountController конструктор для создания нового экземпляра ServiceAccountController
+func NewServiceAccountController(accountUseCase ServiceAccountUseCase, logger Logger) *ServiceAccountController {
+	if accountUseCase == nil {
+		log.Fatalf("ServiceAccountController initialization failed: accountUseCase is nil")
+	}
+	if logger == nil {
+		log.Fatalf("ServiceAccountController initialization failed: logger is nil")
+	}
+	return &ServiceAccountController{accountUseCase: accountUseCase, logger: logger}
+}
+
+// GetServiceAccounts обрабатывает запрос на получение списка сервисных аккаунтов
+func (c *ServiceAccountController) GetServiceAccounts(ctx *fiber.Ctx) error {
+	if ctx == nil {
+		c.logger.Error("received nil ctx", "controller", "ServiceAccountController", "method", "GetServiceAccounts", "error")
+		return ctx.Status(fiber.StatusBadRequest).JSON(onlymodels.Error{Message: model.ErrInternal})
+	}
+	userRole := getUserRoleFromContext(ctx)
+	contWithTimeout, cancel := context.WithTimeout(ctx.UserContext(), cancelContextTime)
+	defer cancel()
+	accounts, err := c.accountUseCase.List(contWithTimeout, userRole)
+	if err != nil {
+		return serviceAccountErrorResponse(ctx, err)
+	}
+	return ctx.JSON(accounts)
+}
+
+// CreateServiceAccount обрабатывает запрос на создание сервисного аккаунта
+func (c *ServiceAccountController) CreateServiceAccount(ctx *fiber.Ctx) error {
+	if ctx == nil {
+		c.logger.Error("received nil ctx", "controller", "ServiceAccountController", "method", "CreateServiceAccount", "error")
+		return ctx.Status(fiber.StatusBadRequest).JSON(onlymodels.Error{Message: model.ErrInternal})
+	}
+	userRole := getUserRoleFromContext(ctx)
+	userID := getUserIDFromContext(ctx)
+	var req onlymodels.PostServiceAccountsJSONBody
+	if err := ctx.BodyParser(&req); err != nil {
+		return ctx.Status(fiber.StatusBadRequest).JSON(onlymodels.Error{Message: model.ErrInvalidRequest})
+	}
+	contWithTimeout, cancel := context.WithTimeout(ctx.UserContext(), cancelContextTime)
+	defer cancel()
+	account, err := c.accountUseCase.Create(contWithTimeout, &req, userID, userRole)
+	if err != nil {
+		return serviceAccountErrorResponse(ctx, err)
+	}
+	return ctx.Status(fiber.StatusCreated).JSON(account)
+}
+
+// RevokeServiceAccount обрабатывает запрос на отзыв ключа сервисного аккаунта
+func (c *ServiceAccountController) RevokeServiceAccount(ctx *fiber.Ctx) error {
+	if ctx == nil {
+		c.logger.Error("received nil ctx", "controller", "ServiceAccountController", "method", "RevokeServiceAccount", "error")
+		return ctx.Status(fiber.StatusBadRequest).JSON(onlymodels.Error{Message: model.ErrInternal})
+	}
+	userRole := getUserRoleFromContext(ctx)
+	accountID, err := uuid.Parse(ctx.Params("serviceAccountId"))
+	if err != nil {
+		return ctx.Status(fiber.StatusBadRequest).JSON(onlymodels.Error{Message: model.ErrInvalidServiceAccountID})
+	}
+	contWithTimeout, cancel := context.WithTimeout(ctx.UserContext(), cancelContextTime)
+	defer cancel()
+	account, err := c.accountUseCase.Revoke(contWithTimeout, accountID, userRole)
+	if err != nil {
+		return serviceAccountErrorResponse(ctx, err)
+	}
+	return ctx.JSON(account)
+}
+
+func serviceAccountErrorResponse(ctx *fiber.Ctx, err error) error {
+	switch err.Error() {
+	case model.ErrAccessDenied:
+		return ctx.Status(fiber.StatusForbidden).JSON(onlymodels.Error{Message: err.Error()})
+	case model.ErrServiceAccountNotFound:
+		return ctx.Status(fiber.StatusNotFound).JSON(onlymodels.Error{Message: err.Error()})
+	default:
+		return ctx.Status(fiber.StatusBadRequest).JSON(onlymodels.Error{Message: err.Error()})
+	}
+}
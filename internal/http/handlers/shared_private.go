@@ -22,3 +22,13 @@ func getUserRoleFromContext(ctx *fiber.Ctx) string {
 	userRoleStr := ctx.Locals("userRole").(string)
 	return userRoleStr
 }
+
+func getUserIDFromContext(ctx *fiber.Ctx) string {
+	userID, _ := ctx.Locals("userID").(string)
+	return userID
+}
+
+func getSessionIDFromContext(ctx *fiber.Ctx) string {
+	sessionID, _ := ctx.Locals("sessionID").(string)
+	return sessionID
+}
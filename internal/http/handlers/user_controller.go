@@ -0,0 +1,133 @@
+// Package handlers это http хэндлеры
+package handlers
+
+import (
+	"context"
+	"github.com/gofiber/fiber/v2"
+	"github.com/google/uuid"
+	"internshipPVZ/internal/domain/model"
+	"internshipPVZ/internal/http/onlymodels"
+	"log"
+)
+
+// UserUseCase интерфейс для администрирования пользователей
+type UserUseCase interface {
+	List(ctx context.Context, search string, page, limit int, userRole string) ([]onlymodels.User, error)
+	ChangeRole(ctx context.Context, targetID uuid.UUID, request *onlymodels.PutUsersUserIdRoleJSONBody, userID, userRole string) (*onlymodels.User, error)
+	Deactivate(ctx context.Context, targetID uuid.UUID, userID, userRole string) (*onlymodels.User, error)
+	Reactivate(ctx context.Context, targetID uuid.UUID, userID, userRole string) (*onlymodels.User, error)
+}
+
+// UserController контроллер для администрирования пользователей
+type UserController struct {
+	userUseCase UserUseCase
+	logger      Logger
+}
+
+// NewUserController конструктор для создания нового экземпляра UserController
+func NewUserController(userUseCase UserUseCase, logger Logger) *UserController {
+	if userUseCase == nil {
+		log.Fatalf("UserController initialization failed: userUseCase is nil")
+	}
+	if logger == nil {
+		log.Fatalf("UserController initialization failed: logger is nil")
+	}
+	return &UserController{userUseCase: userUseCase, logger: logger}
+}
+
+// GetUsers обрабатывает запрос на получение списка пользователей
+func (c *UserController) GetUsers(ctx *fiber.Ctx) error {
+	if ctx == nil {
+		c.logger.Error("received nil ctx", "controller", "UserController", "method", "GetUsers", "error")
+		return ctx.Status(fiber.StatusBadRequest).JSON(onlymodels.Error{Message: model.ErrInternal})
+	}
+	userRole := getUserRoleFromContext(ctx)
+	search := ctx.Query("search")
+	page := ctx.QueryInt("page")
+	limit := ctx.QueryInt("limit")
+	contWithTimeout, cancel := context.WithTimeout(ctx.UserContext(), cancelContextTime)
+	defer cancel()
+	users, err := c.userUseCase.List(contWithTimeout, search, page, limit, userRole)
+	if err != nil {
+		return userErrorResponse(ctx, err)
+	}
+	return ctx.JSON(users)
+}
+
+// ChangeUserRole обрабатывает запрос на смену роли пользователя
+func (c *UserController) ChangeUserRole(ctx *fiber.Ctx) error {
+	if ctx == nil {
+		c.logger.Error("received nil ctx", "controller", "UserController", "method", "ChangeUserRole", "error")
+		return ctx.Status(fiber.StatusBadRequest).JSON(onlymodels.Error{Message: model.ErrInternal})
+	}
+	userRole := getUserRoleFromContext(ctx)
+	userID := getUserIDFromContext(ctx)
+	targetID, err := uuid.Parse(ctx.Params("userId"))
+	if err != nil {
+		return ctx.Status(fiber.StatusBadRequest).JSON(onlymodels.Error{Message: model.ErrInvalidUserID})
+	}
+	var req onlymodels.PutUsersUserIdRoleJSONBody
+	if err := ctx.BodyParser(&req); err != nil {
+		return ctx.Status(fiber.StatusBadRequest).JSON(onlymodels.Error{Message: model.ErrInvalidRequest})
+	}
+	contWithTimeout, cancel := context.WithTimeout(ctx.UserContext(), cancelContextTime)
+	defer cancel()
+	user, err := c.userUseCase.ChangeRole(contWithTimeout, targetID, &req, userID, userRole)
+	if err != nil {
+		return userErrorResponse(ctx, err)
+	}
+	return ctx.JSON(user)
+}
+
+// DeactivateUser обрабатывает запрос на деактивацию пользователя
+func (c *UserController) DeactivateUser(ctx *fiber.Ctx) error {
+	if ctx == nil {
+		c.logger.Error("received nil ctx", "controller", "UserController", "method", "DeactivateUser", "error")
+		return ctx.Status(fiber.StatusBadRequest).JSON(onlymodels.Error{Message: model.ErrInternal})
+	}
+	userRole := getUserRoleFromContext(ctx)
+	userID := getUserIDFromContext(ctx)
+	targetID, err := uuid.Parse(ctx.Params("userId"))
+	if err != nil {
+		return ctx.Status(fiber.StatusBadRequest).JSON(onlymodels.Error{Message: model.ErrInvalidUserID})
+	}
+	contWithTimeout, cancel := context.WithTimeout(ctx.UserContext(), cancelContextTime)
+	defer cancel()
+	user, err := c.userUseCase.Deactivate(contWithTimeout, targetID, userID, userRole)
+	if err != nil {
+		return userErrorResponse(ctx, err)
+	}
+	return ctx.JSON(user)
+}
+
+// ReactivateUser обрабатывает запрос на повторную активацию пользователя
+func (c *UserController) ReactivateUser(ctx *fiber.Ctx) error {
+	if ctx == nil {
+		c.logger.Error("received nil ctx", "controller", "UserController", "method", "ReactivateUser", "error")
+		return ctx.Status(fiber.StatusBadRequest).JSON(onlymodels.Error{Message: model.ErrInternal})
+	}
+	userRole := getUserRoleFromContext(ctx)
+	userID := getUserIDFromContext(ctx)
+	targetID, err := uuid.Parse(ctx.Params("userId"))
+	if err != nil {
+		return ctx.Status(fiber.StatusBadRequest).JSON(onlymodels.Error{Message: model.ErrInvalidUserID})
+	}
+	contWithTimeout, cancel := context.WithTimeout(ctx.UserContext(), cancelContextTime)
+	defer cancel()
+	user, err := c.userUseCase.Reactivate(contWithTimeout, targetID, userID, userRole)
+	if err != nil {
+		return userErrorResponse(ctx, err)
+	}
+	return ctx.JSON(user)
+}
+
+func userErrorResponse(ctx *fiber.Ctx, err error) error {
+	switch err.Error() {
+	case model.ErrAccessDenied:
+		return ctx.Status(fiber.StatusForbidden).JSON(onlymodels.Error{Message: err.Error()})
+	case model.ErrUserNotFound:
+		return ctx.Status(fiber.StatusNotFound).JSON(onlymodels.Error{Message: err.Error()})
+	default:
+		return ctx.Status(fiber.StatusBadRequest).JSON(onlymodels.Error{Message: err.Error()})
+	}
+}
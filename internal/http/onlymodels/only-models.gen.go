@@ -10,27 +10,57 @@ import (
 )
 
 const (
+	ApiKeyAuthScopes = "apiKeyAuth.Scopes"
 	BearerAuthScopes = "bearerAuth.Scopes"
 )
 
-// Defines values for PVZCity.
+// Defines values for JWKAlg.
 const (
-	Казань         PVZCity = "Казань"
-	Москва         PVZCity = "Москва"
-	СанктПетербург PVZCity = "Санкт-Петербург"
+	EdDSA JWKAlg = "EdDSA"
+	RS256 JWKAlg = "RS256"
 )
 
-// Defines values for ProductType.
+// Defines values for JWKCrv.
 const (
-	ProductTypeОбувь       ProductType = "обувь"
-	ProductTypeОдежда      ProductType = "одежда"
-	ProductTypeЭлектроника ProductType = "электроника"
+	Ed25519 JWKCrv = "Ed25519"
+)
+
+// Defines values for JWKKty.
+const (
+	OKP JWKKty = "OKP"
+	RSA JWKKty = "RSA"
+)
+
+// Defines values for JWKUse.
+const (
+	Sig JWKUse = "sig"
+)
+
+// Defines values for OpeningHoursWeekday.
+const (
+	Friday    OpeningHoursWeekday = "friday"
+	Monday    OpeningHoursWeekday = "monday"
+	Saturday  OpeningHoursWeekday = "saturday"
+	Sunday    OpeningHoursWeekday = "sunday"
+	Thursday  OpeningHoursWeekday = "thursday"
+	Tuesday   OpeningHoursWeekday = "tuesday"
+	Wednesday OpeningHoursWeekday = "wednesday"
+)
+
+// Defines values for ProductStatus.
+const (
+	Issued   ProductStatus = "issued"
+	Received ProductStatus = "received"
+	Returned ProductStatus = "returned"
+	Stored   ProductStatus = "stored"
 )
 
 // Defines values for ReceptionStatus.
 const (
+	Cancelled  ReceptionStatus = "cancelled"
 	Close      ReceptionStatus = "close"
 	InProgress ReceptionStatus = "in_progress"
+	Paused     ReceptionStatus = "paused"
 )
 
 // Defines values for UserRole.
@@ -45,11 +75,10 @@ const (
 	PostDummyLoginJSONBodyRoleModerator PostDummyLoginJSONBodyRole = "moderator"
 )
 
-// Defines values for PostProductsJSONBodyType.
+// Defines values for GetPvzParamsMode.
 const (
-	PostProductsJSONBodyTypeОбувь       PostProductsJSONBodyType = "обувь"
-	PostProductsJSONBodyTypeОдежда      PostProductsJSONBodyType = "одежда"
-	PostProductsJSONBodyTypeЭлектроника PostProductsJSONBodyType = "электроника"
+	All            GetPvzParamsMode = "all"
+	WithReceptions GetPvzParamsMode = "withReceptions"
 )
 
 // Defines values for PostRegisterJSONBodyRole.
@@ -58,6 +87,12 @@ const (
 	Moderator PostRegisterJSONBodyRole = "moderator"
 )
 
+// City defines model for City.
+type City struct {
+	Id   *int32 `json:"id,omitempty"`
+	Name string `json:"name"`
+}
+
 // Error defines model for Error.
 type Error struct {
 	Message string `json:"message"`
@@ -75,54 +110,207 @@ type GetFilteredResponseReceptions = []struct {
 	Reception *Reception `json:"reception,omitempty"`
 }
 
+// JWK Публичный ключ проверки подписи токенов (RFC 7517)
+type JWK struct {
+	Alg JWKAlg  `json:"alg"`
+	Crv *JWKCrv `json:"crv,omitempty"`
+
+	// E Экспонента RSA ключа, base64url
+	E   *string `json:"e,omitempty"`
+	Kid string  `json:"kid"`
+	Kty JWKKty  `json:"kty"`
+
+	// N Модуль RSA ключа, base64url
+	N   *string `json:"n,omitempty"`
+	Use JWKUse  `json:"use"`
+
+	// X Публичный Ed25519 ключ, base64url
+	X *string `json:"x,omitempty"`
+}
+
+// JWKAlg defines model for JWK.Alg.
+type JWKAlg string
+
+// JWKCrv defines model for JWK.Crv.
+type JWKCrv string
+
+// JWKKty defines model for JWK.Kty.
+type JWKKty string
+
+// JWKUse defines model for JWK.Use.
+type JWKUse string
+
+// JWKSet defines model for JWKSet.
+type JWKSet struct {
+	Keys []JWK `json:"keys"`
+}
+
+// ModeratorInvite defines model for ModeratorInvite.
+type ModeratorInvite struct {
+	// Code Код для поля inviteCode в /register
+	Code           string             `json:"code"`
+	ExpiresAt      time.Time          `json:"expiresAt"`
+	OrganizationId openapi_types.UUID `json:"organizationId"`
+}
+
+// OpeningHours defines model for OpeningHours.
+type OpeningHours struct {
+	// Closes Время закрытия в формате HH:MM
+	Closes string `json:"closes"`
+
+	// Opens Время открытия в формате HH:MM
+	Opens   string              `json:"opens"`
+	Weekday OpeningHoursWeekday `json:"weekday"`
+}
+
+// OpeningHoursWeekday defines model for OpeningHours.Weekday.
+type OpeningHoursWeekday string
+
 // PVZ defines model for PVZ.
 type PVZ struct {
-	City             PVZCity             `json:"city"`
-	Id               *openapi_types.UUID `json:"id,omitempty"`
-	RegistrationDate *time.Time          `json:"registrationDate,omitempty"`
+	// Address Адрес ПВЗ
+	Address *string `json:"address,omitempty"`
+
+	// ArchivedAt Время переноса ПВЗ в архив, у действующих ПВЗ отсутствует
+	ArchivedAt *time.Time `json:"archivedAt,omitempty"`
+
+	// City Название города из справочника городов
+	City      string              `json:"city"`
+	Id        *openapi_types.UUID `json:"id,omitempty"`
+	Latitude  *float64            `json:"latitude,omitempty"`
+	Longitude *float64            `json:"longitude,omitempty"`
+
+	// Name Отображаемое название ПВЗ
+	Name             *string    `json:"name,omitempty"`
+	RegistrationDate *time.Time `json:"registrationDate,omitempty"`
+
+	// WorkingHours Недельный график работы, дни без записи считаются выходными
+	WorkingHours *WorkingHours `json:"workingHours,omitempty"`
+}
+
+// PVZAssignment Закрепление сотрудника за ПВЗ, открывать приемки и работать с товарами можно только в закрепленных ПВЗ
+type PVZAssignment struct {
+	AssignedAt *time.Time `json:"assignedAt,omitempty"`
+
+	// AssignedBy ID модератора, закрепившего сотрудника
+	AssignedBy *openapi_types.UUID `json:"assignedBy,omitempty"`
+	PvzId      *openapi_types.UUID `json:"pvzId,omitempty"`
+	UserId     openapi_types.UUID  `json:"userId"`
 }
 
-// PVZCity defines model for PVZ.City.
-type PVZCity string
+// PVZUpdate Изменяемые поля профиля ПВЗ, отсутствующие поля не меняются
+type PVZUpdate struct {
+	Address   *string  `json:"address,omitempty"`
+	Latitude  *float64 `json:"latitude,omitempty"`
+	Longitude *float64 `json:"longitude,omitempty"`
+	Name      *string  `json:"name,omitempty"`
+
+	// WorkingHours Недельный график работы, дни без записи считаются выходными
+	WorkingHours *WorkingHours `json:"workingHours,omitempty"`
+}
 
 // Product defines model for Product.
 type Product struct {
-	DateTime    *time.Time          `json:"dateTime,omitempty"`
-	Id          *openapi_types.UUID `json:"id,omitempty"`
-	ReceptionId openapi_types.UUID  `json:"receptionId"`
-	Type        ProductType         `json:"type"`
+	// AddedBy ID пользователя, добавившего товар
+	AddedBy *openapi_types.UUID `json:"addedBy,omitempty"`
+
+	// Barcode Штрихкод EAN-13 или Code128, у товаров принятых до появления штрихкодов отсутствует
+	Barcode  *string             `json:"barcode,omitempty"`
+	DateTime *time.Time          `json:"dateTime,omitempty"`
+	Id       *openapi_types.UUID `json:"id,omitempty"`
+
+	// OrderId Номер заказа покупателя
+	OrderId     *string            `json:"orderId,omitempty"`
+	ReceptionId openapi_types.UUID `json:"receptionId"`
+
+	// Sku Артикул товара
+	Sku *string `json:"sku,omitempty"`
+
+	// Status Допустимые переходы: received -> stored; stored -> issued, returned; issued -> returned.
+	// Убрать на хранение можно только товар из закрытой приемки, возвращенный отправителю товар не меняет статус
+	Status *ProductStatus `json:"status,omitempty"`
+
+	// Type Название типа товара из справочника типов товаров
+	Type string `json:"type"`
 }
 
-// ProductType defines model for Product.Type.
-type ProductType string
+// ProductStatus Допустимые переходы: received -> stored; stored -> issued, returned; issued -> returned.
+// Убрать на хранение можно только товар из закрытой приемки, возвращенный отправителю товар не меняет статус
+type ProductStatus string
+
+// ProductType defines model for ProductType.
+type ProductType struct {
+	// Active Деактивированный тип нельзя указать у нового товара
+	Active *bool  `json:"active,omitempty"`
+	Id     *int32 `json:"id,omitempty"`
+	Name   string `json:"name"`
+}
 
 // Reception defines model for Reception.
 type Reception struct {
+	// ClosedAt Время закрытия или отмены приемки
+	ClosedAt *time.Time `json:"closedAt,omitempty"`
+
+	// ClosedBy ID пользователя, закрывшего или отменившего приемку
+	ClosedBy *openapi_types.UUID `json:"closedBy,omitempty"`
 	DateTime time.Time           `json:"dateTime"`
 	Id       *openapi_types.UUID `json:"id,omitempty"`
+
+	// OpenedBy ID пользователя, открывшего приемку
+	OpenedBy *openapi_types.UUID `json:"openedBy,omitempty"`
 	PvzId    openapi_types.UUID  `json:"pvzId"`
-	Status   ReceptionStatus     `json:"status"`
+
+	// Status Допустимые переходы: in_progress -> close, cancelled, paused; paused -> in_progress, cancelled.
+	// Закрытая и отмененная приемки не меняют статус
+	Status ReceptionStatus `json:"status"`
 }
 
-// ReceptionStatus defines model for Reception.Status.
+// ReceptionStatus Допустимые переходы: in_progress -> close, cancelled, paused; paused -> in_progress, cancelled.
+// Закрытая и отмененная приемки не меняют статус
 type ReceptionStatus string
 
+// ServiceAccount defines model for ServiceAccount.
+type ServiceAccount struct {
+	// Active false для аккаунтов с отозванным ключом
+	Active bool `json:"active"`
+
+	// ApiKey Ключ для заголовка X-API-Key, возвращается только при создании
+	ApiKey         *string            `json:"apiKey,omitempty"`
+	CreatedAt      time.Time          `json:"createdAt"`
+	Id             openapi_types.UUID `json:"id"`
+	Name           string             `json:"name"`
+	OrganizationId openapi_types.UUID `json:"organizationId"`
+
+	// Scopes Разрешённые ключу действия: pvz:read, receptions:open, receptions:close, products:add, products:delete
+	Scopes []string `json:"scopes"`
+}
+
 // Token defines model for Token.
 type Token = string
 
 // User defines model for User.
 type User struct {
-	Email openapi_types.Email `json:"email"`
-	Id    *openapi_types.UUID `json:"id,omitempty"`
-	Role  UserRole            `json:"role"`
+	// Active false для деактивированных пользователей, им закрыт вход
+	Active *bool               `json:"active,omitempty"`
+	Email  openapi_types.Email `json:"email"`
+	Id     *openapi_types.UUID `json:"id,omitempty"`
+
+	// OrganizationId Организация, которой принадлежат пользователь и его ПВЗ
+	OrganizationId *openapi_types.UUID `json:"organizationId,omitempty"`
+	Role           UserRole            `json:"role"`
 }
 
 // UserRole defines model for User.Role.
 type UserRole string
 
+// WorkingHours Недельный график работы, дни без записи считаются выходными
+type WorkingHours = []OpeningHours
+
 // PostDummyLoginJSONBody defines parameters for PostDummyLogin.
 type PostDummyLoginJSONBody struct {
-	Role PostDummyLoginJSONBodyRole `json:"role"`
+	// OrganizationId Организация для токена, по умолчанию основная сеть
+	OrganizationId *openapi_types.UUID        `json:"organizationId,omitempty"`
+	Role           PostDummyLoginJSONBodyRole `json:"role"`
 }
 
 // PostDummyLoginJSONBodyRole defines parameters for PostDummyLogin.
@@ -134,15 +322,35 @@ type PostLoginJSONBody struct {
 	Password string              `json:"password"`
 }
 
+// PostPasswordChangeJSONBody defines parameters for PostPasswordChange.
+type PostPasswordChangeJSONBody struct {
+	CurrentPassword string `json:"currentPassword"`
+	NewPassword     string `json:"newPassword"`
+}
+
+// PostPasswordResetJSONBody defines parameters for PostPasswordReset.
+type PostPasswordResetJSONBody struct {
+	NewPassword string `json:"newPassword"`
+	Token       string `json:"token"`
+}
+
+// PostPasswordResetRequestJSONBody defines parameters for PostPasswordResetRequest.
+type PostPasswordResetRequestJSONBody struct {
+	Email openapi_types.Email `json:"email"`
+}
+
 // PostProductsJSONBody defines parameters for PostProducts.
 type PostProductsJSONBody struct {
-	PvzId openapi_types.UUID       `json:"pvzId"`
-	Type  PostProductsJSONBodyType `json:"type"`
+	// Barcode Необязательный штрихкод EAN-13 (13 цифр с контрольной цифрой) или Code128 (1-80 печатных ASCII символов)
+	Barcode *string            `json:"barcode,omitempty"`
+	OrderId *string            `json:"orderId,omitempty"`
+	PvzId   openapi_types.UUID `json:"pvzId"`
+	Sku     *string            `json:"sku,omitempty"`
+
+	// Type Название активного типа товара из справочника
+	Type string `json:"type"`
 }
 
-// PostProductsJSONBodyType defines parameters for PostProducts.
-type PostProductsJSONBodyType string
-
 // GetPvzParams defines parameters for GetPvz.
 type GetPvzParams struct {
 	// StartDate Начальная дата диапазона
@@ -151,13 +359,26 @@ type GetPvzParams struct {
 	// EndDate Конечная дата диапазона
 	EndDate *time.Time `form:"endDate,omitempty" json:"endDate,omitempty"`
 
-	// Page Номер страницы
+	// Mode Как даты влияют на список. all - все ПВЗ, у каждого только приёмки из диапазона (у ПВЗ без них receptions пустой);
+	// withReceptions - только ПВЗ, у которых есть приёмки в диапазоне. Пагинация применяется после фильтрации
+	Mode *GetPvzParamsMode `form:"mode,omitempty" json:"mode,omitempty"`
+
+	// Cursor Курсор из заголовка X-Next-Cursor предыдущего ответа, без него возвращается первая страница
+	Cursor *string `form:"cursor,omitempty" json:"cursor,omitempty"`
+
+	// Page Устаревший номер страницы, вместо него используйте cursor. Не учитывается вместе с cursor
 	Page *int `form:"page,omitempty" json:"page,omitempty"`
 
 	// Limit Количество элементов на странице
 	Limit *int `form:"limit,omitempty" json:"limit,omitempty"`
+
+	// IncludeArchived Показывать архивные ПВЗ
+	IncludeArchived *bool `form:"includeArchived,omitempty" json:"includeArchived,omitempty"`
 }
 
+// GetPvzParamsMode defines parameters for GetPvz.
+type GetPvzParamsMode string
+
 // PostReceptionsJSONBody defines parameters for PostReceptions.
 type PostReceptionsJSONBody struct {
 	PvzId openapi_types.UUID `json:"pvzId"`
@@ -165,28 +386,99 @@ type PostReceptionsJSONBody struct {
 
 // PostRegisterJSONBody defines parameters for PostRegister.
 type PostRegisterJSONBody struct {
-	Email    openapi_types.Email      `json:"email"`
-	Password string                   `json:"password"`
-	Role     PostRegisterJSONBodyRole `json:"role"`
+	Email openapi_types.Email `json:"email"`
+
+	// InviteCode Одноразовый код приглашения, обязателен для роли moderator. Организацию задаёт приглашение
+	InviteCode *string `json:"inviteCode,omitempty"`
+
+	// OrganizationId Организация пользователя, по умолчанию основная сеть
+	OrganizationId *openapi_types.UUID      `json:"organizationId,omitempty"`
+	Password       string                   `json:"password"`
+	Role           PostRegisterJSONBodyRole `json:"role"`
 }
 
 // PostRegisterJSONBodyRole defines parameters for PostRegister.
 type PostRegisterJSONBodyRole string
 
+// PostServiceAccountsJSONBody defines parameters for PostServiceAccounts.
+type PostServiceAccountsJSONBody struct {
+	Name   string   `json:"name"`
+	Scopes []string `json:"scopes"`
+}
+
+// PostTokenRefreshJSONBody defines parameters for PostTokenRefresh.
+type PostTokenRefreshJSONBody struct {
+	RefreshToken *string `json:"refreshToken,omitempty"`
+}
+
+// GetUsersParams defines parameters for GetUsers.
+type GetUsersParams struct {
+	// Search Часть email без учёта регистра
+	Search *string `form:"search,omitempty" json:"search,omitempty"`
+
+	// Page Номер страницы
+	Page *int `form:"page,omitempty" json:"page,omitempty"`
+
+	// Limit Количество элементов на странице
+	Limit *int `form:"limit,omitempty" json:"limit,omitempty"`
+}
+
+// PutUsersUserIdRoleJSONBody defines parameters for PutUsersUserIdRole.
+type PutUsersUserIdRoleJSONBody struct {
+	// Role Новая роль, employee или moderator
+	Role string `json:"role"`
+}
+
+// PostCitiesJSONRequestBody defines body for PostCities for application/json ContentType.
+type PostCitiesJSONRequestBody = City
+
+// PutCitiesCityIdJSONRequestBody defines body for PutCitiesCityId for application/json ContentType.
+type PutCitiesCityIdJSONRequestBody = City
+
 // PostDummyLoginJSONRequestBody defines body for PostDummyLogin for application/json ContentType.
 type PostDummyLoginJSONRequestBody PostDummyLoginJSONBody
 
 // PostLoginJSONRequestBody defines body for PostLogin for application/json ContentType.
 type PostLoginJSONRequestBody PostLoginJSONBody
 
+// PostPasswordChangeJSONRequestBody defines body for PostPasswordChange for application/json ContentType.
+type PostPasswordChangeJSONRequestBody PostPasswordChangeJSONBody
+
+// PostPasswordResetJSONRequestBody defines body for PostPasswordReset for application/json ContentType.
+type PostPasswordResetJSONRequestBody PostPasswordResetJSONBody
+
+// PostPasswordResetRequestJSONRequestBody defines body for PostPasswordResetRequest for application/json ContentType.
+type PostPasswordResetRequestJSONRequestBody PostPasswordResetRequestJSONBody
+
+// PostProductTypesJSONRequestBody defines body for PostProductTypes for application/json ContentType.
+type PostProductTypesJSONRequestBody = ProductType
+
+// PutProductTypesTypeIdJSONRequestBody defines body for PutProductTypesTypeId for application/json ContentType.
+type PutProductTypesTypeIdJSONRequestBody = ProductType
+
 // PostProductsJSONRequestBody defines body for PostProducts for application/json ContentType.
 type PostProductsJSONRequestBody PostProductsJSONBody
 
 // PostPvzJSONRequestBody defines body for PostPvz for application/json ContentType.
 type PostPvzJSONRequestBody = PVZ
 
+// PatchPvzPvzIdJSONRequestBody defines body for PatchPvzPvzId for application/json ContentType.
+type PatchPvzPvzIdJSONRequestBody = PVZUpdate
+
+// PostPvzPvzIdAssignmentsJSONRequestBody defines body for PostPvzPvzIdAssignments for application/json ContentType.
+type PostPvzPvzIdAssignmentsJSONRequestBody = PVZAssignment
+
 // PostReceptionsJSONRequestBody defines body for PostReceptions for application/json ContentType.
 type PostReceptionsJSONRequestBody PostReceptionsJSONBody
 
 // PostRegisterJSONRequestBody defines body for PostRegister for application/json ContentType.
 type PostRegisterJSONRequestBody PostRegisterJSONBody
+
+// PostServiceAccountsJSONRequestBody defines body for PostServiceAccounts for application/json ContentType.
+type PostServiceAccountsJSONRequestBody PostServiceAccountsJSONBody
+
+// PostTokenRefreshJSONRequestBody defines body for PostTokenRefresh for application/json ContentType.
+type PostTokenRefreshJSONRequestBody PostTokenRefreshJSONBody
+
+// PutUsersUserIdRoleJSONRequestBody defines body for PutUsersUserIdRole for application/json ContentType.
+type PutUsersUserIdRoleJSONRequestBody PutUsersUserIdRoleJSONBody
@@ -2,7 +2,9 @@
 package http
 
 import (
+	"context"
 	"github.com/gofiber/fiber/v2"
+	"internshipPVZ/internal/domain/model"
 	"internshipPVZ/internal/http/handlers"
 	"internshipPVZ/internal/middleware"
 	"log"
@@ -15,16 +17,26 @@ type AuthController interface {
 	Register(ctx *fiber.Ctx) error
 
 	Login(ctx *fiber.Ctx) error
+
+	RefreshToken(ctx *fiber.Ctx) error
+
+	Logout(ctx *fiber.Ctx) error
 }
 
 // ProductController -
 type ProductController interface {
 	CreateProduct(ctx *fiber.Ctx) error
+	StoreProduct(ctx *fiber.Ctx) error
+	IssueProduct(ctx *fiber.Ctx) error
+	ReturnProduct(ctx *fiber.Ctx) error
+	GetInventory(ctx *fiber.Ctx) error
 }
 
 // PVZController -
 type PVZController interface {
 	CreatePVZ(ctx *fiber.Ctx) error
+	UpdatePVZ(ctx *fiber.Ctx) error
+	ArchivePVZ(ctx *fiber.Ctx) error
 	DeleteLastProduct(ctx *fiber.Ctx) error
 	GetPVZs(ctx *fiber.Ctx) error
 	CloseLastReception(ctx *fiber.Ctx) error
@@ -33,22 +45,125 @@ type PVZController interface {
 // ReceptionController -
 type ReceptionController interface {
 	CreateReception(ctx *fiber.Ctx) error
+	CancelLastReception(ctx *fiber.Ctx) error
+	PauseLastReception(ctx *fiber.Ctx) error
+	ResumeLastReception(ctx *fiber.Ctx) error
+}
+
+// CityController -
+type CityController interface {
+	GetCities(ctx *fiber.Ctx) error
+	CreateCity(ctx *fiber.Ctx) error
+	UpdateCity(ctx *fiber.Ctx) error
+	DeleteCity(ctx *fiber.Ctx) error
+}
+
+// ProductTypeController -
+type ProductTypeController interface {
+	GetProductTypes(ctx *fiber.Ctx) error
+	CreateProductType(ctx *fiber.Ctx) error
+	RenameProductType(ctx *fiber.Ctx) error
+	DeactivateProductType(ctx *fiber.Ctx) error
+}
+
+// PVZAssignmentController -
+type PVZAssignmentController interface {
+	GetAssignments(ctx *fiber.Ctx) error
+	AssignEmployee(ctx *fiber.Ctx) error
+	UnassignEmployee(ctx *fiber.Ctx) error
+}
+
+// PasswordController -
+type PasswordController interface {
+	ChangePassword(ctx *fiber.Ctx) error
+	RequestPasswordReset(ctx *fiber.Ctx) error
+	ResetPassword(ctx *fiber.Ctx) error
+}
+
+// UserController -
+type UserController interface {
+	GetUsers(ctx *fiber.Ctx) error
+	ChangeUserRole(ctx *fiber.Ctx) error
+	DeactivateUser(ctx *fiber.Ctx) error
+	ReactivateUser(ctx *fiber.Ctx) error
+}
+
+// ModeratorInviteController -
+type ModeratorInviteController interface {
+	CreateInvite(ctx *fiber.Ctx) error
+}
+
+// ServiceAccountController -
+type ServiceAccountController interface {
+	GetServiceAccounts(ctx *fiber.Ctx) error
+	CreateServiceAccount(ctx *fiber.Ctx) error
+	RevokeServiceAccount(ctx *fiber.Ctx) error
+}
+
+// JWKSController -
+type JWKSController interface {
+	GetJWKS(ctx *fiber.Ctx) error
+}
+
+// OpenAPIController -
+type OpenAPIController interface {
+	GetSpec(ctx *fiber.Ctx) error
+}
+
+// ProfileConfig профиль развёртывания
+type ProfileConfig interface {
+	GetProfile() model.Profile
 }
 
 // JWTService токены
 type JWTService interface {
-	GetClaims(tokenString string) (string, error)
+	GetClaims(tokenString string) (*model.UserClaims, error)
+}
+
+// SessionChecker проверка отзыва сессий
+type SessionChecker interface {
+	IsSessionActive(ctx context.Context, sessionID string) (bool, error)
+}
+
+// APIKeyChecker проверка ключей сервисных аккаунтов
+type APIKeyChecker interface {
+	AuthenticateAPIKey(ctx context.Context, key string) (*model.ServiceAccountClaims, error)
+}
+
+// apiKeyRoutes маршруты, открытые сервисным аккаунтам; остальные по API-ключу недоступны
+var apiKeyRoutes = []middleware.APIKeyRoute{
+	{Method: fiber.MethodGet, Path: "/pvz", Scope: model.ScopeReadPVZ},
+	{Method: fiber.MethodGet, Path: "/pvz/:pvzId/inventory", Scope: model.ScopeReadPVZ},
+	{Method: fiber.MethodPost, Path: "/receptions", Scope: model.ScopeOpenReception},
+	{Method: fiber.MethodPost, Path: "/pvz/:pvzId/close_last_reception", Scope: model.ScopeCloseReception},
+	{Method: fiber.MethodPost, Path: "/products", Scope: model.ScopeAddProduct},
+	{Method: fiber.MethodPost, Path: "/pvz/:pvzId/delete_last_product", Scope: model.ScopeDeleteProduct},
 }
 
 // NewHTTPServer конструктор
 func NewHTTPServer(
+	profileConfig ProfileConfig,
 	authController AuthController,
 	pvzController PVZController,
 	receptionController ReceptionController,
 	productController ProductController,
+	cityController CityController,
+	productTypeController ProductTypeController,
+	assignmentController PVZAssignmentController,
+	passwordController PasswordController,
+	userController UserController,
+	inviteController ModeratorInviteController,
+	serviceAccountController ServiceAccountController,
+	jwksController JWKSController,
+	openAPIController OpenAPIController,
 	jwtService JWTService,
+	sessionChecker SessionChecker,
+	apiKeyChecker APIKeyChecker,
 	logger handlers.Logger,
 ) *fiber.App {
+	if profileConfig == nil {
+		log.Fatalf("HttpServer initialization failed: profileConfig is nil")
+	}
 	if authController == nil {
 		log.Fatalf("HttpServer initialization failed: authController is nil")
 	}
@@ -61,27 +176,105 @@ func NewHTTPServer(
 	if productController == nil {
 		log.Fatalf("HttpServer initialization failed: productController is nil")
 	}
+	if cityController == nil {
+		log.Fatalf("HttpServer initialization failed: cityController is nil")
+	}
+	if productTypeController == nil {
+		log.Fatalf("HttpServer initialization failed: productTypeController is nil")
+	}
+	if assignmentController == nil {
+		log.Fatalf("HttpServer initialization failed: assignmentController is nil")
+	}
+	if passwordController == nil {
+		log.Fatalf("HttpServer initialization failed: passwordController is nil")
+	}
+	if userController == nil {
+		log.Fatalf("HttpServer initialization failed: userController is nil")
+	}
+	if inviteController == nil {
+		log.Fatalf("HttpServer initialization failed: inviteController is nil")
+	}
+	if serviceAccountController == nil {
+		log.Fatalf("HttpServer initialization failed: serviceAccountController is nil")
+	}
+	if jwksController == nil {
+		log.Fatalf("HttpServer initialization failed: jwksController is nil")
+	}
+	if openAPIController == nil {
+		log.Fatalf("HttpServer initialization failed: openAPIController is nil")
+	}
 	if jwtService == nil {
 		log.Fatalf("HttpServer initialization failed: jwtService is nil")
 	}
+	if sessionChecker == nil {
+		log.Fatalf("HttpServer initialization failed: sessionChecker is nil")
+	}
+	if apiKeyChecker == nil {
+		log.Fatalf("HttpServer initialization failed: apiKeyChecker is nil")
+	}
 	if logger == nil {
 		log.Fatalf("HttpServer initialization failed: logger is nil")
 	}
 
+	publicPaths := []string{"/login", "/register", "/token/refresh", "/.well-known/jwks.json", "/openapi.yaml",
+		"/password/reset/request", "/password/reset"}
+	// вне dev и test выдача токена без пароля недоступна: маршрута нет совсем
+	dummyLoginEnabled := profileConfig.GetProfile().DummyLoginEnabled()
+	if dummyLoginEnabled {
+		publicPaths = append(publicPaths, "/dummyLogin")
+	}
+
 	app := fiber.New()
 
-	app.Use(middleware.AuthMiddleware(jwtService, logger))
+	app.Use(middleware.AuthMiddleware(jwtService, sessionChecker, apiKeyChecker, logger, apiKeyRoutes, dummyLoginEnabled, publicPaths...))
 	app.Use(middleware.PrometheusMiddleware(logger))
 
-	app.Post("/dummyLogin", authController.DummyLogin)
+	app.Get("/.well-known/jwks.json", jwksController.GetJWKS)
+	app.Get("/openapi.yaml", openAPIController.GetSpec)
+	if dummyLoginEnabled {
+		app.Post("/dummyLogin", authController.DummyLogin)
+	}
 	app.Post("/register", authController.Register)
 	app.Post("/login", authController.Login)
+	app.Post("/token/refresh", authController.RefreshToken)
+	app.Post("/logout", authController.Logout)
+	app.Post("/password/change", passwordController.ChangePassword)
+	app.Post("/password/reset/request", passwordController.RequestPasswordReset)
+	app.Post("/password/reset", passwordController.ResetPassword)
+	app.Get("/users", userController.GetUsers)
+	app.Put("/users/:userId/role", userController.ChangeUserRole)
+	app.Post("/users/:userId/deactivate", userController.DeactivateUser)
+	app.Post("/users/:userId/reactivate", userController.ReactivateUser)
+	app.Post("/moderator-invites", inviteController.CreateInvite)
+	app.Get("/service-accounts", serviceAccountController.GetServiceAccounts)
+	app.Post("/service-accounts", serviceAccountController.CreateServiceAccount)
+	app.Post("/service-accounts/:serviceAccountId/revoke", serviceAccountController.RevokeServiceAccount)
 	app.Post("/pvz", pvzController.CreatePVZ)
 	app.Get("/pvz", pvzController.GetPVZs)
+	app.Patch("/pvz/:pvzId", pvzController.UpdatePVZ)
+	app.Post("/pvz/:pvzId/archive", pvzController.ArchivePVZ)
 	app.Post("/pvz/:pvzId/close_last_reception", pvzController.CloseLastReception)
+	app.Post("/pvz/:pvzId/cancel_last_reception", receptionController.CancelLastReception)
+	app.Post("/pvz/:pvzId/pause_last_reception", receptionController.PauseLastReception)
+	app.Post("/pvz/:pvzId/resume_last_reception", receptionController.ResumeLastReception)
 	app.Post("/pvz/:pvzId/delete_last_product", pvzController.DeleteLastProduct)
+	app.Get("/pvz/:pvzId/inventory", productController.GetInventory)
+	app.Get("/pvz/:pvzId/assignments", assignmentController.GetAssignments)
+	app.Post("/pvz/:pvzId/assignments", assignmentController.AssignEmployee)
+	app.Delete("/pvz/:pvzId/assignments/:userId", assignmentController.UnassignEmployee)
 	app.Post("/receptions", receptionController.CreateReception)
 	app.Post("/products", productController.CreateProduct)
+	app.Post("/products/:productId/store", productController.StoreProduct)
+	app.Post("/products/:productId/issue", productController.IssueProduct)
+	app.Post("/products/:productId/return", productController.ReturnProduct)
+	app.Get("/cities", cityController.GetCities)
+	app.Post("/cities", cityController.CreateCity)
+	app.Put("/cities/:cityId", cityController.UpdateCity)
+	app.Delete("/cities/:cityId", cityController.DeleteCity)
+	app.Get("/product_types", productTypeController.GetProductTypes)
+	app.Post("/product_types", productTypeController.CreateProductType)
+	app.Put("/product_types/:typeId", productTypeController.RenameProductType)
+	app.Post("/product_types/:typeId/deactivate", productTypeController.DeactivateProductType)
 
 	return app
 }
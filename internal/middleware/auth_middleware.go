@@ -2,14 +2,34 @@
 package middleware
 
 import (
+	"context"
 	"github.com/gofiber/fiber/v2"
 	"internshipPVZ/internal/domain/model"
 	"internshipPVZ/internal/http/onlymodels"
+	"strings"
 )
 
 // JWTService токены
 type JWTService interface {
-	GetClaims(tokenString string) (string, error)
+	GetClaims(tokenString string) (*model.UserClaims, error)
+}
+
+// SessionChecker проверка отзыва сессий
+type SessionChecker interface {
+	IsSessionActive(ctx context.Context, sessionID string) (bool, error)
+}
+
+// APIKeyChecker проверка ключей сервисных аккаунтов
+type APIKeyChecker interface {
+	AuthenticateAPIKey(ctx context.Context, key string) (*model.ServiceAccountClaims, error)
+}
+
+// APIKeyRoute маршрут, доступный по API-ключу с областью действия Scope.
+// В Path сегменты с двоеточием (:pvzId) совпадают с любым непустым значением
+type APIKeyRoute struct {
+	Method string
+	Path   string
+	Scope  model.APIKeyScope
 }
 
 // Logger логгер
@@ -20,8 +40,22 @@ type Logger interface {
 	Error(msg string, args ...any)
 }
 
-// AuthMiddleware возвращает хэндлер для авторизации
-func AuthMiddleware(jwtService JWTService, logger Logger) fiber.Handler {
+// AuthMiddleware возвращает хэндлер для авторизации, publicPaths пропускаются без токена.
+// Запрос с заголовком X-API-Key проверяется как запрос сервисного аккаунта: пускают только apiKeyRoutes.
+// Токены без сессии принимаются, только если sessionlessTokensAllowed (профили с /dummyLogin)
+func AuthMiddleware(
+	jwtService JWTService,
+	sessionChecker SessionChecker,
+	apiKeyChecker APIKeyChecker,
+	logger Logger,
+	apiKeyRoutes []APIKeyRoute,
+	sessionlessTokensAllowed bool,
+	publicPaths ...string,
+) fiber.Handler {
+	public := make(map[string]struct{}, len(publicPaths))
+	for _, path := range publicPaths {
+		public[path] = struct{}{}
+	}
 	return func(c *fiber.Ctx) error {
 		if c == nil {
 			logger.Error("received nil ctx",
@@ -29,9 +63,12 @@ func AuthMiddleware(jwtService JWTService, logger Logger) fiber.Handler {
 				"error")
 			return c.Status(fiber.StatusBadRequest).JSON(onlymodels.Error{Message: model.ErrInternal})
 		}
-		if c.Path() == "/login" || c.Path() == "/dummyLogin" || c.Path() == "/register" {
+		if _, ok := public[c.Path()]; ok {
 			return c.Next()
 		}
+		if apiKey := c.Get("X-API-Key"); apiKey != "" {
+			return authenticateAPIKey(c, apiKeyChecker, logger, apiKeyRoutes, apiKey)
+		}
 		authHeader := c.Get("Authorization")
 		if authHeader == "" {
 			return c.Status(fiber.StatusForbidden).JSON(onlymodels.Error{
@@ -40,19 +77,114 @@ func AuthMiddleware(jwtService JWTService, logger Logger) fiber.Handler {
 		}
 
 		tokenString := extractToken(authHeader)
-		UserRole, err := jwtService.GetClaims(tokenString)
+		claims, err := jwtService.GetClaims(tokenString)
 		if err != nil {
 			return c.Status(fiber.StatusUnauthorized).JSON(onlymodels.Error{
 				Message: model.ErrAccessDenied,
 			})
 		}
 
-		c.Locals("userRole", UserRole)
+		// токены без сессии выдаёт только /dummyLogin, отзывать у них нечего.
+		// Где /dummyLogin выключен, такой токен не мог быть выдан приложением
+		if claims.SessionID == "" && !sessionlessTokensAllowed {
+			logger.Warn("sessionless token rejected",
+				"middleware", "AuthMiddleware",
+				"user_id", claims.UserID)
+			return c.Status(fiber.StatusUnauthorized).JSON(onlymodels.Error{
+				Message: model.ErrAccessDenied,
+			})
+		}
+		if claims.SessionID != "" {
+			active, err := sessionChecker.IsSessionActive(c.UserContext(), claims.SessionID)
+			if err != nil {
+				return c.Status(fiber.StatusBadRequest).JSON(onlymodels.Error{
+					Message: model.ErrInternal,
+				})
+			}
+			if !active {
+				logger.Warn("session revoked",
+					"middleware", "AuthMiddleware",
+					"session_id", claims.SessionID)
+				return c.Status(fiber.StatusUnauthorized).JSON(onlymodels.Error{
+					Message: model.ErrAccessDenied,
+				})
+			}
+		}
+
+		c.Locals("userRole", claims.Role)
+		c.Locals("userID", claims.UserID)
+		c.Locals("sessionID", claims.SessionID)
+		// организация уходит в контекст запроса, им репозитории ограничивают выборки
+		c.SetUserContext(model.ContextWithOrganizationID(c.UserContext(), claims.OrganizationID))
 
 		return c.Next()
 	}
 }
 
+// authenticateAPIKey пускает сервисный аккаунт с правами сотрудника, но только в маршруты из его областей действия
+func authenticateAPIKey(c *fiber.Ctx, apiKeyChecker APIKeyChecker, logger Logger, routes []APIKeyRoute, apiKey string) error {
+	claims, err := apiKeyChecker.AuthenticateAPIKey(c.UserContext(), apiKey)
+	if err != nil {
+		if err.Error() == model.ErrAccessDenied {
+			return c.Status(fiber.StatusUnauthorized).JSON(onlymodels.Error{
+				Message: model.ErrAccessDenied,
+			})
+		}
+		return c.Status(fiber.StatusBadRequest).JSON(onlymodels.Error{
+			Message: model.ErrInternal,
+		})
+	}
+
+	scope, ok := findAPIKeyScope(routes, c.Method(), c.Path())
+	if !ok || !claims.HasScope(scope) {
+		logger.Warn("API key scope denied",
+			"middleware", "AuthMiddleware",
+			"service_account_id", claims.ID,
+			"method", c.Method(),
+			"path", c.Path())
+		return c.Status(fiber.StatusForbidden).JSON(onlymodels.Error{
+			Message: model.ErrAccessDenied,
+		})
+	}
+
+	c.Locals("userRole", model.RoleEmployee.Get())
+	c.Locals("userID", claims.ID)
+	c.Locals("sessionID", "")
+	ctx := model.ContextWithOrganizationID(c.UserContext(), claims.OrganizationID)
+	c.SetUserContext(model.ContextWithServiceAccountID(ctx, claims.ID))
+
+	return c.Next()
+}
+
+// findAPIKeyScope ищет область действия, которой открыт маршрут, ok ложно для закрытых маршрутов
+func findAPIKeyScope(routes []APIKeyRoute, method, path string) (model.APIKeyScope, bool) {
+	segments := strings.Split(strings.Trim(path, "/"), "/")
+	for _, route := range routes {
+		if route.Method == method && matchRoutePath(strings.Split(strings.Trim(route.Path, "/"), "/"), segments) {
+			return route.Scope, true
+		}
+	}
+	return "", false
+}
+
+func matchRoutePath(pattern, segments []string) bool {
+	if len(pattern) != len(segments) {
+		return false
+	}
+	for i, part := range pattern {
+		if strings.HasPrefix(part, ":") {
+			if segments[i] == "" {
+				return false
+			}
+			continue
+		}
+		if part != segments[i] {
+			return false
+		}
+	}
+	return true
+}
+
 func extractToken(authHeader string) string {
 	if len(authHeader) > 7 && authHeader[:7] == "Bearer " {
 		token := authHeader[7:]
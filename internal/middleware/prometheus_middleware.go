@@ -52,6 +52,22 @@ var (
 			Help: "Total number of products added",
 		},
 	)
+
+	// LoginFailedCount -
+	LoginFailedCount = prometheus.NewCounter(
+		prometheus.CounterOpts{
+			Name: "login_failed_total",
+			Help: "Total number of failed login attempts",
+		},
+	)
+
+	// LoginLockedCount -
+	LoginLockedCount = prometheus.NewCounter(
+		prometheus.CounterOpts{
+			Name: "login_locked_total",
+			Help: "Total number of login attempts rejected due to lockout",
+		},
+	)
 )
 
 func init() {
@@ -60,6 +76,8 @@ func init() {
 	prometheus.MustRegister(HTTPRequestsTotal)
 	prometheus.MustRegister(ProductsAddedCount)
 	prometheus.MustRegister(ReceptionsCreatedCount)
+	prometheus.MustRegister(LoginFailedCount)
+	prometheus.MustRegister(LoginLockedCount)
 }
 
 // PrometheusMiddleware возвращает хэндлер для сбора метрик
@@ -90,6 +108,12 @@ func PrometheusMiddleware(logger Logger) fiber.Handler {
 		if method == "POST" && path == "/products" && status == 201 {
 			ProductsAddedCount.Inc()
 		}
+		if method == "POST" && path == "/login" && status == 401 {
+			LoginFailedCount.Inc()
+		}
+		if method == "POST" && path == "/login" && status == 429 {
+			LoginLockedCount.Inc()
+		}
 		return err
 	}
 }
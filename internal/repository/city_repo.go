@@ -0,0 +1,141 @@
+// Package repository это имплементации репозиториев
+package repository
+
+import (
+	"context"
+	"errors"
+	sqrl "github.com/Masterminds/squirrel"
+	"internshipPVZ/internal/domain/model"
+	"internshipPVZ/internal/domain/repository/dao"
+	"log"
+)
+
+const (
+	errorViolatesUniqueCityNameConstraint = "pq: duplicate key value violates unique constraint \"cities_name_key\""
+	errorViolatesPVZCityForeignKey        = "pq: update or delete on table \"cities\" violates foreign key constraint \"fk_pvz_city\" on table \"pvz\""
+)
+
+// CityRepo реализация репозитория для справочника городов
+type CityRepo struct {
+	db *sqrl.StmtCache
+	qb sqrl.StatementBuilderType
+}
+
+// NewCityRepo конструктор для создания нового экземпляра CityRepo
+func NewCityRepo(config Config) *CityRepo {
+	if config == nil {
+		log.Fatalf("city repo config is nil")
+		return nil
+	}
+	if config.GetDbConnection() == nil {
+		log.Fatalf("city repo config.GetDbConnection() is nil")
+	}
+	return &CityRepo{
+		db: sqrl.NewStmtCache(config.GetDbConnection()),
+		qb: sqrl.StatementBuilder.PlaceholderFormat(sqrl.Dollar),
+	}
+}
+
+// Create добавляет новый город в справочник
+func (r *CityRepo) Create(ctx context.Context, city *dao.City) error {
+	err := r.qb.Insert("cities").
+		Columns("name").
+		Values(city.Name).
+		Suffix("RETURNING id").
+		RunWith(runner(ctx, r.db)).
+		QueryRowContext(ctx).
+		Scan(&city.ID)
+	if err != nil {
+		if err.Error() == errorViolatesUniqueCityNameConstraint {
+			return errors.New(model.ErrCityAlreadyExists)
+		}
+	}
+	return err
+}
+
+// GetAll возвращает весь справочник городов
+func (r *CityRepo) GetAll(ctx context.Context) ([]*dao.City, error) {
+	rows, err := r.qb.Select("id", "name").
+		From("cities").
+		OrderBy("id").
+		RunWith(runner(ctx, r.db)).
+		QueryContext(ctx)
+	if err != nil {
+		return nil, err
+	}
+	var cities []*dao.City
+	for rows.Next() {
+		city := dao.City{}
+		if err := rows.Scan(&city.ID, &city.Name); err != nil {
+			return nil, err
+		}
+		cities = append(cities, &city)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	return cities, nil
+}
+
+// FindByName находит город по названию
+func (r *CityRepo) FindByName(ctx context.Context, name string) (*dao.City, error) {
+	city := &dao.City{}
+	err := r.qb.Select("id", "name").
+		From("cities").
+		Where(sqrl.Eq{"name": name}).
+		RunWith(runner(ctx, r.db)).
+		QueryRowContext(ctx).
+		Scan(&city.ID, &city.Name)
+	if err != nil {
+		if err.Error() == errorNoSQLRows {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return city, nil
+}
+
+// Update переименовывает город
+func (r *CityRepo) Update(ctx context.Context, city *dao.City) error {
+	res, err := r.qb.Update("cities").
+		Set("name", city.Name).
+		Where(sqrl.Eq{"id": city.ID}).
+		RunWith(runner(ctx, r.db)).
+		ExecContext(ctx)
+	if err != nil {
+		if err.Error() == errorViolatesUniqueCityNameConstraint {
+			return errors.New(model.ErrCityAlreadyExists)
+		}
+		return err
+	}
+	affected, err := res.RowsAffected()
+	if err != nil {
+		return err
+	}
+	if affected == 0 {
+		return errors.New(model.ErrCityNotFound)
+	}
+	return nil
+}
+
+// Delete удаляет город, если к нему не привязан ни один ПВЗ
+func (r *CityRepo) Delete(ctx context.Context, id int32) error {
+	res, err := r.qb.Delete("cities").
+		Where(sqrl.Eq{"id": id}).
+		RunWith(runner(ctx, r.db)).
+		ExecContext(ctx)
+	if err != nil {
+		if err.Error() == errorViolatesPVZCityForeignKey {
+			return errors.New(model.ErrCityIsInUse)
+		}
+		return err
+	}
+	affected, err := res.RowsAffected()
+	if err != nil {
+		return err
+	}
+	if affected == 0 {
+		return errors.New(model.ErrCityNotFound)
+	}
+	return nil
+}
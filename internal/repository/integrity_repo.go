@@ -0,0 +1,112 @@
+// Package repository это имплементации репозиториев
+package repository
+
+import (
+	"context"
+	"fmt"
+	sqrl "github.com/Masterminds/squirrel"
+	"github.com/lib/pq"
+	"internshipPVZ/internal/domain/repository/dao"
+	"log"
+)
+
+// integritySampleSize сколько ID нарушителей показывать в отчёте
+const integritySampleSize = 5
+
+// orphanReceptions условие для приёмок, ПВЗ которых не существует
+const orphanReceptions = "NOT EXISTS (SELECT 1 FROM pvz p WHERE p.id = r.pvz_id)"
+
+// severalUnfinishedReceptions условие для незавершённых приёмок ПВЗ, у которого их больше одной.
+// Статусы in_progress (0) и paused (3) те же, что в индексе миграции 017
+const severalUnfinishedReceptions = "r.status IN (0, 3) AND EXISTS " +
+	"(SELECT 1 FROM receptions o WHERE o.pvz_id = r.pvz_id AND o.status IN (0, 3) AND o.id <> r.id)"
+
+// integrityCheck условие where выбирает строки, которые не пройдут ограничения миграций 017 и 018
+type integrityCheck struct {
+	name    string
+	table   string
+	alias   string
+	where   string
+	fixable bool
+}
+
+var integrityChecks = []integrityCheck{
+	{name: "receptions_without_pvz", table: "receptions", alias: "r", where: orphanReceptions, fixable: true},
+	{name: "products_without_reception", table: "products", alias: "p", where: "NOT EXISTS (SELECT 1 FROM receptions r WHERE r.id = p.reception_id)", fixable: true},
+	{name: "receptions_with_unknown_status", table: "receptions", alias: "r", where: "r.status NOT IN (0, 1, 2, 3)"},
+	{name: "pvz_with_several_unfinished_receptions", table: "receptions", alias: "r", where: severalUnfinishedReceptions},
+	{name: "products_with_unknown_status", table: "products", alias: "p", where: "p.status NOT IN (0, 1, 2, 3)"},
+	{name: "users_with_unknown_role", table: "users", alias: "u", where: "u.role NOT IN (0, 1)"},
+}
+
+// IntegrityRepo реализация репозитория проверок целостности
+type IntegrityRepo struct {
+	db *sqrl.StmtCache
+	qb sqrl.StatementBuilderType
+}
+
+// NewIntegrityRepo конструктор для создания нового экземпляра IntegrityRepo
+func NewIntegrityRepo(config Config) *IntegrityRepo {
+	if config == nil {
+		log.Fatalf("integrity repo config is nil")
+		return nil
+	}
+	if config.GetDbConnection() == nil {
+		log.Fatalf("integrity repo config.GetDbConnection() is nil")
+	}
+	return &IntegrityRepo{
+		db: sqrl.NewStmtCache(config.GetDbConnection()),
+		qb: sqrl.StatementBuilder.PlaceholderFormat(sqrl.Dollar),
+	}
+}
+
+// FindViolations считает нарушителей каждой проверки и берёт несколько их ID для отчёта
+func (r *IntegrityRepo) FindViolations(ctx context.Context) ([]*dao.IntegrityViolation, error) {
+	violations := make([]*dao.IntegrityViolation, 0, len(integrityChecks))
+	for _, check := range integrityChecks {
+		violation := &dao.IntegrityViolation{Check: check.name, Fixable: check.fixable}
+		sample := fmt.Sprintf("coalesce((array_agg(%[1]s.id::text ORDER BY %[1]s.id))[1:%[2]d], '{}')", check.alias, integritySampleSize)
+		err := r.qb.Select("count(*)", sample).
+			From(check.table+" "+check.alias).
+			Where(check.where).
+			RunWith(runner(ctx, r.db)).
+			QueryRowContext(ctx).
+			Scan(&violation.Count, pq.Array(&violation.SampleIDs))
+		if err != nil {
+			return nil, err
+		}
+		violations = append(violations, violation)
+	}
+	return violations, nil
+}
+
+// DeleteOrphans удаляет висячие строки, вызывать стоит внутри TxManager, чтобы удаление было атомарным
+func (r *IntegrityRepo) DeleteOrphans(ctx context.Context) (int64, int64, error) {
+	res, err := r.qb.Delete("products p").
+		Where(sqrl.Or{
+			sqrl.Expr("NOT EXISTS (SELECT 1 FROM receptions r WHERE r.id = p.reception_id)"),
+			sqrl.Expr("p.reception_id IN (?)", sqrl.Select("r.id").From("receptions r").Where(orphanReceptions)),
+		}).
+		RunWith(runner(ctx, r.db)).
+		ExecContext(ctx)
+	if err != nil {
+		return 0, 0, err
+	}
+	products, err := res.RowsAffected()
+	if err != nil {
+		return 0, 0, err
+	}
+
+	res, err = r.qb.Delete("receptions r").
+		Where(orphanReceptions).
+		RunWith(runner(ctx, r.db)).
+		ExecContext(ctx)
+	if err != nil {
+		return 0, 0, err
+	}
+	receptions, err := res.RowsAffected()
+	if err != nil {
+		return 0, 0, err
+	}
+	return receptions, products, nil
+}
@@ -0,0 +1,87 @@
+// Package repository это имплементации репозиториев
+package repository
+
+import (
+	"context"
+	sqrl "github.com/Masterminds/squirrel"
+	"internshipPVZ/internal/domain/repository/dao"
+	"log"
+	"time"
+)
+
+// LoginAttemptRepo реализация репозитория неудачных попыток входа
+type LoginAttemptRepo struct {
+	db *sqrl.StmtCache
+	qb sqrl.StatementBuilderType
+}
+
+// NewLoginAttemptRepo конструктор для создания нового экземпляра LoginAttemptRepo
+func NewLoginAttemptRepo(config Config) *LoginAttemptRepo {
+	if config == nil {
+		log.Fatalf("login attempt repo config is nil")
+		return nil
+	}
+	if config.GetDbConnection() == nil {
+		log.Fatalf("login attempt repo config.GetDbConnection() is nil")
+	}
+	return &LoginAttemptRepo{
+		db: sqrl.NewStmtCache(config.GetDbConnection()),
+		qb: sqrl.StatementBuilder.PlaceholderFormat(sqrl.Dollar),
+	}
+}
+
+// Find находит счётчик неудачных попыток по ключу
+func (r *LoginAttemptRepo) Find(ctx context.Context, scope, key string) (*dao.LoginAttempt, error) {
+	attempt := &dao.LoginAttempt{}
+	err := r.qb.Select("scope", "key", "failures", "last_failure_at", "locked_until").
+		From("login_attempts").
+		Where(sqrl.Eq{"scope": scope, "key": key}).
+		RunWith(runner(ctx, r.db)).
+		QueryRowContext(ctx).
+		Scan(&attempt.Scope, &attempt.Key, &attempt.Failures, &attempt.LastFailureAt, &attempt.LockedUntil)
+	if err != nil {
+		if err.Error() == errorNoSQLRows {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return attempt, nil
+}
+
+// RegisterFailure увеличивает счётчик одним upsert, чтобы параллельные попытки с разных реплик не терялись
+func (r *LoginAttemptRepo) RegisterFailure(ctx context.Context, scope, key string, now, resetBefore time.Time) (*dao.LoginAttempt, error) {
+	attempt := &dao.LoginAttempt{}
+	err := r.qb.Insert("login_attempts").
+		Columns("scope", "key", "failures", "last_failure_at").
+		Values(scope, key, 1, now).
+		Suffix(`ON CONFLICT (scope, key) DO UPDATE SET
+			failures = CASE WHEN login_attempts.last_failure_at < ? THEN 1 ELSE login_attempts.failures + 1 END,
+			last_failure_at = EXCLUDED.last_failure_at
+			RETURNING scope, key, failures, last_failure_at, locked_until`, resetBefore).
+		RunWith(runner(ctx, r.db)).
+		QueryRowContext(ctx).
+		Scan(&attempt.Scope, &attempt.Key, &attempt.Failures, &attempt.LastFailureAt, &attempt.LockedUntil)
+	if err != nil {
+		return nil, err
+	}
+	return attempt, nil
+}
+
+// Lock блокирует вход по ключу до указанного момента
+func (r *LoginAttemptRepo) Lock(ctx context.Context, scope, key string, until time.Time) error {
+	_, err := r.qb.Update("login_attempts").
+		Set("locked_until", sqrl.Expr("GREATEST(locked_until, ?)", until)).
+		Where(sqrl.Eq{"scope": scope, "key": key}).
+		RunWith(runner(ctx, r.db)).
+		ExecContext(ctx)
+	return err
+}
+
+// Reset сбрасывает счётчик неудачных попыток после успешного входа
+func (r *LoginAttemptRepo) Reset(ctx context.Context, scope, key string) error {
+	_, err := r.qb.Delete("login_attempts").
+		Where(sqrl.Eq{"scope": scope, "key": key}).
+		RunWith(runner(ctx, r.db)).
+		ExecContext(ctx)
+	return err
+}
@@ -0,0 +1,81 @@
+// Package repository это имплементации репозиториев
+package repository
+
+import (
+	"context"
+	sqrl "github.com/Masterminds/squirrel"
+	"internshipPVZ/internal/domain/repository/dao"
+	"log"
+	"time"
+)
+
+// ModeratorInviteRepo реализация репозитория приглашений модераторов
+type ModeratorInviteRepo struct {
+	db *sqrl.StmtCache
+	qb sqrl.StatementBuilderType
+}
+
+// NewModeratorInviteRepo конструктор для создания нового экземпляра ModeratorInviteRepo
+func NewModeratorInviteRepo(config Config) *ModeratorInviteRepo {
+	if config == nil {
+		log.Fatalf("moderator invite repo config is nil")
+		return nil
+	}
+	if config.GetDbConnection() == nil {
+		log.Fatalf("moderator invite repo config.GetDbConnection() is nil")
+	}
+	return &ModeratorInviteRepo{
+		db: sqrl.NewStmtCache(config.GetDbConnection()),
+		qb: sqrl.StatementBuilder.PlaceholderFormat(sqrl.Dollar),
+	}
+}
+
+// Create добавляет приглашение в организацию модератора, который его выдал
+func (r *ModeratorInviteRepo) Create(ctx context.Context, invite *dao.ModeratorInvite) error {
+	organizationID, err := contextOrganizationID(ctx)
+	if err != nil {
+		return err
+	}
+	err = r.qb.Insert("moderator_invites").
+		Columns("organization_id", "code_hash", "created_by", "created_at", "expires_at").
+		Values(organizationID, invite.CodeHash, invite.CreatedBy, invite.CreatedAt, invite.ExpiresAt).
+		Suffix("RETURNING id").
+		RunWith(runner(ctx, r.db)).
+		QueryRowContext(ctx).
+		Scan(&invite.ID)
+	if err != nil {
+		return err
+	}
+	invite.OrganizationID = organizationID
+	return nil
+}
+
+// Consume использует приглашение одним UPDATE, поэтому по одному коду не зарегистрируются двое
+func (r *ModeratorInviteRepo) Consume(ctx context.Context, codeHash string, now time.Time) (*dao.ModeratorInvite, error) {
+	invite := &dao.ModeratorInvite{}
+	err := r.qb.Update("moderator_invites").
+		Set("used_at", now).
+		Where(sqrl.Eq{"code_hash": codeHash, "used_at": nil}).
+		Where(sqrl.Gt{"expires_at": now}).
+		Suffix("RETURNING id, organization_id, code_hash, created_by, created_at, expires_at, used_at").
+		RunWith(runner(ctx, r.db)).
+		QueryRowContext(ctx).
+		Scan(&invite.ID, &invite.OrganizationID, &invite.CodeHash, &invite.CreatedBy, &invite.CreatedAt, &invite.ExpiresAt, &invite.UsedAt)
+	if err != nil {
+		if err.Error() == errorNoSQLRows {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return invite, nil
+}
+
+// Release сбрасывает отметку об использовании приглашения
+func (r *ModeratorInviteRepo) Release(ctx context.Context, id string) error {
+	_, err := r.qb.Update("moderator_invites").
+		Set("used_at", nil).
+		Where(sqrl.Eq{"id": id}).
+		RunWith(runner(ctx, r.db)).
+		ExecContext(ctx)
+	return err
+}
@@ -0,0 +1,98 @@
+// Package repository это имплементации репозиториев
+package repository
+
+import (
+	"context"
+	"errors"
+	sqrl "github.com/Masterminds/squirrel"
+	"internshipPVZ/internal/domain/model"
+	"internshipPVZ/internal/domain/repository/dao"
+	"log"
+)
+
+const (
+	errorViolatesUniqueOrganizationNameConstraint = "pq: duplicate key value violates unique constraint \"organizations_name_key\""
+)
+
+// errOrganizationMissing запрос к данным организации без организации в контексте
+var errOrganizationMissing = errors.New("organization ID is missing in context")
+
+// OrganizationRepo реализация репозитория организаций
+type OrganizationRepo struct {
+	db *sqrl.StmtCache
+	qb sqrl.StatementBuilderType
+}
+
+// NewOrganizationRepo конструктор для создания нового экземпляра OrganizationRepo
+func NewOrganizationRepo(config Config) *OrganizationRepo {
+	if config == nil {
+		log.Fatalf("organization repo config is nil")
+		return nil
+	}
+	if config.GetDbConnection() == nil {
+		log.Fatalf("organization repo config.GetDbConnection() is nil")
+	}
+	return &OrganizationRepo{
+		db: sqrl.NewStmtCache(config.GetDbConnection()),
+		qb: sqrl.StatementBuilder.PlaceholderFormat(sqrl.Dollar),
+	}
+}
+
+// Create добавляет новую организацию
+func (r *OrganizationRepo) Create(ctx context.Context, organization *dao.Organization) error {
+	err := r.qb.Insert("organizations").
+		Columns("name").
+		Values(organization.Name).
+		Suffix("RETURNING id, created_at").
+		RunWith(runner(ctx, r.db)).
+		QueryRowContext(ctx).
+		Scan(&organization.ID, &organization.CreatedAt)
+	if err != nil {
+		if err.Error() == errorViolatesUniqueOrganizationNameConstraint {
+			return errors.New(model.ErrOrganizationAlreadyExists)
+		}
+	}
+	return err
+}
+
+// FindByID находит организацию по ID
+func (r *OrganizationRepo) FindByID(ctx context.Context, id string) (*dao.Organization, error) {
+	organization := &dao.Organization{}
+	err := r.qb.Select("id", "name", "created_at").
+		From("organizations").
+		Where(sqrl.Eq{"id": id}).
+		RunWith(runner(ctx, r.db)).
+		QueryRowContext(ctx).
+		Scan(&organization.ID, &organization.Name, &organization.CreatedAt)
+	if err != nil {
+		if err.Error() == errorNoSQLRows {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return organization, nil
+}
+
+// contextOrganizationID возвращает организацию, которой ограничены запросы к ПВЗ, приёмкам и продуктам
+func contextOrganizationID(ctx context.Context) (string, error) {
+	organizationID, ok := model.OrganizationIDFromContext(ctx)
+	if !ok {
+		return "", errOrganizationMissing
+	}
+	return organizationID, nil
+}
+
+// organizationPVZIDs подзапрос ID всех ПВЗ организации.
+// Подзапросы собираются с плейсхолдерами "?", нумерацию $n проставляет внешний запрос
+func organizationPVZIDs(organizationID string) sqrl.SelectBuilder {
+	return sqrl.Select("id").
+		From("pvz").
+		Where(sqrl.Eq{"organization_id": organizationID})
+}
+
+// organizationReceptionIDs подзапрос ID всех приёмок организации
+func organizationReceptionIDs(organizationID string) sqrl.SelectBuilder {
+	return sqrl.Select("id").
+		From("receptions").
+		Where(sqrl.Expr("pvz_id IN (?)", organizationPVZIDs(organizationID)))
+}
@@ -0,0 +1,83 @@
+// Package repository это имплементации репозиториев
+package repository
+
+import (
+	"context"
+	"errors"
+	sqrl "github.com/Masterminds/squirrel"
+	"internshipPVZ/internal/domain/model"
+	"internshipPVZ/internal/domain/repository/dao"
+	"log"
+	"time"
+)
+
+const (
+	errorViolatesPasswordResetTokenUserForeignKey = "pq: insert or update on table \"password_reset_tokens\" violates foreign key constraint \"fk_password_reset_tokens_user\""
+)
+
+// PasswordResetTokenRepo реализация репозитория токенов сброса пароля
+type PasswordResetTokenRepo struct {
+	db *sqrl.StmtCache
+	qb sqrl.StatementBuilderType
+}
+
+// NewPasswordResetTokenRepo конструктор для создания нового экземпляра PasswordResetTokenRepo
+func NewPasswordResetTokenRepo(config Config) *PasswordResetTokenRepo {
+	if config == nil {
+		log.Fatalf("password reset token repo config is nil")
+		return nil
+	}
+	if config.GetDbConnection() == nil {
+		log.Fatalf("password reset token repo config.GetDbConnection() is nil")
+	}
+	return &PasswordResetTokenRepo{
+		db: sqrl.NewStmtCache(config.GetDbConnection()),
+		qb: sqrl.StatementBuilder.PlaceholderFormat(sqrl.Dollar),
+	}
+}
+
+// Create добавляет новый токен сброса пароля
+func (r *PasswordResetTokenRepo) Create(ctx context.Context, token *dao.PasswordResetToken) error {
+	err := r.qb.Insert("password_reset_tokens").
+		Columns("user_id", "token_hash", "created_at", "expires_at").
+		Values(token.UserID, token.TokenHash, token.CreatedAt, token.ExpiresAt).
+		Suffix("RETURNING id").
+		RunWith(runner(ctx, r.db)).
+		QueryRowContext(ctx).
+		Scan(&token.ID)
+	if err != nil {
+		if err.Error() == errorViolatesPasswordResetTokenUserForeignKey {
+			return errors.New(model.ErrUserNotFound)
+		}
+	}
+	return err
+}
+
+// Consume использует токен одним UPDATE, поэтому два параллельных сброса одним токеном не пройдут
+func (r *PasswordResetTokenRepo) Consume(ctx context.Context, tokenHash string, now time.Time) (*dao.PasswordResetToken, error) {
+	token := &dao.PasswordResetToken{}
+	err := r.qb.Update("password_reset_tokens").
+		Set("used_at", now).
+		Where(sqrl.Eq{"token_hash": tokenHash, "used_at": nil}).
+		Where(sqrl.Gt{"expires_at": now}).
+		Suffix("RETURNING id, user_id, token_hash, created_at, expires_at, used_at").
+		RunWith(runner(ctx, r.db)).
+		QueryRowContext(ctx).
+		Scan(&token.ID, &token.UserID, &token.TokenHash, &token.CreatedAt, &token.ExpiresAt, &token.UsedAt)
+	if err != nil {
+		if err.Error() == errorNoSQLRows {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return token, nil
+}
+
+// DeleteByUser удаляет все токены сброса пароля пользователя
+func (r *PasswordResetTokenRepo) DeleteByUser(ctx context.Context, userID string) error {
+	_, err := r.qb.Delete("password_reset_tokens").
+		Where(sqrl.Eq{"user_id": userID}).
+		RunWith(runner(ctx, r.db)).
+		ExecContext(ctx)
+	return err
+}
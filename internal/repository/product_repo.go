@@ -3,12 +3,31 @@ package repository
 
 import (
 	"context"
+	"errors"
 	sqrl "github.com/Masterminds/squirrel"
 	"github.com/gofiber/fiber/v2/log"
 	"github.com/jmoiron/sqlx"
+	"internshipPVZ/internal/domain/model"
 	"internshipPVZ/internal/domain/repository/dao"
 )
 
+const (
+	errorViolatesUniqueReceptionBarcodeConstraint = "pq: duplicate key value violates unique constraint \"uq_products_reception_barcode\""
+	errorViolatesNotNullProductReceptionID        = "pq: null value in column \"reception_id\" of relation \"products\" violates not-null constraint"
+)
+
+var productColumns = []string{
+	"p.id",
+	"p.date_time",
+	"p.reception_id",
+	"p.type",
+	"p.barcode",
+	"p.sku",
+	"p.order_id",
+	"p.added_by",
+	"p.status",
+}
+
 // ProductRepo реализация репозитория для продуктов
 type ProductRepo struct {
 	db *sqrl.StmtCache
@@ -35,25 +54,47 @@ func NewProductRepo(config Config) *ProductRepo {
 	}
 }
 
-// Add добавляет новый продукт в базу данных
+// Add добавляет новый продукт в базу данных, штрихкод уникален в пределах приёмки.
+// Приёмку чужой организации подзапрос превращает в NULL, и вставка падает на not-null ограничении
 func (r *ProductRepo) Add(ctx context.Context, product *dao.Product) error {
-	err := r.qb.Insert("products").
-		Columns("reception_id", "date_time", "type").
-		Values(product.ReceptionID, product.DateTime, product.Type).
+	organizationID, err := contextOrganizationID(ctx)
+	if err != nil {
+		return err
+	}
+	receptionID := sqrl.Expr("(SELECT r.id FROM receptions r INNER JOIN pvz p ON p.id = r.pvz_id WHERE r.id = ? AND p.organization_id = ?)",
+		product.ReceptionID, organizationID)
+	err = r.qb.Insert("products").
+		Columns("reception_id", "date_time", "type", "barcode", "sku", "order_id", "added_by", "status").
+		Values(receptionID, product.DateTime, product.Type, product.Barcode, product.SKU, product.OrderID, product.AddedBy, product.Status).
 		Suffix("RETURNING id").
-		RunWith(r.db).
+		RunWith(runner(ctx, r.db)).
 		QueryRowContext(ctx).
 		Scan(&product.ID)
+	if err != nil {
+		switch err.Error() {
+		case errorViolatesUniqueReceptionBarcodeConstraint:
+			return errors.New(model.ErrDuplicateBarcode)
+		case errorViolatesNotNullProductReceptionID:
+			return errors.New(model.ErrNoActiveReception)
+		}
+	}
 	return err
 }
 
 // CountProducts возвращает количество продуктов для данной приёмки
 func (r *ProductRepo) CountProducts(ctx context.Context, receptionID string) (int, error) {
+	organizationID, err := contextOrganizationID(ctx)
+	if err != nil {
+		return 0, err
+	}
 	count := 0
-	err := r.qb.Select("count(*)").
+	err = r.qb.Select("count(*)").
 		From("products").
-		Where(sqrl.Eq{"reception_id": receptionID}).
-		RunWith(r.db).
+		Where(sqrl.And{
+			sqrl.Eq{"reception_id": receptionID},
+			sqrl.Expr("reception_id IN (?)", organizationReceptionIDs(organizationID)),
+		}).
+		RunWith(runner(ctx, r.db)).
 		QueryRowContext(ctx).
 		Scan(&count)
 	if err != nil {
@@ -62,15 +103,139 @@ func (r *ProductRepo) CountProducts(ctx context.Context, receptionID string) (in
 	return count, nil
 }
 
-// DeleteLastFromReception удаляет последний добавленный продукт из приёмки
+// DeleteLastFromReception удаляет последний добавленный продукт из приёмки.
+// Удаление идёт только из приёмки в статусе in_progress, иначе возвращается ErrNoActiveReception
 func (r *ProductRepo) DeleteLastFromReception(ctx context.Context, receptionID string) error {
+	organizationID, err := contextOrganizationID(ctx)
+	if err != nil {
+		return err
+	}
+	inProgress := organizationReceptionIDs(organizationID).
+		Where(sqrl.Eq{"status": model.ReceptionInProgress.ToInt()})
 	subQuery := r.qb.Select("id").
 		From("products").
-		Where(sqrl.Eq{"reception_id": receptionID}).
+		Where(sqrl.And{
+			sqrl.Eq{"reception_id": receptionID},
+			sqrl.Expr("reception_id IN (?)", inProgress),
+		}).
 		OrderBy("date_time DESC").
 		Limit(1)
-	_, err := r.qb.Delete("products").
+	res, err := r.qb.Delete("products").
 		Where(sqrl.Expr("id IN (?)", subQuery)).
-		RunWith(r.db).ExecContext(ctx)
-	return err
+		RunWith(runner(ctx, r.db)).ExecContext(ctx)
+	if err != nil {
+		return err
+	}
+	affected, err := res.RowsAffected()
+	if err != nil {
+		return err
+	}
+	if affected == 0 {
+		return errors.New(model.ErrNoActiveReception)
+	}
+	return nil
+}
+
+// FindByID находит продукт по ID
+func (r *ProductRepo) FindByID(ctx context.Context, id string) (*dao.Product, error) {
+	organizationID, err := contextOrganizationID(ctx)
+	if err != nil {
+		return nil, err
+	}
+	row := r.qb.Select(productColumns...).
+		From("products p").
+		Where(sqrl.And{
+			sqrl.Eq{"p.id": id},
+			sqrl.Expr("p.reception_id IN (?)", organizationReceptionIDs(organizationID)),
+		}).
+		RunWith(runner(ctx, r.db)).
+		QueryRowContext(ctx)
+	product, err := scanProduct(row)
+	if err != nil {
+		if err.Error() == errorNoSQLRows {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return product, nil
+}
+
+// ChangeStatus переводит продукт в новый статус, только если его текущий статус равен from
+func (r *ProductRepo) ChangeStatus(ctx context.Context, product *dao.Product, from int8) error {
+	organizationID, err := contextOrganizationID(ctx)
+	if err != nil {
+		return err
+	}
+	res, err := r.qb.Update("products").
+		Set("status", product.Status).
+		Where(sqrl.And{
+			sqrl.Eq{"id": product.ID, "status": from},
+			sqrl.Expr("reception_id IN (?)", organizationReceptionIDs(organizationID)),
+		}).
+		RunWith(runner(ctx, r.db)).ExecContext(ctx)
+	if err != nil {
+		return err
+	}
+	affected, err := res.RowsAffected()
+	if err != nil {
+		return err
+	}
+	if affected == 0 {
+		return errors.New(model.ErrInvalidProductTransition)
+	}
+	return nil
+}
+
+// GetInventory возвращает продукты ПВЗ в порядке приёма
+func (r *ProductRepo) GetInventory(ctx context.Context, pvzID string, productStatuses, receptionStatuses []int8) ([]*dao.Product, error) {
+	organizationID, err := contextOrganizationID(ctx)
+	if err != nil {
+		return nil, err
+	}
+	rows, err := r.qb.Select(productColumns...).
+		From("products p").
+		InnerJoin("receptions r ON r.id = p.reception_id").
+		Where(sqrl.And{
+			sqrl.Eq{"r.pvz_id": pvzID},
+			sqrl.Expr("r.pvz_id IN (?)", organizationPVZIDs(organizationID)),
+			sqrl.Eq{"p.status": productStatuses},
+			sqrl.Eq{"r.status": receptionStatuses},
+		}).
+		OrderBy("p.date_time").
+		RunWith(runner(ctx, r.db)).
+		QueryContext(ctx)
+	if err != nil {
+		return nil, err
+	}
+	var products []*dao.Product
+	for rows.Next() {
+		product, err := scanProduct(rows)
+		if err != nil {
+			return nil, err
+		}
+		products = append(products, product)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	return products, nil
+}
+
+func scanProduct(row sqrl.RowScanner) (*dao.Product, error) {
+	product := &dao.Product{}
+	err := row.Scan(
+		&product.ID,
+		&product.DateTime,
+		&product.ReceptionID,
+		&product.Type,
+		&product.Barcode,
+		&product.SKU,
+		&product.OrderID,
+		&product.AddedBy,
+		&product.Status,
+	)
+	if err != nil {
+		return nil, err
+	}
+	return product, nil
 }
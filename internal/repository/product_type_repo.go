@@ -0,0 +1,132 @@
+// Package repository это имплементации репозиториев
+package repository
+
+import (
+	"context"
+	"errors"
+	sqrl "github.com/Masterminds/squirrel"
+	"internshipPVZ/internal/domain/model"
+	"internshipPVZ/internal/domain/repository/dao"
+	"log"
+)
+
+const (
+	errorViolatesUniqueProductTypeNameConstraint = "pq: duplicate key value violates unique constraint \"product_types_name_key\""
+)
+
+// ProductTypeRepo реализация репозитория для справочника типов продуктов
+type ProductTypeRepo struct {
+	db *sqrl.StmtCache
+	qb sqrl.StatementBuilderType
+}
+
+// NewProductTypeRepo конструктор для создания нового экземпляра ProductTypeRepo
+func NewProductTypeRepo(config Config) *ProductTypeRepo {
+	if config == nil {
+		log.Fatalf("product type repo config is nil")
+		return nil
+	}
+	if config.GetDbConnection() == nil {
+		log.Fatalf("product type repo config.GetDbConnection() is nil")
+	}
+	return &ProductTypeRepo{
+		db: sqrl.NewStmtCache(config.GetDbConnection()),
+		qb: sqrl.StatementBuilder.PlaceholderFormat(sqrl.Dollar),
+	}
+}
+
+// Create добавляет новый активный тип продукта в справочник
+func (r *ProductTypeRepo) Create(ctx context.Context, productType *dao.ProductType) error {
+	err := r.qb.Insert("product_types").
+		Columns("name").
+		Values(productType.Name).
+		Suffix("RETURNING id, active").
+		RunWith(runner(ctx, r.db)).
+		QueryRowContext(ctx).
+		Scan(&productType.ID, &productType.Active)
+	if err != nil {
+		if err.Error() == errorViolatesUniqueProductTypeNameConstraint {
+			return errors.New(model.ErrProductTypeAlreadyExists)
+		}
+	}
+	return err
+}
+
+// GetAll возвращает весь справочник типов продуктов, включая деактивированные
+func (r *ProductTypeRepo) GetAll(ctx context.Context) ([]*dao.ProductType, error) {
+	rows, err := r.qb.Select("id", "name", "active").
+		From("product_types").
+		OrderBy("id").
+		RunWith(runner(ctx, r.db)).
+		QueryContext(ctx)
+	if err != nil {
+		return nil, err
+	}
+	var productTypes []*dao.ProductType
+	for rows.Next() {
+		productType := dao.ProductType{}
+		if err := rows.Scan(&productType.ID, &productType.Name, &productType.Active); err != nil {
+			return nil, err
+		}
+		productTypes = append(productTypes, &productType)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	return productTypes, nil
+}
+
+// FindByName находит тип продукта по названию
+func (r *ProductTypeRepo) FindByName(ctx context.Context, name string) (*dao.ProductType, error) {
+	productType := &dao.ProductType{}
+	err := r.qb.Select("id", "name", "active").
+		From("product_types").
+		Where(sqrl.Eq{"name": name}).
+		RunWith(runner(ctx, r.db)).
+		QueryRowContext(ctx).
+		Scan(&productType.ID, &productType.Name, &productType.Active)
+	if err != nil {
+		if err.Error() == errorNoSQLRows {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return productType, nil
+}
+
+// Rename переименовывает тип продукта
+func (r *ProductTypeRepo) Rename(ctx context.Context, productType *dao.ProductType) error {
+	err := r.qb.Update("product_types").
+		Set("name", productType.Name).
+		Where(sqrl.Eq{"id": productType.ID}).
+		Suffix("RETURNING active").
+		RunWith(runner(ctx, r.db)).
+		QueryRowContext(ctx).
+		Scan(&productType.Active)
+	if err != nil {
+		switch err.Error() {
+		case errorViolatesUniqueProductTypeNameConstraint:
+			return errors.New(model.ErrProductTypeAlreadyExists)
+		case errorNoSQLRows:
+			return errors.New(model.ErrProductTypeNotFound)
+		}
+	}
+	return err
+}
+
+// Deactivate запрещает использовать тип продукта для новых товаров
+func (r *ProductTypeRepo) Deactivate(ctx context.Context, productType *dao.ProductType) error {
+	err := r.qb.Update("product_types").
+		Set("active", false).
+		Where(sqrl.Eq{"id": productType.ID}).
+		Suffix("RETURNING name, active").
+		RunWith(runner(ctx, r.db)).
+		QueryRowContext(ctx).
+		Scan(&productType.Name, &productType.Active)
+	if err != nil {
+		if err.Error() == errorNoSQLRows {
+			return errors.New(model.ErrProductTypeNotFound)
+		}
+	}
+	return err
+}
@@ -0,0 +1,141 @@
+// Package repository это имплементации репозиториев
+package repository
+
+import (
+	"context"
+	"errors"
+	sqrl "github.com/Masterminds/squirrel"
+	"internshipPVZ/internal/domain/model"
+	"internshipPVZ/internal/domain/repository/dao"
+	"log"
+)
+
+const (
+	errorViolatesUniqueAssignmentConstraint = "pq: duplicate key value violates unique constraint \"pvz_assignments_pkey\""
+	errorViolatesAssignmentUserForeignKey   = "pq: insert or update on table \"pvz_assignments\" violates foreign key constraint \"fk_pvz_assignments_user\""
+	errorViolatesAssignmentPVZForeignKey    = "pq: insert or update on table \"pvz_assignments\" violates foreign key constraint \"fk_pvz_assignments_pvz\""
+	errorViolatesNotNullAssignmentUserID    = "pq: null value in column \"user_id\" of relation \"pvz_assignments\" violates not-null constraint"
+	errorViolatesNotNullAssignmentPVZID     = "pq: null value in column \"pvz_id\" of relation \"pvz_assignments\" violates not-null constraint"
+)
+
+// PVZAssignmentRepo реализация репозитория закреплений сотрудников за ПВЗ
+type PVZAssignmentRepo struct {
+	db *sqrl.StmtCache
+	qb sqrl.StatementBuilderType
+}
+
+// NewPVZAssignmentRepo конструктор для создания нового экземпляра PVZAssignmentRepo
+func NewPVZAssignmentRepo(config Config) *PVZAssignmentRepo {
+	if config == nil {
+		log.Fatalf("pvz assignment repo config is nil")
+		return nil
+	}
+	if config.GetDbConnection() == nil {
+		log.Fatalf("pvz assignment repo config.GetDbConnection() is nil")
+	}
+	return &PVZAssignmentRepo{
+		db: sqrl.NewStmtCache(config.GetDbConnection()),
+		qb: sqrl.StatementBuilder.PlaceholderFormat(sqrl.Dollar),
+	}
+}
+
+// Assign закрепляет сотрудника за ПВЗ.
+// Сотрудник и ПВЗ должны принадлежать организации из контекста, иначе подзапрос вернёт NULL
+func (r *PVZAssignmentRepo) Assign(ctx context.Context, assignment *dao.PVZAssignment) error {
+	organizationID, err := contextOrganizationID(ctx)
+	if err != nil {
+		return err
+	}
+	userID := sqrl.Expr("(SELECT id FROM users WHERE id = ? AND organization_id = ?)", assignment.UserID, organizationID)
+	pvzID := sqrl.Expr("(SELECT id FROM pvz WHERE id = ? AND organization_id = ?)", assignment.PVZID, organizationID)
+	_, err = r.qb.Insert("pvz_assignments").
+		Columns("user_id", "pvz_id", "assigned_by", "assigned_at").
+		Values(userID, pvzID, assignment.AssignedBy, assignment.AssignedAt).
+		RunWith(runner(ctx, r.db)).
+		ExecContext(ctx)
+	if err != nil {
+		switch err.Error() {
+		case errorViolatesUniqueAssignmentConstraint:
+			return errors.New(model.ErrAssignmentAlreadyExists)
+		case errorViolatesAssignmentUserForeignKey, errorViolatesNotNullAssignmentUserID:
+			return errors.New(model.ErrUserNotFound)
+		case errorViolatesAssignmentPVZForeignKey, errorViolatesNotNullAssignmentPVZID:
+			return errors.New(model.ErrPVZNotFound)
+		}
+	}
+	return err
+}
+
+// Unassign открепляет сотрудника от ПВЗ
+func (r *PVZAssignmentRepo) Unassign(ctx context.Context, userID, pvzID string) error {
+	organizationID, err := contextOrganizationID(ctx)
+	if err != nil {
+		return err
+	}
+	res, err := r.qb.Delete("pvz_assignments").
+		Where(sqrl.Eq{"user_id": userID, "pvz_id": pvzID}).
+		Where(sqrl.Expr("pvz_id IN (?)", organizationPVZIDs(organizationID))).
+		RunWith(runner(ctx, r.db)).
+		ExecContext(ctx)
+	if err != nil {
+		return err
+	}
+	affected, err := res.RowsAffected()
+	if err != nil {
+		return err
+	}
+	if affected == 0 {
+		return errors.New(model.ErrAssignmentNotFound)
+	}
+	return nil
+}
+
+// GetByPVZ возвращает сотрудников, закреплённых за ПВЗ, в порядке закрепления
+func (r *PVZAssignmentRepo) GetByPVZ(ctx context.Context, pvzID string) ([]*dao.PVZAssignment, error) {
+	organizationID, err := contextOrganizationID(ctx)
+	if err != nil {
+		return nil, err
+	}
+	rows, err := r.qb.Select("user_id", "pvz_id", "assigned_by", "assigned_at").
+		From("pvz_assignments").
+		Where(sqrl.Eq{"pvz_id": pvzID}).
+		Where(sqrl.Expr("pvz_id IN (?)", organizationPVZIDs(organizationID))).
+		OrderBy("assigned_at").
+		RunWith(runner(ctx, r.db)).
+		QueryContext(ctx)
+	if err != nil {
+		return nil, err
+	}
+	var assignments []*dao.PVZAssignment
+	for rows.Next() {
+		assignment := dao.PVZAssignment{}
+		if err := rows.Scan(&assignment.UserID, &assignment.PVZID, &assignment.AssignedBy, &assignment.AssignedAt); err != nil {
+			return nil, err
+		}
+		assignments = append(assignments, &assignment)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	return assignments, nil
+}
+
+// CheckIfAssigned проверяет, закреплён ли сотрудник за ПВЗ
+func (r *PVZAssignmentRepo) CheckIfAssigned(ctx context.Context, userID, pvzID string) (bool, error) {
+	organizationID, err := contextOrganizationID(ctx)
+	if err != nil {
+		return false, err
+	}
+	count := 0
+	err = r.qb.Select("count(*)").
+		From("pvz_assignments").
+		Where(sqrl.Eq{"user_id": userID, "pvz_id": pvzID}).
+		Where(sqrl.Expr("pvz_id IN (?)", organizationPVZIDs(organizationID))).
+		RunWith(runner(ctx, r.db)).
+		QueryRowContext(ctx).
+		Scan(&count)
+	if err != nil {
+		return false, err
+	}
+	return count == 1, nil
+}
@@ -3,11 +3,18 @@ package repository
 
 import (
 	"context"
+	"encoding/json"
+	"errors"
 	sqrl "github.com/Masterminds/squirrel"
+	"github.com/lib/pq"
+	"internshipPVZ/internal/domain/model"
 	"internshipPVZ/internal/domain/repository/dao"
 	"log"
+	"time"
 )
 
+var pvzColumns = []string{"id", "registration_date", "city", "name", "address", "latitude", "longitude", "working_hours", "archived_at", "archived_by"}
+
 // PvzRepo реализация репозитория для ПВЗ
 type PvzRepo struct {
 	db *sqrl.StmtCache
@@ -29,108 +36,348 @@ func NewPVZRepo(config Config) *PvzRepo {
 	}
 }
 
-// Create добавляет новый ПВЗ в базу данных
+// Create добавляет новый ПВЗ в организацию из контекста
 func (r *PvzRepo) Create(ctx context.Context, pvz *dao.PVZ) error {
-	err := r.qb.Insert("pvz").
-		Columns("registration_date", "city").
-		Values(pvz.RegistrationDate, pvz.City).
+	organizationID, err := contextOrganizationID(ctx)
+	if err != nil {
+		return err
+	}
+	workingHours, err := marshalWorkingHours(pvz.WorkingHours)
+	if err != nil {
+		return err
+	}
+	err = r.qb.Insert("pvz").
+		Columns("registration_date", "city", "name", "address", "latitude", "longitude", "working_hours", "organization_id").
+		Values(pvz.RegistrationDate, pvz.City, pvz.Name, pvz.Address, pvz.Latitude, pvz.Longitude, workingHours, organizationID).
 		Suffix("RETURNING id").
-		RunWith(r.db).
+		RunWith(runner(ctx, r.db)).
 		QueryRowContext(ctx).Scan(&pvz.ID)
 	return err
 }
 
-// GetAllWithFilter возвращает список ПВЗ с фильтрацией по дате и пагинацией
-func (r *PvzRepo) GetAllWithFilter(ctx context.Context, startDate, endDate string, page, limit int) ([]*dao.PVZList, error) {
-	offset := (page - 1) * limit
-	subQuery := r.qb.Select("id").
+// FindByID находит ПВЗ организации из контекста по ID
+func (r *PvzRepo) FindByID(ctx context.Context, id string) (*dao.PVZ, error) {
+	return r.findByID(ctx, id, "")
+}
+
+// FindByIDForUpdate находит ПВЗ и блокирует его строку до конца транзакции
+func (r *PvzRepo) FindByIDForUpdate(ctx context.Context, id string) (*dao.PVZ, error) {
+	return r.findByID(ctx, id, "FOR UPDATE")
+}
+
+func (r *PvzRepo) findByID(ctx context.Context, id, lock string) (*dao.PVZ, error) {
+	organizationID, err := contextOrganizationID(ctx)
+	if err != nil {
+		return nil, err
+	}
+	query := r.qb.Select(pvzColumns...).
 		From("pvz").
-		OrderBy("id").
-		Limit(uint64(limit)).
-		Offset(uint64(offset))
+		Where(sqrl.Eq{"id": id, "organization_id": organizationID})
+	if lock != "" {
+		query = query.Suffix(lock)
+	}
+	pvz := &dao.PVZ{}
+	var workingHours []byte
+	err = query.
+		RunWith(runner(ctx, r.db)).
+		QueryRowContext(ctx).
+		Scan(
+			&pvz.ID,
+			&pvz.RegistrationDate,
+			&pvz.City,
+			&pvz.Name,
+			&pvz.Address,
+			&pvz.Latitude,
+			&pvz.Longitude,
+			&workingHours,
+			&pvz.ArchivedAt,
+			&pvz.ArchivedBy,
+		)
+	if err != nil {
+		if err.Error() == errorNoSQLRows {
+			return nil, nil
+		}
+		return nil, err
+	}
+	if pvz.WorkingHours, err = unmarshalWorkingHours(workingHours); err != nil {
+		return nil, err
+	}
+	return pvz, nil
+}
+
+// Update сохраняет профиль ПВЗ: название, адрес, координаты и график работы
+func (r *PvzRepo) Update(ctx context.Context, pvz *dao.PVZ) error {
+	organizationID, err := contextOrganizationID(ctx)
+	if err != nil {
+		return err
+	}
+	workingHours, err := marshalWorkingHours(pvz.WorkingHours)
+	if err != nil {
+		return err
+	}
+	_, err = r.qb.Update("pvz").
+		Set("name", pvz.Name).
+		Set("address", pvz.Address).
+		Set("latitude", pvz.Latitude).
+		Set("longitude", pvz.Longitude).
+		Set("working_hours", workingHours).
+		Where(sqrl.Eq{"id": pvz.ID, "organization_id": organizationID}).
+		RunWith(runner(ctx, r.db)).
+		ExecContext(ctx)
+	return err
+}
+
+// Archive переносит ПВЗ в архив, запоминая кто и когда это сделал
+func (r *PvzRepo) Archive(ctx context.Context, pvz *dao.PVZ) error {
+	organizationID, err := contextOrganizationID(ctx)
+	if err != nil {
+		return err
+	}
+	res, err := r.qb.Update("pvz").
+		Set("archived_at", pvz.ArchivedAt).
+		Set("archived_by", pvz.ArchivedBy).
+		Where(sqrl.Eq{"id": pvz.ID, "organization_id": organizationID, "archived_at": nil}).
+		RunWith(runner(ctx, r.db)).
+		ExecContext(ctx)
+	if err != nil {
+		return err
+	}
+	affected, err := res.RowsAffected()
+	if err != nil {
+		return err
+	}
+	if affected == 0 {
+		return errors.New(model.ErrPVZArchived)
+	}
+	return nil
+}
+
+// GetAllWithFilter возвращает страницу ПВЗ с приёмками, отфильтрованными по дате.
+// Сначала отбирается страница ПВЗ, при OnlyWithReceptions только с приёмками в диапазоне, затем к ней
+// присоединяются приёмки из диапазона, так что ПВЗ без них остаются в ответе с пустыми полями приёмки.
+// ПВЗ упорядочены по дате регистрации и ID, next указывает на последний ПВЗ страницы, если за ним есть ещё
+func (r *PvzRepo) GetAllWithFilter(ctx context.Context, filter dao.PVZListFilter) ([]*dao.PVZList, *dao.PVZCursor, error) {
+	organizationID, err := contextOrganizationID(ctx)
+	if err != nil {
+		return nil, nil, err
+	}
+	inRange := receptionsInRange(filter.StartDate, filter.EndDate)
+
+	pageQuery := r.qb.Select("p.id", "p.registration_date").
+		From("pvz p").
+		Where(sqrl.Eq{"p.organization_id": organizationID})
+	if !filter.IncludeArchived {
+		pageQuery = pageQuery.Where(sqrl.Eq{"p.archived_at": nil})
+	}
+	if filter.OnlyWithReceptions {
+		pageQuery = pageQuery.Where(sqrl.Expr("EXISTS (?)", sqrl.Select("1").From("receptions r").Where(inRange)))
+	}
+	pageQuery = pvzKeyset(pageQuery, filter.After, filter.Limit)
+	// page устарел, OFFSET остаётся только для клиентов без курсора
+	if filter.After == nil && filter.Page > 1 {
+		pageQuery = pageQuery.Offset(uint64((filter.Page - 1) * filter.Limit))
+	}
+
+	pageRows, err := pageQuery.RunWith(runner(ctx, r.db)).QueryContext(ctx)
+	if err != nil {
+		return nil, nil, err
+	}
+	var page []dao.PVZCursor
+	for pageRows.Next() {
+		var cursor dao.PVZCursor
+		if err := pageRows.Scan(&cursor.ID, &cursor.RegistrationDate); err != nil {
+			pageRows.Close()
+			return nil, nil, err
+		}
+		page = append(page, cursor)
+	}
+	if err := pageRows.Close(); err != nil {
+		return nil, nil, err
+	}
+	var next *dao.PVZCursor
+	if filter.Limit > 0 && len(page) > filter.Limit {
+		page = page[:filter.Limit]
+		next = &page[filter.Limit-1]
+	}
+	if len(page) == 0 {
+		return nil, nil, nil
+	}
+	ids := make([]string, 0, len(page))
+	for _, cursor := range page {
+		ids = append(ids, cursor.ID)
+	}
+	receptionJoin, receptionJoinArgs, err := inRange.ToSql()
+	if err != nil {
+		return nil, nil, err
+	}
 
 	pvzQuery := r.qb.Select(
 		"p.id",
 		"p.registration_date",
 		"p.city",
+		"p.name",
+		"p.address",
+		"p.latitude",
+		"p.longitude",
+		"p.working_hours",
+		"p.archived_at",
 		"r.id",
 		"r.date_time",
 		"r.status",
+		"r.opened_by",
+		"r.closed_by",
+		"r.closed_at",
 		"pr.id",
 		"pr.date_time",
 		"pr.type",
+		"pr.barcode",
+		"pr.sku",
+		"pr.order_id",
+		"pr.added_by",
+		"pr.status",
 	).
 		From("pvz p").
-		InnerJoin("receptions r ON p.id = r.pvz_id").
-		LeftJoin("products pr ON r.id = pr.reception_id")
-	if len(startDate) > 0 {
-		pvzQuery = pvzQuery.Where(sqrl.GtOrEq{"r.date_time": startDate})
-	}
-	if len(endDate) > 0 {
-		pvzQuery = pvzQuery.Where(sqrl.LtOrEq{"r.date_time": endDate})
-	}
-	pvzQuery = pvzQuery.Where(sqrl.Expr("p.id in (?)", subQuery))
+		LeftJoin("receptions r ON "+receptionJoin, receptionJoinArgs...).
+		LeftJoin("products pr ON r.id = pr.reception_id").
+		Where("p.id = ANY(?::uuid[])", pq.Array(ids)).
+		OrderBy("p.registration_date", "p.id", "r.date_time")
 
-	pvzRows, err := pvzQuery.RunWith(r.db).QueryContext(ctx)
+	pvzRows, err := pvzQuery.RunWith(runner(ctx, r.db)).QueryContext(ctx)
 	if err != nil {
-		return nil, err
+		return nil, nil, err
 	}
 
 	var pvzs []*dao.PVZList
 	for pvzRows.Next() {
 		pvz := dao.PVZList{}
+		var workingHours []byte
 		err := pvzRows.Scan(
 			&pvz.PvzID,
 			&pvz.RegistrationDate,
 			&pvz.City,
+			&pvz.Name,
+			&pvz.Address,
+			&pvz.Latitude,
+			&pvz.Longitude,
+			&workingHours,
+			&pvz.ArchivedAt,
 			&pvz.ReceptionID,
 			&pvz.ReceptionDateTime,
 			&pvz.Status,
+			&pvz.OpenedBy,
+			&pvz.ClosedBy,
+			&pvz.ClosedAt,
 			&pvz.ProductID,
 			&pvz.ProductDateTime,
 			&pvz.Type,
+			&pvz.Barcode,
+			&pvz.SKU,
+			&pvz.OrderID,
+			&pvz.AddedBy,
+			&pvz.ProductStatus,
 		)
 		if err != nil {
-			return nil, err
+			return nil, nil, err
+		}
+		if pvz.WorkingHours, err = unmarshalWorkingHours(workingHours); err != nil {
+			return nil, nil, err
 		}
 		pvzs = append(pvzs, &pvz)
 	}
 	if err := pvzRows.Close(); err != nil {
-		return nil, err
+		return nil, nil, err
 	}
-	return pvzs, nil
+	return pvzs, next, nil
 }
 
-// Get возвращает список всех ПВЗ
-func (r *PvzRepo) Get(ctx context.Context) ([]*dao.PVZ, error) {
-	rows, err := r.qb.Select("id", "registration_date", "city").From("pvz").
-		RunWith(r.db).QueryContext(ctx)
+// Get возвращает ПВЗ организации из контекста по порядку даты регистрации и ID.
+// При limit > 0 отдаёт страницу после after, next указывает на последний ПВЗ страницы, если за ним есть ещё
+func (r *PvzRepo) Get(ctx context.Context, after *dao.PVZCursor, limit int, includeArchived bool) ([]*dao.PVZ, *dao.PVZCursor, error) {
+	organizationID, err := contextOrganizationID(ctx)
 	if err != nil {
-		return nil, err
+		return nil, nil, err
+	}
+	query := r.qb.Select(pvzColumns...).
+		From("pvz").
+		Where(sqrl.Eq{"organization_id": organizationID})
+	if !includeArchived {
+		query = query.Where(sqrl.Eq{"archived_at": nil})
 	}
+	query = pvzKeyset(query, after, limit)
+	rows, err := query.RunWith(runner(ctx, r.db)).QueryContext(ctx)
+	if err != nil {
+		return nil, nil, err
+	}
+	defer rows.Close()
 	var pvzs []*dao.PVZ
 	for rows.Next() {
 		pvz := dao.PVZ{}
+		var workingHours []byte
 		err := rows.Scan(
 			&pvz.ID,
 			&pvz.RegistrationDate,
 			&pvz.City,
+			&pvz.Name,
+			&pvz.Address,
+			&pvz.Latitude,
+			&pvz.Longitude,
+			&workingHours,
+			&pvz.ArchivedAt,
+			&pvz.ArchivedBy,
 		)
 		if err != nil {
-			return nil, err
+			return nil, nil, err
+		}
+		if pvz.WorkingHours, err = unmarshalWorkingHours(workingHours); err != nil {
+			return nil, nil, err
 		}
 		pvzs = append(pvzs, &pvz)
 	}
-	return pvzs, nil
+	var next *dao.PVZCursor
+	if limit > 0 && len(pvzs) > limit {
+		pvzs = pvzs[:limit]
+		last := pvzs[limit-1]
+		next = &dao.PVZCursor{RegistrationDate: last.RegistrationDate, ID: last.ID}
+	}
+	return pvzs, next, nil
+}
+
+// receptionsInRange условие на приёмки ПВЗ с алиасом p, пустая граница диапазона не ограничивает
+func receptionsInRange(startDate, endDate string) sqrl.And {
+	condition := sqrl.And{sqrl.Expr("r.pvz_id = p.id")}
+	if len(startDate) > 0 {
+		condition = append(condition, sqrl.GtOrEq{"r.date_time": startDate})
+	}
+	if len(endDate) > 0 {
+		condition = append(condition, sqrl.LtOrEq{"r.date_time": endDate})
+	}
+	return condition
 }
 
-// CheckIfExists проверяет существование ПВЗ по ID
+// pvzKeyset упорядочивает выборку из pvz по дате регистрации и ID и начинает её сразу после курсора.
+// Берётся на строку больше limit, чтобы понять, есть ли следующая страница
+func pvzKeyset(query sqrl.SelectBuilder, after *dao.PVZCursor, limit int) sqrl.SelectBuilder {
+	if after != nil {
+		query = query.Where("(registration_date, id) > (?::date, ?::uuid)", after.RegistrationDate.Format(time.DateOnly), after.ID)
+	}
+	query = query.OrderBy("registration_date", "id")
+	if limit > 0 {
+		query = query.Limit(uint64(limit) + 1)
+	}
+	return query
+}
+
+// CheckIfExists проверяет существование ПВЗ организации из контекста по ID
 func (r *PvzRepo) CheckIfExists(ctx context.Context, id string) (bool, error) {
+	organizationID, err := contextOrganizationID(ctx)
+	if err != nil {
+		return false, err
+	}
 	count := 0
-	err := r.qb.Select("count(*)").
+	err = r.qb.Select("count(*)").
 		From("pvz").
-		Where(sqrl.Eq{"id": id}).
-		RunWith(r.db).
+		Where(sqrl.Eq{"id": id, "organization_id": organizationID}).
+		RunWith(runner(ctx, r.db)).
 		QueryRowContext(ctx).
 		Scan(&count)
 	if err != nil {
@@ -138,3 +385,21 @@ func (r *PvzRepo) CheckIfExists(ctx context.Context, id string) (bool, error) {
 	}
 	return count == 1, nil
 }
+
+// marshalWorkingHours возвращает строку, т.к. []byte драйвер передаёт как bytea, а не jsonb
+func marshalWorkingHours(workingHours []dao.OpeningHours) (string, error) {
+	if workingHours == nil {
+		workingHours = []dao.OpeningHours{}
+	}
+	raw, err := json.Marshal(workingHours)
+	return string(raw), err
+}
+
+func unmarshalWorkingHours(raw []byte) ([]dao.OpeningHours, error) {
+	var workingHours []dao.OpeningHours
+	if len(raw) == 0 {
+		return workingHours, nil
+	}
+	err := json.Unmarshal(raw, &workingHours)
+	return workingHours, err
+}
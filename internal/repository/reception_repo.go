@@ -3,13 +3,17 @@ package repository
 
 import (
 	"context"
+	"errors"
 	sqrl "github.com/Masterminds/squirrel"
+	"internshipPVZ/internal/domain/model"
 	"internshipPVZ/internal/domain/repository/dao"
 	"log"
 )
 
 const (
-	errorNoSQLRows string = "sql: no rows in result set"
+	errorNoSQLRows                         string = "sql: no rows in result set"
+	errorViolatesNotNullReceptionPVZID     string = "pq: null value in column \"pvz_id\" of relation \"receptions\" violates not-null constraint"
+	errorViolatesUniqueUnfinishedReception string = "pq: duplicate key value violates unique constraint \"uq_receptions_pvz_unfinished\""
 )
 
 // ReceptionRepo реализация репозитория для приёмок
@@ -33,28 +37,48 @@ func NewReceptionRepo(config Config) *ReceptionRepo {
 	}
 }
 
-// Create добавляет новую приёмку в базу данных
+// Create добавляет новую приёмку в базу данных.
+// ПВЗ чужой организации подзапрос превращает в NULL, и вставка падает на not-null ограничении.
+// Вторую незавершённую приёмку ПВЗ не пропускает уникальный индекс, это ErrReceptionAlreadyOpened
 func (r *ReceptionRepo) Create(ctx context.Context, rec *dao.Reception) error {
-	err := r.qb.Insert("receptions").
-		Columns("pvz_id", "date_time", "status").
-		Values(rec.PVZID, rec.DateTime, rec.Status).
+	organizationID, err := contextOrganizationID(ctx)
+	if err != nil {
+		return err
+	}
+	pvzID := sqrl.Expr("(SELECT id FROM pvz WHERE id = ? AND organization_id = ?)", rec.PVZID, organizationID)
+	err = r.qb.Insert("receptions").
+		Columns("pvz_id", "date_time", "status", "opened_by").
+		Values(pvzID, rec.DateTime, rec.Status, rec.OpenedBy).
 		Suffix("RETURNING id").
-		RunWith(r.db).
+		RunWith(runner(ctx, r.db)).
 		QueryRowContext(ctx).
 		Scan(&rec.ID)
+	if err != nil {
+		switch err.Error() {
+		case errorViolatesNotNullReceptionPVZID:
+			return errors.New(model.ErrInvalidPVZID)
+		case errorViolatesUniqueUnfinishedReception:
+			return errors.New(model.ErrReceptionAlreadyOpened)
+		}
+	}
 	return err
 }
 
 // FindOpened находит открытую приёмку для данного ПВЗ
 func (r *ReceptionRepo) FindOpened(ctx context.Context, rec *dao.Reception) error {
-	err := r.qb.Select("id").
+	organizationID, err := contextOrganizationID(ctx)
+	if err != nil {
+		return err
+	}
+	err = r.qb.Select("id").
 		From("receptions").
 		Where(sqrl.And{
 			sqrl.Eq{"pvz_id": rec.PVZID},
 			sqrl.Eq{"status": rec.Status},
+			sqrl.Expr("pvz_id IN (?)", organizationPVZIDs(organizationID)),
 		}).
 		Limit(1).
-		RunWith(r.db).
+		RunWith(runner(ctx, r.db)).
 		QueryRowContext(ctx).
 		Scan(&rec.ID)
 	if err != nil {
@@ -66,29 +90,83 @@ func (r *ReceptionRepo) FindOpened(ctx context.Context, rec *dao.Reception) erro
 	return err
 }
 
-// CheckIfExists проверяет существование приёмки для данного ПВЗ
-func (r *ReceptionRepo) CheckIfExists(ctx context.Context, rec *dao.Reception) (bool, error) {
-	count := 0
-	err := r.qb.Select("count(*)").
+// FindLast находит последнюю приёмку ПВЗ с одним из переданных статусов
+func (r *ReceptionRepo) FindLast(ctx context.Context, rec *dao.Reception, statuses ...int8) error {
+	organizationID, err := contextOrganizationID(ctx)
+	if err != nil {
+		return err
+	}
+	err = r.qb.Select("id", "date_time", "status", "opened_by").
 		From("receptions").
 		Where(sqrl.And{
 			sqrl.Eq{"pvz_id": rec.PVZID},
-			sqrl.Eq{"status": rec.Status},
+			sqrl.Eq{"status": statuses},
+			sqrl.Expr("pvz_id IN (?)", organizationPVZIDs(organizationID)),
 		}).
-		RunWith(r.db).
+		OrderBy("date_time DESC").
+		Limit(1).
+		RunWith(runner(ctx, r.db)).
 		QueryRowContext(ctx).
-		Scan(&count)
+		Scan(&rec.ID, &rec.DateTime, &rec.Status, &rec.OpenedBy)
 	if err != nil {
-		return false, err
+		if err.Error() == errorNoSQLRows {
+			return nil
+		}
+		return err
 	}
-	return count == 1, nil
+	return nil
 }
 
-// Close закрывает приёмку по её ID
-func (r *ReceptionRepo) Close(ctx context.Context, rec *dao.Reception) error {
-	_, err := r.qb.Update("receptions").
+// FindByID находит приёмку по ID
+func (r *ReceptionRepo) FindByID(ctx context.Context, id string) (*dao.Reception, error) {
+	organizationID, err := contextOrganizationID(ctx)
+	if err != nil {
+		return nil, err
+	}
+	rec := &dao.Reception{}
+	err = r.qb.Select("id", "pvz_id", "date_time", "status", "opened_by", "closed_by", "closed_at").
+		From("receptions").
+		Where(sqrl.And{
+			sqrl.Eq{"id": id},
+			sqrl.Expr("pvz_id IN (?)", organizationPVZIDs(organizationID)),
+		}).
+		RunWith(runner(ctx, r.db)).
+		QueryRowContext(ctx).
+		Scan(&rec.ID, &rec.PVZID, &rec.DateTime, &rec.Status, &rec.OpenedBy, &rec.ClosedBy, &rec.ClosedAt)
+	if err != nil {
+		if err.Error() == errorNoSQLRows {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return rec, nil
+}
+
+// ChangeStatus переводит приёмку в новый статус, только если её текущий статус равен from.
+// closed_by и closed_at перезаписываются значениями из dao
+func (r *ReceptionRepo) ChangeStatus(ctx context.Context, rec *dao.Reception, from int8) error {
+	organizationID, err := contextOrganizationID(ctx)
+	if err != nil {
+		return err
+	}
+	res, err := r.qb.Update("receptions").
 		Set("status", rec.Status).
-		Where(sqrl.Eq{"id": rec.ID}).
-		RunWith(r.db).ExecContext(ctx)
-	return err
+		Set("closed_by", rec.ClosedBy).
+		Set("closed_at", rec.ClosedAt).
+		Where(sqrl.And{
+			sqrl.Eq{"id": rec.ID, "status": from},
+			sqrl.Expr("pvz_id IN (?)", organizationPVZIDs(organizationID)),
+		}).
+		RunWith(runner(ctx, r.db)).ExecContext(ctx)
+	if err != nil {
+		return err
+	}
+	affected, err := res.RowsAffected()
+	if err != nil {
+		return err
+	}
+	if affected == 0 {
+		return errors.New(model.ErrInvalidReceptionTransition)
+	}
+	return nil
 }
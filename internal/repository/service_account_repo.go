@@ -0,0 +1,152 @@
+// Package repository это имплементации репозиториев
+package repository
+
+import (
+	"context"
+	"errors"
+	sqrl "github.com/Masterminds/squirrel"
+	"github.com/lib/pq"
+	"internshipPVZ/internal/domain/model"
+	"internshipPVZ/internal/domain/repository/dao"
+	"log"
+	"strings"
+	"time"
+)
+
+var serviceAccountColumns = []string{
+	"id",
+	"organization_id",
+	"name",
+	"key_hash",
+	"scopes",
+	"created_by",
+	"created_at",
+	"revoked_at",
+}
+
+// ServiceAccountRepo реализация репозитория сервисных аккаунтов
+type ServiceAccountRepo struct {
+	db *sqrl.StmtCache
+	qb sqrl.StatementBuilderType
+}
+
+// NewServiceAccountRepo конструктор для создания нового экземпляра ServiceAccountRepo
+func NewServiceAccountRepo(config Config) *ServiceAccountRepo {
+	if config == nil {
+		log.Fatalf("service account repo config is nil")
+		return nil
+	}
+	if config.GetDbConnection() == nil {
+		log.Fatalf("service account repo config.GetDbConnection() is nil")
+	}
+	return &ServiceAccountRepo{
+		db: sqrl.NewStmtCache(config.GetDbConnection()),
+		qb: sqrl.StatementBuilder.PlaceholderFormat(sqrl.Dollar),
+	}
+}
+
+// Create добавляет сервисный аккаунт в организацию модератора, который его создал
+func (r *ServiceAccountRepo) Create(ctx context.Context, account *dao.ServiceAccount) error {
+	organizationID, err := contextOrganizationID(ctx)
+	if err != nil {
+		return err
+	}
+	err = r.qb.Insert("service_accounts").
+		Columns("organization_id", "name", "key_hash", "scopes", "created_by", "created_at").
+		Values(organizationID, account.Name, account.KeyHash, pq.Array(account.Scopes), account.CreatedBy, account.CreatedAt).
+		Suffix("RETURNING id").
+		RunWith(runner(ctx, r.db)).
+		QueryRowContext(ctx).
+		Scan(&account.ID)
+	if err != nil {
+		return err
+	}
+	account.OrganizationID = organizationID
+	return nil
+}
+
+// List возвращает сервисные аккаунты своей организации в порядке создания
+func (r *ServiceAccountRepo) List(ctx context.Context) ([]*dao.ServiceAccount, error) {
+	organizationID, err := contextOrganizationID(ctx)
+	if err != nil {
+		return nil, err
+	}
+	rows, err := r.qb.Select(serviceAccountColumns...).
+		From("service_accounts").
+		Where(sqrl.Eq{"organization_id": organizationID}).
+		OrderBy("created_at", "id").
+		RunWith(runner(ctx, r.db)).
+		QueryContext(ctx)
+	if err != nil {
+		return nil, err
+	}
+	var accounts []*dao.ServiceAccount
+	for rows.Next() {
+		account, err := scanServiceAccount(rows)
+		if err != nil {
+			return nil, err
+		}
+		accounts = append(accounts, account)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	return accounts, nil
+}
+
+// Revoke отзывает ключ сервисного аккаунта своей организации
+func (r *ServiceAccountRepo) Revoke(ctx context.Context, id string, now time.Time) (*dao.ServiceAccount, error) {
+	organizationID, err := contextOrganizationID(ctx)
+	if err != nil {
+		return nil, err
+	}
+	row := r.qb.Update("service_accounts").
+		Set("revoked_at", sqrl.Expr("COALESCE(revoked_at, ?)", now)).
+		Where(sqrl.Eq{"id": id, "organization_id": organizationID}).
+		Suffix("RETURNING " + strings.Join(serviceAccountColumns, ", ")).
+		RunWith(runner(ctx, r.db)).
+		QueryRowContext(ctx)
+	account, err := scanServiceAccount(row)
+	if err != nil {
+		if err.Error() == errorNoSQLRows {
+			return nil, errors.New(model.ErrServiceAccountNotFound)
+		}
+		return nil, err
+	}
+	return account, nil
+}
+
+// FindActiveByKeyHash ищет действующий ключ без ограничения организацией: её задаёт сам ключ
+func (r *ServiceAccountRepo) FindActiveByKeyHash(ctx context.Context, keyHash string) (*dao.ServiceAccount, error) {
+	row := r.qb.Select(serviceAccountColumns...).
+		From("service_accounts").
+		Where(sqrl.Eq{"key_hash": keyHash, "revoked_at": nil}).
+		RunWith(runner(ctx, r.db)).
+		QueryRowContext(ctx)
+	account, err := scanServiceAccount(row)
+	if err != nil {
+		if err.Error() == errorNoSQLRows {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return account, nil
+}
+
+func scanServiceAccount(row sqrl.RowScanner) (*dao.ServiceAccount, error) {
+	account := &dao.ServiceAccount{}
+	err := row.Scan(
+		&account.ID,
+		&account.OrganizationID,
+		&account.Name,
+		&account.KeyHash,
+		pq.Array(&account.Scopes),
+		&account.CreatedBy,
+		&account.CreatedAt,
+		&account.RevokedAt,
+	)
+	if err != nil {
+		return nil, err
+	}
+	return account, nil
+}
@@ -0,0 +1,132 @@
+// Package repository это имплементации репозиториев
+package repository
+
+import (
+	"context"
+	"errors"
+	sqrl "github.com/Masterminds/squirrel"
+	"internshipPVZ/internal/domain/model"
+	"internshipPVZ/internal/domain/repository/dao"
+	"log"
+	"time"
+)
+
+const (
+	errorViolatesSessionUserForeignKey = "pq: insert or update on table \"sessions\" violates foreign key constraint \"fk_sessions_user\""
+)
+
+// SessionRepo реализация репозитория сессий пользователей
+type SessionRepo struct {
+	db *sqrl.StmtCache
+	qb sqrl.StatementBuilderType
+}
+
+// NewSessionRepo конструктор для создания нового экземпляра SessionRepo
+func NewSessionRepo(config Config) *SessionRepo {
+	if config == nil {
+		log.Fatalf("session repo config is nil")
+		return nil
+	}
+	if config.GetDbConnection() == nil {
+		log.Fatalf("session repo config.GetDbConnection() is nil")
+	}
+	return &SessionRepo{
+		db: sqrl.NewStmtCache(config.GetDbConnection()),
+		qb: sqrl.StatementBuilder.PlaceholderFormat(sqrl.Dollar),
+	}
+}
+
+// Create добавляет новую сессию
+func (r *SessionRepo) Create(ctx context.Context, session *dao.Session) error {
+	err := r.qb.Insert("sessions").
+		Columns("user_id", "refresh_token_hash", "created_at", "expires_at").
+		Values(session.UserID, session.RefreshTokenHash, session.CreatedAt, session.ExpiresAt).
+		Suffix("RETURNING id").
+		RunWith(runner(ctx, r.db)).
+		QueryRowContext(ctx).
+		Scan(&session.ID)
+	if err != nil {
+		if err.Error() == errorViolatesSessionUserForeignKey {
+			return errors.New(model.ErrUserNotFound)
+		}
+	}
+	return err
+}
+
+// Rotate заменяет refresh токен активной сессии на новый, старый запоминается для обнаружения повторного использования
+func (r *SessionRepo) Rotate(ctx context.Context, tokenHash, newTokenHash string, expiresAt, now time.Time) (*dao.Session, error) {
+	session := &dao.Session{}
+	err := r.qb.Update("sessions").
+		Set("previous_token_hash", tokenHash).
+		Set("refresh_token_hash", newTokenHash).
+		Set("expires_at", expiresAt).
+		Where(sqrl.Eq{"refresh_token_hash": tokenHash, "revoked_at": nil}).
+		Where(sqrl.Gt{"expires_at": now}).
+		Suffix("RETURNING id, user_id, refresh_token_hash, previous_token_hash, created_at, expires_at").
+		RunWith(runner(ctx, r.db)).
+		QueryRowContext(ctx).
+		Scan(&session.ID, &session.UserID, &session.RefreshTokenHash, &session.PreviousTokenHash, &session.CreatedAt, &session.ExpiresAt)
+	if err != nil {
+		if err.Error() == errorNoSQLRows {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return session, nil
+}
+
+// RevokeByPreviousToken отзывает сессию, если предъявлен уже заменённый refresh токен
+func (r *SessionRepo) RevokeByPreviousToken(ctx context.Context, tokenHash string, now time.Time) (bool, error) {
+	res, err := r.qb.Update("sessions").
+		Set("revoked_at", now).
+		Where(sqrl.Eq{"previous_token_hash": tokenHash, "revoked_at": nil}).
+		RunWith(runner(ctx, r.db)).
+		ExecContext(ctx)
+	if err != nil {
+		return false, err
+	}
+	affected, err := res.RowsAffected()
+	if err != nil {
+		return false, err
+	}
+	return affected > 0, nil
+}
+
+// Revoke отзывает сессию, повторный отзыв ничего не меняет
+func (r *SessionRepo) Revoke(ctx context.Context, sessionID string, now time.Time) error {
+	_, err := r.qb.Update("sessions").
+		Set("revoked_at", now).
+		Where(sqrl.Eq{"id": sessionID, "revoked_at": nil}).
+		RunWith(runner(ctx, r.db)).
+		ExecContext(ctx)
+	return err
+}
+
+// RevokeByUser отзывает активные сессии пользователя, например после смены пароля
+func (r *SessionRepo) RevokeByUser(ctx context.Context, userID, exceptSessionID string, now time.Time) error {
+	query := r.qb.Update("sessions").
+		Set("revoked_at", now).
+		Where(sqrl.Eq{"user_id": userID, "revoked_at": nil})
+	if exceptSessionID != "" {
+		query = query.Where(sqrl.NotEq{"id": exceptSessionID})
+	}
+	_, err := query.
+		RunWith(runner(ctx, r.db)).
+		ExecContext(ctx)
+	return err
+}
+
+// IsActive проверяет, что сессия существует и не отозвана
+func (r *SessionRepo) IsActive(ctx context.Context, sessionID string) (bool, error) {
+	count := 0
+	err := r.qb.Select("count(*)").
+		From("sessions").
+		Where(sqrl.Eq{"id": sessionID, "revoked_at": nil}).
+		RunWith(runner(ctx, r.db)).
+		QueryRowContext(ctx).
+		Scan(&count)
+	if err != nil {
+		return false, err
+	}
+	return count == 1, nil
+}
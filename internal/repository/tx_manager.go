@@ -0,0 +1,62 @@
+// Package repository это имплементации репозиториев
+package repository
+
+import (
+	"context"
+	"database/sql"
+	sqrl "github.com/Masterminds/squirrel"
+	"github.com/jmoiron/sqlx"
+	"log"
+)
+
+type txContextKey struct{}
+
+// TxManager реализация менеджера транзакций поверх соединения из конфига
+type TxManager struct {
+	db *sqlx.DB
+}
+
+// NewTxManager конструктор для создания нового экземпляра TxManager
+func NewTxManager(config Config) *TxManager {
+	if config == nil {
+		log.Fatalf("tx manager config is nil")
+		return nil
+	}
+	if config.GetDbConnection() == nil {
+		log.Fatalf("tx manager config.GetDbConnection() is nil")
+	}
+	return &TxManager{db: config.GetDbConnection()}
+}
+
+// WithinTx открывает транзакцию и кладёт её в контекст для fn.
+// Коммитит, если fn завершился без ошибки, иначе откатывает
+func (m *TxManager) WithinTx(ctx context.Context, fn func(ctx context.Context) error) (err error) {
+	if _, ok := ctx.Value(txContextKey{}).(*sql.Tx); ok {
+		return fn(ctx)
+	}
+	tx, err := m.db.BeginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
+	defer func() {
+		if p := recover(); p != nil {
+			_ = tx.Rollback()
+			panic(p)
+		}
+		if err != nil {
+			// ошибку fn возвращаем как есть, по ней юзкейсы выбирают ответ
+			_ = tx.Rollback()
+			return
+		}
+		err = tx.Commit()
+	}()
+	return fn(context.WithValue(ctx, txContextKey{}, tx))
+}
+
+// runner возвращает транзакцию из контекста, а вне транзакции кэш подготовленных запросов репозитория
+func runner(ctx context.Context, db *sqrl.StmtCache) sqrl.BaseRunner {
+	if tx, ok := ctx.Value(txContextKey{}).(*sql.Tx); ok {
+		return tx
+	}
+	return db
+}
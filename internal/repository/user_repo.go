@@ -8,10 +8,19 @@ import (
 	"internshipPVZ/internal/domain/model"
 	"internshipPVZ/internal/domain/repository/dao"
 	"log"
+	"strings"
+	"time"
 )
 
+// userColumns колонки пользователя в порядке сканирования scanUser
+var userColumns = []string{"id", "email", "password", "role", "organization_id", "deactivated_at"}
+
+// likeEscaper экранирует спецсимволы LIKE в строке поиска
+var likeEscaper = strings.NewReplacer(`\`, `\\`, "%", `\%`, "_", `\_`)
+
 const (
-	errorViolatesUniqueEmailConstraint = "pq: duplicate key value violates unique constraint \"users_email_key\""
+	errorViolatesUniqueEmailConstraint      = "pq: duplicate key value violates unique constraint \"users_email_key\""
+	errorViolatesUserOrganizationForeignKey = "pq: insert or update on table \"users\" violates foreign key constraint \"fk_users_organization\""
 )
 
 // UserRepo реализация репозитория для пользователей
@@ -38,14 +47,17 @@ func NewUserRepo(config Config) *UserRepo {
 // Create добавляет нового пользователя в базу данных
 func (r *UserRepo) Create(ctx context.Context, user *dao.User) error {
 	err := r.qb.Insert("users").
-		Columns("email", "password", "role").
-		Values(user.Email, user.Password, user.Role).
+		Columns("email", "password", "role", "organization_id").
+		Values(user.Email, user.Password, user.Role, user.OrganizationID).
 		Suffix("RETURNING id").
-		RunWith(r.db).
+		RunWith(runner(ctx, r.db)).
 		QueryRowContext(ctx).Scan(&user.ID)
 	if err != nil {
-		if err.Error() == errorViolatesUniqueEmailConstraint {
+		switch err.Error() {
+		case errorViolatesUniqueEmailConstraint:
 			return errors.New(model.ErrUserWithEmailAlreadyExists)
+		case errorViolatesUserOrganizationForeignKey:
+			return errors.New(model.ErrOrganizationNotFound)
 		}
 	}
 	return err
@@ -53,13 +65,29 @@ func (r *UserRepo) Create(ctx context.Context, user *dao.User) error {
 
 // FindByEmail находит пользователя по email
 func (r *UserRepo) FindByEmail(ctx context.Context, email string) (*dao.User, error) {
-	row := r.qb.Select("id", "email", "password", "role").
+	row := r.qb.Select(userColumns...).
 		From("users").
 		Where(sqrl.Eq{"email": email}).
-		RunWith(r.db).QueryRowContext(ctx)
+		RunWith(runner(ctx, r.db)).QueryRowContext(ctx)
 
-	u := &dao.User{}
-	err := row.Scan(&u.ID, &u.Email, &u.Password, &u.Role)
+	u, err := scanUser(row)
+	if err != nil {
+		if err.Error() == errorNoSQLRows {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return u, nil
+}
+
+// FindByID находит пользователя по ID
+func (r *UserRepo) FindByID(ctx context.Context, id string) (*dao.User, error) {
+	row := r.qb.Select(userColumns...).
+		From("users").
+		Where(sqrl.Eq{"id": id}).
+		RunWith(runner(ctx, r.db)).QueryRowContext(ctx)
+
+	u, err := scanUser(row)
 	if err != nil {
 		if err.Error() == errorNoSQLRows {
 			return nil, nil
@@ -68,3 +96,118 @@ func (r *UserRepo) FindByEmail(ctx context.Context, email string) (*dao.User, er
 	}
 	return u, nil
 }
+
+// UpdatePassword заменяет хэш пароля пользователя
+func (r *UserRepo) UpdatePassword(ctx context.Context, id, passwordHash string) error {
+	res, err := r.qb.Update("users").
+		Set("password", passwordHash).
+		Where(sqrl.Eq{"id": id}).
+		RunWith(runner(ctx, r.db)).
+		ExecContext(ctx)
+	if err != nil {
+		return err
+	}
+	affected, err := res.RowsAffected()
+	if err != nil {
+		return err
+	}
+	if affected == 0 {
+		return errors.New(model.ErrUserNotFound)
+	}
+	return nil
+}
+
+// List возвращает пользователей организации, отсортированных по email
+func (r *UserRepo) List(ctx context.Context, search string, page, limit int) ([]*dao.User, error) {
+	organizationID, err := contextOrganizationID(ctx)
+	if err != nil {
+		return nil, err
+	}
+	query := r.qb.Select(userColumns...).
+		From("users").
+		Where(sqrl.Eq{"organization_id": organizationID}).
+		OrderBy("email", "id").
+		Limit(uint64(limit)).
+		Offset(uint64((page - 1) * limit))
+	if search != "" {
+		query = query.Where(sqrl.ILike{"email": "%" + likeEscaper.Replace(search) + "%"})
+	}
+	rows, err := query.
+		RunWith(runner(ctx, r.db)).
+		QueryContext(ctx)
+	if err != nil {
+		return nil, err
+	}
+	var users []*dao.User
+	for rows.Next() {
+		u, err := scanUser(rows)
+		if err != nil {
+			return nil, err
+		}
+		users = append(users, u)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	return users, nil
+}
+
+// UpdateRole меняет роль пользователя своей организации
+func (r *UserRepo) UpdateRole(ctx context.Context, id string, role int8) (*dao.User, error) {
+	return r.updateInOrganization(ctx, id, sqrl.Eq{"role": role})
+}
+
+// Deactivate закрывает вход пользователю своей организации
+func (r *UserRepo) Deactivate(ctx context.Context, id string, now time.Time) (*dao.User, error) {
+	return r.updateInOrganization(ctx, id, sqrl.Eq{"deactivated_at": sqrl.Expr("COALESCE(deactivated_at, ?)", now)})
+}
+
+// Reactivate возвращает вход пользователю своей организации
+func (r *UserRepo) Reactivate(ctx context.Context, id string) (*dao.User, error) {
+	return r.updateInOrganization(ctx, id, sqrl.Eq{"deactivated_at": nil})
+}
+
+// CheckIfRoleExists проверяет, есть ли в организации пользователь с ролью
+func (r *UserRepo) CheckIfRoleExists(ctx context.Context, organizationID string, role int8) (bool, error) {
+	count := 0
+	err := r.qb.Select("count(*)").
+		From("users").
+		Where(sqrl.Eq{"organization_id": organizationID, "role": role}).
+		RunWith(runner(ctx, r.db)).
+		QueryRowContext(ctx).
+		Scan(&count)
+	if err != nil {
+		return false, err
+	}
+	return count > 0, nil
+}
+
+func (r *UserRepo) updateInOrganization(ctx context.Context, id string, set sqrl.Eq) (*dao.User, error) {
+	organizationID, err := contextOrganizationID(ctx)
+	if err != nil {
+		return nil, err
+	}
+	row := r.qb.Update("users").
+		SetMap(set).
+		Where(sqrl.Eq{"id": id, "organization_id": organizationID}).
+		Suffix("RETURNING " + strings.Join(userColumns, ", ")).
+		RunWith(runner(ctx, r.db)).
+		QueryRowContext(ctx)
+	u, err := scanUser(row)
+	if err != nil {
+		if err.Error() == errorNoSQLRows {
+			return nil, errors.New(model.ErrUserNotFound)
+		}
+		return nil, err
+	}
+	return u, nil
+}
+
+func scanUser(row sqrl.RowScanner) (*dao.User, error) {
+	u := &dao.User{}
+	err := row.Scan(&u.ID, &u.Email, &u.Password, &u.Role, &u.OrganizationID, &u.DeactivatedAt)
+	if err != nil {
+		return nil, err
+	}
+	return u, nil
+}
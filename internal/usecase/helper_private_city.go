@@ -0,0 +1,58 @@
+// Package usecase это юзкейсы и вспомогательная логика
+package usecase
+
+import (
+	"errors"
+	"fmt"
+	"internshipPVZ/internal/domain/repository/dao"
+	"internshipPVZ/internal/http/onlymodels"
+	"strings"
+	"unicode/utf8"
+)
+
+const (
+	maxCityNameLength = 100
+)
+
+// cityCatalog справочник названий городов по их ID
+type cityCatalog map[int32]string
+
+func newCityCatalog(cities []*dao.City) (cityCatalog, error) {
+	catalog := make(cityCatalog, len(cities))
+	for _, city := range cities {
+		if city == nil {
+			return nil, errors.New("city is nil")
+		}
+		catalog[city.ID] = city.Name
+	}
+	return catalog, nil
+}
+
+func (c cityCatalog) name(id int32) (string, error) {
+	name, ok := c[id]
+	if !ok {
+		return "", fmt.Errorf("city %d is missing in catalog", id)
+	}
+	return name, nil
+}
+
+func cityDaoToDto(city *dao.City) (*onlymodels.City, error) {
+	if city == nil {
+		return nil, errors.New("city is nil")
+	}
+	id := city.ID
+	return &onlymodels.City{Id: &id, Name: city.Name}, nil
+}
+
+func normalizeCityName(name string) (string, bool) {
+	return normalizeCatalogName(name, maxCityNameLength)
+}
+
+// normalizeCatalogName обрезает пробелы и проверяет длину названия элемента справочника
+func normalizeCatalogName(name string, maxLength int) (string, bool) {
+	name = strings.TrimSpace(name)
+	if name == "" || utf8.RuneCountInString(name) > maxLength {
+		return "", false
+	}
+	return name, true
+}
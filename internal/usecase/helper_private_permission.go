@@ -0,0 +1,25 @@
+// Package usecase это юзкейсы и вспомогательная логика
+package usecase
+
+import (
+	"errors"
+	"internshipPVZ/internal/domain/model"
+)
+
+// Authorizer проверка прав ролей
+type Authorizer interface {
+	HasPermission(role model.Role, permission model.Permission) bool
+}
+
+// checkPermission отказывает в доступе, если у роли нет права на действие
+func checkPermission(authorizer Authorizer, logger Logger, usecaseName, method string, role model.Role, permission model.Permission) error {
+	if !authorizer.HasPermission(role, permission) {
+		logger.Warn("access denied",
+			"usecase", usecaseName,
+			"method", method,
+			"required_permission", permission,
+			"user_role", role)
+		return errors.New(model.ErrAccessDenied)
+	}
+	return nil
+}
@@ -0,0 +1,269 @@
+// Package usecase это юзкейсы и вспомогательная логика
+package usecase
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"github.com/google/uuid"
+	"internshipPVZ/internal/domain/model"
+	repo "internshipPVZ/internal/domain/repository"
+	"internshipPVZ/internal/domain/repository/dao"
+	"internshipPVZ/internal/http/onlymodels"
+	"strings"
+	"unicode/utf8"
+)
+
+const (
+	ean13Length               = 13
+	maxCode128Length          = 80
+	maxProductReferenceLength = 64
+)
+
+// normalizeBarcode принимает EAN-13 с верной контрольной цифрой или Code128 из печатных ASCII символов.
+// Строка из 13 цифр всегда считается EAN-13, иначе опечатка в контрольной цифре прошла бы как Code128.
+// Штрихкод необязателен: без него или с пустой строкой возвращается пустая строка
+func normalizeBarcode(value *string) (string, error) {
+	if value == nil {
+		return "", nil
+	}
+	barcode := strings.TrimSpace(*value)
+	if len(barcode) == 0 {
+		return "", nil
+	}
+	if len(barcode) > maxCode128Length {
+		return "", errors.New(model.ErrInvalidBarcode)
+	}
+	if len(barcode) == ean13Length && isDigits(barcode) {
+		if !validEAN13Checksum(barcode) {
+			return "", errors.New(model.ErrInvalidBarcode)
+		}
+		return barcode, nil
+	}
+	for i := 0; i < len(barcode); i++ {
+		if barcode[i] < ' ' || barcode[i] > '~' {
+			return "", errors.New(model.ErrInvalidBarcode)
+		}
+	}
+	return barcode, nil
+}
+
+func validEAN13Checksum(barcode string) bool {
+	sum := 0
+	for i := 0; i < ean13Length-1; i++ {
+		digit := int(barcode[i] - '0')
+		if i%2 == 1 {
+			digit *= 3
+		}
+		sum += digit
+	}
+	return (10-sum%10)%10 == int(barcode[ean13Length-1]-'0')
+}
+
+func isDigits(value string) bool {
+	for i := 0; i < len(value); i++ {
+		if value[i] < '0' || value[i] > '9' {
+			return false
+		}
+	}
+	return true
+}
+
+// normalizeProductReference обрезает пробелы у необязательных SKU и номера заказа
+func normalizeProductReference(value *string) (string, bool) {
+	if value == nil {
+		return "", true
+	}
+	trimmed := strings.TrimSpace(*value)
+	return trimmed, utf8.RuneCountInString(trimmed) <= maxProductReferenceLength
+}
+
+func fromNullString(value sql.NullString) *string {
+	if !value.Valid {
+		return nil
+	}
+	result := value.String
+	return &result
+}
+
+func productDaoToDto(product *dao.Product, productTypeName string) (*onlymodels.Product, error) {
+	if product == nil {
+		return nil, errors.New("product is nil")
+	}
+	id, err := validateRawID(product.ID)
+	if err != nil {
+		return nil, err
+	}
+	receptionID, err := validateRawID(product.ReceptionID)
+	if err != nil {
+		return nil, err
+	}
+	addedBy, err := fromNullUUID(product.AddedBy)
+	if err != nil {
+		return nil, err
+	}
+	status := onlymodels.ProductStatus(model.NewProductStatus(product.Status))
+	dto := &onlymodels.Product{
+		Id:          &id,
+		DateTime:    &product.DateTime,
+		Type:        productTypeName,
+		ReceptionId: receptionID,
+		Barcode:     fromNullString(product.Barcode),
+		Sku:         fromNullString(product.SKU),
+		OrderId:     fromNullString(product.OrderID),
+		AddedBy:     addedBy,
+		Status:      &status,
+	}
+	return dto, nil
+}
+
+func productStatusesToInt(statuses []model.ProductStatus) []int8 {
+	result := make([]int8, 0, len(statuses))
+	for _, status := range statuses {
+		result = append(result, status.ToInt())
+	}
+	return result
+}
+
+// productStatusChange параметры перехода продукта в новый статус
+type productStatusChange struct {
+	usecase   string
+	productID uuid.UUID
+	userID    uuid.UUID
+	target    model.ProductStatus
+}
+
+// productStatusRepos репозитории, нужные для перехода продукта в новый статус
+type productStatusRepos struct {
+	productRepo     repo.ProductRepo
+	receptionRepo   repo.ReceptionRepo
+	productTypeRepo repo.ProductTypeRepo
+	assignmentRepo  repo.PVZAssignmentRepo
+}
+
+// changeProductStatus переводит продукт в статус target по правилам жизненного цикла продукта.
+// Продукт покидает статус received, только когда его приёмка закрыта.
+// Менять статус может только сотрудник, закреплённый за ПВЗ приёмки продукта
+func changeProductStatus(
+	ctx context.Context,
+	repos productStatusRepos,
+	logger Logger,
+	change productStatusChange,
+) (*onlymodels.Product, error) {
+	usecaseName, target := change.usecase, change.target
+
+	productDao, err := repos.productRepo.FindByID(ctx, change.productID.String())
+	if err != nil {
+		logger.Error("failed to find product",
+			"usecase", usecaseName,
+			"method", "productRepo.FindByID",
+			"product_id", change.productID,
+			"error", err)
+		return nil, errors.New(model.ErrInternal)
+	}
+	if productDao == nil {
+		logger.Warn("product not found",
+			"usecase", usecaseName,
+			"method", "Execute",
+			"product_id", change.productID)
+		return nil, errors.New(model.ErrProductNotFound)
+	}
+
+	recDao, err := repos.receptionRepo.FindByID(ctx, productDao.ReceptionID)
+	if err != nil || recDao == nil {
+		logger.Error("failed to find product reception",
+			"usecase", usecaseName,
+			"method", "receptionRepo.FindByID",
+			"reception_id", productDao.ReceptionID,
+			"error", err)
+		return nil, errors.New(model.ErrInternal)
+	}
+	if err = checkPVZAssignment(ctx, repos.assignmentRepo, logger, usecaseName, change.userID.String(), recDao.PVZID); err != nil {
+		return nil, err
+	}
+
+	current := model.NewProductStatus(productDao.Status)
+	if !current.CanTransitionTo(target) {
+		logger.Warn("invalid product status transition",
+			"usecase", usecaseName,
+			"method", "Execute",
+			"product_id", productDao.ID,
+			"from", current,
+			"to", target)
+		return nil, errors.New(model.ErrInvalidProductTransition)
+	}
+
+	if current == model.ProductReceived && model.NewReceptionStatus(recDao.Status) != model.ReceptionClosed {
+		logger.Warn("product reception is not closed",
+			"usecase", usecaseName,
+			"method", "Execute",
+			"product_id", productDao.ID,
+			"reception_id", recDao.ID)
+		return nil, errors.New(model.ErrReceptionNotClosed)
+	}
+
+	productTypes, err := repos.productTypeRepo.GetAll(ctx)
+	if err != nil {
+		logger.Error("failed to get product types",
+			"usecase", usecaseName,
+			"method", "productTypeRepo.GetAll",
+			"error", err)
+		return nil, errors.New(model.ErrInternal)
+	}
+	catalog, err := newProductTypeCatalog(productTypes)
+	if err != nil {
+		logger.Error("failed to build product type catalog",
+			"usecase", usecaseName,
+			"method", "newProductTypeCatalog",
+			"error", err)
+		return nil, errors.New(model.ErrInternal)
+	}
+	productTypeName, err := catalog.name(productDao.Type)
+	if err != nil {
+		logger.Error("failed to resolve product type",
+			"usecase", usecaseName,
+			"method", "productTypeCatalog.name",
+			"product_id", productDao.ID,
+			"error", err)
+		return nil, errors.New(model.ErrInternal)
+	}
+
+	from := productDao.Status
+	productDao.Status = target.ToInt()
+	err = repos.productRepo.ChangeStatus(ctx, productDao, from)
+	if err != nil {
+		if err.Error() == model.ErrInvalidProductTransition {
+			logger.Warn("product status was changed concurrently",
+				"usecase", usecaseName,
+				"method", "productRepo.ChangeStatus",
+				"product_id", productDao.ID,
+				"from", current,
+				"to", target)
+			return nil, err
+		}
+		logger.Error("failed to change product status",
+			"usecase", usecaseName,
+			"method", "productRepo.ChangeStatus",
+			"product_id", productDao.ID,
+			"error", err)
+		return nil, errors.New(model.ErrInternal)
+	}
+
+	productDto, err := productDaoToDto(productDao, productTypeName)
+	if err != nil {
+		logger.Error("failed to convert product DAO to DTO",
+			"usecase", usecaseName,
+			"method", "productDaoToDto",
+			"product_id", productDao.ID,
+			"error", err)
+		return nil, errors.New(model.ErrInternal)
+	}
+
+	logger.Info("product status changed successfully",
+		"usecase", usecaseName,
+		"product_id", productDao.ID,
+		"from", current,
+		"to", target,
+		"user_id", change.userID)
+	return productDto, nil
+}
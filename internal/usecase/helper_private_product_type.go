@@ -0,0 +1,48 @@
+// Package usecase это юзкейсы и вспомогательная логика
+package usecase
+
+import (
+	"errors"
+	"fmt"
+	"internshipPVZ/internal/domain/repository/dao"
+	"internshipPVZ/internal/http/onlymodels"
+)
+
+const (
+	maxProductTypeNameLength = 50
+)
+
+// productTypeCatalog справочник названий типов продуктов по их ID, включая деактивированные
+type productTypeCatalog map[int16]string
+
+func newProductTypeCatalog(productTypes []*dao.ProductType) (productTypeCatalog, error) {
+	catalog := make(productTypeCatalog, len(productTypes))
+	for _, productType := range productTypes {
+		if productType == nil {
+			return nil, errors.New("product type is nil")
+		}
+		catalog[productType.ID] = productType.Name
+	}
+	return catalog, nil
+}
+
+func (c productTypeCatalog) name(id int16) (string, error) {
+	name, ok := c[id]
+	if !ok {
+		return "", fmt.Errorf("product type %d is missing in catalog", id)
+	}
+	return name, nil
+}
+
+func productTypeDaoToDto(productType *dao.ProductType) (*onlymodels.ProductType, error) {
+	if productType == nil {
+		return nil, errors.New("product type is nil")
+	}
+	id := int32(productType.ID)
+	active := productType.Active
+	return &onlymodels.ProductType{Id: &id, Name: productType.Name, Active: &active}, nil
+}
+
+func normalizeProductTypeName(name string) (string, bool) {
+	return normalizeCatalogName(name, maxProductTypeNameLength)
+}
@@ -2,16 +2,18 @@
 package usecase
 
 import (
+	"context"
 	"errors"
 	"google.golang.org/protobuf/types/known/timestamppb"
 	"internshipPVZ/internal/domain/model"
+	repo "internshipPVZ/internal/domain/repository"
 	"internshipPVZ/internal/domain/repository/dao"
 	pb "internshipPVZ/internal/grpc/models"
 	"internshipPVZ/internal/http/onlymodels"
 	"sort"
 )
 
-func pvzDaoToDto(pvzDao *dao.PVZ) (*onlymodels.PVZ, error) {
+func pvzDaoToDto(pvzDao *dao.PVZ, cities cityCatalog) (*onlymodels.PVZ, error) {
 	if pvzDao == nil {
 		return nil, errors.New("pvzDao is nil")
 	}
@@ -19,15 +21,25 @@ func pvzDaoToDto(pvzDao *dao.PVZ) (*onlymodels.PVZ, error) {
 	if err != nil {
 		return nil, err
 	}
+	city, err := cities.name(pvzDao.City)
+	if err != nil {
+		return nil, err
+	}
 	dto := &onlymodels.PVZ{
 		Id:               &id,
 		RegistrationDate: &pvzDao.RegistrationDate,
-		City:             onlymodels.PVZCity(model.NewCity(pvzDao.City)),
+		City:             city,
+		Name:             &pvzDao.Name,
+		Address:          &pvzDao.Address,
+		Latitude:         fromNullFloat64(pvzDao.Latitude),
+		Longitude:        fromNullFloat64(pvzDao.Longitude),
+		WorkingHours:     workingHoursDaoToDto(pvzDao.WorkingHours),
+		ArchivedAt:       fromNullTime(pvzDao.ArchivedAt),
 	}
 	return dto, nil
 }
 
-func pvzsToGrpcDto(input []*dao.PVZ) ([]*pb.PVZ, error) {
+func pvzsToGrpcDto(input []*dao.PVZ, cities cityCatalog) ([]*pb.PVZ, error) {
 	if input == nil {
 		return nil, errors.New("input is nil")
 	}
@@ -36,10 +48,22 @@ func pvzsToGrpcDto(input []*dao.PVZ) ([]*pb.PVZ, error) {
 		if item == nil {
 			return nil, errors.New("pvzs is nil")
 		}
+		city, err := cities.name(item.City)
+		if err != nil {
+			return nil, err
+		}
 		dto := &pb.PVZ{
 			Id:               item.ID,
 			RegistrationDate: timestamppb.New(item.RegistrationDate),
-			City:             model.NewCity(item.City).Get(),
+			City:             city,
+			Name:             item.Name,
+			Address:          item.Address,
+			Latitude:         fromNullFloat64(item.Latitude),
+			Longitude:        fromNullFloat64(item.Longitude),
+			WorkingHours:     workingHoursDaoToGrpc(item.WorkingHours),
+		}
+		if item.ArchivedAt.Valid {
+			dto.ArchivedAt = timestamppb.New(item.ArchivedAt.Time)
 		}
 		result = append(result, dto)
 	}
@@ -47,40 +71,65 @@ func pvzsToGrpcDto(input []*dao.PVZ) ([]*pb.PVZ, error) {
 	return result, nil
 }
 
-func pvzListToDto(input []*dao.PVZList) (*onlymodels.GetFilteredResponse, error) {
+func pvzListToDto(input []*dao.PVZList, cities cityCatalog, productTypes productTypeCatalog) (*onlymodels.GetFilteredResponse, error) {
 	if input == nil {
 		return nil, errors.New("input is nil")
 	}
+	// порядок ПВЗ из репозитория сохраняется, по нему листает курсор
+	var pvzOrder []string
 	pvzGroups := make(map[string][]*dao.PVZList)
 	for _, item := range input {
 		if item == nil {
 			return nil, errors.New("pvz list is nil")
 		}
+		if _, ok := pvzGroups[item.PvzID]; !ok {
+			pvzOrder = append(pvzOrder, item.PvzID)
+		}
 		pvzGroups[item.PvzID] = append(pvzGroups[item.PvzID], item)
 	}
 
 	var result onlymodels.GetFilteredResponse
-	for _, items := range pvzGroups {
+	for _, pvzID := range pvzOrder {
+		items := pvzGroups[pvzID]
 		first := items[0]
 		id, err := validateRawID(first.PvzID)
 		if err != nil {
 			return nil, err
 		}
+		city, err := cities.name(first.City)
+		if err != nil {
+			return nil, err
+		}
 		pvz := &onlymodels.PVZ{
 			Id:               &id,
 			RegistrationDate: &first.RegistrationDate,
-			City:             onlymodels.PVZCity(model.NewCity(first.City).Get()),
+			City:             city,
+			Name:             &first.Name,
+			Address:          &first.Address,
+			Latitude:         fromNullFloat64(first.Latitude),
+			Longitude:        fromNullFloat64(first.Longitude),
+			WorkingHours:     workingHoursDaoToDto(first.WorkingHours),
+			ArchivedAt:       fromNullTime(first.ArchivedAt),
 		}
 
+		// ПВЗ без приёмок в диапазоне приходит одной строкой с пустыми полями приёмки
+		var receptionOrder []string
 		receptionGroups := make(map[string][]*dao.PVZList)
 		for _, item := range items {
-			receptionGroups[item.ReceptionID] = append(receptionGroups[item.ReceptionID], item)
+			if !item.ReceptionID.Valid {
+				continue
+			}
+			if _, ok := receptionGroups[item.ReceptionID.String]; !ok {
+				receptionOrder = append(receptionOrder, item.ReceptionID.String)
+			}
+			receptionGroups[item.ReceptionID.String] = append(receptionGroups[item.ReceptionID.String], item)
 		}
 
-		var receptions onlymodels.GetFilteredResponseReceptions
-		for _, recItems := range receptionGroups {
+		receptions := onlymodels.GetFilteredResponseReceptions{}
+		for _, receptionID := range receptionOrder {
+			recItems := receptionGroups[receptionID]
 			firstRec := recItems[0]
-			id, err := validateRawID(firstRec.ReceptionID)
+			id, err := validateRawID(receptionID)
 			if err != nil {
 				return nil, err
 			}
@@ -88,29 +137,50 @@ func pvzListToDto(input []*dao.PVZList) (*onlymodels.GetFilteredResponse, error)
 			if err != nil {
 				return nil, err
 			}
+			openedBy, err := fromNullUUID(firstRec.OpenedBy)
+			if err != nil {
+				return nil, err
+			}
+			closedBy, err := fromNullUUID(firstRec.ClosedBy)
+			if err != nil {
+				return nil, err
+			}
 			reception := &onlymodels.Reception{
 				Id:       &id,
-				DateTime: firstRec.ReceptionDateTime,
+				DateTime: firstRec.ReceptionDateTime.Time,
 				PvzId:    pvzID,
-				Status:   onlymodels.ReceptionStatus(model.NewReceptionStatus(firstRec.Status)),
+				Status:   onlymodels.ReceptionStatus(model.NewReceptionStatus(int8(firstRec.Status.Int16))),
+				OpenedBy: openedBy,
+				ClosedBy: closedBy,
+				ClosedAt: fromNullTime(firstRec.ClosedAt),
 			}
 
 			var products []onlymodels.Product
 			for _, r := range recItems {
 				if r.ProductID.Valid {
-					id, err := validateRawID(r.ProductID.String)
+					productID, err := validateRawID(r.ProductID.String)
+					if err != nil {
+						return nil, err
+					}
+					productType, err := productTypes.name(r.Type.Int16)
 					if err != nil {
 						return nil, err
 					}
-					receptionID, err := validateRawID(r.ReceptionID)
+					addedBy, err := fromNullUUID(r.AddedBy)
 					if err != nil {
 						return nil, err
 					}
+					status := onlymodels.ProductStatus(model.NewProductStatus(int8(r.ProductStatus.Int16)))
 					p := onlymodels.Product{
-						Id:          &id,
+						Id:          &productID,
 						DateTime:    &r.ProductDateTime.Time,
-						Type:        onlymodels.ProductType(model.NewProductType(r.Type.Int16)),
-						ReceptionId: receptionID,
+						Type:        productType,
+						ReceptionId: id,
+						Barcode:     fromNullString(r.Barcode),
+						Sku:         fromNullString(r.SKU),
+						OrderId:     fromNullString(r.OrderID),
+						AddedBy:     addedBy,
+						Status:      &status,
 					}
 					products = append(products, p)
 				}
@@ -145,3 +215,32 @@ func pvzListToDto(input []*dao.PVZList) (*onlymodels.GetFilteredResponse, error)
 
 	return &result, nil
 }
+
+// checkPVZActive проверяет, что ПВЗ существует и не перенесён в архив.
+// Внутри транзакции строка ПВЗ остаётся заблокированной до её конца
+func checkPVZActive(ctx context.Context, pvzRepo repo.PVZRepo, logger Logger, usecaseName, pvzID string) error {
+	pvzDao, err := pvzRepo.FindByIDForUpdate(ctx, pvzID)
+	if err != nil {
+		logger.Error("failed to find PVZ",
+			"usecase", usecaseName,
+			"method", "pvzRepo.FindByIDForUpdate",
+			"pvz_id", pvzID,
+			"error", err)
+		return errors.New(model.ErrInternal)
+	}
+	if pvzDao == nil {
+		logger.Warn("invalid PVZ ID",
+			"usecase", usecaseName,
+			"method", "Execute",
+			"pvz_id", pvzID)
+		return errors.New(model.ErrInvalidPVZID)
+	}
+	if pvzDao.ArchivedAt.Valid {
+		logger.Warn("PVZ is archived",
+			"usecase", usecaseName,
+			"method", "Execute",
+			"pvz_id", pvzID)
+		return errors.New(model.ErrPVZArchived)
+	}
+	return nil
+}
@@ -0,0 +1,63 @@
+// Package usecase это юзкейсы и вспомогательная логика
+package usecase
+
+import (
+	"context"
+	"errors"
+	"internshipPVZ/internal/domain/model"
+	repo "internshipPVZ/internal/domain/repository"
+	"internshipPVZ/internal/domain/repository/dao"
+	"internshipPVZ/internal/http/onlymodels"
+)
+
+func pvzAssignmentDaoToDto(assignment *dao.PVZAssignment) (*onlymodels.PVZAssignment, error) {
+	if assignment == nil {
+		return nil, errors.New("pvz assignment is nil")
+	}
+	userID, err := validateRawID(assignment.UserID)
+	if err != nil {
+		return nil, err
+	}
+	pvzID, err := validateRawID(assignment.PVZID)
+	if err != nil {
+		return nil, err
+	}
+	assignedBy, err := fromNullUUID(assignment.AssignedBy)
+	if err != nil {
+		return nil, err
+	}
+	assignedAt := assignment.AssignedAt
+	return &onlymodels.PVZAssignment{
+		UserId:     userID,
+		PvzId:      &pvzID,
+		AssignedBy: assignedBy,
+		AssignedAt: &assignedAt,
+	}, nil
+}
+
+// checkPVZAssignment пускает сотрудника только в ПВЗ, за которые он закреплён.
+// Сервисный аккаунт работает со всеми ПВЗ своей организации, его ограничивают области действия ключа
+func checkPVZAssignment(ctx context.Context, assignmentRepo repo.PVZAssignmentRepo, logger Logger, usecaseName, userID, pvzID string) error {
+	if _, ok := model.ServiceAccountIDFromContext(ctx); ok {
+		return nil
+	}
+	assigned, err := assignmentRepo.CheckIfAssigned(ctx, userID, pvzID)
+	if err != nil {
+		logger.Error("failed to check PVZ assignment",
+			"usecase", usecaseName,
+			"method", "assignmentRepo.CheckIfAssigned",
+			"user_id", userID,
+			"pvz_id", pvzID,
+			"error", err)
+		return errors.New(model.ErrInternal)
+	}
+	if !assigned {
+		logger.Warn("user is not assigned to PVZ",
+			"usecase", usecaseName,
+			"method", "Execute",
+			"user_id", userID,
+			"pvz_id", pvzID)
+		return errors.New(model.ErrAccessDenied)
+	}
+	return nil
+}
@@ -0,0 +1,61 @@
+// Package usecase это юзкейсы и вспомогательная логика
+package usecase
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"internshipPVZ/internal/domain/model"
+	"internshipPVZ/internal/domain/repository/dao"
+	"time"
+)
+
+const (
+	defaultPVZLimit = 10
+	maxPVZLimit     = 30
+)
+
+// pvzCursor содержимое курсора списка ПВЗ. Клиенту он отдаётся непрозрачной строкой
+type pvzCursor struct {
+	RegistrationDate string `json:"d"`
+	ID               string `json:"id"`
+}
+
+// encodePVZCursor пустая строка означает, что следующей страницы нет
+func encodePVZCursor(cursor *dao.PVZCursor) string {
+	if cursor == nil {
+		return ""
+	}
+	raw, err := json.Marshal(pvzCursor{
+		RegistrationDate: cursor.RegistrationDate.Format(time.DateOnly),
+		ID:               cursor.ID,
+	})
+	if err != nil {
+		return ""
+	}
+	return base64.RawURLEncoding.EncodeToString(raw)
+}
+
+// decodePVZCursor пустой курсор означает первую страницу, для неё возвращается nil
+func decodePVZCursor(cursor string) (*dao.PVZCursor, error) {
+	if cursor == "" {
+		return nil, nil
+	}
+	raw, err := base64.RawURLEncoding.DecodeString(cursor)
+	if err != nil {
+		return nil, errors.New(model.ErrInvalidPVZCursor)
+	}
+	var decoded pvzCursor
+	if err = json.Unmarshal(raw, &decoded); err != nil {
+		return nil, errors.New(model.ErrInvalidPVZCursor)
+	}
+	registrationDate, err := time.Parse(time.DateOnly, decoded.RegistrationDate)
+	if err != nil {
+		return nil, errors.New(model.ErrInvalidPVZCursor)
+	}
+	id, err := validateRawID(decoded.ID)
+	if err != nil {
+		return nil, errors.New(model.ErrInvalidPVZCursor)
+	}
+	return &dao.PVZCursor{RegistrationDate: registrationDate, ID: id.String()}, nil
+}
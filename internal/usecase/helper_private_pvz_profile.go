@@ -0,0 +1,134 @@
+// Package usecase это юзкейсы и вспомогательная логика
+package usecase
+
+import (
+	"database/sql"
+	"errors"
+	"internshipPVZ/internal/domain/model"
+	"internshipPVZ/internal/domain/repository/dao"
+	pb "internshipPVZ/internal/grpc/models"
+	"internshipPVZ/internal/http/onlymodels"
+	"sort"
+	"strings"
+	"time"
+	"unicode/utf8"
+)
+
+const (
+	maxPVZNameLength    = 100
+	maxPVZAddressLength = 255
+	openingHoursLayout  = "15:04"
+)
+
+func normalizePVZName(name string) (string, error) {
+	name = strings.TrimSpace(name)
+	if utf8.RuneCountInString(name) > maxPVZNameLength {
+		return "", errors.New(model.ErrInvalidPVZName)
+	}
+	return name, nil
+}
+
+func normalizePVZAddress(address string) (string, error) {
+	address = strings.TrimSpace(address)
+	if utf8.RuneCountInString(address) > maxPVZAddressLength {
+		return "", errors.New(model.ErrInvalidPVZAddress)
+	}
+	return address, nil
+}
+
+// validateCoordinates координаты задаются либо обе, либо ни одной
+func validateCoordinates(latitude, longitude *float64) error {
+	if latitude == nil && longitude == nil {
+		return nil
+	}
+	if latitude == nil || longitude == nil {
+		return errors.New(model.ErrInvalidCoordinates)
+	}
+	if *latitude < -90 || *latitude > 90 || *longitude < -180 || *longitude > 180 {
+		return errors.New(model.ErrInvalidCoordinates)
+	}
+	return nil
+}
+
+// workingHoursDtoToModel проверяет график и сортирует его по дням недели
+func workingHoursDtoToModel(input *onlymodels.WorkingHours) ([]model.OpeningHours, error) {
+	if input == nil {
+		return nil, nil
+	}
+	result := make([]model.OpeningHours, 0, len(*input))
+	seen := make(map[model.Weekday]struct{}, len(*input))
+	for _, item := range *input {
+		weekday := model.Weekday(item.Weekday)
+		if weekday.ToInt() == 0 {
+			return nil, errors.New(model.ErrInvalidWorkingHours)
+		}
+		if _, ok := seen[weekday]; ok {
+			return nil, errors.New(model.ErrInvalidWorkingHours)
+		}
+		seen[weekday] = struct{}{}
+		opens, err := time.Parse(openingHoursLayout, item.Opens)
+		if err != nil {
+			return nil, errors.New(model.ErrInvalidWorkingHours)
+		}
+		closes, err := time.Parse(openingHoursLayout, item.Closes)
+		if err != nil {
+			return nil, errors.New(model.ErrInvalidWorkingHours)
+		}
+		if !opens.Before(closes) {
+			return nil, errors.New(model.ErrInvalidWorkingHours)
+		}
+		result = append(result, model.OpeningHours{
+			Weekday: weekday,
+			Opens:   opens.Format(openingHoursLayout),
+			Closes:  closes.Format(openingHoursLayout),
+		})
+	}
+	sort.Slice(result, func(i, j int) bool {
+		return result[i].Weekday.ToInt() < result[j].Weekday.ToInt()
+	})
+	return result, nil
+}
+
+func workingHoursDaoToDto(input []dao.OpeningHours) *onlymodels.WorkingHours {
+	result := make(onlymodels.WorkingHours, 0, len(input))
+	for _, item := range input {
+		result = append(result, onlymodels.OpeningHours{
+			Weekday: onlymodels.OpeningHoursWeekday(model.NewWeekday(item.Weekday)),
+			Opens:   item.Opens,
+			Closes:  item.Closes,
+		})
+	}
+	return &result
+}
+
+func workingHoursDaoToGrpc(input []dao.OpeningHours) []*pb.OpeningHours {
+	result := make([]*pb.OpeningHours, 0, len(input))
+	for _, item := range input {
+		result = append(result, &pb.OpeningHours{
+			Weekday: model.NewWeekday(item.Weekday).Get(),
+			Opens:   item.Opens,
+			Closes:  item.Closes,
+		})
+	}
+	return result
+}
+
+func fromNullFloat64(value sql.NullFloat64) *float64 {
+	if !value.Valid {
+		return nil
+	}
+	result := value.Float64
+	return &result
+}
+
+func workingHoursDaoToModel(input []dao.OpeningHours) []model.OpeningHours {
+	result := make([]model.OpeningHours, 0, len(input))
+	for _, item := range input {
+		result = append(result, model.OpeningHours{
+			Weekday: model.NewWeekday(item.Weekday),
+			Opens:   item.Opens,
+			Closes:  item.Closes,
+		})
+	}
+	return result
+}
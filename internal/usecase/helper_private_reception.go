@@ -2,11 +2,15 @@
 package usecase
 
 import (
+	"context"
+	"database/sql"
 	"errors"
 	"github.com/google/uuid"
 	"internshipPVZ/internal/domain/model"
+	repo "internshipPVZ/internal/domain/repository"
 	"internshipPVZ/internal/domain/repository/dao"
 	"internshipPVZ/internal/http/onlymodels"
+	"time"
 )
 
 func receptionDaoToDto(reception *dao.Reception) (*onlymodels.Reception, error) {
@@ -21,11 +25,178 @@ func receptionDaoToDto(reception *dao.Reception) (*onlymodels.Reception, error)
 	if err != nil {
 		return nil, err
 	}
+	openedBy, err := fromNullUUID(reception.OpenedBy)
+	if err != nil {
+		return nil, err
+	}
+	closedBy, err := fromNullUUID(reception.ClosedBy)
+	if err != nil {
+		return nil, err
+	}
 	dto := &onlymodels.Reception{
 		Id:       &id,
 		DateTime: reception.DateTime,
 		Status:   onlymodels.ReceptionStatus(model.NewReceptionStatus(reception.Status)),
 		PvzId:    pvzID,
+		OpenedBy: openedBy,
+		ClosedBy: closedBy,
+		ClosedAt: fromNullTime(reception.ClosedAt),
 	}
 	return dto, nil
 }
+
+func fromNullUUID(value sql.NullString) (*uuid.UUID, error) {
+	if !value.Valid {
+		return nil, nil
+	}
+	id, err := uuid.Parse(value.String)
+	if err != nil {
+		return nil, err
+	}
+	return &id, nil
+}
+
+func fromNullTime(value sql.NullTime) *time.Time {
+	if !value.Valid {
+		return nil
+	}
+	result := value.Time
+	return &result
+}
+
+func receptionStatusesToInt(statuses []model.ReceptionStatus) []int8 {
+	result := make([]int8, 0, len(statuses))
+	for _, status := range statuses {
+		result = append(result, status.ToInt())
+	}
+	return result
+}
+
+// receptionStatusChange параметры перехода последней незавершённой приёмки ПВЗ в новый статус
+type receptionStatusChange struct {
+	usecase string
+	pvzID   uuid.UUID
+	userID  uuid.UUID
+	target  model.ReceptionStatus
+	at      time.Time
+}
+
+// changeLastReceptionStatus переводит последнюю незавершённую приёмку ПВЗ в статус target по правилам автомата статусов.
+// При переходе в финальный статус запоминает, кто и когда завершил приёмку.
+// Проверки и смена статуса идут в одной транзакции под блокировкой строки ПВЗ
+func changeLastReceptionStatus(
+	ctx context.Context,
+	receptionRepo repo.ReceptionRepo,
+	pvzRepo repo.PVZRepo,
+	assignmentRepo repo.PVZAssignmentRepo,
+	txManager repo.TxManager,
+	logger Logger,
+	change receptionStatusChange,
+) (*onlymodels.Reception, error) {
+	usecaseName, target := change.usecase, change.target
+	rec := &model.Reception{PVZID: change.pvzID}
+	if target.IsFinal() {
+		rec.ClosedBy = change.userID
+		rec.ClosedAt = change.at
+	}
+	recDao := rec.ToDao()
+
+	var current model.ReceptionStatus
+	err := runInTx(ctx, txManager, logger, usecaseName, func(ctx context.Context) error {
+		pvzDao, err := pvzRepo.FindByIDForUpdate(ctx, recDao.PVZID)
+		if err != nil {
+			logger.Error("failed to find PVZ",
+				"usecase", usecaseName,
+				"method", "pvzRepo.FindByIDForUpdate",
+				"pvz_id", recDao.PVZID,
+				"error", err)
+			return errors.New(model.ErrInternal)
+		}
+
+		if pvzDao == nil {
+			logger.Warn("invalid PVZ ID",
+				"usecase", usecaseName,
+				"method", "Execute",
+				"pvz_id", recDao.PVZID)
+			return errors.New(model.ErrInvalidPVZID)
+		}
+
+		if err := checkPVZAssignment(ctx, assignmentRepo, logger, usecaseName, change.userID.String(), recDao.PVZID); err != nil {
+			return err
+		}
+
+		recDao.ID = ""
+		err = receptionRepo.FindLast(ctx, recDao, receptionStatusesToInt(model.UnfinishedReceptionStatuses)...)
+		if err != nil {
+			logger.Error("failed to find unfinished reception",
+				"usecase", usecaseName,
+				"method", "receptionRepo.FindLast",
+				"pvz_id", recDao.PVZID,
+				"error", err)
+			return errors.New(model.ErrInternal)
+		}
+
+		if recDao.ID == "" {
+			logger.Warn("no active reception found",
+				"usecase", usecaseName,
+				"method", "Execute",
+				"pvz_id", recDao.PVZID)
+			return errors.New(model.ErrNoActiveReception)
+		}
+
+		current = model.NewReceptionStatus(recDao.Status)
+		if !current.CanTransitionTo(target) {
+			logger.Warn("invalid reception status transition",
+				"usecase", usecaseName,
+				"method", "Execute",
+				"reception_id", recDao.ID,
+				"from", current,
+				"to", target)
+			return errors.New(model.ErrInvalidReceptionTransition)
+		}
+
+		from := recDao.Status
+		recDao.Status = target.ToInt()
+		err = receptionRepo.ChangeStatus(ctx, recDao, from)
+		if err != nil {
+			if err.Error() == model.ErrInvalidReceptionTransition {
+				logger.Warn("reception status was changed concurrently",
+					"usecase", usecaseName,
+					"method", "receptionRepo.ChangeStatus",
+					"reception_id", recDao.ID,
+					"from", current,
+					"to", target)
+				return err
+			}
+			logger.Error("failed to change reception status",
+				"usecase", usecaseName,
+				"method", "receptionRepo.ChangeStatus",
+				"reception_id", recDao.ID,
+				"error", err)
+			return errors.New(model.ErrInternal)
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	recDto, err := receptionDaoToDto(recDao)
+	if err != nil {
+		logger.Error("failed to convert reception DAO to DTO",
+			"usecase", usecaseName,
+			"method", "receptionDaoToDto",
+			"reception_id", recDao.ID,
+			"error", err)
+		return nil, errors.New(model.ErrInternal)
+	}
+
+	logger.Info("reception status changed successfully",
+		"usecase", usecaseName,
+		"reception_id", recDao.ID,
+		"pvz_id", recDao.PVZID,
+		"from", current,
+		"to", target,
+		"user_id", change.userID)
+	return recDto, nil
+}
@@ -0,0 +1,56 @@
+// Package usecase это юзкейсы и вспомогательная логика
+package usecase
+
+import (
+	"errors"
+	"internshipPVZ/internal/domain/model"
+	"internshipPVZ/internal/domain/repository/dao"
+	"internshipPVZ/internal/http/onlymodels"
+)
+
+const maxServiceAccountNameLength = 100
+
+func serviceAccountDaoToDto(account *dao.ServiceAccount) (*onlymodels.ServiceAccount, error) {
+	if account == nil {
+		return nil, errors.New("service account is nil")
+	}
+	id, err := validateRawID(account.ID)
+	if err != nil {
+		return nil, err
+	}
+	organizationID, err := validateRawID(account.OrganizationID)
+	if err != nil {
+		return nil, err
+	}
+	scopes := make([]string, len(account.Scopes))
+	copy(scopes, account.Scopes)
+	return &onlymodels.ServiceAccount{
+		Id:             id,
+		Name:           account.Name,
+		Scopes:         scopes,
+		OrganizationId: organizationID,
+		CreatedAt:      account.CreatedAt,
+		Active:         !account.RevokedAt.Valid,
+	}, nil
+}
+
+// normalizeAPIKeyScopes проверяет области действия ключа и убирает повторы, порядок сохраняется
+func normalizeAPIKeyScopes(scopes []string) ([]string, bool) {
+	if len(scopes) == 0 {
+		return nil, false
+	}
+	seen := make(map[model.APIKeyScope]struct{}, len(scopes))
+	result := make([]string, 0, len(scopes))
+	for _, raw := range scopes {
+		scope, ok := model.NewAPIKeyScope(raw)
+		if !ok {
+			return nil, false
+		}
+		if _, ok := seen[scope]; ok {
+			continue
+		}
+		seen[scope] = struct{}{}
+		result = append(result, string(scope))
+	}
+	return result, true
+}
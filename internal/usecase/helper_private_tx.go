@@ -0,0 +1,30 @@
+// Package usecase это юзкейсы и вспомогательная логика
+package usecase
+
+import (
+	"context"
+	"errors"
+	"internshipPVZ/internal/domain/model"
+	repo "internshipPVZ/internal/domain/repository"
+)
+
+// runInTx выполняет fn в транзакции. Ошибки fn уже залогированы и возвращаются как есть,
+// а ошибку открытия или коммита транзакции логирует и заменяет на ErrInternal
+func runInTx(ctx context.Context, txManager repo.TxManager, logger Logger, usecaseName string, fn func(ctx context.Context) error) error {
+	var fnErr error
+	err := txManager.WithinTx(ctx, func(ctx context.Context) error {
+		fnErr = fn(ctx)
+		return fnErr
+	})
+	if fnErr != nil {
+		return fnErr
+	}
+	if err != nil {
+		logger.Error("transaction failed",
+			"usecase", usecaseName,
+			"method", "txManager.WithinTx",
+			"error", err)
+		return errors.New(model.ErrInternal)
+	}
+	return nil
+}
@@ -0,0 +1,49 @@
+// Package usecase это юзкейсы и вспомогательная логика
+package usecase
+
+import (
+	"errors"
+	"github.com/oapi-codegen/runtime/types"
+	"internshipPVZ/internal/domain/model"
+	"internshipPVZ/internal/domain/repository/dao"
+	"internshipPVZ/internal/http/onlymodels"
+)
+
+const (
+	defaultUsersPage  = 1
+	defaultUsersLimit = 20
+	maxUsersLimit     = 100
+)
+
+func userDaoToDto(user *dao.User) (*onlymodels.User, error) {
+	if user == nil {
+		return nil, errors.New("user is nil")
+	}
+	id, err := validateRawID(user.ID)
+	if err != nil {
+		return nil, err
+	}
+	organizationID, err := validateRawID(user.OrganizationID)
+	if err != nil {
+		return nil, err
+	}
+	active := !user.DeactivatedAt.Valid
+	return &onlymodels.User{
+		Id:             &id,
+		Email:          types.Email(user.Email),
+		Role:           onlymodels.UserRole(model.NewUserRole(user.Role)),
+		OrganizationId: &organizationID,
+		Active:         &active,
+	}, nil
+}
+
+// normalizeUsersPage подставляет значения по умолчанию вместо пропущенных или неверных параметров страницы
+func normalizeUsersPage(page, limit int) (int, int) {
+	if page < 1 {
+		page = defaultUsersPage
+	}
+	if limit < 1 || limit > maxUsersLimit {
+		limit = defaultUsersLimit
+	}
+	return page, limit
+}
@@ -4,6 +4,8 @@ package usecase
 import (
 	"database/sql"
 	"errors"
+	"slices"
+	"strings"
 	"testing"
 	"time"
 
@@ -21,25 +23,31 @@ func TestPvzDaoToDto(t *testing.T) {
 		pvzDao := &dao.PVZ{
 			ID:               testID.String(),
 			RegistrationDate: testTime,
-			City:             model.CityMoscow.ToInt(),
+			City:             testCityMoscowID,
 		}
 
-		dto, err := pvzDaoToDto(pvzDao)
+		dto, err := pvzDaoToDto(pvzDao, testCityCatalog())
 
 		assert.NoError(t, err)
 		assert.Equal(t, testID, *dto.Id)
 		assert.Equal(t, testTime, *dto.RegistrationDate)
-		assert.Equal(t, onlymodels.PVZCity("Москва"), dto.City)
+		assert.Equal(t, "Москва", dto.City)
 	})
 
 	t.Run("invalid UUID", func(t *testing.T) {
 		pvzDao := &dao.PVZ{ID: "invalid-uuid"}
-		_, err := pvzDaoToDto(pvzDao)
+		_, err := pvzDaoToDto(pvzDao, testCityCatalog())
 		assert.Error(t, err)
 	})
 
 	t.Run("nil input", func(t *testing.T) {
-		_, err := pvzDaoToDto(nil)
+		_, err := pvzDaoToDto(nil, testCityCatalog())
+		assert.Error(t, err)
+	})
+
+	t.Run("city missing in catalog", func(t *testing.T) {
+		pvzDao := &dao.PVZ{ID: uuid.New().String(), City: 42}
+		_, err := pvzDaoToDto(pvzDao, testCityCatalog())
 		assert.Error(t, err)
 	})
 }
@@ -51,11 +59,11 @@ func TestPvzsToGrpcDto(t *testing.T) {
 			{
 				ID:               uuid.New().String(),
 				RegistrationDate: testTime,
-				City:             model.CitySPB.ToInt(),
+				City:             testCitySPBID,
 			},
 		}
 
-		dtos, err := pvzsToGrpcDto(pvzDaos)
+		dtos, err := pvzsToGrpcDto(pvzDaos, testCityCatalog())
 
 		assert.NoError(t, err)
 		assert.Len(t, dtos, 1)
@@ -65,18 +73,18 @@ func TestPvzsToGrpcDto(t *testing.T) {
 	})
 
 	t.Run("nil input", func(t *testing.T) {
-		_, err := pvzsToGrpcDto(nil)
+		_, err := pvzsToGrpcDto(nil, testCityCatalog())
 		assert.Error(t, err)
 	})
 
 	t.Run("nil item in slice", func(t *testing.T) {
-		_, err := pvzsToGrpcDto([]*dao.PVZ{nil})
+		_, err := pvzsToGrpcDto([]*dao.PVZ{nil}, testCityCatalog())
 		assert.Error(t, err)
 	})
 }
 
 func TestPvzListToDto(t *testing.T) {
-	t.Run("successful conversion with products", func(t *testing.T) {
+	t.Run("successful conversion with deactivated product type", func(t *testing.T) {
 		pvzID := uuid.New().String()
 		recID := uuid.New().String()
 		productID := uuid.New().String()
@@ -86,35 +94,130 @@ func TestPvzListToDto(t *testing.T) {
 			{
 				PvzID:             pvzID,
 				RegistrationDate:  testTime,
-				City:              model.CityKazan.ToInt(),
-				ReceptionID:       recID,
-				ReceptionDateTime: testTime,
-				Status:            model.ReceptionInProgress.ToInt(),
+				City:              testCityKazanID,
+				ReceptionID:       sql.NullString{String: recID, Valid: true},
+				ReceptionDateTime: sql.NullTime{Time: testTime, Valid: true},
+				Status:            sql.NullInt16{Int16: int16(model.ReceptionInProgress.ToInt()), Valid: true},
 				ProductID:         sql.NullString{String: productID, Valid: true},
 				ProductDateTime:   sql.NullTime{Time: testTime, Valid: true},
-				Type:              sql.NullInt16{Int16: model.ProductShoes.ToInt(), Valid: true},
+				Type:              sql.NullInt16{Int16: testDeactivatedProductType().ID, Valid: true},
 			},
 		}
 
-		result, err := pvzListToDto(input)
+		result, err := pvzListToDto(input, testCityCatalog(), testProductTypeCatalog())
 
 		assert.NoError(t, err)
 		assert.Len(t, *result, 1)
 		assert.Equal(t, "Казань", string((*result)[0].Pvz.City))
 		assert.Len(t, *(*result)[0].Receptions, 1)
 		assert.NotNil(t, (*(*result)[0].Receptions)[0].Products)
-		assert.Equal(t, "обувь", string((*(*(*result)[0].Receptions)[0].Products)[0].Type))
+		assert.Equal(t, testDeactivatedProductType().Name, (*(*(*result)[0].Receptions)[0].Products)[0].Type)
 	})
 
 	t.Run("nil input", func(t *testing.T) {
-		_, err := pvzListToDto(nil)
+		_, err := pvzListToDto(nil, testCityCatalog(), testProductTypeCatalog())
 		assert.Error(t, err)
 	})
 
 	t.Run("nil item in slice", func(t *testing.T) {
-		_, err := pvzListToDto([]*dao.PVZList{nil})
+		_, err := pvzListToDto([]*dao.PVZList{nil}, testCityCatalog(), testProductTypeCatalog())
 		assert.Error(t, err)
 	})
+	t.Run("audit fields", func(t *testing.T) {
+		openedBy := uuid.New()
+		closedBy := uuid.New()
+		addedBy := uuid.New()
+		testTime := time.Now()
+
+		input := []*dao.PVZList{
+			{
+				PvzID:             uuid.New().String(),
+				RegistrationDate:  testTime,
+				City:              testCityKazanID,
+				ReceptionID:       sql.NullString{String: uuid.New().String(), Valid: true},
+				ReceptionDateTime: sql.NullTime{Time: testTime, Valid: true},
+				Status:            sql.NullInt16{Int16: int16(model.ReceptionClosed.ToInt()), Valid: true},
+				OpenedBy:          sql.NullString{String: openedBy.String(), Valid: true},
+				ClosedBy:          sql.NullString{String: closedBy.String(), Valid: true},
+				ClosedAt:          sql.NullTime{Time: testTime, Valid: true},
+				ProductID:         sql.NullString{String: uuid.New().String(), Valid: true},
+				ProductDateTime:   sql.NullTime{Time: testTime, Valid: true},
+				Type:              sql.NullInt16{Int16: 0, Valid: true},
+				AddedBy:           sql.NullString{String: addedBy.String(), Valid: true},
+			},
+		}
+
+		result, err := pvzListToDto(input, testCityCatalog(), testProductTypeCatalog())
+
+		assert.NoError(t, err)
+		reception := (*(*result)[0].Receptions)[0]
+		assert.Equal(t, openedBy, *reception.Reception.OpenedBy)
+		assert.Equal(t, closedBy, *reception.Reception.ClosedBy)
+		assert.Equal(t, testTime, *reception.Reception.ClosedAt)
+		assert.Equal(t, addedBy, *(*reception.Products)[0].AddedBy)
+	})
+
+	t.Run("pvz without receptions in range", func(t *testing.T) {
+		emptyPVZID := uuid.New()
+		pvzID := uuid.New()
+		recID := uuid.New()
+		testTime := time.Now()
+
+		input := []*dao.PVZList{
+			{
+				PvzID:            emptyPVZID.String(),
+				RegistrationDate: testTime,
+				City:             testCityKazanID,
+			},
+			{
+				PvzID:             pvzID.String(),
+				RegistrationDate:  testTime,
+				City:              testCityKazanID,
+				ReceptionID:       sql.NullString{String: recID.String(), Valid: true},
+				ReceptionDateTime: sql.NullTime{Time: testTime, Valid: true},
+				Status:            sql.NullInt16{Int16: int16(model.ReceptionClosed.ToInt()), Valid: true},
+			},
+		}
+
+		result, err := pvzListToDto(input, testCityCatalog(), testProductTypeCatalog())
+
+		assert.NoError(t, err)
+		assert.Len(t, *result, 2)
+		assert.Equal(t, emptyPVZID, *(*result)[0].Pvz.Id)
+		assert.NotNil(t, (*result)[0].Receptions)
+		assert.Empty(t, *(*result)[0].Receptions)
+		receptions := *(*result)[1].Receptions
+		assert.Len(t, receptions, 1)
+		assert.Equal(t, recID, *receptions[0].Reception.Id)
+		assert.Equal(t, onlymodels.ReceptionStatus(model.ReceptionClosed), receptions[0].Reception.Status)
+		assert.Nil(t, receptions[0].Products)
+	})
+
+	t.Run("keeps repository order", func(t *testing.T) {
+		testTime := time.Now()
+		var input []*dao.PVZList
+		var expected []uuid.UUID
+		for range 5 {
+			pvzID := uuid.New()
+			expected = append(expected, pvzID)
+			input = append(input, &dao.PVZList{
+				PvzID:             pvzID.String(),
+				RegistrationDate:  testTime,
+				City:              testCityKazanID,
+				ReceptionID:       sql.NullString{String: uuid.New().String(), Valid: true},
+				ReceptionDateTime: sql.NullTime{Time: testTime, Valid: true},
+			})
+		}
+
+		result, err := pvzListToDto(input, testCityCatalog(), testProductTypeCatalog())
+
+		assert.NoError(t, err)
+		var actual []uuid.UUID
+		for _, item := range *result {
+			actual = append(actual, *item.Pvz.Id)
+		}
+		assert.Equal(t, expected, actual)
+	})
 }
 
 func TestReceptionDaoToDto(t *testing.T) {
@@ -214,3 +317,162 @@ func TestValidateRole(t *testing.T) {
 		})
 	}
 }
+
+func TestWorkingHoursDtoToModel(t *testing.T) {
+	tests := []struct {
+		name          string
+		input         *onlymodels.WorkingHours
+		expected      []model.OpeningHours
+		expectedError bool
+	}{
+		{
+			name:     "nil schedule",
+			input:    nil,
+			expected: nil,
+		},
+		{
+			name: "sorted by weekday",
+			input: &onlymodels.WorkingHours{
+				{Weekday: onlymodels.Sunday, Opens: "10:00", Closes: "18:00"},
+				{Weekday: onlymodels.Monday, Opens: "09:00", Closes: "21:00"},
+			},
+			expected: []model.OpeningHours{
+				{Weekday: model.WeekdayMonday, Opens: "09:00", Closes: "21:00"},
+				{Weekday: model.WeekdaySunday, Opens: "10:00", Closes: "18:00"},
+			},
+		},
+		{
+			name: "duplicate weekday",
+			input: &onlymodels.WorkingHours{
+				{Weekday: onlymodels.Monday, Opens: "09:00", Closes: "12:00"},
+				{Weekday: onlymodels.Monday, Opens: "13:00", Closes: "18:00"},
+			},
+			expectedError: true,
+		},
+		{
+			name: "unknown weekday",
+			input: &onlymodels.WorkingHours{
+				{Weekday: "holiday", Opens: "09:00", Closes: "12:00"},
+			},
+			expectedError: true,
+		},
+		{
+			name: "invalid time format",
+			input: &onlymodels.WorkingHours{
+				{Weekday: onlymodels.Friday, Opens: "9am", Closes: "18:00"},
+			},
+			expectedError: true,
+		},
+		{
+			name: "closes before opens",
+			input: &onlymodels.WorkingHours{
+				{Weekday: onlymodels.Friday, Opens: "18:00", Closes: "09:00"},
+			},
+			expectedError: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result, err := workingHoursDtoToModel(tt.input)
+
+			if tt.expectedError {
+				assert.Error(t, err)
+				assert.Equal(t, model.ErrInvalidWorkingHours, err.Error())
+				return
+			}
+
+			assert.NoError(t, err)
+			assert.Equal(t, tt.expected, result)
+		})
+	}
+}
+
+func TestValidateCoordinates(t *testing.T) {
+	lat, lon := 55.75, 37.61
+	badLat := 91.0
+
+	assert.NoError(t, validateCoordinates(nil, nil))
+	assert.NoError(t, validateCoordinates(&lat, &lon))
+	assert.Error(t, validateCoordinates(&lat, nil))
+	assert.Error(t, validateCoordinates(nil, &lon))
+	assert.Error(t, validateCoordinates(&badLat, &lon))
+}
+
+func TestNormalizeBarcode(t *testing.T) {
+	tests := []struct {
+		name     string
+		input    string
+		expected string
+		wantErr  bool
+	}{
+		{name: "Valid EAN-13", input: "4006381333931", expected: "4006381333931"},
+		{name: "EAN-13 with spaces", input: " 4600051000057 ", expected: "4600051000057"},
+		{name: "EAN-13 wrong checksum", input: "4006381333932", wantErr: true},
+		{name: "Code128 alphanumeric", input: "WB-1234/ab", expected: "WB-1234/ab"},
+		{name: "Code128 digits of other length", input: "123456789012", expected: "123456789012"},
+		{name: "Blank means no barcode", input: "  ", expected: ""},
+		{name: "Non-ASCII", input: "штрихкод", wantErr: true},
+		{name: "Control character", input: "ABC\t123", wantErr: true},
+		{name: "Too long", input: strings.Repeat("A", maxCode128Length+1), wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result, err := normalizeBarcode(&tt.input)
+			if tt.wantErr {
+				assert.EqualError(t, err, model.ErrInvalidBarcode)
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, tt.expected, result)
+		})
+	}
+
+	result, err := normalizeBarcode(nil)
+	assert.NoError(t, err)
+	assert.Empty(t, result)
+}
+
+func TestReceptionStatusTransitions(t *testing.T) {
+	statuses := []model.ReceptionStatus{
+		model.ReceptionInProgress,
+		model.ReceptionPaused,
+		model.ReceptionClosed,
+		model.ReceptionCancelled,
+	}
+	allowed := map[model.ReceptionStatus][]model.ReceptionStatus{
+		model.ReceptionInProgress: {model.ReceptionClosed, model.ReceptionCancelled, model.ReceptionPaused},
+		model.ReceptionPaused:     {model.ReceptionInProgress, model.ReceptionCancelled},
+	}
+
+	for _, from := range statuses {
+		for _, to := range statuses {
+			t.Run(from.Get()+"->"+to.Get(), func(t *testing.T) {
+				assert.Equal(t, slices.Contains(allowed[from], to), from.CanTransitionTo(to))
+			})
+		}
+	}
+}
+
+func TestProductStatusTransitions(t *testing.T) {
+	statuses := []model.ProductStatus{
+		model.ProductReceived,
+		model.ProductStored,
+		model.ProductIssued,
+		model.ProductReturned,
+	}
+	allowed := map[model.ProductStatus][]model.ProductStatus{
+		model.ProductReceived: {model.ProductStored},
+		model.ProductStored:   {model.ProductIssued, model.ProductReturned},
+		model.ProductIssued:   {model.ProductReturned},
+	}
+
+	for _, from := range statuses {
+		for _, to := range statuses {
+			t.Run(from.Get()+"->"+to.Get(), func(t *testing.T) {
+				assert.Equal(t, slices.Contains(allowed[from], to), from.CanTransitionTo(to))
+			})
+		}
+	}
+}
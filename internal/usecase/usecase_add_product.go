@@ -31,7 +31,11 @@ func NewUseCaseAddProduct(
 	receptionRepo repo.ReceptionRepo,
 	productRepo repo.ProductRepo,
 	pvzRepo repo.PVZRepo,
+	productTypeRepo repo.ProductTypeRepo,
+	assignmentRepo repo.PVZAssignmentRepo,
+	txManager repo.TxManager,
 	timeService TimeService,
+	authorizer Authorizer,
 	logger Logger,
 ) *AddProduct {
 	if receptionRepo == nil {
@@ -43,33 +47,53 @@ func NewUseCaseAddProduct(
 	if pvzRepo == nil {
 		log.Fatalf("AddProduct usecase pvzRepo nil")
 	}
+	if productTypeRepo == nil {
+		log.Fatalf("AddProduct usecase productTypeRepo nil")
+	}
+	if assignmentRepo == nil {
+		log.Fatalf("AddProduct usecase assignmentRepo nil")
+	}
+	if txManager == nil {
+		log.Fatalf("AddProduct usecase txManager nil")
+	}
 	if timeService == nil {
 		log.Fatalf("AddProduct usecase timeService nil")
 	}
+	if authorizer == nil {
+		log.Fatalf("AddProduct usecase authorizer nil")
+	}
 	if logger == nil {
 		log.Fatalf("AddProduct usecase logger nil")
 	}
 	return &AddProduct{
-		receptionRepo: receptionRepo,
-		productRepo:   productRepo,
-		pvzRepo:       pvzRepo,
-		timeService:   timeService,
-		logger:        logger,
+		receptionRepo:   receptionRepo,
+		productRepo:     productRepo,
+		pvzRepo:         pvzRepo,
+		productTypeRepo: productTypeRepo,
+		assignmentRepo:  assignmentRepo,
+		txManager:       txManager,
+		timeService:     timeService,
+		authorizer:      authorizer,
+		logger:          logger,
 	}
 }
 
 // AddProduct юзкейс
 type AddProduct struct {
-	receptionRepo repo.ReceptionRepo
-	productRepo   repo.ProductRepo
-	pvzRepo       repo.PVZRepo
-	timeService   TimeService
-	logger        Logger
+	receptionRepo   repo.ReceptionRepo
+	productRepo     repo.ProductRepo
+	pvzRepo         repo.PVZRepo
+	productTypeRepo repo.ProductTypeRepo
+	assignmentRepo  repo.PVZAssignmentRepo
+	txManager       repo.TxManager
+	timeService     TimeService
+	authorizer      Authorizer
+	logger          Logger
 }
 
 // Execute добавляет продукт
-func (uc *AddProduct) Execute(ctx context.Context, request *onlymodels.PostProductsJSONBody, userRole string) (*onlymodels.Product, error) {
-	pvzID, role, product, err := uc.validateInput(request, userRole)
+func (uc *AddProduct) Execute(ctx context.Context, request *onlymodels.PostProductsJSONBody, userID, userRole string) (*onlymodels.Product, error) {
+	pvzID, userUUID, role, productTypeName, err := uc.validateInput(request, userID, userRole)
 	if err != nil {
 		uc.logger.Error("validation failed",
 			"usecase", "AddProduct",
@@ -77,70 +101,86 @@ func (uc *AddProduct) Execute(ctx context.Context, request *onlymodels.PostProdu
 			"error", err)
 		return nil, err
 	}
-	if model.RoleEmployee != role {
-		uc.logger.Warn("access denied",
-			"usecase", "AddProduct",
-			"method", "Execute",
-			"required_role", model.RoleEmployee,
-			"user_role", role)
-		return nil, errors.New(model.ErrAccessDenied)
+	if err = checkPermission(uc.authorizer, uc.logger, "AddProduct", "Execute", role, model.PermissionAddProduct); err != nil {
+		return nil, err
 	}
 
-	product.DateTime = uc.timeService.GetTime()
-	rec := &model.Reception{PVZID: pvzID, Status: model.ReceptionInProgress}
-	recDao := rec.ToDao()
-
-	exists, err := uc.pvzRepo.CheckIfExists(ctx, recDao.PVZID)
+	product, err := uc.validateIdentification(request)
 	if err != nil {
-		uc.logger.Error("failed to check PVZ existence",
-			"usecase", "AddProduct",
-			"method", "pvzRepo.CheckIfExists",
-			"error", err)
-		return nil, errors.New(model.ErrInternal)
-	}
-	if !exists {
-		uc.logger.Warn("invalid PVZ ID",
-			"usecase", "AddProduct",
-			"method", "Execute",
-			"pvz_id", recDao.PVZID)
-		return nil, errors.New(model.ErrInvalidPVZID)
+		return nil, err
 	}
 
-	recDao.ID = ""
-	err = uc.receptionRepo.FindOpened(ctx, recDao)
+	productType, err := uc.validateProductType(ctx, productTypeName)
 	if err != nil {
-		uc.logger.Error("failed to find opened reception",
-			"usecase", "AddProduct",
-			"method", "receptionRepo.FindOpened",
-			"error", err)
-		return nil, errors.New(model.ErrInternal)
-	}
-	if recDao.ID == "" {
-		uc.logger.Warn("no active reception found",
-			"usecase", "AddProduct",
-			"method", "Execute")
-		return nil, errors.New(model.ErrNoActiveReception)
-	}
-	product.ReceptionID, err = validateRawID(recDao.ID)
-	if err != nil {
-		uc.logger.Error("failed to validate reception ID",
-			"usecase", "AddProduct",
-			"method", "validateRawID",
-			"error", err)
-		return nil, errors.New(model.ErrInternal)
+		return nil, err
 	}
-	productDao := product.ToDao()
 
-	err = uc.productRepo.Add(ctx, productDao)
+	product.Type = *productType
+	product.AddedBy = userUUID
+	product.Status = model.ProductReceived
+	product.DateTime = uc.timeService.GetTime()
+	rec := &model.Reception{PVZID: pvzID, Status: model.ReceptionInProgress}
+	recDao := rec.ToDao()
+
+	// приёмка ищется и продукт добавляется под блокировкой ПВЗ, чтобы её не закрыли между проверкой и вставкой
+	var productDao *dao.Product
+	err = runInTx(ctx, uc.txManager, uc.logger, "AddProduct", func(ctx context.Context) error {
+		if err := checkPVZActive(ctx, uc.pvzRepo, uc.logger, "AddProduct", recDao.PVZID); err != nil {
+			return err
+		}
+
+		if err := checkPVZAssignment(ctx, uc.assignmentRepo, uc.logger, "AddProduct", userUUID.String(), recDao.PVZID); err != nil {
+			return err
+		}
+
+		recDao.ID = ""
+		err := uc.receptionRepo.FindOpened(ctx, recDao)
+		if err != nil {
+			uc.logger.Error("failed to find opened reception",
+				"usecase", "AddProduct",
+				"method", "receptionRepo.FindOpened",
+				"error", err)
+			return errors.New(model.ErrInternal)
+		}
+		if recDao.ID == "" {
+			uc.logger.Warn("no active reception found",
+				"usecase", "AddProduct",
+				"method", "Execute")
+			return errors.New(model.ErrNoActiveReception)
+		}
+		product.ReceptionID, err = validateRawID(recDao.ID)
+		if err != nil {
+			uc.logger.Error("failed to validate reception ID",
+				"usecase", "AddProduct",
+				"method", "validateRawID",
+				"error", err)
+			return errors.New(model.ErrInternal)
+		}
+		productDao = product.ToDao()
+
+		err = uc.productRepo.Add(ctx, productDao)
+		if err != nil {
+			if err.Error() == model.ErrDuplicateBarcode {
+				uc.logger.Warn("duplicate barcode in reception",
+					"usecase", "AddProduct",
+					"method", "productRepo.Add",
+					"reception_id", productDao.ReceptionID,
+					"barcode", productDao.Barcode.String)
+				return err
+			}
+			uc.logger.Error("failed to add product",
+				"usecase", "AddProduct",
+				"method", "productRepo.Add",
+				"error", err)
+			return errors.New(model.ErrInternal)
+		}
+		return nil
+	})
 	if err != nil {
-		uc.logger.Error("failed to add product",
-			"usecase", "AddProduct",
-			"method", "productRepo.Add",
-			"error", err)
-		return nil, errors.New(model.ErrInternal)
+		return nil, err
 	}
 
-	productDto, err := uc.productDaoToDto(productDao)
+	productDto, err := productDaoToDto(productDao, productType.Name)
 	if err != nil {
 		uc.logger.Error("failed to convert product DAO to DTO",
 			"usecase", "AddProduct",
@@ -152,58 +192,88 @@ func (uc *AddProduct) Execute(ctx context.Context, request *onlymodels.PostProdu
 	uc.logger.Info("product added successfully",
 		"usecase", "AddProduct",
 		"product_id", productDao.ID,
-		"reception_id", productDao.ReceptionID)
+		"reception_id", productDao.ReceptionID,
+		"user_id", userUUID)
 	return productDto, nil
 }
 
-func (uc *AddProduct) validateInput(request *onlymodels.PostProductsJSONBody, userRole string) (pvzID uuid.UUID, role model.Role, product *model.Product, err error) {
+func (uc *AddProduct) validateInput(request *onlymodels.PostProductsJSONBody, userID, userRole string) (pvzID, userUUID uuid.UUID, role model.Role, productTypeName string, err error) {
 	if pvzID, err = validateID(request.PvzId); err != nil {
 		return
 	}
+	if userUUID, err = validateRawID(userID); err != nil {
+		uc.logger.Warn("invalid user ID in token",
+			"usecase", "AddProduct",
+			"method", "validateInput",
+			"user_id", userID)
+		return
+	}
 	if role, err = validateRole(userRole); err != nil {
 		return
 	}
-	product = &model.Product{}
-	if product.Type, err = uc.validateProductType(string(request.Type)); err != nil {
+	var ok bool
+	if productTypeName, ok = normalizeProductTypeName(request.Type); !ok {
+		uc.logger.Warn("invalid product type",
+			"usecase", "AddProduct",
+			"method", "validateInput",
+			"product_type", request.Type)
+		err = errors.New(model.ErrInvalidProductType)
 		return
 	}
 	return
 }
 
-func (uc *AddProduct) validateProductType(productType string) (model.ProductType, error) {
-	switch productType {
-	case model.ProductClothes.Get():
-		return model.ProductClothes, nil
-	case model.ProductElectronics.Get():
-		return model.ProductElectronics, nil
-	case model.ProductShoes.Get():
-		return model.ProductShoes, nil
-	default:
-		uc.logger.Warn("invalid product type",
+// validateIdentification проверяет штрихкод, SKU и номер заказа
+func (uc *AddProduct) validateIdentification(request *onlymodels.PostProductsJSONBody) (*model.Product, error) {
+	barcode, err := normalizeBarcode(request.Barcode)
+	if err != nil {
+		uc.logger.Warn("invalid barcode",
 			"usecase", "AddProduct",
-			"method", "validateProductType",
-			"product_type", productType)
-		return model.ProductDefault, errors.New(model.ErrInvalidProductType)
+			"method", "validateIdentification",
+			"barcode", *request.Barcode)
+		return nil, err
+	}
+	sku, ok := normalizeProductReference(request.Sku)
+	if !ok {
+		uc.logger.Warn("invalid SKU",
+			"usecase", "AddProduct",
+			"method", "validateIdentification")
+		return nil, errors.New(model.ErrInvalidSKU)
+	}
+	orderID, ok := normalizeProductReference(request.OrderId)
+	if !ok {
+		uc.logger.Warn("invalid order ID",
+			"usecase", "AddProduct",
+			"method", "validateIdentification")
+		return nil, errors.New(model.ErrInvalidOrderID)
 	}
+	return &model.Product{Barcode: barcode, SKU: sku, OrderID: orderID}, nil
 }
 
-func (uc *AddProduct) productDaoToDto(product *dao.Product) (*onlymodels.Product, error) {
-	if product == nil {
-		return nil, errors.New("product is nil")
-	}
-	id, err := validateRawID(product.ID)
+// validateProductType ищет активный тип продукта в справочнике
+func (uc *AddProduct) validateProductType(ctx context.Context, productTypeName string) (*model.ProductType, error) {
+	productType, err := uc.productTypeRepo.FindByName(ctx, productTypeName)
 	if err != nil {
-		return nil, err
+		uc.logger.Error("failed to find product type",
+			"usecase", "AddProduct",
+			"method", "productTypeRepo.FindByName",
+			"product_type", productTypeName,
+			"error", err)
+		return nil, errors.New(model.ErrInternal)
 	}
-	receptionID, err := validateRawID(product.ReceptionID)
-	if err != nil {
-		return nil, err
+	if productType == nil {
+		uc.logger.Warn("product type is missing in catalog",
+			"usecase", "AddProduct",
+			"method", "validateProductType",
+			"product_type", productTypeName)
+		return nil, errors.New(model.ErrInvalidProductType)
 	}
-	dto := &onlymodels.Product{
-		Id:          &id,
-		DateTime:    &product.DateTime,
-		Type:        onlymodels.ProductType(model.NewProductType(product.Type)),
-		ReceptionId: receptionID,
+	if !productType.Active {
+		uc.logger.Warn("product type is deactivated",
+			"usecase", "AddProduct",
+			"method", "validateProductType",
+			"product_type", productTypeName)
+		return nil, errors.New(model.ErrProductTypeDeactivated)
 	}
-	return dto, nil
+	return &model.ProductType{ID: productType.ID, Name: productType.Name, Active: productType.Active}, nil
 }
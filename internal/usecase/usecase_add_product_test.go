@@ -3,7 +3,9 @@ package usecase
 
 import (
 	"context"
+	"database/sql"
 	"errors"
+	"strings"
 	"testing"
 	"time"
 
@@ -15,6 +17,8 @@ import (
 	"internshipPVZ/internal/http/onlymodels"
 )
 
+var testUserID = uuid.NewString()
+
 type mockReceptionRepo struct{ mock.Mock }
 
 func (m *mockReceptionRepo) Create(ctx context.Context, reception *dao.Reception) error {
@@ -27,16 +31,24 @@ func (m *mockReceptionRepo) FindOpened(ctx context.Context, reception *dao.Recep
 	return args.Error(0)
 }
 
-func (m *mockReceptionRepo) CheckIfExists(ctx context.Context, reception *dao.Reception) (bool, error) {
-	args := m.Called(ctx, reception)
-	return args.Bool(0), args.Error(1)
+func (m *mockReceptionRepo) FindLast(ctx context.Context, reception *dao.Reception, statuses ...int8) error {
+	args := m.Called(ctx, reception, statuses)
+	return args.Error(0)
 }
 
-func (m *mockReceptionRepo) Close(ctx context.Context, rec *dao.Reception) error {
-	args := m.Called(ctx, rec)
+func (m *mockReceptionRepo) ChangeStatus(ctx context.Context, reception *dao.Reception, from int8) error {
+	args := m.Called(ctx, reception, from)
 	return args.Error(0)
 }
 
+func (m *mockReceptionRepo) FindByID(ctx context.Context, id string) (*dao.Reception, error) {
+	args := m.Called(ctx, id)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*dao.Reception), args.Error(1)
+}
+
 type mockProductRepo struct{ mock.Mock }
 
 func (m *mockProductRepo) Add(ctx context.Context, product *dao.Product) error {
@@ -54,6 +66,52 @@ func (m *mockProductRepo) DeleteLastFromReception(ctx context.Context, reception
 	return args.Error(0)
 }
 
+func (m *mockProductRepo) FindByID(ctx context.Context, id string) (*dao.Product, error) {
+	args := m.Called(ctx, id)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*dao.Product), args.Error(1)
+}
+
+func (m *mockProductRepo) ChangeStatus(ctx context.Context, product *dao.Product, from int8) error {
+	args := m.Called(ctx, product, from)
+	return args.Error(0)
+}
+
+func (m *mockProductRepo) GetInventory(ctx context.Context, pvzID string, productStatuses, receptionStatuses []int8) ([]*dao.Product, error) {
+	args := m.Called(ctx, pvzID, productStatuses, receptionStatuses)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]*dao.Product), args.Error(1)
+}
+
+type txMarker struct{}
+
+// mockTxManager вместо транзакции помечает контекст, чтобы тесты проверяли, какие вызовы шли внутри неё
+type mockTxManager struct {
+	calls     int
+	commitErr error
+}
+
+func newTestTxManager() *mockTxManager {
+	return &mockTxManager{}
+}
+
+func (m *mockTxManager) WithinTx(ctx context.Context, fn func(ctx context.Context) error) error {
+	m.calls++
+	if err := fn(context.WithValue(ctx, txMarker{}, true)); err != nil {
+		return err
+	}
+	return m.commitErr
+}
+
+func inTx(ctx context.Context) bool {
+	marked, _ := ctx.Value(txMarker{}).(bool)
+	return marked
+}
+
 type mockPVZRepo struct{ mock.Mock }
 
 func (m *mockPVZRepo) Create(ctx context.Context, pvz *dao.PVZ) error {
@@ -61,21 +119,23 @@ func (m *mockPVZRepo) Create(ctx context.Context, pvz *dao.PVZ) error {
 	return args.Error(0)
 }
 
-func (m *mockPVZRepo) GetAllWithFilter(ctx context.Context, startDate, endDate string, page, limit int) ([]*dao.PVZList, error) {
-	args := m.Called(ctx, startDate, endDate, page, limit)
+func (m *mockPVZRepo) GetAllWithFilter(ctx context.Context, filter dao.PVZListFilter) ([]*dao.PVZList, *dao.PVZCursor, error) {
+	args := m.Called(ctx, filter)
+	next, _ := args.Get(1).(*dao.PVZCursor)
 	if args.Get(0) == nil {
-		return nil, args.Error(1)
+		return nil, next, args.Error(2)
 	}
 
-	return args.Get(0).([]*dao.PVZList), args.Error(1)
+	return args.Get(0).([]*dao.PVZList), next, args.Error(2)
 }
 
-func (m *mockPVZRepo) Get(ctx context.Context) ([]*dao.PVZ, error) {
-	args := m.Called(ctx)
+func (m *mockPVZRepo) Get(ctx context.Context, after *dao.PVZCursor, limit int, includeArchived bool) ([]*dao.PVZ, *dao.PVZCursor, error) {
+	args := m.Called(ctx, after, limit, includeArchived)
+	next, _ := args.Get(1).(*dao.PVZCursor)
 	if args.Get(0) == nil {
-		return nil, args.Error(1)
+		return nil, next, args.Error(2)
 	}
-	return args.Get(0).([]*dao.PVZ), args.Error(1)
+	return args.Get(0).([]*dao.PVZ), next, args.Error(2)
 }
 
 func (m *mockPVZRepo) CheckIfExists(ctx context.Context, id string) (bool, error) {
@@ -83,6 +143,32 @@ func (m *mockPVZRepo) CheckIfExists(ctx context.Context, id string) (bool, error
 	return args.Bool(0), args.Error(1)
 }
 
+func (m *mockPVZRepo) FindByID(ctx context.Context, id string) (*dao.PVZ, error) {
+	args := m.Called(ctx, id)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*dao.PVZ), args.Error(1)
+}
+
+func (m *mockPVZRepo) FindByIDForUpdate(ctx context.Context, id string) (*dao.PVZ, error) {
+	args := m.Called(ctx, id)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*dao.PVZ), args.Error(1)
+}
+
+func (m *mockPVZRepo) Update(ctx context.Context, pvz *dao.PVZ) error {
+	args := m.Called(ctx, pvz)
+	return args.Error(0)
+}
+
+func (m *mockPVZRepo) Archive(ctx context.Context, pvz *dao.PVZ) error {
+	args := m.Called(ctx, pvz)
+	return args.Error(0)
+}
+
 type mockTimeService struct{ mock.Mock }
 
 func (m *mockTimeService) GetTime() time.Time {
@@ -97,11 +183,17 @@ func (m *mockLogger) Info(msg string, args ...any)  { m.Called(msg, args) }
 func (m *mockLogger) Warn(msg string, args ...any)  { m.Called(msg, args) }
 func (m *mockLogger) Error(msg string, args ...any) { m.Called(msg, args) }
 
+// barcodeRef штрихкод в запросе необязателен, поэтому передаётся указателем
+func barcodeRef(barcode string) *string {
+	return &barcode
+}
+
 func TestAddProduct_Execute(t *testing.T) {
 	validPVZID := uuid.New()
 	validReceptionID := uuid.New()
 	validProductID := uuid.New()
 	testTime := time.Now()
+	testBarcode := "4006381333931"
 
 	tests := []struct {
 		name          string
@@ -114,7 +206,7 @@ func TestAddProduct_Execute(t *testing.T) {
 		{
 			name: "Successfully add product",
 			setupMocks: func(mr *mockReceptionRepo, mp *mockProductRepo, mz *mockPVZRepo, mt *mockTimeService, ml *mockLogger) {
-				mz.On("CheckIfExists", mock.Anything, validPVZID.String()).Return(true, nil)
+				mz.On("FindByIDForUpdate", mock.Anything, validPVZID.String()).Return(&dao.PVZ{ID: validPVZID.String()}, nil)
 				mr.On("FindOpened", mock.Anything, mock.Anything).Run(func(args mock.Arguments) {
 					rec := args.Get(1).(*dao.Reception)
 					rec.ID = validReceptionID.String()
@@ -126,6 +218,38 @@ func TestAddProduct_Execute(t *testing.T) {
 				mt.On("GetTime").Return(testTime)
 				ml.On("Info", mock.Anything, mock.Anything)
 			},
+			request: &onlymodels.PostProductsJSONBody{
+				PvzId:   validPVZID,
+				Type:    "электроника",
+				Barcode: barcodeRef("4006381333931"),
+			},
+			userRole: model.RoleEmployee.Get(),
+			expected: &onlymodels.Product{
+				Id:          &validProductID,
+				DateTime:    &testTime,
+				Type:        "электроника",
+				ReceptionId: validReceptionID,
+				Barcode:     &testBarcode,
+			},
+		},
+		{
+			name: "Successfully add product without barcode",
+			setupMocks: func(mr *mockReceptionRepo, mp *mockProductRepo, mz *mockPVZRepo, mt *mockTimeService, ml *mockLogger) {
+				mz.On("FindByIDForUpdate", mock.Anything, validPVZID.String()).Return(&dao.PVZ{ID: validPVZID.String()}, nil)
+				mr.On("FindOpened", mock.Anything, mock.Anything).Run(func(args mock.Arguments) {
+					rec := args.Get(1).(*dao.Reception)
+					rec.ID = validReceptionID.String()
+				}).Return(nil)
+				// без штрихкода в базу пишется NULL, и проверка дубликатов его не касается
+				mp.On("Add", mock.Anything, mock.MatchedBy(func(p *dao.Product) bool {
+					return !p.Barcode.Valid
+				})).Run(func(args mock.Arguments) {
+					p := args.Get(1).(*dao.Product)
+					p.ID = validProductID.String()
+				}).Return(nil)
+				mt.On("GetTime").Return(testTime)
+				ml.On("Info", mock.Anything, mock.Anything)
+			},
 			request: &onlymodels.PostProductsJSONBody{
 				PvzId: validPVZID,
 				Type:  "электроника",
@@ -144,8 +268,9 @@ func TestAddProduct_Execute(t *testing.T) {
 				ml.On("Error", mock.Anything, mock.Anything)
 			},
 			request: &onlymodels.PostProductsJSONBody{
-				PvzId: uuid.Nil,
-				Type:  "электроника",
+				PvzId:   uuid.Nil,
+				Type:    "электроника",
+				Barcode: barcodeRef("4006381333931"),
 			},
 			userRole:      model.RoleEmployee.Get(),
 			expectedError: model.ErrAccessDenied,
@@ -157,20 +282,35 @@ func TestAddProduct_Execute(t *testing.T) {
 				ml.On("Warn", mock.Anything, mock.Anything)
 			},
 			request: &onlymodels.PostProductsJSONBody{
-				PvzId: validPVZID,
-				Type:  "invalid-type",
+				PvzId:   validPVZID,
+				Type:    "invalid-type",
+				Barcode: barcodeRef("4006381333931"),
 			},
 			userRole:      model.RoleEmployee.Get(),
 			expectedError: model.ErrInvalidProductType,
 		},
+		{
+			name: "Deactivated product type",
+			setupMocks: func(_ *mockReceptionRepo, _ *mockProductRepo, _ *mockPVZRepo, _ *mockTimeService, ml *mockLogger) {
+				ml.On("Warn", mock.Anything, mock.Anything)
+			},
+			request: &onlymodels.PostProductsJSONBody{
+				PvzId:   validPVZID,
+				Type:    testDeactivatedProductType().Name,
+				Barcode: barcodeRef("4006381333931"),
+			},
+			userRole:      model.RoleEmployee.Get(),
+			expectedError: model.ErrProductTypeDeactivated,
+		},
 		{
 			name: "Non-employee role",
 			setupMocks: func(_ *mockReceptionRepo, _ *mockProductRepo, _ *mockPVZRepo, _ *mockTimeService, ml *mockLogger) {
 				ml.On("Warn", mock.Anything, mock.Anything)
 			},
 			request: &onlymodels.PostProductsJSONBody{
-				PvzId: validPVZID,
-				Type:  "электроника",
+				PvzId:   validPVZID,
+				Type:    "электроника",
+				Barcode: barcodeRef("4006381333931"),
 			},
 			userRole:      model.RoleModerator.Get(),
 			expectedError: model.ErrAccessDenied,
@@ -178,37 +318,120 @@ func TestAddProduct_Execute(t *testing.T) {
 		{
 			name: "PVZ not found",
 			setupMocks: func(_ *mockReceptionRepo, _ *mockProductRepo, mz *mockPVZRepo, mt *mockTimeService, ml *mockLogger) {
-				mz.On("CheckIfExists", mock.Anything, validPVZID.String()).Return(false, nil)
+				mz.On("FindByIDForUpdate", mock.Anything, validPVZID.String()).Return(nil, nil)
 				ml.On("Warn", mock.Anything, mock.Anything)
 				mt.On("GetTime").Return(time.Now())
 			},
 			request: &onlymodels.PostProductsJSONBody{
-				PvzId: validPVZID,
-				Type:  "электроника",
+				PvzId:   validPVZID,
+				Type:    "электроника",
+				Barcode: barcodeRef("4006381333931"),
 			},
 			userRole:      model.RoleEmployee.Get(),
 			expectedError: model.ErrInvalidPVZID,
 		},
+		{
+			name: "Archived PVZ",
+			setupMocks: func(_ *mockReceptionRepo, _ *mockProductRepo, mz *mockPVZRepo, mt *mockTimeService, ml *mockLogger) {
+				mz.On("FindByIDForUpdate", mock.Anything, validPVZID.String()).Return(&dao.PVZ{
+					ID:         validPVZID.String(),
+					ArchivedAt: sql.NullTime{Time: time.Now(), Valid: true},
+				}, nil)
+				ml.On("Error", mock.Anything, mock.Anything)
+				ml.On("Warn", mock.Anything, mock.Anything)
+				mt.On("GetTime").Return(time.Now())
+			},
+			request: &onlymodels.PostProductsJSONBody{
+				PvzId:   validPVZID,
+				Type:    "электроника",
+				Barcode: barcodeRef("4006381333931"),
+			},
+			userRole:      model.RoleEmployee.Get(),
+			expectedError: model.ErrPVZArchived,
+		},
+		{
+			name: "PVZ not assigned to user",
+			setupMocks: func(_ *mockReceptionRepo, _ *mockProductRepo, mz *mockPVZRepo, mt *mockTimeService, ml *mockLogger) {
+				mz.On("FindByIDForUpdate", mock.Anything, testForeignPVZID.String()).Return(&dao.PVZ{ID: testForeignPVZID.String()}, nil)
+				ml.On("Warn", mock.Anything, mock.Anything)
+				mt.On("GetTime").Return(time.Now())
+			},
+			request: &onlymodels.PostProductsJSONBody{
+				PvzId:   testForeignPVZID,
+				Type:    "электроника",
+				Barcode: barcodeRef("4006381333931"),
+			},
+			userRole:      model.RoleEmployee.Get(),
+			expectedError: model.ErrAccessDenied,
+		},
 		{
 			name: "No active reception",
 			setupMocks: func(mr *mockReceptionRepo, _ *mockProductRepo, mz *mockPVZRepo, mt *mockTimeService, ml *mockLogger) {
-				mz.On("CheckIfExists", mock.Anything, validPVZID.String()).Return(true, nil)
+				mz.On("FindByIDForUpdate", mock.Anything, validPVZID.String()).Return(&dao.PVZ{ID: validPVZID.String()}, nil)
 				mr.On("FindOpened", mock.Anything, mock.Anything).Return(nil)
 				ml.On("Error", mock.Anything, mock.Anything)
 				ml.On("Warn", mock.Anything, mock.Anything)
 				mt.On("GetTime").Return(time.Now())
 			},
 			request: &onlymodels.PostProductsJSONBody{
-				PvzId: validPVZID,
-				Type:  "электроника",
+				PvzId:   validPVZID,
+				Type:    "электроника",
+				Barcode: barcodeRef("4006381333931"),
 			},
 			userRole:      model.RoleEmployee.Get(),
 			expectedError: model.ErrNoActiveReception,
 		},
+		{
+			name: "Invalid barcode",
+			setupMocks: func(_ *mockReceptionRepo, _ *mockProductRepo, _ *mockPVZRepo, _ *mockTimeService, ml *mockLogger) {
+				ml.On("Warn", mock.Anything, mock.Anything)
+			},
+			request: &onlymodels.PostProductsJSONBody{
+				PvzId:   validPVZID,
+				Type:    "электроника",
+				Barcode: barcodeRef("4006381333932"),
+			},
+			userRole:      model.RoleEmployee.Get(),
+			expectedError: model.ErrInvalidBarcode,
+		},
+		{
+			name: "Too long SKU",
+			setupMocks: func(_ *mockReceptionRepo, _ *mockProductRepo, _ *mockPVZRepo, _ *mockTimeService, ml *mockLogger) {
+				ml.On("Warn", mock.Anything, mock.Anything)
+			},
+			request: &onlymodels.PostProductsJSONBody{
+				PvzId:   validPVZID,
+				Type:    "электроника",
+				Barcode: barcodeRef("4006381333931"),
+				Sku:     func() *string { s := strings.Repeat("1", maxProductReferenceLength+1); return &s }(),
+			},
+			userRole:      model.RoleEmployee.Get(),
+			expectedError: model.ErrInvalidSKU,
+		},
+		{
+			name: "Duplicate barcode in reception",
+			setupMocks: func(mr *mockReceptionRepo, mp *mockProductRepo, mz *mockPVZRepo, mt *mockTimeService, ml *mockLogger) {
+				mz.On("FindByIDForUpdate", mock.Anything, validPVZID.String()).Return(&dao.PVZ{ID: validPVZID.String()}, nil)
+				mr.On("FindOpened", mock.Anything, mock.Anything).Run(func(args mock.Arguments) {
+					rec := args.Get(1).(*dao.Reception)
+					rec.ID = validReceptionID.String()
+				}).Return(nil)
+				mp.On("Add", mock.Anything, mock.Anything).Return(errors.New(model.ErrDuplicateBarcode))
+				ml.On("Warn", mock.Anything, mock.Anything)
+				mt.On("GetTime").Return(time.Now())
+			},
+			request: &onlymodels.PostProductsJSONBody{
+				PvzId:   validPVZID,
+				Type:    "электроника",
+				Barcode: barcodeRef("4006381333931"),
+			},
+			userRole:      model.RoleEmployee.Get(),
+			expectedError: model.ErrDuplicateBarcode,
+		},
 		{
 			name: "Error adding product",
 			setupMocks: func(mr *mockReceptionRepo, mp *mockProductRepo, mz *mockPVZRepo, mt *mockTimeService, ml *mockLogger) {
-				mz.On("CheckIfExists", mock.Anything, validPVZID.String()).Return(true, nil)
+				mz.On("FindByIDForUpdate", mock.Anything, validPVZID.String()).Return(&dao.PVZ{ID: validPVZID.String()}, nil)
 				mr.On("FindOpened", mock.Anything, mock.Anything).Run(func(args mock.Arguments) {
 					rec := args.Get(1).(*dao.Reception)
 					rec.ID = validReceptionID.String()
@@ -218,8 +441,9 @@ func TestAddProduct_Execute(t *testing.T) {
 				mt.On("GetTime").Return(time.Now())
 			},
 			request: &onlymodels.PostProductsJSONBody{
-				PvzId: validPVZID,
-				Type:  "электроника",
+				PvzId:   validPVZID,
+				Type:    "электроника",
+				Barcode: barcodeRef("4006381333931"),
 			},
 			userRole:      model.RoleEmployee.Get(),
 			expectedError: model.ErrInternal,
@@ -234,12 +458,14 @@ func TestAddProduct_Execute(t *testing.T) {
 			mt := &mockTimeService{}
 			ml := &mockLogger{}
 
+			mpt := newTestProductTypeRepo()
+
 			if tt.setupMocks != nil {
 				tt.setupMocks(mr, mp, mz, mt, ml)
 			}
 
-			uc := NewUseCaseAddProduct(mr, mp, mz, mt, ml)
-			result, err := uc.Execute(context.Background(), tt.request, tt.userRole)
+			uc := NewUseCaseAddProduct(mr, mp, mz, mpt, newTestAssignmentRepo(), newTestTxManager(), mt, newTestAuthorizer(), ml)
+			result, err := uc.Execute(context.Background(), tt.request, testUserID, tt.userRole)
 
 			if tt.expectedError != "" {
 				assert.Error(t, err)
@@ -252,6 +478,9 @@ func TestAddProduct_Execute(t *testing.T) {
 			assert.Equal(t, tt.expected.Type, result.Type)
 			assert.Equal(t, tt.expected.DateTime, result.DateTime)
 			assert.Equal(t, tt.expected.ReceptionId, result.ReceptionId)
+			assert.Equal(t, tt.expected.Barcode, result.Barcode)
+			assert.Equal(t, testUserID, result.AddedBy.String())
+			assert.Equal(t, onlymodels.Received, *result.Status)
 		})
 	}
 }
@@ -263,48 +492,71 @@ func TestAddProduct_validateInput(t *testing.T) {
 		name          string
 		setupMocks    func(*mockTimeService, *mockLogger)
 		request       *onlymodels.PostProductsJSONBody
+		userID        string
 		userRole      string
 		expectedError string
 	}{
 		{
 			name: "Valid input",
 			request: &onlymodels.PostProductsJSONBody{
-				PvzId: validPVZID,
-				Type:  "электроника",
+				PvzId:   validPVZID,
+				Type:    "электроника",
+				Barcode: barcodeRef("4006381333931"),
 			},
+			userID:   testUserID,
 			userRole: model.RoleEmployee.Get(),
 		},
 		{
 			name: "Invalid PVZ ID",
 			request: &onlymodels.PostProductsJSONBody{
-				PvzId: uuid.Nil,
-				Type:  "электроника",
+				PvzId:   uuid.Nil,
+				Type:    "электроника",
+				Barcode: barcodeRef("4006381333931"),
 			},
+			userID:        testUserID,
 			userRole:      model.RoleEmployee.Get(),
 			expectedError: model.ErrAccessDenied,
 		},
 		{
 			name: "Invalid user role",
 			request: &onlymodels.PostProductsJSONBody{
-				PvzId: validPVZID,
-				Type:  "электроника",
+				PvzId:   validPVZID,
+				Type:    "электроника",
+				Barcode: barcodeRef("4006381333931"),
 			},
+			userID:        testUserID,
 			userRole:      "invalid-role",
 			expectedError: model.ErrInvalidRole,
 		},
 		{
-			name: "Invalid product type",
+			name: "Empty product type",
 			setupMocks: func(mt *mockTimeService, ml *mockLogger) {
 				mt.On("GetTime").Return(time.Now())
 				ml.On("Warn", mock.Anything, mock.Anything)
 			},
 			request: &onlymodels.PostProductsJSONBody{
-				PvzId: validPVZID,
-				Type:  "invalid-type",
+				PvzId:   validPVZID,
+				Type:    " ",
+				Barcode: barcodeRef("4006381333931"),
 			},
+			userID:        testUserID,
 			userRole:      model.RoleEmployee.Get(),
 			expectedError: model.ErrInvalidProductType,
 		},
+		{
+			name: "Invalid user ID",
+			setupMocks: func(_ *mockTimeService, ml *mockLogger) {
+				ml.On("Warn", mock.Anything, mock.Anything)
+			},
+			request: &onlymodels.PostProductsJSONBody{
+				PvzId:   validPVZID,
+				Type:    "электроника",
+				Barcode: barcodeRef("4006381333931"),
+			},
+			userID:        "not-a-uuid",
+			userRole:      model.RoleEmployee.Get(),
+			expectedError: model.ErrAccessDenied,
+		},
 	}
 
 	for _, tt := range tests {
@@ -317,7 +569,7 @@ func TestAddProduct_validateInput(t *testing.T) {
 			}
 			uc := &AddProduct{logger: ml}
 
-			_, _, _, err := uc.validateInput(tt.request, tt.userRole)
+			_, _, _, _, err := uc.validateInput(tt.request, tt.userID, tt.userRole)
 
 			if tt.expectedError != "" {
 				assert.Error(t, err)
@@ -335,30 +587,33 @@ func TestAddProduct_validateProductType(t *testing.T) {
 		name          string
 		productType   string
 		setupMocks    func(*mockLogger)
-		expected      model.ProductType
+		expected      *model.ProductType
 		expectedError string
 	}{
 		{
 			name:        "Valid electronics",
 			productType: "электроника",
-			expected:    model.ProductElectronics,
-		},
-		{
-			name:        "Valid clothes",
-			productType: "одежда",
-			expected:    model.ProductClothes,
+			expected:    &model.ProductType{ID: 0, Name: "электроника", Active: true},
 		},
 		{
 			name:        "Valid shoes",
 			productType: "обувь",
-			expected:    model.ProductShoes,
+			expected:    &model.ProductType{ID: 1, Name: "обувь", Active: true},
+		},
+		{
+			name: "Deactivated type",
+			setupMocks: func(ml *mockLogger) {
+				ml.On("Warn", mock.Anything, mock.Anything)
+			},
+			productType:   testDeactivatedProductType().Name,
+			expectedError: model.ErrProductTypeDeactivated,
 		},
 		{
 			name: "Invalid type",
 			setupMocks: func(ml *mockLogger) {
 				ml.On("Warn", mock.Anything, mock.Anything)
 			},
-			productType:   "invalid",
+			productType:   "invalid-type",
 			expectedError: model.ErrInvalidProductType,
 		},
 	}
@@ -370,9 +625,9 @@ func TestAddProduct_validateProductType(t *testing.T) {
 			if tt.setupMocks != nil {
 				tt.setupMocks(ml)
 			}
-			uc := &AddProduct{logger: ml}
+			uc := &AddProduct{productTypeRepo: newTestProductTypeRepo(), logger: ml}
 
-			result, err := uc.validateProductType(tt.productType)
+			result, err := uc.validateProductType(context.Background(), tt.productType)
 
 			if tt.expectedError != "" {
 				assert.Error(t, err)
@@ -386,7 +641,7 @@ func TestAddProduct_validateProductType(t *testing.T) {
 	}
 }
 
-func TestAddProduct_productDaoToDto(t *testing.T) {
+func TestProductDaoToDto(t *testing.T) {
 	validProductID := uuid.New()
 	validReceptionID := uuid.New()
 	testTime := time.Now()
@@ -403,7 +658,8 @@ func TestAddProduct_productDaoToDto(t *testing.T) {
 				ID:          validProductID.String(),
 				DateTime:    testTime,
 				ReceptionID: validReceptionID.String(),
-				Type:        model.ProductElectronics.ToInt(),
+				Type:        0,
+				Status:      model.ProductStored.ToInt(),
 			},
 			expected: &onlymodels.Product{
 				Id:          &validProductID,
@@ -423,7 +679,7 @@ func TestAddProduct_productDaoToDto(t *testing.T) {
 				ID:          "invalid",
 				DateTime:    testTime,
 				ReceptionID: validReceptionID.String(),
-				Type:        model.ProductElectronics.ToInt(),
+				Type:        0,
 			},
 			expectedError: model.ErrAccessDenied,
 		},
@@ -431,10 +687,7 @@ func TestAddProduct_productDaoToDto(t *testing.T) {
 
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			ml := &mockLogger{}
-			uc := &AddProduct{logger: ml}
-
-			result, err := uc.productDaoToDto(tt.product)
+			result, err := productDaoToDto(tt.product, "электроника")
 
 			if tt.expectedError != "" {
 				assert.Error(t, err)
@@ -447,6 +700,63 @@ func TestAddProduct_productDaoToDto(t *testing.T) {
 			assert.Equal(t, tt.expected.Type, result.Type)
 			assert.Equal(t, tt.expected.DateTime, result.DateTime)
 			assert.Equal(t, tt.expected.ReceptionId, result.ReceptionId)
+			assert.Equal(t, onlymodels.Stored, *result.Status)
 		})
 	}
 }
+
+func TestAddProduct_Transaction(t *testing.T) {
+	validPVZID := uuid.New()
+	validReceptionID := uuid.New()
+	request := &onlymodels.PostProductsJSONBody{
+		PvzId:   validPVZID,
+		Type:    "электроника",
+		Barcode: barcodeRef("4006381333931"),
+	}
+
+	setup := func() (*mockReceptionRepo, *mockProductRepo, *mockPVZRepo, *mockLogger) {
+		mr := &mockReceptionRepo{}
+		mp := &mockProductRepo{}
+		mz := &mockPVZRepo{}
+		ml := &mockLogger{}
+		mz.On("FindByIDForUpdate", mock.MatchedBy(inTx), validPVZID.String()).Return(&dao.PVZ{ID: validPVZID.String()}, nil)
+		mr.On("FindOpened", mock.MatchedBy(inTx), mock.Anything).Run(func(args mock.Arguments) {
+			args.Get(1).(*dao.Reception).ID = validReceptionID.String()
+		}).Return(nil)
+		mp.On("Add", mock.MatchedBy(inTx), mock.Anything).Run(func(args mock.Arguments) {
+			args.Get(1).(*dao.Product).ID = uuid.NewString()
+		}).Return(nil)
+		return mr, mp, mz, ml
+	}
+
+	t.Run("Lock, lookup and insert run in one transaction", func(t *testing.T) {
+		mr, mp, mz, ml := setup()
+		ml.On("Info", mock.Anything, mock.Anything)
+		mt := &mockTimeService{}
+		mt.On("GetTime").Return(time.Now())
+		tx := newTestTxManager()
+
+		uc := NewUseCaseAddProduct(mr, mp, mz, newTestProductTypeRepo(), newTestAssignmentRepo(), tx, mt, newTestAuthorizer(), ml)
+		_, err := uc.Execute(context.Background(), request, testUserID, model.RoleEmployee.Get())
+
+		assert.NoError(t, err)
+		assert.Equal(t, 1, tx.calls)
+		mz.AssertExpectations(t)
+		mr.AssertExpectations(t)
+		mp.AssertExpectations(t)
+	})
+
+	t.Run("Commit error", func(t *testing.T) {
+		mr, mp, mz, ml := setup()
+		ml.On("Error", mock.Anything, mock.Anything).Once()
+		mt := &mockTimeService{}
+		mt.On("GetTime").Return(time.Now())
+		tx := &mockTxManager{commitErr: errors.New("commit failed")}
+
+		uc := NewUseCaseAddProduct(mr, mp, mz, newTestProductTypeRepo(), newTestAssignmentRepo(), tx, mt, newTestAuthorizer(), ml)
+		_, err := uc.Execute(context.Background(), request, testUserID, model.RoleEmployee.Get())
+
+		assert.EqualError(t, err, model.ErrInternal)
+		ml.AssertExpectations(t)
+	})
+}
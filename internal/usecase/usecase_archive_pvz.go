@@ -0,0 +1,206 @@
+// Package usecase это юзкейсы и вспомогательная логика
+package usecase
+
+import (
+	"context"
+	"errors"
+	"github.com/google/uuid"
+	"internshipPVZ/internal/domain/model"
+	repo "internshipPVZ/internal/domain/repository"
+	"internshipPVZ/internal/domain/repository/dao"
+	"internshipPVZ/internal/http/onlymodels"
+	"log"
+)
+
+// NewUseCaseArchivePVZ конструктор
+func NewUseCaseArchivePVZ(
+	pvzRepo repo.PVZRepo,
+	receptionRepo repo.ReceptionRepo,
+	cityRepo repo.CityRepo,
+	timeService TimeService,
+	authorizer Authorizer,
+	logger Logger,
+) *ArchivePVZ {
+	if pvzRepo == nil {
+		log.Fatalf("ArchivePVZ usecase pvzRepo nil")
+
+	}
+	if receptionRepo == nil {
+		log.Fatalf("ArchivePVZ usecase receptionRepo nil")
+
+	}
+	if cityRepo == nil {
+		log.Fatalf("ArchivePVZ usecase cityRepo nil")
+
+	}
+	if timeService == nil {
+		log.Fatalf("ArchivePVZ usecase timeService nil")
+
+	}
+	if authorizer == nil {
+		log.Fatalf("ArchivePVZ usecase authorizer nil")
+
+	}
+	if logger == nil {
+		log.Fatalf("ArchivePVZ usecase logger nil")
+
+	}
+
+	return &ArchivePVZ{
+		pvzRepo:       pvzRepo,
+		receptionRepo: receptionRepo,
+		cityRepo:      cityRepo,
+		timeService:   timeService,
+		authorizer:    authorizer,
+		logger:        logger,
+	}
+}
+
+// ArchivePVZ юзкейс
+type ArchivePVZ struct {
+	pvzRepo       repo.PVZRepo
+	receptionRepo repo.ReceptionRepo
+	cityRepo      repo.CityRepo
+	timeService   TimeService
+	authorizer    Authorizer
+	logger        Logger
+}
+
+// Execute переносит ПВЗ в архив, если в нём нет незавершённой приёмки
+func (uc *ArchivePVZ) Execute(ctx context.Context, PVZID uuid.UUID, userID, userRole string) (*onlymodels.PVZ, error) {
+	pvzID, userUUID, role, err := uc.validateInput(PVZID, userID, userRole)
+	if err != nil {
+		uc.logger.Error("validation failed",
+			"usecase", "ArchivePVZ",
+			"method", "validateInput",
+			"pvz_id", PVZID,
+			"error", err)
+		return nil, err
+	}
+
+	if err = checkPermission(uc.authorizer, uc.logger, "ArchivePVZ", "Execute", role, model.PermissionArchivePVZ); err != nil {
+		return nil, err
+	}
+
+	pvzDao, err := uc.pvzRepo.FindByID(ctx, pvzID.String())
+	if err != nil {
+		uc.logger.Error("failed to find PVZ",
+			"usecase", "ArchivePVZ",
+			"method", "pvzRepo.FindByID",
+			"pvz_id", pvzID,
+			"error", err)
+		return nil, errors.New(model.ErrInternal)
+	}
+	if pvzDao == nil {
+		uc.logger.Warn("PVZ not found",
+			"usecase", "ArchivePVZ",
+			"method", "Execute",
+			"pvz_id", pvzID)
+		return nil, errors.New(model.ErrPVZNotFound)
+	}
+	if pvzDao.ArchivedAt.Valid {
+		uc.logger.Warn("PVZ is already archived",
+			"usecase", "ArchivePVZ",
+			"method", "Execute",
+			"pvz_id", pvzID)
+		return nil, errors.New(model.ErrPVZArchived)
+	}
+
+	unfinished := &dao.Reception{PVZID: pvzDao.ID}
+	err = uc.receptionRepo.FindLast(ctx, unfinished, receptionStatusesToInt(model.UnfinishedReceptionStatuses)...)
+	if err != nil {
+		uc.logger.Error("failed to find unfinished reception",
+			"usecase", "ArchivePVZ",
+			"method", "receptionRepo.FindLast",
+			"pvz_id", pvzID,
+			"error", err)
+		return nil, errors.New(model.ErrInternal)
+	}
+	if unfinished.ID != "" {
+		uc.logger.Warn("PVZ has an unfinished reception",
+			"usecase", "ArchivePVZ",
+			"method", "Execute",
+			"pvz_id", pvzID,
+			"reception_id", unfinished.ID)
+		return nil, errors.New(model.ErrPVZHasUnfinishedReception)
+	}
+
+	archived := &model.PVZ{ArchivedAt: uc.timeService.GetTime(), ArchivedBy: userUUID}
+	archivedDao := archived.ToDao()
+	pvzDao.ArchivedAt = archivedDao.ArchivedAt
+	pvzDao.ArchivedBy = archivedDao.ArchivedBy
+	err = uc.pvzRepo.Archive(ctx, pvzDao)
+	if err != nil {
+		if err.Error() == model.ErrPVZArchived {
+			uc.logger.Warn("PVZ was archived concurrently",
+				"usecase", "ArchivePVZ",
+				"method", "pvzRepo.Archive",
+				"pvz_id", pvzID)
+			return nil, err
+		}
+		uc.logger.Error("failed to archive PVZ",
+			"usecase", "ArchivePVZ",
+			"method", "pvzRepo.Archive",
+			"pvz_id", pvzID,
+			"error", err)
+		return nil, errors.New(model.ErrInternal)
+	}
+
+	cities, err := uc.cityRepo.GetAll(ctx)
+	if err != nil {
+		uc.logger.Error("failed to get cities",
+			"usecase", "ArchivePVZ",
+			"method", "cityRepo.GetAll",
+			"error", err)
+		return nil, errors.New(model.ErrInternal)
+	}
+	catalog, err := newCityCatalog(cities)
+	if err != nil {
+		uc.logger.Error("failed to build city catalog",
+			"usecase", "ArchivePVZ",
+			"method", "newCityCatalog",
+			"error", err)
+		return nil, errors.New(model.ErrInternal)
+	}
+
+	pvzDto, err := pvzDaoToDto(pvzDao, catalog)
+	if err != nil {
+		uc.logger.Error("failed to convert PVZ DAO to DTO",
+			"usecase", "ArchivePVZ",
+			"method", "pvzDaoToDto",
+			"pvz_id", pvzID,
+			"error", err)
+		return nil, errors.New(model.ErrInternal)
+	}
+
+	uc.logger.Info("PVZ archived successfully",
+		"usecase", "ArchivePVZ",
+		"pvz_id", pvzID,
+		"user_id", userUUID)
+	return pvzDto, nil
+}
+
+func (uc *ArchivePVZ) validateInput(PVZID uuid.UUID, userID, userRole string) (pvzID, userUUID uuid.UUID, role model.Role, err error) {
+	if pvzID, err = validateID(PVZID); err != nil {
+		uc.logger.Warn("invalid PVZ ID format",
+			"usecase", "ArchivePVZ",
+			"method", "validateInput",
+			"pvz_id", PVZID)
+		return
+	}
+	if userUUID, err = validateRawID(userID); err != nil {
+		uc.logger.Warn("invalid user ID in token",
+			"usecase", "ArchivePVZ",
+			"method", "validateInput",
+			"user_id", userID)
+		return
+	}
+	if role, err = validateRole(userRole); err != nil {
+		uc.logger.Warn("invalid user role",
+			"usecase", "ArchivePVZ",
+			"method", "validateInput",
+			"user_role", userRole)
+		return
+	}
+	return
+}
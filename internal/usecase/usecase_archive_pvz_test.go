@@ -0,0 +1,139 @@
+// Package usecase это юзкейсы и вспомогательная логика
+package usecase
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"internshipPVZ/internal/domain/model"
+	"internshipPVZ/internal/domain/repository/dao"
+)
+
+func TestArchivePVZ_Execute(t *testing.T) {
+	validPVZID := uuid.New()
+	testTime := time.Now()
+	activePVZ := func() *dao.PVZ {
+		return &dao.PVZ{ID: validPVZID.String(), RegistrationDate: testTime, City: testCityKazanID}
+	}
+
+	tests := []struct {
+		name          string
+		setupMocks    func(*mockPVZRepo, *mockReceptionRepo, *mockLogger)
+		userRole      string
+		expectedError string
+	}{
+		{
+			name: "Archive PVZ without receptions in progress",
+			setupMocks: func(mz *mockPVZRepo, mr *mockReceptionRepo, ml *mockLogger) {
+				mz.On("FindByID", mock.Anything, validPVZID.String()).Return(activePVZ(), nil)
+				mr.On("FindLast", mock.Anything, mock.Anything, mock.Anything).Return(nil)
+				mz.On("Archive", mock.Anything, mock.MatchedBy(func(pvz *dao.PVZ) bool {
+					return pvz.ArchivedAt.Valid && pvz.ArchivedBy.String == testUserID
+				})).Return(nil)
+				ml.On("Info", mock.Anything, mock.Anything)
+			},
+			userRole: model.RoleModerator.Get(),
+		},
+		{
+			name: "Reception in progress",
+			setupMocks: func(mz *mockPVZRepo, mr *mockReceptionRepo, ml *mockLogger) {
+				mz.On("FindByID", mock.Anything, validPVZID.String()).Return(activePVZ(), nil)
+				mr.On("FindLast", mock.Anything, mock.Anything, mock.Anything).Run(func(args mock.Arguments) {
+					rec := args.Get(1).(*dao.Reception)
+					rec.ID = uuid.NewString()
+					rec.Status = model.ReceptionPaused.ToInt()
+				}).Return(nil)
+				ml.On("Warn", mock.Anything, mock.Anything)
+			},
+			userRole:      model.RoleModerator.Get(),
+			expectedError: model.ErrPVZHasUnfinishedReception,
+		},
+		{
+			name: "Already archived",
+			setupMocks: func(mz *mockPVZRepo, _ *mockReceptionRepo, ml *mockLogger) {
+				archived := activePVZ()
+				archived.ArchivedAt = sql.NullTime{Time: testTime, Valid: true}
+				mz.On("FindByID", mock.Anything, validPVZID.String()).Return(archived, nil)
+				ml.On("Warn", mock.Anything, mock.Anything)
+			},
+			userRole:      model.RoleModerator.Get(),
+			expectedError: model.ErrPVZArchived,
+		},
+		{
+			name: "Archived concurrently",
+			setupMocks: func(mz *mockPVZRepo, mr *mockReceptionRepo, ml *mockLogger) {
+				mz.On("FindByID", mock.Anything, validPVZID.String()).Return(activePVZ(), nil)
+				mr.On("FindLast", mock.Anything, mock.Anything, mock.Anything).Return(nil)
+				mz.On("Archive", mock.Anything, mock.Anything).Return(errors.New(model.ErrPVZArchived))
+				ml.On("Warn", mock.Anything, mock.Anything)
+			},
+			userRole:      model.RoleModerator.Get(),
+			expectedError: model.ErrPVZArchived,
+		},
+		{
+			name: "PVZ not found",
+			setupMocks: func(mz *mockPVZRepo, _ *mockReceptionRepo, ml *mockLogger) {
+				mz.On("FindByID", mock.Anything, validPVZID.String()).Return(nil, nil)
+				ml.On("Warn", mock.Anything, mock.Anything)
+			},
+			userRole:      model.RoleModerator.Get(),
+			expectedError: model.ErrPVZNotFound,
+		},
+		{
+			name: "Error archiving PVZ",
+			setupMocks: func(mz *mockPVZRepo, mr *mockReceptionRepo, ml *mockLogger) {
+				mz.On("FindByID", mock.Anything, validPVZID.String()).Return(activePVZ(), nil)
+				mr.On("FindLast", mock.Anything, mock.Anything, mock.Anything).Return(nil)
+				mz.On("Archive", mock.Anything, mock.Anything).Return(errors.New("db error"))
+				ml.On("Error", mock.Anything, mock.Anything)
+			},
+			userRole:      model.RoleModerator.Get(),
+			expectedError: model.ErrInternal,
+		},
+		{
+			name: "Non-moderator role",
+			setupMocks: func(_ *mockPVZRepo, _ *mockReceptionRepo, ml *mockLogger) {
+				ml.On("Warn", mock.Anything, mock.Anything)
+			},
+			userRole:      model.RoleEmployee.Get(),
+			expectedError: model.ErrAccessDenied,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mz := &mockPVZRepo{}
+			mr := &mockReceptionRepo{}
+			mt := &mockTimeService{}
+			ml := &mockLogger{}
+
+			if tt.setupMocks != nil {
+				tt.setupMocks(mz, mr, ml)
+			}
+			mt.On("GetTime").Return(testTime)
+
+			mc := &mockCityRepo{}
+			mc.On("GetAll", mock.Anything).Return(testCities(), nil)
+
+			uc := NewUseCaseArchivePVZ(mz, mr, mc, mt, newTestAuthorizer(), ml)
+			result, err := uc.Execute(context.Background(), validPVZID, testUserID, tt.userRole)
+
+			if tt.expectedError != "" {
+				assert.Error(t, err)
+				assert.Contains(t, err.Error(), tt.expectedError)
+				return
+			}
+
+			assert.NoError(t, err)
+			assert.Equal(t, &validPVZID, result.Id)
+			assert.Equal(t, "Казань", result.City)
+			assert.Equal(t, &testTime, result.ArchivedAt)
+		})
+	}
+}
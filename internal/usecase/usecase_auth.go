@@ -5,18 +5,43 @@ import (
 	"context"
 	"errors"
 	"github.com/golang-jwt/jwt/v5"
-	"github.com/oapi-codegen/runtime/types"
+	"github.com/google/uuid"
 	"internshipPVZ/internal/domain/model"
 	repo "internshipPVZ/internal/domain/repository"
 	"internshipPVZ/internal/domain/repository/dao"
 	"internshipPVZ/internal/http/onlymodels"
 	"log"
 	"regexp"
+	"strings"
+	"time"
+)
+
+// emailPattern допустимый формат email пользователя
+const emailPattern = `^[a-zA-Z0-9._%+-]+@[a-zA-Z0-9.-]+\.[a-zA-Z]{2,}$`
+
+// refreshTokenTTL время жизни refresh токена, при каждом обновлении отсчёт начинается заново
+const refreshTokenTTL = 30 * 24 * time.Hour
+
+// правила блокировки входа: по email защищаемся от подбора пароля к одному аккаунту,
+// по IP от перебора многих аккаунтов с одного адреса
+var (
+	emailLoginPolicy = model.LoginThrottlePolicy{
+		FreeAttempts: 5,
+		BaseLockout:  30 * time.Second,
+		MaxLockout:   15 * time.Minute,
+		ResetAfter:   time.Hour,
+	}
+	ipLoginPolicy = model.LoginThrottlePolicy{
+		FreeAttempts: 20,
+		BaseLockout:  10 * time.Second,
+		MaxLockout:   15 * time.Minute,
+		ResetAfter:   time.Hour,
+	}
 )
 
 // JWTService для генерации и проверки токенов
 type JWTService interface {
-	GenerateToken(role string) (string, error)
+	GenerateToken(userID, role, organizationID, sessionID string) (string, error)
 	ValidateToken(tokenString string) (*jwt.Token, error)
 }
 
@@ -24,27 +49,57 @@ type JWTService interface {
 type HashService interface {
 	HashPassword(password string) (string, error)
 	HashAndComparePassword(password, hash string) bool
+	// NeedsRehash сообщает, что хэш посчитан устаревшим алгоритмом или параметрами
+	NeedsRehash(hash string) bool
+}
+
+// RefreshTokenService для выпуска refresh токенов
+type RefreshTokenService interface {
+	GenerateRefreshToken() (string, error)
+	HashRefreshToken(token string) string
 }
 
 // Auth юзкейс
 type Auth struct {
-	userRepo    repo.UserRepo
-	hashService HashService
-	authService JWTService
-	emailRegex  *regexp.Regexp
-	logger      Logger
+	userRepo            repo.UserRepo
+	sessionRepo         repo.SessionRepo
+	loginAttemptRepo    repo.LoginAttemptRepo
+	inviteRepo          repo.ModeratorInviteRepo
+	hashService         HashService
+	authService         JWTService
+	refreshTokenService RefreshTokenService
+	timeService         TimeService
+	emailRegex          *regexp.Regexp
+	logger              Logger
 }
 
 // NewUseCaseAuth конструктор
 func NewUseCaseAuth(
 	userRepo repo.UserRepo,
+	sessionRepo repo.SessionRepo,
+	loginAttemptRepo repo.LoginAttemptRepo,
+	inviteRepo repo.ModeratorInviteRepo,
 	hashService HashService,
 	authService JWTService,
+	refreshTokenService RefreshTokenService,
+	timeService TimeService,
 	logger Logger,
 ) *Auth {
 	if userRepo == nil {
 		log.Fatalf("Auth usecase userRepo nil")
 
+	}
+	if sessionRepo == nil {
+		log.Fatalf("Auth usecase sessionRepo nil")
+
+	}
+	if loginAttemptRepo == nil {
+		log.Fatalf("Auth usecase loginAttemptRepo nil")
+
+	}
+	if inviteRepo == nil {
+		log.Fatalf("Auth usecase inviteRepo nil")
+
 	}
 	if hashService == nil {
 		log.Fatalf("Auth usecase hashService nil")
@@ -53,6 +108,14 @@ func NewUseCaseAuth(
 	if authService == nil {
 		log.Fatalf("Auth usecase authService nil")
 
+	}
+	if refreshTokenService == nil {
+		log.Fatalf("Auth usecase refreshTokenService nil")
+
+	}
+	if timeService == nil {
+		log.Fatalf("Auth usecase timeService nil")
+
 	}
 	if logger == nil {
 		log.Fatalf("Auth usecase logger nil")
@@ -60,15 +123,20 @@ func NewUseCaseAuth(
 	}
 
 	return &Auth{
-		userRepo:    userRepo,
-		hashService: hashService,
-		authService: authService,
-		emailRegex:  regexp.MustCompile(`^[a-zA-Z0-9._%+-]+@[a-zA-Z0-9.-]+\.[a-zA-Z]{2,}$`),
-		logger:      logger,
+		userRepo:            userRepo,
+		sessionRepo:         sessionRepo,
+		loginAttemptRepo:    loginAttemptRepo,
+		inviteRepo:          inviteRepo,
+		hashService:         hashService,
+		authService:         authService,
+		refreshTokenService: refreshTokenService,
+		timeService:         timeService,
+		emailRegex:          regexp.MustCompile(emailPattern),
+		logger:              logger,
 	}
 }
 
-// DummyLogin тупо выдаёт токен
+// DummyLogin тупо выдаёт токен, ID пользователя в нём случайный
 func (uc *Auth) DummyLogin(_ context.Context, request *onlymodels.PostDummyLoginJSONBody) (string, error) {
 	user, err := uc.validateDummyLoginInput(request)
 	if err != nil {
@@ -79,7 +147,7 @@ func (uc *Auth) DummyLogin(_ context.Context, request *onlymodels.PostDummyLogin
 		return "", err
 	}
 
-	token, err := uc.authService.GenerateToken(user.Role.Get())
+	token, err := uc.authService.GenerateToken(uuid.NewString(), user.Role.Get(), user.OrganizationID.String(), "")
 	if err != nil {
 		uc.logger.Error("failed to generate token",
 			"usecase", "Auth",
@@ -90,11 +158,13 @@ func (uc *Auth) DummyLogin(_ context.Context, request *onlymodels.PostDummyLogin
 
 	uc.logger.Info("dummy login successful",
 		"usecase", "Auth",
-		"role", user.Role.Get())
+		"role", user.Role.Get(),
+		"organization_id", user.OrganizationID)
 	return token, nil
 }
 
-// Register регистрирует пользователя
+// Register регистрирует пользователя. Сотрудники регистрируются свободно,
+// модератор только по одноразовому приглашению, которое и определяет его организацию
 func (uc *Auth) Register(ctx context.Context, request *onlymodels.PostRegisterJSONBody) (*onlymodels.User, error) {
 	user, err := uc.validateRegisterInput(request)
 	if err != nil {
@@ -105,18 +175,41 @@ func (uc *Auth) Register(ctx context.Context, request *onlymodels.PostRegisterJS
 		return nil, err
 	}
 
+	var invite *dao.ModeratorInvite
+	if user.Role == model.RoleModerator {
+		invite, err = uc.consumeInvite(ctx, request)
+		if err != nil {
+			return nil, err
+		}
+		user.OrganizationID, err = uuid.Parse(invite.OrganizationID)
+		if err != nil {
+			uc.logger.Error("failed to parse invite organization ID",
+				"usecase", "Auth",
+				"method", "Register",
+				"invite_id", invite.ID,
+				"error", err)
+			return nil, errors.New(model.ErrInternal)
+		}
+	}
+
 	user.Password, err = uc.hashService.HashPassword(user.Password)
 	if err != nil {
 		uc.logger.Error("failed to hash password",
 			"usecase", "Auth",
 			"method", "hashService.HashPassword",
 			"error", err)
+		if invite != nil {
+			uc.releaseInvite(ctx, invite)
+		}
 		return nil, errors.New(model.ErrInternal)
 	}
 
 	userDao := user.ToDao()
 
 	err = uc.userRepo.Create(ctx, userDao)
+	if err != nil && invite != nil {
+		uc.releaseInvite(ctx, invite)
+	}
 	if err != nil {
 		if err.Error() == model.ErrUserWithEmailAlreadyExists {
 			uc.logger.Warn("user already exists",
@@ -125,6 +218,13 @@ func (uc *Auth) Register(ctx context.Context, request *onlymodels.PostRegisterJS
 				"email", user.Email)
 			return nil, err
 		}
+		if err.Error() == model.ErrOrganizationNotFound {
+			uc.logger.Warn("organization not found",
+				"usecase", "Auth",
+				"method", "userRepo.Create",
+				"organization_id", user.OrganizationID)
+			return nil, err
+		}
 		uc.logger.Error("failed to create user",
 			"usecase", "Auth",
 			"method", "userRepo.Create",
@@ -132,7 +232,7 @@ func (uc *Auth) Register(ctx context.Context, request *onlymodels.PostRegisterJS
 		return nil, errors.New(model.ErrInternal)
 	}
 
-	userDto, err := uc.userDaoToDto(userDao)
+	userDto, err := userDaoToDto(userDao)
 	if err != nil {
 		uc.logger.Error("failed to convert user DAO to DTO",
 			"usecase", "Auth",
@@ -145,19 +245,26 @@ func (uc *Auth) Register(ctx context.Context, request *onlymodels.PostRegisterJS
 		"usecase", "Auth",
 		"user_id", userDao.ID,
 		"email", user.Email,
-		"role", user.Role.Get())
+		"role", user.Role.Get(),
+		"organization_id", user.OrganizationID)
 	return userDto, nil
 }
 
-// Login выдаёт соотв токен по данным пользователя
-func (uc *Auth) Login(ctx context.Context, request *onlymodels.PostLoginJSONBody) (string, error) {
+// Login открывает сессию и выдаёт токен доступа и refresh токен по данным пользователя.
+// После серии неудачных попыток с тем же email или IP вход временно блокируется
+func (uc *Auth) Login(ctx context.Context, request *onlymodels.PostLoginJSONBody, clientIP string) (*model.AuthTokens, error) {
 	user, err := uc.validateLoginInput(request)
 	if err != nil {
 		uc.logger.Error("login validation failed",
 			"usecase", "Auth",
 			"method", "validateLoginInput",
 			"error", err)
-		return "", err
+		return nil, err
+	}
+
+	keys := loginAttemptKeys(user.Email, clientIP)
+	if err := uc.checkLoginLockout(ctx, keys); err != nil {
+		return nil, err
 	}
 
 	foundUser, err := uc.userRepo.FindByEmail(ctx, user.Email)
@@ -167,7 +274,7 @@ func (uc *Auth) Login(ctx context.Context, request *onlymodels.PostLoginJSONBody
 			"method", "userRepo.FindByEmail",
 			"email", user.Email,
 			"error", err)
-		return "", errors.New(model.ErrInternal)
+		return nil, errors.New(model.ErrInternal)
 	}
 
 	if foundUser == nil {
@@ -175,7 +282,7 @@ func (uc *Auth) Login(ctx context.Context, request *onlymodels.PostLoginJSONBody
 			"usecase", "Auth",
 			"method", "Login",
 			"email", user.Email)
-		return "", errors.New(model.ErrEmailOrPasswordIsWrong)
+		return nil, uc.registerLoginFailure(ctx, keys)
 	}
 
 	auth := uc.hashService.HashAndComparePassword(user.Password, foundUser.Password)
@@ -184,40 +291,366 @@ func (uc *Auth) Login(ctx context.Context, request *onlymodels.PostLoginJSONBody
 			"usecase", "Auth",
 			"method", "Login",
 			"email", user.Email)
-		return "", errors.New(model.ErrEmailOrPasswordIsWrong)
+		return nil, uc.registerLoginFailure(ctx, keys)
+	}
+
+	// о деактивации сообщаем только после проверки пароля, чтобы не раскрывать статус аккаунта подбором
+	if foundUser.DeactivatedAt.Valid {
+		uc.logger.Warn("deactivated user tried to log in",
+			"usecase", "Auth",
+			"method", "Login",
+			"user_id", foundUser.ID)
+		return nil, errors.New(model.ErrUserDeactivated)
 	}
 
-	token, err := uc.authService.GenerateToken(model.NewUserRole(foundUser.Role).Get())
+	uc.upgradePasswordHash(ctx, foundUser, user.Password)
+
+	// счётчик по IP не сбрасываем: иначе один свой аккаунт позволял бы перебирать чужие
+	err = uc.loginAttemptRepo.Reset(ctx, string(model.LoginAttemptScopeEmail), keys[model.LoginAttemptScopeEmail])
 	if err != nil {
-		uc.logger.Error("failed to generate token",
+		uc.logger.Error("failed to reset login attempts",
 			"usecase", "Auth",
-			"method", "authService.GenerateToken",
+			"method", "loginAttemptRepo.Reset",
+			"email", user.Email,
 			"error", err)
-		return "", errors.New(model.ErrInternal)
+		return nil, errors.New(model.ErrInternal)
+	}
+
+	now := uc.timeService.GetTime()
+	refreshToken, err := uc.generateRefreshToken()
+	if err != nil {
+		return nil, err
+	}
+	session := &dao.Session{
+		UserID:           foundUser.ID,
+		RefreshTokenHash: uc.refreshTokenService.HashRefreshToken(refreshToken),
+		CreatedAt:        now,
+		ExpiresAt:        now.Add(refreshTokenTTL),
+	}
+	err = uc.sessionRepo.Create(ctx, session)
+	if err != nil {
+		uc.logger.Error("failed to create session",
+			"usecase", "Auth",
+			"method", "sessionRepo.Create",
+			"user_id", foundUser.ID,
+			"error", err)
+		return nil, errors.New(model.ErrInternal)
+	}
+
+	tokens, err := uc.sessionTokens(foundUser, session, refreshToken)
+	if err != nil {
+		return nil, err
 	}
 
 	uc.logger.Info("user logged in successfully",
 		"usecase", "Auth",
 		"user_id", foundUser.ID,
+		"session_id", session.ID,
 		"email", user.Email)
-	return token, nil
+	return tokens, nil
 }
 
-func (uc *Auth) userDaoToDto(user *dao.User) (*onlymodels.User, error) {
-	id, err := validateRawID(user.ID)
+// Refresh меняет refresh токен на новую пару токенов той же сессии.
+// Повторно предъявленный старый refresh токен считается украденным, и сессия отзывается
+func (uc *Auth) Refresh(ctx context.Context, refreshToken string) (*model.AuthTokens, error) {
+	if refreshToken == "" {
+		uc.logger.Warn("missing refresh token",
+			"usecase", "Auth",
+			"method", "Refresh")
+		return nil, errors.New(model.ErrInvalidRefreshToken)
+	}
+
+	now := uc.timeService.GetTime()
+	tokenHash := uc.refreshTokenService.HashRefreshToken(refreshToken)
+	newRefreshToken, err := uc.generateRefreshToken()
 	if err != nil {
-		uc.logger.Error("failed to parse user ID",
+		return nil, err
+	}
+
+	session, err := uc.sessionRepo.Rotate(ctx, tokenHash, uc.refreshTokenService.HashRefreshToken(newRefreshToken), now.Add(refreshTokenTTL), now)
+	if err != nil {
+		uc.logger.Error("failed to rotate refresh token",
 			"usecase", "Auth",
-			"method", "userDaoToDto",
-			"user_id", user.ID,
+			"method", "sessionRepo.Rotate",
+			"error", err)
+		return nil, errors.New(model.ErrInternal)
+	}
+
+	if session == nil {
+		reused, err := uc.sessionRepo.RevokeByPreviousToken(ctx, tokenHash, now)
+		if err != nil {
+			uc.logger.Error("failed to revoke session by reused refresh token",
+				"usecase", "Auth",
+				"method", "sessionRepo.RevokeByPreviousToken",
+				"error", err)
+			return nil, errors.New(model.ErrInternal)
+		}
+		if reused {
+			uc.logger.Warn("refresh token reuse detected, session revoked",
+				"usecase", "Auth",
+				"method", "Refresh")
+		} else {
+			uc.logger.Warn("refresh token not found or expired",
+				"usecase", "Auth",
+				"method", "Refresh")
+		}
+		return nil, errors.New(model.ErrInvalidRefreshToken)
+	}
+
+	foundUser, err := uc.userRepo.FindByID(ctx, session.UserID)
+	if err != nil {
+		uc.logger.Error("failed to find user by ID",
+			"usecase", "Auth",
+			"method", "userRepo.FindByID",
+			"user_id", session.UserID,
 			"error", err)
+		return nil, errors.New(model.ErrInternal)
+	}
+
+	if foundUser == nil {
+		uc.logger.Warn("session user not found",
+			"usecase", "Auth",
+			"method", "Refresh",
+			"user_id", session.UserID)
+		return nil, errors.New(model.ErrInvalidRefreshToken)
+	}
+
+	if foundUser.DeactivatedAt.Valid {
+		err = uc.sessionRepo.Revoke(ctx, session.ID, now)
+		if err != nil {
+			uc.logger.Error("failed to revoke session of deactivated user",
+				"usecase", "Auth",
+				"method", "sessionRepo.Revoke",
+				"session_id", session.ID,
+				"error", err)
+			return nil, errors.New(model.ErrInternal)
+		}
+		uc.logger.Warn("deactivated user tried to refresh session",
+			"usecase", "Auth",
+			"method", "Refresh",
+			"user_id", foundUser.ID)
+		return nil, errors.New(model.ErrInvalidRefreshToken)
+	}
+
+	tokens, err := uc.sessionTokens(foundUser, session, newRefreshToken)
+	if err != nil {
 		return nil, err
 	}
-	return &onlymodels.User{
-		Id:    &id,
-		Email: types.Email(user.Email),
-		Role:  onlymodels.UserRole(model.NewUserRole(user.Role)),
-	}, nil
+
+	uc.logger.Info("session refreshed successfully",
+		"usecase", "Auth",
+		"user_id", foundUser.ID,
+		"session_id", session.ID)
+	return tokens, nil
+}
+
+// Logout отзывает сессию, токены доступа этой сессии перестают приниматься сразу
+func (uc *Auth) Logout(ctx context.Context, sessionID string) error {
+	if sessionID == "" {
+		// у токенов из /dummyLogin нет сессии, отзывать нечего
+		uc.logger.Info("logout without session",
+			"usecase", "Auth",
+			"method", "Logout")
+		return nil
+	}
+	id, err := validateRawID(sessionID)
+	if err != nil {
+		uc.logger.Warn("invalid session ID",
+			"usecase", "Auth",
+			"method", "Logout",
+			"session_id", sessionID)
+		return err
+	}
+
+	err = uc.sessionRepo.Revoke(ctx, id.String(), uc.timeService.GetTime())
+	if err != nil {
+		uc.logger.Error("failed to revoke session",
+			"usecase", "Auth",
+			"method", "sessionRepo.Revoke",
+			"session_id", id,
+			"error", err)
+		return errors.New(model.ErrInternal)
+	}
+
+	uc.logger.Info("user logged out successfully",
+		"usecase", "Auth",
+		"session_id", id)
+	return nil
+}
+
+// IsSessionActive проверяет, что сессия из токена доступа не отозвана
+func (uc *Auth) IsSessionActive(ctx context.Context, sessionID string) (bool, error) {
+	active, err := uc.sessionRepo.IsActive(ctx, sessionID)
+	if err != nil {
+		uc.logger.Error("failed to check session",
+			"usecase", "Auth",
+			"method", "sessionRepo.IsActive",
+			"session_id", sessionID,
+			"error", err)
+		return false, errors.New(model.ErrInternal)
+	}
+	return active, nil
+}
+
+// checkLoginLockout возвращает model.LoginLockedError, если вход заблокирован хотя бы по одному из ключей
+func (uc *Auth) checkLoginLockout(ctx context.Context, keys map[model.LoginAttemptScope]string) error {
+	now := uc.timeService.GetTime()
+	var retryAfter time.Duration
+	for scope, key := range keys {
+		attempt, err := uc.loginAttemptRepo.Find(ctx, string(scope), key)
+		if err != nil {
+			uc.logger.Error("failed to find login attempts",
+				"usecase", "Auth",
+				"method", "loginAttemptRepo.Find",
+				"scope", scope,
+				"error", err)
+			return errors.New(model.ErrInternal)
+		}
+		if attempt == nil || !attempt.LockedUntil.Valid || !attempt.LockedUntil.Time.After(now) {
+			continue
+		}
+		if wait := attempt.LockedUntil.Time.Sub(now); wait > retryAfter {
+			retryAfter = wait
+		}
+	}
+	if retryAfter > 0 {
+		uc.logger.Warn("login locked out",
+			"usecase", "Auth",
+			"method", "Login",
+			"email", keys[model.LoginAttemptScopeEmail],
+			"retry_after", retryAfter)
+		return &model.LoginLockedError{RetryAfter: retryAfter}
+	}
+	return nil
+}
+
+// registerLoginFailure учитывает неудачную попытку по всем ключам и при необходимости блокирует вход.
+// Сама неудачная попытка всегда отвечает ErrEmailOrPasswordIsWrong, блокировка действует со следующей
+func (uc *Auth) registerLoginFailure(ctx context.Context, keys map[model.LoginAttemptScope]string) error {
+	now := uc.timeService.GetTime()
+	for scope, key := range keys {
+		policy := loginPolicy(scope)
+		attempt, err := uc.loginAttemptRepo.RegisterFailure(ctx, string(scope), key, now, now.Add(-policy.ResetAfter))
+		if err != nil {
+			uc.logger.Error("failed to register login failure",
+				"usecase", "Auth",
+				"method", "loginAttemptRepo.RegisterFailure",
+				"scope", scope,
+				"error", err)
+			return errors.New(model.ErrInternal)
+		}
+		lockout := policy.Lockout(attempt.Failures)
+		if lockout == 0 {
+			continue
+		}
+		err = uc.loginAttemptRepo.Lock(ctx, string(scope), key, now.Add(lockout))
+		if err != nil {
+			uc.logger.Error("failed to lock login",
+				"usecase", "Auth",
+				"method", "loginAttemptRepo.Lock",
+				"scope", scope,
+				"error", err)
+			return errors.New(model.ErrInternal)
+		}
+		uc.logger.Warn("login locked after failed attempts",
+			"usecase", "Auth",
+			"method", "Login",
+			"scope", scope,
+			"key", key,
+			"failures", attempt.Failures,
+			"lockout", lockout)
+	}
+	return errors.New(model.ErrEmailOrPasswordIsWrong)
+}
+
+func loginPolicy(scope model.LoginAttemptScope) model.LoginThrottlePolicy {
+	if scope == model.LoginAttemptScopeIP {
+		return ipLoginPolicy
+	}
+	return emailLoginPolicy
+}
+
+// loginAttemptKeys email приводится к нижнему регистру, чтобы блокировку нельзя было обойти сменой регистра
+func loginAttemptKeys(email, clientIP string) map[model.LoginAttemptScope]string {
+	keys := map[model.LoginAttemptScope]string{
+		model.LoginAttemptScopeEmail: strings.ToLower(email),
+	}
+	if clientIP != "" {
+		keys[model.LoginAttemptScopeIP] = clientIP
+	}
+	return keys
+}
+
+// consumeInvite гасит приглашение из запроса. Явно указанная организация должна совпадать с организацией приглашения
+func (uc *Auth) consumeInvite(ctx context.Context, request *onlymodels.PostRegisterJSONBody) (*dao.ModeratorInvite, error) {
+	if request.InviteCode == nil || *request.InviteCode == "" {
+		uc.logger.Warn("moderator registration without invite",
+			"usecase", "Auth",
+			"method", "Register",
+			"email", request.Email)
+		return nil, errors.New(model.ErrModeratorInviteRequired)
+	}
+
+	invite, err := uc.inviteRepo.Consume(ctx, uc.refreshTokenService.HashRefreshToken(*request.InviteCode), uc.timeService.GetTime())
+	if err != nil {
+		uc.logger.Error("failed to consume moderator invite",
+			"usecase", "Auth",
+			"method", "inviteRepo.Consume",
+			"error", err)
+		return nil, errors.New(model.ErrInternal)
+	}
+	if invite == nil {
+		uc.logger.Warn("moderator invite not found, used or expired",
+			"usecase", "Auth",
+			"method", "Register",
+			"email", request.Email)
+		return nil, errors.New(model.ErrInvalidInviteCode)
+	}
+	if request.OrganizationId != nil && request.OrganizationId.String() != invite.OrganizationID {
+		uc.logger.Warn("moderator invite issued for another organization",
+			"usecase", "Auth",
+			"method", "Register",
+			"invite_id", invite.ID,
+			"organization_id", request.OrganizationId)
+		uc.releaseInvite(ctx, invite)
+		return nil, errors.New(model.ErrInvalidInviteCode)
+	}
+	return invite, nil
+}
+
+// releaseInvite возвращает приглашение, если пользователь так и не был создан
+func (uc *Auth) releaseInvite(ctx context.Context, invite *dao.ModeratorInvite) {
+	if err := uc.inviteRepo.Release(ctx, invite.ID); err != nil {
+		uc.logger.Error("failed to release moderator invite",
+			"usecase", "Auth",
+			"method", "inviteRepo.Release",
+			"invite_id", invite.ID,
+			"error", err)
+	}
+}
+
+func (uc *Auth) generateRefreshToken() (string, error) {
+	refreshToken, err := uc.refreshTokenService.GenerateRefreshToken()
+	if err != nil {
+		uc.logger.Error("failed to generate refresh token",
+			"usecase", "Auth",
+			"method", "refreshTokenService.GenerateRefreshToken",
+			"error", err)
+		return "", errors.New(model.ErrInternal)
+	}
+	return refreshToken, nil
+}
+
+func (uc *Auth) sessionTokens(user *dao.User, session *dao.Session, refreshToken string) (*model.AuthTokens, error) {
+	token, err := uc.authService.GenerateToken(user.ID, model.NewUserRole(user.Role).Get(), user.OrganizationID, session.ID)
+	if err != nil {
+		uc.logger.Error("failed to generate token",
+			"usecase", "Auth",
+			"method", "authService.GenerateToken",
+			"error", err)
+		return nil, errors.New(model.ErrInternal)
+	}
+	return &model.AuthTokens{AccessToken: token, RefreshToken: refreshToken, RefreshExpiresAt: session.ExpiresAt}, nil
 }
 
 func (uc *Auth) validateDummyLoginInput(request *onlymodels.PostDummyLoginJSONBody) (user *model.User, err error) {
@@ -229,6 +662,13 @@ func (uc *Auth) validateDummyLoginInput(request *onlymodels.PostDummyLoginJSONBo
 			"requested_role", request.Role)
 		return
 	}
+	if user.OrganizationID, err = uc.validateOrganizationID(request.OrganizationId); err != nil {
+		uc.logger.Warn("invalid organization ID in dummy login",
+			"usecase", "Auth",
+			"method", "validateDummyLoginInput",
+			"organization_id", request.OrganizationId)
+		return
+	}
 	return
 }
 
@@ -241,7 +681,7 @@ func (uc *Auth) validateRegisterInput(request *onlymodels.PostRegisterJSONBody)
 			"email", request.Email)
 		return
 	}
-	if user.Password, err = uc.validatePassword(request.Password); err != nil {
+	if user.Password, err = validatePassword(request.Password); err != nil {
 		uc.logger.Warn("invalid password in registration",
 			"usecase", "Auth",
 			"method", "validateRegisterInput")
@@ -254,6 +694,13 @@ func (uc *Auth) validateRegisterInput(request *onlymodels.PostRegisterJSONBody)
 			"requested_role", request.Role)
 		return
 	}
+	if user.OrganizationID, err = uc.validateOrganizationID(request.OrganizationId); err != nil {
+		uc.logger.Warn("invalid organization ID in registration",
+			"usecase", "Auth",
+			"method", "validateRegisterInput",
+			"organization_id", request.OrganizationId)
+		return
+	}
 	return
 }
 
@@ -266,7 +713,7 @@ func (uc *Auth) validateLoginInput(request *onlymodels.PostLoginJSONBody) (user
 			"email", request.Email)
 		return
 	}
-	if user.Password, err = uc.validatePassword(request.Password); err != nil {
+	if user.Password, err = validatePassword(request.Password); err != nil {
 		uc.logger.Warn("invalid password in login",
 			"usecase", "Auth",
 			"method", "validateLoginInput")
@@ -282,9 +729,51 @@ func (uc *Auth) validateEmail(email string) (string, error) {
 	return email, nil
 }
 
-func (uc *Auth) validatePassword(password string) (string, error) {
+// validateOrganizationID без явной организации пользователь попадает в организацию по умолчанию
+func (uc *Auth) validateOrganizationID(organizationID *uuid.UUID) (uuid.UUID, error) {
+	if organizationID == nil {
+		return uuid.MustParse(model.DefaultOrganizationID), nil
+	}
+	id, err := validateID(*organizationID)
+	if err != nil {
+		return id, errors.New(model.ErrInvalidOrganizationID)
+	}
+	return id, nil
+}
+
+func validatePassword(password string) (string, error) {
 	if len(password) < 8 || len(password) > 50 {
 		return "", errors.New(model.ErrInvalidPassword)
 	}
 	return password, nil
 }
+
+// upgradePasswordHash пересчитывает хэш пароля текущим алгоритмом, пока пароль известен после входа.
+// Ошибки только логируются: вход не должен зависеть от миграции хэша
+func (uc *Auth) upgradePasswordHash(ctx context.Context, user *dao.User, password string) {
+	if !uc.hashService.NeedsRehash(user.Password) {
+		return
+	}
+	hash, err := uc.hashService.HashPassword(password)
+	if err != nil {
+		uc.logger.Error("failed to rehash password",
+			"usecase", "Auth",
+			"method", "hashService.HashPassword",
+			"user_id", user.ID,
+			"error", err)
+		return
+	}
+	err = uc.userRepo.UpdatePassword(ctx, user.ID, hash)
+	if err != nil {
+		uc.logger.Error("failed to update password hash",
+			"usecase", "Auth",
+			"method", "userRepo.UpdatePassword",
+			"user_id", user.ID,
+			"error", err)
+		return
+	}
+	user.Password = hash
+	uc.logger.Info("password hash upgraded",
+		"usecase", "Auth",
+		"user_id", user.ID)
+}
@@ -3,8 +3,10 @@ package usecase
 
 import (
 	"context"
+	"database/sql"
 	"errors"
 	"testing"
+	"time"
 
 	"github.com/golang-jwt/jwt/v5"
 	"github.com/google/uuid"
@@ -16,6 +18,17 @@ import (
 	"internshipPVZ/internal/http/onlymodels"
 )
 
+var testOrganizationID = uuid.MustParse("3b2f8f4e-4a6f-4c1b-9f0e-2d8c5a7e1f10")
+
+const (
+	testSessionID           = "0c8e7a52-9d3f-4b6e-8a1c-5f2d4e6b7a90"
+	testRefreshToken        = "new-refresh-token"
+	testRefreshTokenHash    = "new-refresh-token-hash"
+	testOldRefreshToken     = "old-refresh-token"
+	testOldRefreshTokenHash = "old-refresh-token-hash"
+	testClientIP            = "192.0.2.10"
+)
+
 type mockUserRepo struct{ mock.Mock }
 
 func (m *mockUserRepo) Create(ctx context.Context, user *dao.User) error {
@@ -32,6 +45,179 @@ func (m *mockUserRepo) FindByEmail(ctx context.Context, email string) (*dao.User
 	return args.Get(0).(*dao.User), args.Error(1)
 }
 
+func (m *mockUserRepo) FindByID(ctx context.Context, id string) (*dao.User, error) {
+	args := m.Called(ctx, id)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+
+	return args.Get(0).(*dao.User), args.Error(1)
+}
+
+func (m *mockUserRepo) UpdatePassword(ctx context.Context, id, passwordHash string) error {
+	args := m.Called(ctx, id, passwordHash)
+	return args.Error(0)
+}
+
+func (m *mockUserRepo) List(ctx context.Context, search string, page, limit int) ([]*dao.User, error) {
+	args := m.Called(ctx, search, page, limit)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+
+	return args.Get(0).([]*dao.User), args.Error(1)
+}
+
+func (m *mockUserRepo) UpdateRole(ctx context.Context, id string, role int8) (*dao.User, error) {
+	args := m.Called(ctx, id, role)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+
+	return args.Get(0).(*dao.User), args.Error(1)
+}
+
+func (m *mockUserRepo) Deactivate(ctx context.Context, id string, now time.Time) (*dao.User, error) {
+	args := m.Called(ctx, id, now)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+
+	return args.Get(0).(*dao.User), args.Error(1)
+}
+
+func (m *mockUserRepo) CheckIfRoleExists(ctx context.Context, organizationID string, role int8) (bool, error) {
+	args := m.Called(ctx, organizationID, role)
+	return args.Bool(0), args.Error(1)
+}
+
+func (m *mockUserRepo) Reactivate(ctx context.Context, id string) (*dao.User, error) {
+	args := m.Called(ctx, id)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+
+	return args.Get(0).(*dao.User), args.Error(1)
+}
+
+type mockModeratorInviteRepo struct{ mock.Mock }
+
+func (m *mockModeratorInviteRepo) Create(ctx context.Context, invite *dao.ModeratorInvite) error {
+	args := m.Called(ctx, invite)
+	return args.Error(0)
+}
+
+func (m *mockModeratorInviteRepo) Consume(ctx context.Context, codeHash string, now time.Time) (*dao.ModeratorInvite, error) {
+	args := m.Called(ctx, codeHash, now)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+
+	return args.Get(0).(*dao.ModeratorInvite), args.Error(1)
+}
+
+func (m *mockModeratorInviteRepo) Release(ctx context.Context, id string) error {
+	args := m.Called(ctx, id)
+	return args.Error(0)
+}
+
+type mockSessionRepo struct{ mock.Mock }
+
+func (m *mockSessionRepo) Create(ctx context.Context, session *dao.Session) error {
+	args := m.Called(ctx, session)
+	return args.Error(0)
+}
+
+func (m *mockSessionRepo) Rotate(ctx context.Context, tokenHash, newTokenHash string, expiresAt, now time.Time) (*dao.Session, error) {
+	args := m.Called(ctx, tokenHash, newTokenHash, expiresAt, now)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+
+	return args.Get(0).(*dao.Session), args.Error(1)
+}
+
+func (m *mockSessionRepo) RevokeByPreviousToken(ctx context.Context, tokenHash string, now time.Time) (bool, error) {
+	args := m.Called(ctx, tokenHash, now)
+	return args.Bool(0), args.Error(1)
+}
+
+func (m *mockSessionRepo) Revoke(ctx context.Context, sessionID string, now time.Time) error {
+	args := m.Called(ctx, sessionID, now)
+	return args.Error(0)
+}
+
+func (m *mockSessionRepo) RevokeByUser(ctx context.Context, userID, exceptSessionID string, now time.Time) error {
+	args := m.Called(ctx, userID, exceptSessionID, now)
+	return args.Error(0)
+}
+
+func (m *mockSessionRepo) IsActive(ctx context.Context, sessionID string) (bool, error) {
+	args := m.Called(ctx, sessionID)
+	return args.Bool(0), args.Error(1)
+}
+
+type mockLoginAttemptRepo struct{ mock.Mock }
+
+func (m *mockLoginAttemptRepo) Find(ctx context.Context, scope, key string) (*dao.LoginAttempt, error) {
+	args := m.Called(ctx, scope, key)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+
+	return args.Get(0).(*dao.LoginAttempt), args.Error(1)
+}
+
+func (m *mockLoginAttemptRepo) RegisterFailure(ctx context.Context, scope, key string, now, resetBefore time.Time) (*dao.LoginAttempt, error) {
+	args := m.Called(ctx, scope, key, now, resetBefore)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+
+	return args.Get(0).(*dao.LoginAttempt), args.Error(1)
+}
+
+func (m *mockLoginAttemptRepo) Lock(ctx context.Context, scope, key string, until time.Time) error {
+	args := m.Called(ctx, scope, key, until)
+	return args.Error(0)
+}
+
+func (m *mockLoginAttemptRepo) Reset(ctx context.Context, scope, key string) error {
+	args := m.Called(ctx, scope, key)
+	return args.Error(0)
+}
+
+// newTestLoginAttemptRepo никого не блокирует и считает каждую неудачу первой
+func newTestLoginAttemptRepo() *mockLoginAttemptRepo {
+	m := &mockLoginAttemptRepo{}
+	m.On("Find", mock.Anything, mock.Anything, mock.Anything).Return(nil, nil).Maybe()
+	m.On("RegisterFailure", mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything).
+		Return(&dao.LoginAttempt{Failures: 1}, nil).Maybe()
+	m.On("Reset", mock.Anything, mock.Anything, mock.Anything).Return(nil).Maybe()
+	return m
+}
+
+type mockRefreshTokenService struct{ mock.Mock }
+
+func (m *mockRefreshTokenService) GenerateRefreshToken() (string, error) {
+	args := m.Called()
+	return args.String(0), args.Error(1)
+}
+
+func (m *mockRefreshTokenService) HashRefreshToken(token string) string {
+	args := m.Called(token)
+	return args.String(0)
+}
+
+// newTestRefreshTokenService всегда выдаёт testRefreshToken и знает хэши обоих тестовых токенов
+func newTestRefreshTokenService() *mockRefreshTokenService {
+	m := &mockRefreshTokenService{}
+	m.On("GenerateRefreshToken").Return(testRefreshToken, nil).Maybe()
+	m.On("HashRefreshToken", testRefreshToken).Return(testRefreshTokenHash).Maybe()
+	m.On("HashRefreshToken", testOldRefreshToken).Return(testOldRefreshTokenHash).Maybe()
+	return m
+}
+
 type mockHashService struct{ mock.Mock }
 
 func (m *mockHashService) HashPassword(password string) (string, error) {
@@ -44,10 +230,15 @@ func (m *mockHashService) HashAndComparePassword(password, hash string) bool {
 	return args.Bool(0)
 }
 
+func (m *mockHashService) NeedsRehash(hash string) bool {
+	args := m.Called(hash)
+	return args.Bool(0)
+}
+
 type mockJWTService struct{ mock.Mock }
 
-func (m *mockJWTService) GenerateToken(role string) (string, error) {
-	args := m.Called(role)
+func (m *mockJWTService) GenerateToken(userID, role, organizationID, sessionID string) (string, error) {
+	args := m.Called(userID, role, organizationID, sessionID)
 	return args.String(0), args.Error(1)
 }
 
@@ -69,7 +260,7 @@ func TestAuth_DummyLogin(t *testing.T) {
 		{
 			name: "Success - employee role",
 			setupMocks: func(mj *mockJWTService, ml *mockLogger) {
-				mj.On("GenerateToken", model.RoleEmployee.Get()).Return(validToken, nil)
+				mj.On("GenerateToken", mock.Anything, model.RoleEmployee.Get(), model.DefaultOrganizationID, "").Return(validToken, nil)
 				ml.On("Info", mock.Anything, mock.Anything)
 			},
 			request: &onlymodels.PostDummyLoginJSONBody{
@@ -80,7 +271,7 @@ func TestAuth_DummyLogin(t *testing.T) {
 		{
 			name: "Success - moderator role",
 			setupMocks: func(mj *mockJWTService, ml *mockLogger) {
-				mj.On("GenerateToken", model.RoleModerator.Get()).Return(validToken, nil)
+				mj.On("GenerateToken", mock.Anything, model.RoleModerator.Get(), model.DefaultOrganizationID, "").Return(validToken, nil)
 				ml.On("Info", mock.Anything, mock.Anything)
 			},
 			request: &onlymodels.PostDummyLoginJSONBody{
@@ -88,6 +279,30 @@ func TestAuth_DummyLogin(t *testing.T) {
 			},
 			expectedToken: validToken,
 		},
+		{
+			name: "Success - explicit organization",
+			setupMocks: func(mj *mockJWTService, ml *mockLogger) {
+				mj.On("GenerateToken", mock.Anything, model.RoleEmployee.Get(), testOrganizationID.String(), "").Return(validToken, nil)
+				ml.On("Info", mock.Anything, mock.Anything)
+			},
+			request: &onlymodels.PostDummyLoginJSONBody{
+				Role:           onlymodels.PostDummyLoginJSONBodyRoleEmployee,
+				OrganizationId: &testOrganizationID,
+			},
+			expectedToken: validToken,
+		},
+		{
+			name: "Invalid organization ID",
+			setupMocks: func(_ *mockJWTService, ml *mockLogger) {
+				ml.On("Warn", mock.Anything, mock.Anything)
+				ml.On("Error", mock.Anything, mock.Anything)
+			},
+			request: &onlymodels.PostDummyLoginJSONBody{
+				Role:           onlymodels.PostDummyLoginJSONBodyRoleEmployee,
+				OrganizationId: &uuid.Nil,
+			},
+			expectedError: model.ErrInvalidOrganizationID,
+		},
 		{
 			name: "Invalid role",
 			setupMocks: func(_ *mockJWTService, ml *mockLogger) {
@@ -102,7 +317,7 @@ func TestAuth_DummyLogin(t *testing.T) {
 		{
 			name: "Token generation error",
 			setupMocks: func(mj *mockJWTService, ml *mockLogger) {
-				mj.On("GenerateToken", model.RoleEmployee.Get()).Return("", errors.New("jwt error"))
+				mj.On("GenerateToken", mock.Anything, model.RoleEmployee.Get(), mock.Anything, mock.Anything).Return("", errors.New("jwt error"))
 				ml.On("Error", mock.Anything, mock.Anything)
 			},
 			request: &onlymodels.PostDummyLoginJSONBody{
@@ -115,15 +330,18 @@ func TestAuth_DummyLogin(t *testing.T) {
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
 			mu := &mockUserRepo{}
+			ms := &mockSessionRepo{}
 			mh := &mockHashService{}
 			mj := &mockJWTService{}
+			mr := newTestRefreshTokenService()
+			mt := &mockTimeService{}
 			ml := &mockLogger{}
 
 			if tt.setupMocks != nil {
 				tt.setupMocks(mj, ml)
 			}
 
-			uc := NewUseCaseAuth(mu, mh, mj, ml)
+			uc := NewUseCaseAuth(mu, ms, newTestLoginAttemptRepo(), &mockModeratorInviteRepo{}, mh, mj, mr, mt, ml)
 			token, err := uc.DummyLogin(context.Background(), tt.request)
 
 			if tt.expectedError != "" {
@@ -143,6 +361,7 @@ func TestAuth_Register(t *testing.T) {
 	validPassword := "securePassword123"
 	validUserID := uuid.New()
 	hashedPassword := "hashedPassword123"
+	defaultOrganizationID := uuid.MustParse(model.DefaultOrganizationID)
 
 	tests := []struct {
 		name          string
@@ -167,10 +386,51 @@ func TestAuth_Register(t *testing.T) {
 				Role:     onlymodels.Employee,
 			},
 			expectedUser: &onlymodels.User{
-				Id:    &validUserID,
-				Email: types.Email(validEmail),
-				Role:  onlymodels.UserRoleEmployee,
+				Id:             &validUserID,
+				Email:          types.Email(validEmail),
+				Role:           onlymodels.UserRoleEmployee,
+				OrganizationId: &defaultOrganizationID,
+			},
+		},
+		{
+			name: "Success - registration in explicit organization",
+			setupMocks: func(mu *mockUserRepo, mh *mockHashService, ml *mockLogger) {
+				mh.On("HashPassword", validPassword).Return(hashedPassword, nil)
+				mu.On("Create", mock.Anything, mock.MatchedBy(func(user *dao.User) bool {
+					return user.OrganizationID == testOrganizationID.String()
+				})).Run(func(args mock.Arguments) {
+					user := args.Get(1).(*dao.User)
+					user.ID = validUserID.String()
+				}).Return(nil)
+				ml.On("Info", mock.Anything, mock.Anything)
+			},
+			request: &onlymodels.PostRegisterJSONBody{
+				Email:          types.Email(validEmail),
+				Password:       validPassword,
+				Role:           onlymodels.Employee,
+				OrganizationId: &testOrganizationID,
+			},
+			expectedUser: &onlymodels.User{
+				Id:             &validUserID,
+				Email:          types.Email(validEmail),
+				Role:           onlymodels.UserRoleEmployee,
+				OrganizationId: &testOrganizationID,
+			},
+		},
+		{
+			name: "Organization not found",
+			setupMocks: func(mu *mockUserRepo, mh *mockHashService, ml *mockLogger) {
+				mh.On("HashPassword", validPassword).Return(hashedPassword, nil)
+				mu.On("Create", mock.Anything, mock.Anything).Return(errors.New(model.ErrOrganizationNotFound))
+				ml.On("Warn", mock.Anything, mock.Anything)
+			},
+			request: &onlymodels.PostRegisterJSONBody{
+				Email:          types.Email(validEmail),
+				Password:       validPassword,
+				Role:           onlymodels.Employee,
+				OrganizationId: &testOrganizationID,
 			},
+			expectedError: model.ErrOrganizationNotFound,
 		},
 		{
 			name: "Invalid email",
@@ -257,15 +517,18 @@ func TestAuth_Register(t *testing.T) {
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
 			mu := &mockUserRepo{}
+			ms := &mockSessionRepo{}
 			mh := &mockHashService{}
 			mj := &mockJWTService{}
+			mr := newTestRefreshTokenService()
+			mt := &mockTimeService{}
 			ml := &mockLogger{}
 
 			if tt.setupMocks != nil {
 				tt.setupMocks(mu, mh, ml)
 			}
 
-			uc := NewUseCaseAuth(mu, mh, mj, ml)
+			uc := NewUseCaseAuth(mu, ms, newTestLoginAttemptRepo(), &mockModeratorInviteRepo{}, mh, mj, mr, mt, ml)
 			user, err := uc.Register(context.Background(), tt.request)
 
 			if tt.expectedError != "" {
@@ -278,6 +541,112 @@ func TestAuth_Register(t *testing.T) {
 			assert.Equal(t, tt.expectedUser.Id, user.Id)
 			assert.Equal(t, tt.expectedUser.Email, user.Email)
 			assert.Equal(t, tt.expectedUser.Role, user.Role)
+			assert.Equal(t, tt.expectedUser.OrganizationId, user.OrganizationId)
+		})
+	}
+}
+
+func TestAuth_RegisterModerator(t *testing.T) {
+	validEmail := "moderator@example.com"
+	validPassword := "securePassword123"
+	validUserID := uuid.New()
+	testTime := time.Now()
+	inviteCode := testOldRefreshToken
+	invite := &dao.ModeratorInvite{ID: "invite-id", OrganizationID: testOrganizationID.String()}
+	otherOrganizationID := uuid.New()
+
+	tests := []struct {
+		name          string
+		setupMocks    func(*mockUserRepo, *mockModeratorInviteRepo, *mockHashService, *mockLogger)
+		inviteCode    *string
+		organization  *uuid.UUID
+		expectedError string
+	}{
+		{
+			name: "Success - organization taken from invite",
+			setupMocks: func(mu *mockUserRepo, mi *mockModeratorInviteRepo, mh *mockHashService, ml *mockLogger) {
+				mi.On("Consume", mock.Anything, testOldRefreshTokenHash, testTime).Return(invite, nil)
+				mh.On("HashPassword", validPassword).Return("hash", nil)
+				mu.On("Create", mock.Anything, mock.MatchedBy(func(user *dao.User) bool {
+					return user.OrganizationID == testOrganizationID.String() && user.Role == model.RoleModerator.ToInt()
+				})).Run(func(args mock.Arguments) {
+					args.Get(1).(*dao.User).ID = validUserID.String()
+				}).Return(nil)
+				ml.On("Info", mock.Anything, mock.Anything)
+			},
+			inviteCode: &inviteCode,
+		},
+		{
+			name: "Without invite",
+			setupMocks: func(_ *mockUserRepo, _ *mockModeratorInviteRepo, _ *mockHashService, ml *mockLogger) {
+				ml.On("Warn", mock.Anything, mock.Anything)
+			},
+			expectedError: model.ErrModeratorInviteRequired,
+		},
+		{
+			name: "Used or expired invite",
+			setupMocks: func(_ *mockUserRepo, mi *mockModeratorInviteRepo, _ *mockHashService, ml *mockLogger) {
+				mi.On("Consume", mock.Anything, testOldRefreshTokenHash, testTime).Return(nil, nil)
+				ml.On("Warn", mock.Anything, mock.Anything)
+			},
+			inviteCode:    &inviteCode,
+			expectedError: model.ErrInvalidInviteCode,
+		},
+		{
+			name: "Invite for another organization is released",
+			setupMocks: func(_ *mockUserRepo, mi *mockModeratorInviteRepo, _ *mockHashService, ml *mockLogger) {
+				mi.On("Consume", mock.Anything, testOldRefreshTokenHash, testTime).Return(invite, nil)
+				mi.On("Release", mock.Anything, invite.ID).Return(nil).Once()
+				ml.On("Warn", mock.Anything, mock.Anything)
+			},
+			inviteCode:    &inviteCode,
+			organization:  &otherOrganizationID,
+			expectedError: model.ErrInvalidInviteCode,
+		},
+		{
+			name: "Existing email releases invite",
+			setupMocks: func(mu *mockUserRepo, mi *mockModeratorInviteRepo, mh *mockHashService, ml *mockLogger) {
+				mi.On("Consume", mock.Anything, testOldRefreshTokenHash, testTime).Return(invite, nil)
+				mh.On("HashPassword", validPassword).Return("hash", nil)
+				mu.On("Create", mock.Anything, mock.Anything).Return(errors.New(model.ErrUserWithEmailAlreadyExists))
+				mi.On("Release", mock.Anything, invite.ID).Return(nil).Once()
+				ml.On("Warn", mock.Anything, mock.Anything)
+			},
+			inviteCode:    &inviteCode,
+			expectedError: model.ErrUserWithEmailAlreadyExists,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mu := &mockUserRepo{}
+			mi := &mockModeratorInviteRepo{}
+			mh := &mockHashService{}
+			mt := &mockTimeService{}
+			ml := &mockLogger{}
+
+			tt.setupMocks(mu, mi, mh, ml)
+			mt.On("GetTime").Return(testTime).Maybe()
+
+			uc := NewUseCaseAuth(mu, &mockSessionRepo{}, newTestLoginAttemptRepo(), mi, mh, &mockJWTService{}, newTestRefreshTokenService(), mt, ml)
+			user, err := uc.Register(context.Background(), &onlymodels.PostRegisterJSONBody{
+				Email:          types.Email(validEmail),
+				Password:       validPassword,
+				Role:           onlymodels.Moderator,
+				OrganizationId: tt.organization,
+				InviteCode:     tt.inviteCode,
+			})
+
+			mi.AssertExpectations(t)
+			if tt.expectedError != "" {
+				assert.Error(t, err)
+				assert.Equal(t, tt.expectedError, err.Error())
+				return
+			}
+
+			assert.NoError(t, err)
+			assert.Equal(t, onlymodels.UserRoleModerator, user.Role)
+			assert.Equal(t, testOrganizationID, *user.OrganizationId)
 		})
 	}
 }
@@ -288,25 +657,85 @@ func TestAuth_Login(t *testing.T) {
 	validToken := "valid.token.123"
 	validUserID := uuid.New()
 	hashedPassword := "hashedPassword123"
+	testTime := time.Now()
 
 	tests := []struct {
 		name          string
-		setupMocks    func(*mockUserRepo, *mockHashService, *mockJWTService, *mockLogger)
+		setupMocks    func(*mockUserRepo, *mockHashService, *mockJWTService, *mockSessionRepo, *mockLogger)
 		request       *onlymodels.PostLoginJSONBody
 		expectedToken string
 		expectedError string
 	}{
 		{
 			name: "Success - valid credentials",
-			setupMocks: func(mu *mockUserRepo, mh *mockHashService, mj *mockJWTService, ml *mockLogger) {
+			setupMocks: func(mu *mockUserRepo, mh *mockHashService, mj *mockJWTService, ms *mockSessionRepo, ml *mockLogger) {
 				mu.On("FindByEmail", mock.Anything, validEmail).Return(&dao.User{
-					ID:       validUserID.String(),
-					Email:    validEmail,
-					Password: hashedPassword,
-					Role:     model.RoleEmployee.ToInt(),
+					ID:             validUserID.String(),
+					Email:          validEmail,
+					Password:       hashedPassword,
+					Role:           model.RoleEmployee.ToInt(),
+					OrganizationID: testOrganizationID.String(),
 				}, nil)
 				mh.On("HashAndComparePassword", validPassword, hashedPassword).Return(true)
-				mj.On("GenerateToken", model.RoleEmployee.Get()).Return(validToken, nil)
+				mh.On("NeedsRehash", hashedPassword).Return(false)
+				ms.On("Create", mock.Anything, mock.MatchedBy(func(session *dao.Session) bool {
+					return session.UserID == validUserID.String() &&
+						session.RefreshTokenHash == testRefreshTokenHash &&
+						session.ExpiresAt.Equal(testTime.Add(refreshTokenTTL))
+				})).Run(func(args mock.Arguments) {
+					session := args.Get(1).(*dao.Session)
+					session.ID = testSessionID
+				}).Return(nil)
+				mj.On("GenerateToken", validUserID.String(), model.RoleEmployee.Get(), testOrganizationID.String(), testSessionID).Return(validToken, nil)
+				ml.On("Info", mock.Anything, mock.Anything)
+			},
+			request: &onlymodels.PostLoginJSONBody{
+				Email:    types.Email(validEmail),
+				Password: validPassword,
+			},
+			expectedToken: validToken,
+		},
+		{
+			name: "Success - legacy hash upgraded",
+			setupMocks: func(mu *mockUserRepo, mh *mockHashService, mj *mockJWTService, ms *mockSessionRepo, ml *mockLogger) {
+				mu.On("FindByEmail", mock.Anything, validEmail).Return(&dao.User{
+					ID:             validUserID.String(),
+					Email:          validEmail,
+					Password:       hashedPassword,
+					Role:           model.RoleEmployee.ToInt(),
+					OrganizationID: testOrganizationID.String(),
+				}, nil)
+				mh.On("HashAndComparePassword", validPassword, hashedPassword).Return(true)
+				mh.On("NeedsRehash", hashedPassword).Return(true)
+				mh.On("HashPassword", validPassword).Return("upgradedHash", nil)
+				mu.On("UpdatePassword", mock.Anything, validUserID.String(), "upgradedHash").Return(nil).Once()
+				ms.On("Create", mock.Anything, mock.Anything).Return(nil)
+				mj.On("GenerateToken", validUserID.String(), model.RoleEmployee.Get(), testOrganizationID.String(), mock.Anything).Return(validToken, nil)
+				ml.On("Info", mock.Anything, mock.Anything)
+			},
+			request: &onlymodels.PostLoginJSONBody{
+				Email:    types.Email(validEmail),
+				Password: validPassword,
+			},
+			expectedToken: validToken,
+		},
+		{
+			name: "Success - hash upgrade error does not block login",
+			setupMocks: func(mu *mockUserRepo, mh *mockHashService, mj *mockJWTService, ms *mockSessionRepo, ml *mockLogger) {
+				mu.On("FindByEmail", mock.Anything, validEmail).Return(&dao.User{
+					ID:             validUserID.String(),
+					Email:          validEmail,
+					Password:       hashedPassword,
+					Role:           model.RoleEmployee.ToInt(),
+					OrganizationID: testOrganizationID.String(),
+				}, nil)
+				mh.On("HashAndComparePassword", validPassword, hashedPassword).Return(true)
+				mh.On("NeedsRehash", hashedPassword).Return(true)
+				mh.On("HashPassword", validPassword).Return("upgradedHash", nil)
+				mu.On("UpdatePassword", mock.Anything, validUserID.String(), "upgradedHash").Return(errors.New("db error"))
+				ms.On("Create", mock.Anything, mock.Anything).Return(nil)
+				mj.On("GenerateToken", validUserID.String(), model.RoleEmployee.Get(), testOrganizationID.String(), mock.Anything).Return(validToken, nil)
+				ml.On("Error", mock.Anything, mock.Anything)
 				ml.On("Info", mock.Anything, mock.Anything)
 			},
 			request: &onlymodels.PostLoginJSONBody{
@@ -317,7 +746,7 @@ func TestAuth_Login(t *testing.T) {
 		},
 		{
 			name: "Invalid email",
-			setupMocks: func(_ *mockUserRepo, _ *mockHashService, _ *mockJWTService, ml *mockLogger) {
+			setupMocks: func(_ *mockUserRepo, _ *mockHashService, _ *mockJWTService, _ *mockSessionRepo, ml *mockLogger) {
 				ml.On("Warn", mock.Anything, mock.Anything)
 				ml.On("Error", mock.Anything, mock.Anything)
 			},
@@ -329,7 +758,7 @@ func TestAuth_Login(t *testing.T) {
 		},
 		{
 			name: "Invalid password",
-			setupMocks: func(_ *mockUserRepo, _ *mockHashService, _ *mockJWTService, ml *mockLogger) {
+			setupMocks: func(_ *mockUserRepo, _ *mockHashService, _ *mockJWTService, _ *mockSessionRepo, ml *mockLogger) {
 				ml.On("Warn", mock.Anything, mock.Anything)
 				ml.On("Error", mock.Anything, mock.Anything)
 			},
@@ -339,9 +768,28 @@ func TestAuth_Login(t *testing.T) {
 			},
 			expectedError: model.ErrInvalidPassword,
 		},
+		{
+			name: "Deactivated user",
+			setupMocks: func(mu *mockUserRepo, mh *mockHashService, _ *mockJWTService, _ *mockSessionRepo, ml *mockLogger) {
+				mu.On("FindByEmail", mock.Anything, validEmail).Return(&dao.User{
+					ID:             validUserID.String(),
+					Email:          validEmail,
+					Password:       hashedPassword,
+					OrganizationID: testOrganizationID.String(),
+					DeactivatedAt:  sql.NullTime{Time: testTime, Valid: true},
+				}, nil)
+				mh.On("HashAndComparePassword", validPassword, hashedPassword).Return(true)
+				ml.On("Warn", mock.Anything, mock.Anything)
+			},
+			request: &onlymodels.PostLoginJSONBody{
+				Email:    types.Email(validEmail),
+				Password: validPassword,
+			},
+			expectedError: model.ErrUserDeactivated,
+		},
 		{
 			name: "User not found",
-			setupMocks: func(mu *mockUserRepo, _ *mockHashService, _ *mockJWTService, ml *mockLogger) {
+			setupMocks: func(mu *mockUserRepo, _ *mockHashService, _ *mockJWTService, _ *mockSessionRepo, ml *mockLogger) {
 				mu.On("FindByEmail", mock.Anything, validEmail).Return(nil, nil)
 				ml.On("Warn", mock.Anything, mock.Anything)
 			},
@@ -353,7 +801,7 @@ func TestAuth_Login(t *testing.T) {
 		},
 		{
 			name: "Database error",
-			setupMocks: func(mu *mockUserRepo, _ *mockHashService, _ *mockJWTService, ml *mockLogger) {
+			setupMocks: func(mu *mockUserRepo, _ *mockHashService, _ *mockJWTService, _ *mockSessionRepo, ml *mockLogger) {
 				mu.On("FindByEmail", mock.Anything, validEmail).Return(nil, errors.New("db error"))
 				ml.On("Error", mock.Anything, mock.Anything)
 			},
@@ -365,12 +813,13 @@ func TestAuth_Login(t *testing.T) {
 		},
 		{
 			name: "Wrong password",
-			setupMocks: func(mu *mockUserRepo, mh *mockHashService, _ *mockJWTService, ml *mockLogger) {
+			setupMocks: func(mu *mockUserRepo, mh *mockHashService, _ *mockJWTService, _ *mockSessionRepo, ml *mockLogger) {
 				mu.On("FindByEmail", mock.Anything, validEmail).Return(&dao.User{
-					ID:       validUserID.String(),
-					Email:    validEmail,
-					Password: hashedPassword,
-					Role:     model.RoleEmployee.ToInt(),
+					ID:             validUserID.String(),
+					Email:          validEmail,
+					Password:       hashedPassword,
+					Role:           model.RoleEmployee.ToInt(),
+					OrganizationID: testOrganizationID.String(),
 				}, nil)
 				mh.On("HashAndComparePassword", validPassword, hashedPassword).Return(false)
 				ml.On("Warn", mock.Anything, mock.Anything)
@@ -381,17 +830,41 @@ func TestAuth_Login(t *testing.T) {
 			},
 			expectedError: model.ErrEmailOrPasswordIsWrong,
 		},
+		{
+			name: "Session creation error",
+			setupMocks: func(mu *mockUserRepo, mh *mockHashService, _ *mockJWTService, ms *mockSessionRepo, ml *mockLogger) {
+				mu.On("FindByEmail", mock.Anything, validEmail).Return(&dao.User{
+					ID:             validUserID.String(),
+					Email:          validEmail,
+					Password:       hashedPassword,
+					Role:           model.RoleEmployee.ToInt(),
+					OrganizationID: testOrganizationID.String(),
+				}, nil)
+				mh.On("HashAndComparePassword", validPassword, hashedPassword).Return(true)
+				mh.On("NeedsRehash", hashedPassword).Return(false)
+				ms.On("Create", mock.Anything, mock.Anything).Return(errors.New("db error"))
+				ml.On("Error", mock.Anything, mock.Anything)
+			},
+			request: &onlymodels.PostLoginJSONBody{
+				Email:    types.Email(validEmail),
+				Password: validPassword,
+			},
+			expectedError: model.ErrInternal,
+		},
 		{
 			name: "Token generation error",
-			setupMocks: func(mu *mockUserRepo, mh *mockHashService, mj *mockJWTService, ml *mockLogger) {
+			setupMocks: func(mu *mockUserRepo, mh *mockHashService, mj *mockJWTService, ms *mockSessionRepo, ml *mockLogger) {
 				mu.On("FindByEmail", mock.Anything, validEmail).Return(&dao.User{
-					ID:       validUserID.String(),
-					Email:    validEmail,
-					Password: hashedPassword,
-					Role:     model.RoleEmployee.ToInt(),
+					ID:             validUserID.String(),
+					Email:          validEmail,
+					Password:       hashedPassword,
+					Role:           model.RoleEmployee.ToInt(),
+					OrganizationID: testOrganizationID.String(),
 				}, nil)
 				mh.On("HashAndComparePassword", validPassword, hashedPassword).Return(true)
-				mj.On("GenerateToken", model.RoleEmployee.Get()).Return("", errors.New("jwt error"))
+				mh.On("NeedsRehash", hashedPassword).Return(false)
+				ms.On("Create", mock.Anything, mock.Anything).Return(nil)
+				mj.On("GenerateToken", mock.Anything, model.RoleEmployee.Get(), mock.Anything, mock.Anything).Return("", errors.New("jwt error"))
 				ml.On("Error", mock.Anything, mock.Anything)
 			},
 			request: &onlymodels.PostLoginJSONBody{
@@ -405,17 +878,22 @@ func TestAuth_Login(t *testing.T) {
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
 			mu := &mockUserRepo{}
+			ms := &mockSessionRepo{}
 			mh := &mockHashService{}
 			mj := &mockJWTService{}
+			mr := newTestRefreshTokenService()
+			mt := &mockTimeService{}
 			ml := &mockLogger{}
 
 			if tt.setupMocks != nil {
-				tt.setupMocks(mu, mh, mj, ml)
+				tt.setupMocks(mu, mh, mj, ms, ml)
 			}
+			mt.On("GetTime").Return(testTime).Maybe()
 
-			uc := NewUseCaseAuth(mu, mh, mj, ml)
-			token, err := uc.Login(context.Background(), tt.request)
+			uc := NewUseCaseAuth(mu, ms, newTestLoginAttemptRepo(), &mockModeratorInviteRepo{}, mh, mj, mr, mt, ml)
+			tokens, err := uc.Login(context.Background(), tt.request, testClientIP)
 
+			mu.AssertExpectations(t)
 			if tt.expectedError != "" {
 				assert.Error(t, err)
 				assert.Contains(t, err.Error(), tt.expectedError)
@@ -423,7 +901,428 @@ func TestAuth_Login(t *testing.T) {
 			}
 
 			assert.NoError(t, err)
-			assert.Equal(t, tt.expectedToken, token)
+			assert.Equal(t, tt.expectedToken, tokens.AccessToken)
+			assert.Equal(t, testRefreshToken, tokens.RefreshToken)
+			assert.Equal(t, testTime.Add(refreshTokenTTL), tokens.RefreshExpiresAt)
+		})
+	}
+}
+
+func TestAuth_LoginLockout(t *testing.T) {
+	validEmail := "test@example.com"
+	validPassword := "securePassword123"
+	hashedPassword := "hashedPassword123"
+	testTime := time.Now()
+	request := &onlymodels.PostLoginJSONBody{
+		Email:    types.Email("Test@Example.com"),
+		Password: validPassword,
+	}
+	foundUser := &dao.User{
+		ID:             uuid.NewString(),
+		Email:          validEmail,
+		Password:       hashedPassword,
+		Role:           model.RoleEmployee.ToInt(),
+		OrganizationID: testOrganizationID.String(),
+	}
+	email := string(model.LoginAttemptScopeEmail)
+	ip := string(model.LoginAttemptScopeIP)
+
+	tests := []struct {
+		name               string
+		setupMocks         func(*mockUserRepo, *mockHashService, *mockLoginAttemptRepo, *mockLogger)
+		expectedError      string
+		expectedRetryAfter time.Duration
+	}{
+		{
+			name: "Locked by email",
+			setupMocks: func(_ *mockUserRepo, _ *mockHashService, ma *mockLoginAttemptRepo, ml *mockLogger) {
+				ma.On("Find", mock.Anything, email, validEmail).Return(&dao.LoginAttempt{
+					Failures:    5,
+					LockedUntil: sql.NullTime{Time: testTime.Add(30 * time.Second), Valid: true},
+				}, nil)
+				ma.On("Find", mock.Anything, ip, testClientIP).Return(nil, nil)
+				ml.On("Warn", mock.Anything, mock.Anything)
+			},
+			expectedError:      model.ErrTooManyLoginAttempts,
+			expectedRetryAfter: 30 * time.Second,
+		},
+		{
+			name: "Longest lockout wins",
+			setupMocks: func(_ *mockUserRepo, _ *mockHashService, ma *mockLoginAttemptRepo, ml *mockLogger) {
+				ma.On("Find", mock.Anything, email, validEmail).Return(&dao.LoginAttempt{
+					Failures:    5,
+					LockedUntil: sql.NullTime{Time: testTime.Add(30 * time.Second), Valid: true},
+				}, nil)
+				ma.On("Find", mock.Anything, ip, testClientIP).Return(&dao.LoginAttempt{
+					Failures:    25,
+					LockedUntil: sql.NullTime{Time: testTime.Add(5 * time.Minute), Valid: true},
+				}, nil)
+				ml.On("Warn", mock.Anything, mock.Anything)
+			},
+			expectedError:      model.ErrTooManyLoginAttempts,
+			expectedRetryAfter: 5 * time.Minute,
+		},
+		{
+			name: "Expired lockout lets through",
+			setupMocks: func(mu *mockUserRepo, mh *mockHashService, ma *mockLoginAttemptRepo, ml *mockLogger) {
+				ma.On("Find", mock.Anything, email, validEmail).Return(&dao.LoginAttempt{
+					Failures:    5,
+					LockedUntil: sql.NullTime{Time: testTime.Add(-time.Second), Valid: true},
+				}, nil)
+				ma.On("Find", mock.Anything, ip, testClientIP).Return(nil, nil)
+				mu.On("FindByEmail", mock.Anything, "Test@Example.com").Return(foundUser, nil)
+				mh.On("HashAndComparePassword", validPassword, hashedPassword).Return(false)
+				ma.On("RegisterFailure", mock.Anything, email, validEmail, testTime, testTime.Add(-emailLoginPolicy.ResetAfter)).
+					Return(&dao.LoginAttempt{Failures: 1}, nil)
+				ma.On("RegisterFailure", mock.Anything, ip, testClientIP, testTime, testTime.Add(-ipLoginPolicy.ResetAfter)).
+					Return(&dao.LoginAttempt{Failures: 1}, nil)
+				ml.On("Warn", mock.Anything, mock.Anything)
+			},
+			expectedError: model.ErrEmailOrPasswordIsWrong,
+		},
+		{
+			name: "Failure over the limit locks email with backoff",
+			setupMocks: func(mu *mockUserRepo, mh *mockHashService, ma *mockLoginAttemptRepo, ml *mockLogger) {
+				ma.On("Find", mock.Anything, mock.Anything, mock.Anything).Return(nil, nil)
+				mu.On("FindByEmail", mock.Anything, "Test@Example.com").Return(foundUser, nil)
+				mh.On("HashAndComparePassword", validPassword, hashedPassword).Return(false)
+				ma.On("RegisterFailure", mock.Anything, email, validEmail, testTime, mock.Anything).
+					Return(&dao.LoginAttempt{Failures: 7}, nil)
+				ma.On("RegisterFailure", mock.Anything, ip, testClientIP, testTime, mock.Anything).
+					Return(&dao.LoginAttempt{Failures: 7}, nil)
+				ma.On("Lock", mock.Anything, email, validEmail, testTime.Add(2*time.Minute)).Return(nil)
+				ml.On("Warn", mock.Anything, mock.Anything)
+			},
+			expectedError: model.ErrEmailOrPasswordIsWrong,
+		},
+		{
+			name: "Unknown email counts as failure",
+			setupMocks: func(mu *mockUserRepo, _ *mockHashService, ma *mockLoginAttemptRepo, ml *mockLogger) {
+				ma.On("Find", mock.Anything, mock.Anything, mock.Anything).Return(nil, nil)
+				mu.On("FindByEmail", mock.Anything, "Test@Example.com").Return(nil, nil)
+				ma.On("RegisterFailure", mock.Anything, email, validEmail, testTime, mock.Anything).
+					Return(&dao.LoginAttempt{Failures: 1}, nil)
+				ma.On("RegisterFailure", mock.Anything, ip, testClientIP, testTime, mock.Anything).
+					Return(&dao.LoginAttempt{Failures: 1}, nil)
+				ml.On("Warn", mock.Anything, mock.Anything)
+			},
+			expectedError: model.ErrEmailOrPasswordIsWrong,
+		},
+		{
+			name: "Lockout storage error",
+			setupMocks: func(_ *mockUserRepo, _ *mockHashService, ma *mockLoginAttemptRepo, ml *mockLogger) {
+				ma.On("Find", mock.Anything, mock.Anything, mock.Anything).Return(nil, errors.New("db error"))
+				ml.On("Error", mock.Anything, mock.Anything)
+			},
+			expectedError: model.ErrInternal,
+		},
+		{
+			name: "Success resets email counter only",
+			setupMocks: func(mu *mockUserRepo, mh *mockHashService, ma *mockLoginAttemptRepo, ml *mockLogger) {
+				ma.On("Find", mock.Anything, mock.Anything, mock.Anything).Return(nil, nil)
+				mu.On("FindByEmail", mock.Anything, "Test@Example.com").Return(foundUser, nil)
+				mh.On("HashAndComparePassword", validPassword, hashedPassword).Return(true)
+				mh.On("NeedsRehash", hashedPassword).Return(false)
+				ma.On("Reset", mock.Anything, email, validEmail).Return(nil)
+				ml.On("Info", mock.Anything, mock.Anything)
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mu := &mockUserRepo{}
+			ms := &mockSessionRepo{}
+			ma := &mockLoginAttemptRepo{}
+			mh := &mockHashService{}
+			mj := &mockJWTService{}
+			mt := &mockTimeService{}
+			ml := &mockLogger{}
+
+			tt.setupMocks(mu, mh, ma, ml)
+			ms.On("Create", mock.Anything, mock.Anything).Return(nil).Maybe()
+			mj.On("GenerateToken", mock.Anything, mock.Anything, mock.Anything, mock.Anything).Return("token", nil).Maybe()
+			mt.On("GetTime").Return(testTime)
+
+			uc := NewUseCaseAuth(mu, ms, ma, &mockModeratorInviteRepo{}, mh, mj, newTestRefreshTokenService(), mt, ml)
+			_, err := uc.Login(context.Background(), request, testClientIP)
+
+			ma.AssertExpectations(t)
+			if tt.expectedError == "" {
+				assert.NoError(t, err)
+				return
+			}
+			assert.Error(t, err)
+			assert.Equal(t, tt.expectedError, err.Error())
+			var locked *model.LoginLockedError
+			if tt.expectedRetryAfter > 0 {
+				assert.True(t, errors.As(err, &locked))
+				assert.Equal(t, tt.expectedRetryAfter, locked.RetryAfter)
+			} else {
+				assert.False(t, errors.As(err, &locked))
+			}
+		})
+	}
+}
+
+func TestAuth_Refresh(t *testing.T) {
+	validToken := "valid.token.123"
+	validUserID := uuid.New()
+	testTime := time.Now()
+	rotatedSession := &dao.Session{
+		ID:               testSessionID,
+		UserID:           validUserID.String(),
+		RefreshTokenHash: testRefreshTokenHash,
+		ExpiresAt:        testTime.Add(refreshTokenTTL),
+	}
+	sessionUser := &dao.User{
+		ID:             validUserID.String(),
+		Role:           model.RoleModerator.ToInt(),
+		OrganizationID: testOrganizationID.String(),
+	}
+
+	tests := []struct {
+		name          string
+		setupMocks    func(*mockUserRepo, *mockSessionRepo, *mockJWTService, *mockRefreshTokenService, *mockLogger)
+		refreshToken  string
+		expectedToken string
+		expectedError string
+	}{
+		{
+			name: "Success - token rotated",
+			setupMocks: func(mu *mockUserRepo, ms *mockSessionRepo, mj *mockJWTService, _ *mockRefreshTokenService, ml *mockLogger) {
+				ms.On("Rotate", mock.Anything, testOldRefreshTokenHash, testRefreshTokenHash, testTime.Add(refreshTokenTTL), testTime).Return(rotatedSession, nil)
+				mu.On("FindByID", mock.Anything, validUserID.String()).Return(sessionUser, nil)
+				mj.On("GenerateToken", validUserID.String(), model.RoleModerator.Get(), testOrganizationID.String(), testSessionID).Return(validToken, nil)
+				ml.On("Info", mock.Anything, mock.Anything)
+			},
+			refreshToken:  testOldRefreshToken,
+			expectedToken: validToken,
+		},
+		{
+			name: "Missing refresh token",
+			setupMocks: func(_ *mockUserRepo, _ *mockSessionRepo, _ *mockJWTService, _ *mockRefreshTokenService, ml *mockLogger) {
+				ml.On("Warn", mock.Anything, mock.Anything)
+			},
+			expectedError: model.ErrInvalidRefreshToken,
+		},
+		{
+			name: "Unknown or expired refresh token",
+			setupMocks: func(_ *mockUserRepo, ms *mockSessionRepo, _ *mockJWTService, _ *mockRefreshTokenService, ml *mockLogger) {
+				ms.On("Rotate", mock.Anything, testOldRefreshTokenHash, testRefreshTokenHash, mock.Anything, testTime).Return(nil, nil)
+				ms.On("RevokeByPreviousToken", mock.Anything, testOldRefreshTokenHash, testTime).Return(false, nil)
+				ml.On("Warn", mock.Anything, mock.Anything)
+			},
+			refreshToken:  testOldRefreshToken,
+			expectedError: model.ErrInvalidRefreshToken,
+		},
+		{
+			name: "Reused refresh token revokes session",
+			setupMocks: func(_ *mockUserRepo, ms *mockSessionRepo, _ *mockJWTService, _ *mockRefreshTokenService, ml *mockLogger) {
+				ms.On("Rotate", mock.Anything, testOldRefreshTokenHash, testRefreshTokenHash, mock.Anything, testTime).Return(nil, nil)
+				ms.On("RevokeByPreviousToken", mock.Anything, testOldRefreshTokenHash, testTime).Return(true, nil).Once()
+				ml.On("Warn", "refresh token reuse detected, session revoked", mock.Anything).Once()
+			},
+			refreshToken:  testOldRefreshToken,
+			expectedError: model.ErrInvalidRefreshToken,
+		},
+		{
+			name: "Session user not found",
+			setupMocks: func(mu *mockUserRepo, ms *mockSessionRepo, _ *mockJWTService, _ *mockRefreshTokenService, ml *mockLogger) {
+				ms.On("Rotate", mock.Anything, testOldRefreshTokenHash, testRefreshTokenHash, mock.Anything, testTime).Return(rotatedSession, nil)
+				mu.On("FindByID", mock.Anything, validUserID.String()).Return(nil, nil)
+				ml.On("Warn", mock.Anything, mock.Anything)
+			},
+			refreshToken:  testOldRefreshToken,
+			expectedError: model.ErrInvalidRefreshToken,
+		},
+		{
+			name: "Deactivated user - session revoked",
+			setupMocks: func(mu *mockUserRepo, ms *mockSessionRepo, _ *mockJWTService, _ *mockRefreshTokenService, ml *mockLogger) {
+				ms.On("Rotate", mock.Anything, testOldRefreshTokenHash, testRefreshTokenHash, mock.Anything, testTime).Return(rotatedSession, nil)
+				mu.On("FindByID", mock.Anything, validUserID.String()).Return(&dao.User{
+					ID:             validUserID.String(),
+					OrganizationID: testOrganizationID.String(),
+					DeactivatedAt:  sql.NullTime{Time: testTime, Valid: true},
+				}, nil)
+				ms.On("Revoke", mock.Anything, testSessionID, testTime).Return(nil).Once()
+				ml.On("Warn", mock.Anything, mock.Anything)
+			},
+			refreshToken:  testOldRefreshToken,
+			expectedError: model.ErrInvalidRefreshToken,
+		},
+		{
+			name: "Refresh token generation error",
+			setupMocks: func(_ *mockUserRepo, _ *mockSessionRepo, _ *mockJWTService, mr *mockRefreshTokenService, ml *mockLogger) {
+				mr.ExpectedCalls = nil
+				mr.On("HashRefreshToken", testOldRefreshToken).Return(testOldRefreshTokenHash)
+				mr.On("GenerateRefreshToken").Return("", errors.New("rand error"))
+				ml.On("Error", mock.Anything, mock.Anything)
+			},
+			refreshToken:  testOldRefreshToken,
+			expectedError: model.ErrInternal,
+		},
+		{
+			name: "Database error",
+			setupMocks: func(_ *mockUserRepo, ms *mockSessionRepo, _ *mockJWTService, _ *mockRefreshTokenService, ml *mockLogger) {
+				ms.On("Rotate", mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything).Return(nil, errors.New("db error"))
+				ml.On("Error", mock.Anything, mock.Anything)
+			},
+			refreshToken:  testOldRefreshToken,
+			expectedError: model.ErrInternal,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mu := &mockUserRepo{}
+			ms := &mockSessionRepo{}
+			mh := &mockHashService{}
+			mj := &mockJWTService{}
+			mr := newTestRefreshTokenService()
+			mt := &mockTimeService{}
+			ml := &mockLogger{}
+
+			if tt.setupMocks != nil {
+				tt.setupMocks(mu, ms, mj, mr, ml)
+			}
+			mt.On("GetTime").Return(testTime).Maybe()
+
+			uc := NewUseCaseAuth(mu, ms, newTestLoginAttemptRepo(), &mockModeratorInviteRepo{}, mh, mj, mr, mt, ml)
+			tokens, err := uc.Refresh(context.Background(), tt.refreshToken)
+
+			ms.AssertExpectations(t)
+			if tt.expectedError != "" {
+				assert.Error(t, err)
+				assert.Contains(t, err.Error(), tt.expectedError)
+				return
+			}
+
+			assert.NoError(t, err)
+			assert.Equal(t, tt.expectedToken, tokens.AccessToken)
+			assert.Equal(t, testRefreshToken, tokens.RefreshToken)
+		})
+	}
+}
+
+func TestAuth_Logout(t *testing.T) {
+	testTime := time.Now()
+
+	tests := []struct {
+		name          string
+		setupMocks    func(*mockSessionRepo, *mockLogger)
+		sessionID     string
+		expectedError string
+	}{
+		{
+			name: "Success",
+			setupMocks: func(ms *mockSessionRepo, ml *mockLogger) {
+				ms.On("Revoke", mock.Anything, testSessionID, testTime).Return(nil).Once()
+				ml.On("Info", mock.Anything, mock.Anything)
+			},
+			sessionID: testSessionID,
+		},
+		{
+			name: "Token without session",
+			setupMocks: func(_ *mockSessionRepo, ml *mockLogger) {
+				ml.On("Info", mock.Anything, mock.Anything)
+			},
+		},
+		{
+			name: "Invalid session ID",
+			setupMocks: func(_ *mockSessionRepo, ml *mockLogger) {
+				ml.On("Warn", mock.Anything, mock.Anything)
+			},
+			sessionID:     "not-a-uuid",
+			expectedError: model.ErrAccessDenied,
+		},
+		{
+			name: "Database error",
+			setupMocks: func(ms *mockSessionRepo, ml *mockLogger) {
+				ms.On("Revoke", mock.Anything, testSessionID, testTime).Return(errors.New("db error"))
+				ml.On("Error", mock.Anything, mock.Anything)
+			},
+			sessionID:     testSessionID,
+			expectedError: model.ErrInternal,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ms := &mockSessionRepo{}
+			mt := &mockTimeService{}
+			ml := &mockLogger{}
+
+			if tt.setupMocks != nil {
+				tt.setupMocks(ms, ml)
+			}
+			mt.On("GetTime").Return(testTime).Maybe()
+
+			uc := NewUseCaseAuth(&mockUserRepo{}, ms, newTestLoginAttemptRepo(), &mockModeratorInviteRepo{}, &mockHashService{}, &mockJWTService{}, newTestRefreshTokenService(), mt, ml)
+			err := uc.Logout(context.Background(), tt.sessionID)
+
+			ms.AssertExpectations(t)
+			if tt.expectedError != "" {
+				assert.Error(t, err)
+				assert.Contains(t, err.Error(), tt.expectedError)
+				return
+			}
+
+			assert.NoError(t, err)
+		})
+	}
+}
+
+func TestAuth_IsSessionActive(t *testing.T) {
+	tests := []struct {
+		name           string
+		setupMocks     func(*mockSessionRepo, *mockLogger)
+		expectedActive bool
+		expectedError  string
+	}{
+		{
+			name: "Active session",
+			setupMocks: func(ms *mockSessionRepo, _ *mockLogger) {
+				ms.On("IsActive", mock.Anything, testSessionID).Return(true, nil)
+			},
+			expectedActive: true,
+		},
+		{
+			name: "Revoked session",
+			setupMocks: func(ms *mockSessionRepo, _ *mockLogger) {
+				ms.On("IsActive", mock.Anything, testSessionID).Return(false, nil)
+			},
+		},
+		{
+			name: "Database error",
+			setupMocks: func(ms *mockSessionRepo, ml *mockLogger) {
+				ms.On("IsActive", mock.Anything, testSessionID).Return(false, errors.New("db error"))
+				ml.On("Error", mock.Anything, mock.Anything)
+			},
+			expectedError: model.ErrInternal,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ms := &mockSessionRepo{}
+			ml := &mockLogger{}
+
+			if tt.setupMocks != nil {
+				tt.setupMocks(ms, ml)
+			}
+
+			uc := NewUseCaseAuth(&mockUserRepo{}, ms, newTestLoginAttemptRepo(), &mockModeratorInviteRepo{}, &mockHashService{}, &mockJWTService{}, newTestRefreshTokenService(), &mockTimeService{}, ml)
+			active, err := uc.IsSessionActive(context.Background(), testSessionID)
+
+			if tt.expectedError != "" {
+				assert.Error(t, err)
+				assert.Contains(t, err.Error(), tt.expectedError)
+				return
+			}
+
+			assert.NoError(t, err)
+			assert.Equal(t, tt.expectedActive, active)
 		})
 	}
 }
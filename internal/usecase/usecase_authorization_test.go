@@ -0,0 +1,386 @@
+// Package usecase это юзкейсы и вспомогательная логика
+package usecase
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"internshipPVZ/internal/domain/model"
+	"internshipPVZ/internal/domain/service"
+	"internshipPVZ/internal/http/onlymodels"
+)
+
+func newTestAuthorizer() Authorizer {
+	return service.NewRoleAuthorizer()
+}
+
+func TestRoleAuthorizer_Matrix(t *testing.T) {
+	authorizer := newTestAuthorizer()
+	supervisor := model.Role("supervisor")
+
+	tests := []struct {
+		role       model.Role
+		permission model.Permission
+		allowed    bool
+	}{
+		{model.RoleModerator, model.PermissionCreatePVZ, true},
+		{model.RoleModerator, model.PermissionReadPVZ, true},
+		{model.RoleModerator, model.PermissionOpenReception, false},
+		{model.RoleModerator, model.PermissionCloseReception, false},
+		{model.RoleModerator, model.PermissionAddProduct, false},
+		{model.RoleModerator, model.PermissionDeleteProduct, false},
+		{model.RoleEmployee, model.PermissionCreatePVZ, false},
+		{model.RoleEmployee, model.PermissionReadPVZ, true},
+		{model.RoleEmployee, model.PermissionOpenReception, true},
+		{model.RoleEmployee, model.PermissionCloseReception, true},
+		{model.RoleEmployee, model.PermissionAddProduct, true},
+		{model.RoleEmployee, model.PermissionDeleteProduct, true},
+		{model.RoleModerator, model.PermissionUpdatePVZ, true},
+		{model.RoleModerator, model.PermissionArchivePVZ, true},
+		{model.RoleModerator, model.PermissionReadInventory, true},
+		{model.RoleModerator, model.PermissionManageAssignments, true},
+		{model.RoleModerator, model.PermissionPauseReception, false},
+		{model.RoleModerator, model.PermissionResumeReception, false},
+		{model.RoleModerator, model.PermissionCancelReception, false},
+		{model.RoleModerator, model.PermissionStoreProduct, false},
+		{model.RoleModerator, model.PermissionIssueProduct, false},
+		{model.RoleModerator, model.PermissionReturnProduct, false},
+		{model.RoleModerator, model.PermissionReadCities, true},
+		{model.RoleModerator, model.PermissionManageCities, true},
+		{model.RoleModerator, model.PermissionReadProductTypes, true},
+		{model.RoleModerator, model.PermissionManageProductTypes, true},
+		{model.RoleModerator, model.PermissionInviteModerator, true},
+		{model.RoleModerator, model.PermissionManageUsers, true},
+		{model.RoleModerator, model.PermissionManageServiceAccounts, true},
+		{model.RoleEmployee, model.PermissionUpdatePVZ, false},
+		{model.RoleEmployee, model.PermissionArchivePVZ, false},
+		{model.RoleEmployee, model.PermissionReadInventory, true},
+		{model.RoleEmployee, model.PermissionManageAssignments, false},
+		{model.RoleEmployee, model.PermissionPauseReception, true},
+		{model.RoleEmployee, model.PermissionResumeReception, true},
+		{model.RoleEmployee, model.PermissionCancelReception, true},
+		{model.RoleEmployee, model.PermissionStoreProduct, true},
+		{model.RoleEmployee, model.PermissionIssueProduct, true},
+		{model.RoleEmployee, model.PermissionReturnProduct, true},
+		{model.RoleEmployee, model.PermissionReadCities, false},
+		{model.RoleEmployee, model.PermissionManageCities, false},
+		{model.RoleEmployee, model.PermissionReadProductTypes, true},
+		{model.RoleEmployee, model.PermissionManageProductTypes, false},
+		{model.RoleEmployee, model.PermissionInviteModerator, false},
+		{model.RoleEmployee, model.PermissionManageUsers, false},
+		{model.RoleEmployee, model.PermissionManageServiceAccounts, false},
+		{model.RoleDefault, model.PermissionReadPVZ, false},
+		{supervisor, model.PermissionReadPVZ, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(string(tt.role)+" "+string(tt.permission), func(t *testing.T) {
+			assert.Equal(t, tt.allowed, authorizer.HasPermission(tt.role, tt.permission))
+		})
+	}
+}
+
+// authzMocks репозитории, которые отвечают ошибкой на любой запрос: юзкейс, прошедший проверку прав, дальше не продвинется
+type authzMocks struct {
+	receptions   *mockReceptionRepo
+	products     *mockProductRepo
+	pvzs         *mockPVZRepo
+	cities       *mockCityRepo
+	productTypes *mockProductTypeRepo
+	assignments  *mockPVZAssignmentRepo
+	users        *mockUserRepo
+	invites      *mockModeratorInviteRepo
+	accounts     *mockServiceAccountRepo
+	time         *mockTimeService
+	logger       *mockLogger
+}
+
+func newAuthzMocks() *authzMocks {
+	dbErr := errors.New("db error")
+	m := &authzMocks{
+		receptions:   &mockReceptionRepo{},
+		products:     &mockProductRepo{},
+		pvzs:         &mockPVZRepo{},
+		cities:       &mockCityRepo{},
+		productTypes: &mockProductTypeRepo{},
+		assignments:  &mockPVZAssignmentRepo{},
+		users:        &mockUserRepo{},
+		invites:      &mockModeratorInviteRepo{},
+		accounts:     &mockServiceAccountRepo{},
+		time:         &mockTimeService{},
+		logger:       &mockLogger{},
+	}
+	m.pvzs.On("FindByID", mock.Anything, mock.Anything).Return(nil, dbErr).Maybe()
+	m.pvzs.On("FindByIDForUpdate", mock.Anything, mock.Anything).Return(nil, dbErr).Maybe()
+	m.pvzs.On("CheckIfExists", mock.Anything, mock.Anything).Return(false, dbErr).Maybe()
+	m.pvzs.On("GetAllWithFilter", mock.Anything, mock.Anything).Return(nil, nil, dbErr).Maybe()
+	m.products.On("FindByID", mock.Anything, mock.Anything).Return(nil, dbErr).Maybe()
+	m.cities.On("FindByName", mock.Anything, mock.Anything).Return(nil, dbErr).Maybe()
+	m.cities.On("GetAll", mock.Anything).Return(nil, dbErr).Maybe()
+	m.cities.On("Create", mock.Anything, mock.Anything).Return(dbErr).Maybe()
+	m.productTypes.On("GetAll", mock.Anything).Return(nil, dbErr).Maybe()
+	m.productTypes.On("Create", mock.Anything, mock.Anything).Return(dbErr).Maybe()
+	m.users.On("List", mock.Anything, mock.Anything, mock.Anything, mock.Anything).Return(nil, dbErr).Maybe()
+	m.invites.On("Create", mock.Anything, mock.Anything).Return(dbErr).Maybe()
+	m.accounts.On("List", mock.Anything).Return(nil, dbErr).Maybe()
+	m.time.On("GetTime").Return(time.Now()).Maybe()
+	m.logger.On("Info", mock.Anything, mock.Anything).Maybe()
+	m.logger.On("Warn", mock.Anything, mock.Anything).Maybe()
+	m.logger.On("Error", mock.Anything, mock.Anything).Maybe()
+	return m
+}
+
+// reachedRepos сообщает, дошёл ли юзкейс до репозиториев после проверки прав
+func (m *authzMocks) reachedRepos() bool {
+	return len(m.pvzs.Calls)+len(m.cities.Calls)+len(m.receptions.Calls)+len(m.products.Calls)+
+		len(m.productTypes.Calls)+len(m.assignments.Calls)+len(m.users.Calls)+len(m.invites.Calls)+len(m.accounts.Calls) > 0
+}
+
+func TestUseCases_AuthorizationMatrix(t *testing.T) {
+	usecases := []struct {
+		name       string
+		permission model.Permission
+		run        func(m *authzMocks, role string) error
+	}{
+		{
+			name:       "CreatePVZ",
+			permission: model.PermissionCreatePVZ,
+			run: func(m *authzMocks, role string) error {
+				uc := NewUseCaseCreatePVZ(m.pvzs, m.cities, m.time, newTestAuthorizer(), m.logger)
+				_, err := uc.Execute(context.Background(), &onlymodels.PVZ{City: "Москва"}, role)
+				return err
+			},
+		},
+		{
+			name:       "GetPvz",
+			permission: model.PermissionReadPVZ,
+			run: func(m *authzMocks, role string) error {
+				uc := NewUseCaseGetPvz(m.pvzs, m.cities, newTestProductTypeRepo(), newTestAuthorizer(), m.logger)
+				uc.GetFiltered(context.Background(), "", "", "", 1, 10, "", false, role)
+				if !m.reachedRepos() {
+					return errors.New(model.ErrAccessDenied)
+				}
+				return nil
+			},
+		},
+		{
+			name:       "OpenReception",
+			permission: model.PermissionOpenReception,
+			run: func(m *authzMocks, role string) error {
+				uc := NewUseCaseOpenReception(m.receptions, m.pvzs, newTestAssignmentRepo(), newTestTxManager(), m.time, newTestAuthorizer(), m.logger)
+				_, err := uc.Execute(context.Background(), uuid.New(), testUserID, role)
+				return err
+			},
+		},
+		{
+			name:       "CloseReception",
+			permission: model.PermissionCloseReception,
+			run: func(m *authzMocks, role string) error {
+				uc := NewUseCaseCloseReception(m.receptions, m.pvzs, newTestAssignmentRepo(), newTestTxManager(), m.time, newTestAuthorizer(), m.logger)
+				_, err := uc.Execute(context.Background(), uuid.New(), testUserID, role)
+				return err
+			},
+		},
+		{
+			name:       "AddProduct",
+			permission: model.PermissionAddProduct,
+			run: func(m *authzMocks, role string) error {
+				uc := NewUseCaseAddProduct(m.receptions, m.products, m.pvzs, newTestProductTypeRepo(), newTestAssignmentRepo(), newTestTxManager(), m.time, newTestAuthorizer(), m.logger)
+				_, err := uc.Execute(context.Background(), &onlymodels.PostProductsJSONBody{
+					PvzId:   uuid.New(),
+					Type:    "электроника",
+					Barcode: barcodeRef("4006381333931"),
+				}, testUserID, role)
+				return err
+			},
+		},
+		{
+			name:       "DeleteProduct",
+			permission: model.PermissionDeleteProduct,
+			run: func(m *authzMocks, role string) error {
+				uc := NewUseCaseDeleteProduct(m.products, m.receptions, m.pvzs, newTestAssignmentRepo(), newTestTxManager(), newTestAuthorizer(), m.logger)
+				return uc.Execute(context.Background(), uuid.New(), testUserID, role)
+			},
+		},
+		{
+			name:       "UpdatePVZ",
+			permission: model.PermissionUpdatePVZ,
+			run: func(m *authzMocks, role string) error {
+				uc := NewUseCaseUpdatePVZ(m.pvzs, m.cities, newTestAuthorizer(), m.logger)
+				_, err := uc.Execute(context.Background(), uuid.New(), &onlymodels.PVZUpdate{}, role)
+				return err
+			},
+		},
+		{
+			name:       "ArchivePVZ",
+			permission: model.PermissionArchivePVZ,
+			run: func(m *authzMocks, role string) error {
+				uc := NewUseCaseArchivePVZ(m.pvzs, m.receptions, m.cities, m.time, newTestAuthorizer(), m.logger)
+				_, err := uc.Execute(context.Background(), uuid.New(), testUserID, role)
+				return err
+			},
+		},
+		{
+			name:       "GetInventory",
+			permission: model.PermissionReadInventory,
+			run: func(m *authzMocks, role string) error {
+				uc := NewUseCaseGetInventory(m.products, m.pvzs, m.productTypes, newTestAuthorizer(), m.logger)
+				_, err := uc.Execute(context.Background(), uuid.New(), role)
+				return err
+			},
+		},
+		{
+			name:       "ManagePVZAssignment",
+			permission: model.PermissionManageAssignments,
+			run: func(m *authzMocks, role string) error {
+				uc := NewUseCaseManagePVZAssignment(m.assignments, m.pvzs, m.time, newTestAuthorizer(), m.logger)
+				_, err := uc.GetByPVZ(context.Background(), uuid.New(), role)
+				return err
+			},
+		},
+		{
+			name:       "PauseReception",
+			permission: model.PermissionPauseReception,
+			run: func(m *authzMocks, role string) error {
+				uc := NewUseCasePauseReception(m.receptions, m.pvzs, m.assignments, newTestTxManager(), newTestAuthorizer(), m.logger)
+				_, err := uc.Execute(context.Background(), uuid.New(), testUserID, role)
+				return err
+			},
+		},
+		{
+			name:       "ResumeReception",
+			permission: model.PermissionResumeReception,
+			run: func(m *authzMocks, role string) error {
+				uc := NewUseCaseResumeReception(m.receptions, m.pvzs, m.assignments, newTestTxManager(), newTestAuthorizer(), m.logger)
+				_, err := uc.Execute(context.Background(), uuid.New(), testUserID, role)
+				return err
+			},
+		},
+		{
+			name:       "CancelReception",
+			permission: model.PermissionCancelReception,
+			run: func(m *authzMocks, role string) error {
+				uc := NewUseCaseCancelReception(m.receptions, m.pvzs, m.assignments, newTestTxManager(), m.time, newTestAuthorizer(), m.logger)
+				_, err := uc.Execute(context.Background(), uuid.New(), testUserID, role)
+				return err
+			},
+		},
+		{
+			name:       "StoreProduct",
+			permission: model.PermissionStoreProduct,
+			run: func(m *authzMocks, role string) error {
+				uc := NewUseCaseStoreProduct(m.products, m.receptions, m.productTypes, m.assignments, newTestAuthorizer(), m.logger)
+				_, err := uc.Execute(context.Background(), uuid.New(), testUserID, role)
+				return err
+			},
+		},
+		{
+			name:       "IssueProduct",
+			permission: model.PermissionIssueProduct,
+			run: func(m *authzMocks, role string) error {
+				uc := NewUseCaseIssueProduct(m.products, m.receptions, m.productTypes, m.assignments, newTestAuthorizer(), m.logger)
+				_, err := uc.Execute(context.Background(), uuid.New(), testUserID, role)
+				return err
+			},
+		},
+		{
+			name:       "ReturnProduct",
+			permission: model.PermissionReturnProduct,
+			run: func(m *authzMocks, role string) error {
+				uc := NewUseCaseReturnProduct(m.products, m.receptions, m.productTypes, m.assignments, newTestAuthorizer(), m.logger)
+				_, err := uc.Execute(context.Background(), uuid.New(), testUserID, role)
+				return err
+			},
+		},
+		{
+			name:       "ManageCity.GetAll",
+			permission: model.PermissionReadCities,
+			run: func(m *authzMocks, role string) error {
+				uc := NewUseCaseManageCity(m.cities, newTestAuthorizer(), m.logger)
+				_, err := uc.GetAll(context.Background(), role)
+				return err
+			},
+		},
+		{
+			name:       "ManageCity.Create",
+			permission: model.PermissionManageCities,
+			run: func(m *authzMocks, role string) error {
+				uc := NewUseCaseManageCity(m.cities, newTestAuthorizer(), m.logger)
+				_, err := uc.Create(context.Background(), &onlymodels.City{Name: "Тверь"}, role)
+				return err
+			},
+		},
+		{
+			name:       "ManageProductType.GetAll",
+			permission: model.PermissionReadProductTypes,
+			run: func(m *authzMocks, role string) error {
+				uc := NewUseCaseManageProductType(m.productTypes, newTestAuthorizer(), m.logger)
+				_, err := uc.GetAll(context.Background(), role)
+				return err
+			},
+		},
+		{
+			name:       "ManageProductType.Create",
+			permission: model.PermissionManageProductTypes,
+			run: func(m *authzMocks, role string) error {
+				uc := NewUseCaseManageProductType(m.productTypes, newTestAuthorizer(), m.logger)
+				_, err := uc.Create(context.Background(), &onlymodels.ProductType{Name: "книги"}, role)
+				return err
+			},
+		},
+		{
+			name:       "ManageModeratorInvite",
+			permission: model.PermissionInviteModerator,
+			run: func(m *authzMocks, role string) error {
+				uc := NewUseCaseManageModeratorInvite(m.invites, newTestRefreshTokenService(), m.time, newTestAuthorizer(), m.logger)
+				_, err := uc.Create(context.Background(), testUserID, role)
+				return err
+			},
+		},
+		{
+			name:       "ManageUsers",
+			permission: model.PermissionManageUsers,
+			run: func(m *authzMocks, role string) error {
+				uc := NewUseCaseManageUsers(m.users, &mockSessionRepo{}, newTestTxManager(), m.time, newTestAuthorizer(), m.logger)
+				_, err := uc.List(context.Background(), "", 1, 10, role)
+				return err
+			},
+		},
+		{
+			name:       "ManageServiceAccounts",
+			permission: model.PermissionManageServiceAccounts,
+			run: func(m *authzMocks, role string) error {
+				uc := NewUseCaseManageServiceAccounts(m.accounts, newTestRefreshTokenService(), m.time, newTestAuthorizer(), m.logger)
+				_, err := uc.List(context.Background(), role)
+				return err
+			},
+		},
+	}
+	roles := []model.Role{model.RoleEmployee, model.RoleModerator}
+	authorizer := newTestAuthorizer()
+
+	for _, usecase := range usecases {
+		for _, role := range roles {
+			allowed := authorizer.HasPermission(role, usecase.permission)
+			t.Run(usecase.name+" as "+role.Get(), func(t *testing.T) {
+				m := newAuthzMocks()
+				err := usecase.run(m, role.Get())
+
+				if allowed {
+					assert.True(t, m.reachedRepos())
+					if err != nil {
+						assert.NotEqual(t, model.ErrAccessDenied, err.Error())
+					}
+					return
+				}
+				assert.EqualError(t, err, model.ErrAccessDenied)
+				assert.False(t, m.reachedRepos())
+			})
+		}
+	}
+}
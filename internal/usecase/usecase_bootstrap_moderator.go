@@ -0,0 +1,152 @@
+// Package usecase это юзкейсы и вспомогательная логика
+package usecase
+
+import (
+	"context"
+	"errors"
+	"github.com/google/uuid"
+	"internshipPVZ/internal/domain/model"
+	repo "internshipPVZ/internal/domain/repository"
+	"internshipPVZ/internal/http/onlymodels"
+	"log"
+	"regexp"
+)
+
+// NewUseCaseBootstrapModerator конструктор
+func NewUseCaseBootstrapModerator(
+	userRepo repo.UserRepo,
+	hashService HashService,
+	logger Logger,
+) *BootstrapModerator {
+	if userRepo == nil {
+		log.Fatalf("BootstrapModerator usecase userRepo nil")
+
+	}
+	if hashService == nil {
+		log.Fatalf("BootstrapModerator usecase hashService nil")
+
+	}
+	if logger == nil {
+		log.Fatalf("BootstrapModerator usecase logger nil")
+
+	}
+
+	return &BootstrapModerator{
+		userRepo:    userRepo,
+		hashService: hashService,
+		emailRegex:  regexp.MustCompile(emailPattern),
+		logger:      logger,
+	}
+}
+
+// BootstrapModerator юзкейс
+type BootstrapModerator struct {
+	userRepo    repo.UserRepo
+	hashService HashService
+	emailRegex  *regexp.Regexp
+	logger      Logger
+}
+
+// Execute создаёт первого модератора организации в обход приглашений.
+// Если в организации уже есть модератор, новых нужно приглашать через /moderator-invites
+func (uc *BootstrapModerator) Execute(ctx context.Context, email, password, organizationID string) (*onlymodels.User, error) {
+	user := model.User{Role: model.RoleModerator}
+	if !uc.emailRegex.MatchString(email) {
+		uc.logger.Warn("invalid email",
+			"usecase", "BootstrapModerator",
+			"method", "Execute",
+			"email", email)
+		return nil, errors.New(model.ErrInvalidEmail)
+	}
+	user.Email = email
+	var err error
+	if user.Password, err = validatePassword(password); err != nil {
+		uc.logger.Warn("invalid password",
+			"usecase", "BootstrapModerator",
+			"method", "Execute")
+		return nil, err
+	}
+	if user.OrganizationID, err = uc.validateOrganizationID(organizationID); err != nil {
+		uc.logger.Warn("invalid organization ID",
+			"usecase", "BootstrapModerator",
+			"method", "Execute",
+			"organization_id", organizationID)
+		return nil, err
+	}
+
+	exists, err := uc.userRepo.CheckIfRoleExists(ctx, user.OrganizationID.String(), model.RoleModerator.ToInt())
+	if err != nil {
+		uc.logger.Error("failed to check moderators",
+			"usecase", "BootstrapModerator",
+			"method", "userRepo.CheckIfRoleExists",
+			"organization_id", user.OrganizationID,
+			"error", err)
+		return nil, errors.New(model.ErrInternal)
+	}
+	if exists {
+		uc.logger.Warn("organization already has a moderator",
+			"usecase", "BootstrapModerator",
+			"method", "Execute",
+			"organization_id", user.OrganizationID)
+		return nil, errors.New(model.ErrModeratorAlreadyExists)
+	}
+
+	user.Password, err = uc.hashService.HashPassword(user.Password)
+	if err != nil {
+		uc.logger.Error("failed to hash password",
+			"usecase", "BootstrapModerator",
+			"method", "hashService.HashPassword",
+			"error", err)
+		return nil, errors.New(model.ErrInternal)
+	}
+
+	userDao := user.ToDao()
+	err = uc.userRepo.Create(ctx, userDao)
+	if err != nil {
+		if err.Error() == model.ErrUserWithEmailAlreadyExists || err.Error() == model.ErrOrganizationNotFound {
+			uc.logger.Warn("failed to create moderator",
+				"usecase", "BootstrapModerator",
+				"method", "userRepo.Create",
+				"email", email,
+				"error", err)
+			return nil, err
+		}
+		uc.logger.Error("failed to create moderator",
+			"usecase", "BootstrapModerator",
+			"method", "userRepo.Create",
+			"email", email,
+			"error", err)
+		return nil, errors.New(model.ErrInternal)
+	}
+
+	userDto, err := userDaoToDto(userDao)
+	if err != nil {
+		uc.logger.Error("failed to convert user DAO to DTO",
+			"usecase", "BootstrapModerator",
+			"method", "userDaoToDto",
+			"error", err)
+		return nil, errors.New(model.ErrInternal)
+	}
+
+	uc.logger.Info("first moderator created successfully",
+		"usecase", "BootstrapModerator",
+		"user_id", userDao.ID,
+		"email", email,
+		"organization_id", user.OrganizationID)
+	return userDto, nil
+}
+
+// validateOrganizationID пустая строка означает организацию по умолчанию
+func (uc *BootstrapModerator) validateOrganizationID(organizationID string) (uuid.UUID, error) {
+	if organizationID == "" {
+		return uuid.MustParse(model.DefaultOrganizationID), nil
+	}
+	id, err := uuid.Parse(organizationID)
+	if err != nil {
+		return id, errors.New(model.ErrInvalidOrganizationID)
+	}
+	if id, err = validateID(id); err != nil {
+		return id, errors.New(model.ErrInvalidOrganizationID)
+	}
+	return id, nil
+}
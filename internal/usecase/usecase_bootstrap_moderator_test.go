@@ -0,0 +1,135 @@
+// Package usecase это юзкейсы и вспомогательная логика
+package usecase
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"internshipPVZ/internal/domain/model"
+	"internshipPVZ/internal/domain/repository/dao"
+	"internshipPVZ/internal/http/onlymodels"
+)
+
+func TestBootstrapModerator_Execute(t *testing.T) {
+	validEmail := "first@example.com"
+	validPassword := "securePassword123"
+	validUserID := uuid.New()
+	moderatorRole := model.RoleModerator.ToInt()
+
+	tests := []struct {
+		name           string
+		setupMocks     func(*mockUserRepo, *mockHashService, *mockLogger)
+		email          string
+		password       string
+		organizationID string
+		expectedOrgID  string
+		expectedError  string
+	}{
+		{
+			name: "Success - default organization",
+			setupMocks: func(mu *mockUserRepo, mh *mockHashService, ml *mockLogger) {
+				mu.On("CheckIfRoleExists", mock.Anything, model.DefaultOrganizationID, moderatorRole).Return(false, nil)
+				mh.On("HashPassword", validPassword).Return("hash", nil)
+				mu.On("Create", mock.Anything, mock.MatchedBy(func(user *dao.User) bool {
+					return user.Role == moderatorRole && user.Password == "hash"
+				})).Run(func(args mock.Arguments) {
+					args.Get(1).(*dao.User).ID = validUserID.String()
+				}).Return(nil)
+				ml.On("Info", mock.Anything, mock.Anything)
+			},
+			email:         validEmail,
+			password:      validPassword,
+			expectedOrgID: model.DefaultOrganizationID,
+		},
+		{
+			name: "Success - explicit organization",
+			setupMocks: func(mu *mockUserRepo, mh *mockHashService, ml *mockLogger) {
+				mu.On("CheckIfRoleExists", mock.Anything, testOrganizationID.String(), moderatorRole).Return(false, nil)
+				mh.On("HashPassword", validPassword).Return("hash", nil)
+				mu.On("Create", mock.Anything, mock.Anything).Run(func(args mock.Arguments) {
+					args.Get(1).(*dao.User).ID = validUserID.String()
+				}).Return(nil)
+				ml.On("Info", mock.Anything, mock.Anything)
+			},
+			email:          validEmail,
+			password:       validPassword,
+			organizationID: testOrganizationID.String(),
+			expectedOrgID:  testOrganizationID.String(),
+		},
+		{
+			name: "Organization already has a moderator",
+			setupMocks: func(mu *mockUserRepo, _ *mockHashService, ml *mockLogger) {
+				mu.On("CheckIfRoleExists", mock.Anything, model.DefaultOrganizationID, moderatorRole).Return(true, nil)
+				ml.On("Warn", mock.Anything, mock.Anything)
+			},
+			email:         validEmail,
+			password:      validPassword,
+			expectedError: model.ErrModeratorAlreadyExists,
+		},
+		{
+			name: "Invalid email",
+			setupMocks: func(_ *mockUserRepo, _ *mockHashService, ml *mockLogger) {
+				ml.On("Warn", mock.Anything, mock.Anything)
+			},
+			email:         "invalid-email",
+			password:      validPassword,
+			expectedError: model.ErrInvalidEmail,
+		},
+		{
+			name: "Invalid password",
+			setupMocks: func(_ *mockUserRepo, _ *mockHashService, ml *mockLogger) {
+				ml.On("Warn", mock.Anything, mock.Anything)
+			},
+			email:         validEmail,
+			password:      "short",
+			expectedError: model.ErrInvalidPassword,
+		},
+		{
+			name: "Invalid organization ID",
+			setupMocks: func(_ *mockUserRepo, _ *mockHashService, ml *mockLogger) {
+				ml.On("Warn", mock.Anything, mock.Anything)
+			},
+			email:          validEmail,
+			password:       validPassword,
+			organizationID: "not-a-uuid",
+			expectedError:  model.ErrInvalidOrganizationID,
+		},
+		{
+			name: "Database error",
+			setupMocks: func(mu *mockUserRepo, _ *mockHashService, ml *mockLogger) {
+				mu.On("CheckIfRoleExists", mock.Anything, mock.Anything, mock.Anything).Return(false, errors.New("db error"))
+				ml.On("Error", mock.Anything, mock.Anything)
+			},
+			email:         validEmail,
+			password:      validPassword,
+			expectedError: model.ErrInternal,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mu := &mockUserRepo{}
+			mh := &mockHashService{}
+			ml := &mockLogger{}
+
+			tt.setupMocks(mu, mh, ml)
+
+			uc := NewUseCaseBootstrapModerator(mu, mh, ml)
+			user, err := uc.Execute(context.Background(), tt.email, tt.password, tt.organizationID)
+
+			if tt.expectedError != "" {
+				assert.Error(t, err)
+				assert.Equal(t, tt.expectedError, err.Error())
+				return
+			}
+
+			assert.NoError(t, err)
+			assert.Equal(t, onlymodels.UserRoleModerator, user.Role)
+			assert.Equal(t, tt.expectedOrgID, user.OrganizationId.String())
+		})
+	}
+}
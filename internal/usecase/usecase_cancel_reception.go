@@ -0,0 +1,122 @@
+// Package usecase это юзкейсы и вспомогательная логика
+package usecase
+
+import (
+	"context"
+	"github.com/google/uuid"
+	"internshipPVZ/internal/domain/model"
+	repo "internshipPVZ/internal/domain/repository"
+	"internshipPVZ/internal/http/onlymodels"
+	"log"
+)
+
+// NewUseCaseCancelReception конструктор
+func NewUseCaseCancelReception(
+	receptionRepo repo.ReceptionRepo,
+	pvzRepo repo.PVZRepo,
+	assignmentRepo repo.PVZAssignmentRepo,
+	txManager repo.TxManager,
+	timeService TimeService,
+	authorizer Authorizer,
+	logger Logger,
+) *CancelReception {
+	if receptionRepo == nil {
+		log.Fatalf("CancelReception usecase receptionRepo nil")
+
+	}
+	if pvzRepo == nil {
+		log.Fatalf("CancelReception usecase pvzRepo nil")
+
+	}
+	if assignmentRepo == nil {
+		log.Fatalf("CancelReception usecase assignmentRepo nil")
+
+	}
+	if txManager == nil {
+		log.Fatalf("CancelReception usecase txManager nil")
+
+	}
+	if timeService == nil {
+		log.Fatalf("CancelReception usecase timeService nil")
+
+	}
+	if authorizer == nil {
+		log.Fatalf("CancelReception usecase authorizer nil")
+
+	}
+	if logger == nil {
+		log.Fatalf("CancelReception usecase logger nil")
+
+	}
+
+	return &CancelReception{
+		receptionRepo:  receptionRepo,
+		pvzRepo:        pvzRepo,
+		assignmentRepo: assignmentRepo,
+		txManager:      txManager,
+		timeService:    timeService,
+		authorizer:     authorizer,
+		logger:         logger,
+	}
+}
+
+// CancelReception юзкейс
+type CancelReception struct {
+	receptionRepo  repo.ReceptionRepo
+	pvzRepo        repo.PVZRepo
+	assignmentRepo repo.PVZAssignmentRepo
+	txManager      repo.TxManager
+	timeService    TimeService
+	authorizer     Authorizer
+	logger         Logger
+}
+
+// Execute отменяет последнюю незавершённую приёмку
+func (uc *CancelReception) Execute(ctx context.Context, PVZID uuid.UUID, userID, userRole string) (*onlymodels.Reception, error) {
+	pvzID, userUUID, role, err := uc.validateInput(PVZID, userID, userRole)
+	if err != nil {
+		uc.logger.Error("validation failed",
+			"usecase", "CancelReception",
+			"method", "validateInput",
+			"pvz_id", PVZID,
+			"error", err)
+		return nil, err
+	}
+
+	if err = checkPermission(uc.authorizer, uc.logger, "CancelReception", "Execute", role, model.PermissionCancelReception); err != nil {
+		return nil, err
+	}
+
+	return changeLastReceptionStatus(ctx, uc.receptionRepo, uc.pvzRepo, uc.assignmentRepo, uc.txManager, uc.logger, receptionStatusChange{
+		usecase: "CancelReception",
+		pvzID:   pvzID,
+		userID:  userUUID,
+		target:  model.ReceptionCancelled,
+		at:      uc.timeService.GetTime(),
+	})
+}
+
+func (uc *CancelReception) validateInput(PVZID uuid.UUID, userID, userRole string) (pvzID, userUUID uuid.UUID, role model.Role, err error) {
+	if pvzID, err = validateID(PVZID); err != nil {
+		uc.logger.Warn("invalid PVZ ID format",
+			"usecase", "CancelReception",
+			"method", "validateInput",
+			"pvz_id", PVZID)
+		return
+	}
+	if userUUID, err = validateRawID(userID); err != nil {
+		uc.logger.Warn("invalid user ID in token",
+			"usecase", "CancelReception",
+			"method", "validateInput",
+			"user_id", userID)
+		return
+	}
+	if role, err = validateRole(userRole); err != nil {
+		uc.logger.Warn("invalid user role",
+			"usecase", "CancelReception",
+			"method", "validateInput",
+			"user_role", userRole)
+		return
+	}
+	return
+}
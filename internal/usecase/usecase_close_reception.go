@@ -3,7 +3,6 @@ package usecase
 
 import (
 	"context"
-	"errors"
 	"github.com/google/uuid"
 	"internshipPVZ/internal/domain/model"
 	repo "internshipPVZ/internal/domain/repository"
@@ -15,6 +14,10 @@ import (
 func NewUseCaseCloseReception(
 	receptionRepo repo.ReceptionRepo,
 	pvzRepo repo.PVZRepo,
+	assignmentRepo repo.PVZAssignmentRepo,
+	txManager repo.TxManager,
+	timeService TimeService,
+	authorizer Authorizer,
 	logger Logger,
 ) *CloseReception {
 	if receptionRepo == nil {
@@ -24,6 +27,22 @@ func NewUseCaseCloseReception(
 	if pvzRepo == nil {
 		log.Fatalf("CloseReception usecase pvzRepo nil")
 
+	}
+	if assignmentRepo == nil {
+		log.Fatalf("CloseReception usecase assignmentRepo nil")
+
+	}
+	if txManager == nil {
+		log.Fatalf("CloseReception usecase txManager nil")
+
+	}
+	if timeService == nil {
+		log.Fatalf("CloseReception usecase timeService nil")
+
+	}
+	if authorizer == nil {
+		log.Fatalf("CloseReception usecase authorizer nil")
+
 	}
 	if logger == nil {
 		log.Fatalf("CloseReception usecase logger nil")
@@ -31,22 +50,30 @@ func NewUseCaseCloseReception(
 	}
 
 	return &CloseReception{
-		receptionRepo: receptionRepo,
-		pvzRepo:       pvzRepo,
-		logger:        logger,
+		receptionRepo:  receptionRepo,
+		pvzRepo:        pvzRepo,
+		assignmentRepo: assignmentRepo,
+		txManager:      txManager,
+		timeService:    timeService,
+		authorizer:     authorizer,
+		logger:         logger,
 	}
 }
 
 // CloseReception юзкейс
 type CloseReception struct {
-	receptionRepo repo.ReceptionRepo
-	pvzRepo       repo.PVZRepo
-	logger        Logger
+	receptionRepo  repo.ReceptionRepo
+	pvzRepo        repo.PVZRepo
+	assignmentRepo repo.PVZAssignmentRepo
+	txManager      repo.TxManager
+	timeService    TimeService
+	authorizer     Authorizer
+	logger         Logger
 }
 
 // Execute закрывает приёмку
-func (uc *CloseReception) Execute(ctx context.Context, PVZID uuid.UUID, userRole string) (*onlymodels.Reception, error) {
-	pvzID, role, err := uc.validateInput(PVZID, userRole)
+func (uc *CloseReception) Execute(ctx context.Context, PVZID uuid.UUID, userID, userRole string) (*onlymodels.Reception, error) {
+	pvzID, userUUID, role, err := uc.validateInput(PVZID, userID, userRole)
 	if err != nil {
 		uc.logger.Error("validation failed",
 			"usecase", "CloseReception",
@@ -56,85 +83,20 @@ func (uc *CloseReception) Execute(ctx context.Context, PVZID uuid.UUID, userRole
 		return nil, err
 	}
 
-	if model.RoleEmployee != role {
-		uc.logger.Warn("access denied",
-			"usecase", "CloseReception",
-			"method", "Execute",
-			"required_role", model.RoleEmployee,
-			"user_role", role)
-		return nil, errors.New(model.ErrAccessDenied)
-	}
-
-	rec := &model.Reception{PVZID: pvzID, Status: model.ReceptionInProgress}
-	recDao := rec.ToDao()
-
-	exists, err := uc.pvzRepo.CheckIfExists(ctx, recDao.PVZID)
-	if err != nil {
-		uc.logger.Error("failed to check PVZ existence",
-			"usecase", "CloseReception",
-			"method", "pvzRepo.CheckIfExists",
-			"pvz_id", recDao.PVZID,
-			"error", err)
-		return nil, errors.New(model.ErrInternal)
-	}
-
-	if !exists {
-		uc.logger.Warn("invalid PVZ ID",
-			"usecase", "CloseReception",
-			"method", "Execute",
-			"pvz_id", recDao.PVZID)
-		return nil, errors.New(model.ErrInvalidPVZID)
-	}
-
-	recDao.ID = ""
-	err = uc.receptionRepo.FindOpened(ctx, recDao)
-	if err != nil {
-		uc.logger.Error("failed to find opened reception",
-			"usecase", "CloseReception",
-			"method", "receptionRepo.FindOpened",
-			"pvz_id", recDao.PVZID,
-			"error", err)
-		return nil, errors.New(model.ErrInternal)
-	}
-
-	if recDao.ID == "" {
-		uc.logger.Warn("no active reception found",
-			"usecase", "CloseReception",
-			"method", "Execute",
-			"pvz_id", recDao.PVZID)
-		return nil, errors.New(model.ErrNoActiveReception)
-	}
-
-	recDao.Status = model.ReceptionClosed.ToInt()
-
-	err = uc.receptionRepo.Close(ctx, recDao)
-	if err != nil {
-		uc.logger.Error("failed to close reception",
-			"usecase", "CloseReception",
-			"method", "receptionRepo.Close",
-			"reception_id", recDao.ID,
-			"error", err)
-		return nil, errors.New(model.ErrInternal)
-	}
-
-	recDto, err := receptionDaoToDto(recDao)
-	if err != nil {
-		uc.logger.Error("failed to convert reception DAO to DTO",
-			"usecase", "CloseReception",
-			"method", "receptionDaoToDto",
-			"reception_id", recDao.ID,
-			"error", err)
-		return nil, errors.New(model.ErrInternal)
+	if err = checkPermission(uc.authorizer, uc.logger, "CloseReception", "Execute", role, model.PermissionCloseReception); err != nil {
+		return nil, err
 	}
 
-	uc.logger.Info("reception closed successfully",
-		"usecase", "CloseReception",
-		"reception_id", recDao.ID,
-		"pvz_id", recDao.PVZID)
-	return recDto, nil
+	return changeLastReceptionStatus(ctx, uc.receptionRepo, uc.pvzRepo, uc.assignmentRepo, uc.txManager, uc.logger, receptionStatusChange{
+		usecase: "CloseReception",
+		pvzID:   pvzID,
+		userID:  userUUID,
+		target:  model.ReceptionClosed,
+		at:      uc.timeService.GetTime(),
+	})
 }
 
-func (uc *CloseReception) validateInput(PVZID uuid.UUID, userRole string) (pvzID uuid.UUID, role model.Role, err error) {
+func (uc *CloseReception) validateInput(PVZID uuid.UUID, userID, userRole string) (pvzID, userUUID uuid.UUID, role model.Role, err error) {
 	if pvzID, err = validateID(PVZID); err != nil {
 		uc.logger.Warn("invalid PVZ ID format",
 			"usecase", "CloseReception",
@@ -142,6 +104,13 @@ func (uc *CloseReception) validateInput(PVZID uuid.UUID, userRole string) (pvzID
 			"pvz_id", PVZID)
 		return
 	}
+	if userUUID, err = validateRawID(userID); err != nil {
+		uc.logger.Warn("invalid user ID in token",
+			"usecase", "CloseReception",
+			"method", "validateInput",
+			"user_id", userID)
+		return
+	}
 	if role, err = validateRole(userRole); err != nil {
 		uc.logger.Warn("invalid user role",
 			"usecase", "CloseReception",
@@ -19,6 +19,7 @@ func TestCloseReception_Execute(t *testing.T) {
 	validPVZID := uuid.New()
 	validReceptionID := uuid.New()
 	testTime := time.Now()
+	testClosedAt := testTime.Add(time.Hour)
 
 	tests := []struct {
 		name          string
@@ -31,14 +32,15 @@ func TestCloseReception_Execute(t *testing.T) {
 		{
 			name: "Successfully close reception",
 			setupMocks: func(mr *mockReceptionRepo, mz *mockPVZRepo, ml *mockLogger) {
-				mz.On("CheckIfExists", mock.Anything, validPVZID.String()).Return(true, nil)
-				mr.On("FindOpened", mock.Anything, mock.Anything).Run(func(args mock.Arguments) {
+				mz.On("FindByIDForUpdate", mock.Anything, validPVZID.String()).Return(&dao.PVZ{ID: validPVZID.String()}, nil)
+				mr.On("FindLast", mock.Anything, mock.Anything, mock.Anything).Run(func(args mock.Arguments) {
 					rec := args.Get(1).(*dao.Reception)
 					rec.ID = validReceptionID.String()
 					rec.PVZID = validPVZID.String()
 					rec.DateTime = testTime
+					rec.Status = model.ReceptionInProgress.ToInt()
 				}).Return(nil)
-				mr.On("Close", mock.Anything, mock.Anything).Return(nil)
+				mr.On("ChangeStatus", mock.Anything, mock.Anything, model.ReceptionInProgress.ToInt()).Return(nil)
 				ml.On("Info", mock.Anything, mock.Anything)
 			},
 			pvzID:    validPVZID,
@@ -83,7 +85,7 @@ func TestCloseReception_Execute(t *testing.T) {
 		{
 			name: "PVZ not found",
 			setupMocks: func(_ *mockReceptionRepo, mz *mockPVZRepo, ml *mockLogger) {
-				mz.On("CheckIfExists", mock.Anything, validPVZID.String()).Return(false, nil)
+				mz.On("FindByIDForUpdate", mock.Anything, validPVZID.String()).Return(nil, nil)
 				ml.On("Warn", mock.Anything, mock.Anything)
 				ml.On("Error", mock.Anything, mock.Anything)
 			},
@@ -91,10 +93,20 @@ func TestCloseReception_Execute(t *testing.T) {
 			userRole:      model.RoleEmployee.Get(),
 			expectedError: model.ErrInvalidPVZID,
 		},
+		{
+			name: "PVZ not assigned to user",
+			setupMocks: func(_ *mockReceptionRepo, mz *mockPVZRepo, ml *mockLogger) {
+				mz.On("FindByIDForUpdate", mock.Anything, testForeignPVZID.String()).Return(&dao.PVZ{ID: testForeignPVZID.String()}, nil)
+				ml.On("Warn", mock.Anything, mock.Anything)
+			},
+			pvzID:         testForeignPVZID,
+			userRole:      model.RoleEmployee.Get(),
+			expectedError: model.ErrAccessDenied,
+		},
 		{
 			name: "Error checking PVZ existence",
 			setupMocks: func(_ *mockReceptionRepo, mz *mockPVZRepo, ml *mockLogger) {
-				mz.On("CheckIfExists", mock.Anything, validPVZID.String()).Return(false, errors.New("db error"))
+				mz.On("FindByIDForUpdate", mock.Anything, validPVZID.String()).Return(nil, errors.New("db error"))
 				ml.On("Error", mock.Anything, mock.Anything)
 			},
 			pvzID:         validPVZID,
@@ -104,8 +116,8 @@ func TestCloseReception_Execute(t *testing.T) {
 		{
 			name: "No active reception found",
 			setupMocks: func(mr *mockReceptionRepo, mz *mockPVZRepo, ml *mockLogger) {
-				mz.On("CheckIfExists", mock.Anything, validPVZID.String()).Return(true, nil)
-				mr.On("FindOpened", mock.Anything, mock.Anything).Return(nil)
+				mz.On("FindByIDForUpdate", mock.Anything, validPVZID.String()).Return(&dao.PVZ{ID: validPVZID.String()}, nil)
+				mr.On("FindLast", mock.Anything, mock.Anything, mock.Anything).Return(nil)
 				ml.On("Warn", mock.Anything, mock.Anything)
 			},
 			pvzID:         validPVZID,
@@ -115,8 +127,8 @@ func TestCloseReception_Execute(t *testing.T) {
 		{
 			name: "Error finding opened reception",
 			setupMocks: func(mr *mockReceptionRepo, mz *mockPVZRepo, ml *mockLogger) {
-				mz.On("CheckIfExists", mock.Anything, validPVZID.String()).Return(true, nil)
-				mr.On("FindOpened", mock.Anything, mock.Anything).Return(errors.New("db error"))
+				mz.On("FindByIDForUpdate", mock.Anything, validPVZID.String()).Return(&dao.PVZ{ID: validPVZID.String()}, nil)
+				mr.On("FindLast", mock.Anything, mock.Anything, mock.Anything).Return(errors.New("db error"))
 				ml.On("Error", mock.Anything, mock.Anything)
 			},
 			pvzID:         validPVZID,
@@ -126,32 +138,64 @@ func TestCloseReception_Execute(t *testing.T) {
 		{
 			name: "Error closing reception",
 			setupMocks: func(mr *mockReceptionRepo, mz *mockPVZRepo, ml *mockLogger) {
-				mz.On("CheckIfExists", mock.Anything, validPVZID.String()).Return(true, nil)
-				mr.On("FindOpened", mock.Anything, mock.Anything).Run(func(args mock.Arguments) {
+				mz.On("FindByIDForUpdate", mock.Anything, validPVZID.String()).Return(&dao.PVZ{ID: validPVZID.String()}, nil)
+				mr.On("FindLast", mock.Anything, mock.Anything, mock.Anything).Run(func(args mock.Arguments) {
 					rec := args.Get(1).(*dao.Reception)
 					rec.ID = validReceptionID.String()
 				}).Return(nil)
-				mr.On("Close", mock.Anything, mock.Anything).Return(errors.New("db error"))
+				mr.On("ChangeStatus", mock.Anything, mock.Anything, mock.Anything).Return(errors.New("db error"))
 				ml.On("Error", mock.Anything, mock.Anything)
 			},
 			pvzID:         validPVZID,
 			userRole:      model.RoleEmployee.Get(),
 			expectedError: model.ErrInternal,
 		},
+		{
+			name: "Paused reception cannot be closed",
+			setupMocks: func(mr *mockReceptionRepo, mz *mockPVZRepo, ml *mockLogger) {
+				mz.On("FindByIDForUpdate", mock.Anything, validPVZID.String()).Return(&dao.PVZ{ID: validPVZID.String()}, nil)
+				mr.On("FindLast", mock.Anything, mock.Anything, mock.Anything).Run(func(args mock.Arguments) {
+					rec := args.Get(1).(*dao.Reception)
+					rec.ID = validReceptionID.String()
+					rec.Status = model.ReceptionPaused.ToInt()
+				}).Return(nil)
+				ml.On("Warn", mock.Anything, mock.Anything)
+			},
+			pvzID:         validPVZID,
+			userRole:      model.RoleEmployee.Get(),
+			expectedError: model.ErrInvalidReceptionTransition,
+		},
+		{
+			name: "Reception status changed concurrently",
+			setupMocks: func(mr *mockReceptionRepo, mz *mockPVZRepo, ml *mockLogger) {
+				mz.On("FindByIDForUpdate", mock.Anything, validPVZID.String()).Return(&dao.PVZ{ID: validPVZID.String()}, nil)
+				mr.On("FindLast", mock.Anything, mock.Anything, mock.Anything).Run(func(args mock.Arguments) {
+					rec := args.Get(1).(*dao.Reception)
+					rec.ID = validReceptionID.String()
+				}).Return(nil)
+				mr.On("ChangeStatus", mock.Anything, mock.Anything, mock.Anything).Return(errors.New(model.ErrInvalidReceptionTransition))
+				ml.On("Warn", mock.Anything, mock.Anything)
+			},
+			pvzID:         validPVZID,
+			userRole:      model.RoleEmployee.Get(),
+			expectedError: model.ErrInvalidReceptionTransition,
+		},
 	}
 
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
 			mr := &mockReceptionRepo{}
 			mz := &mockPVZRepo{}
+			mt := &mockTimeService{}
 			ml := &mockLogger{}
 
+			mt.On("GetTime").Return(testClosedAt).Maybe()
 			if tt.setupMocks != nil {
 				tt.setupMocks(mr, mz, ml)
 			}
 
-			uc := NewUseCaseCloseReception(mr, mz, ml)
-			result, err := uc.Execute(context.Background(), tt.pvzID, tt.userRole)
+			uc := NewUseCaseCloseReception(mr, mz, newTestAssignmentRepo(), newTestTxManager(), mt, newTestAuthorizer(), ml)
+			result, err := uc.Execute(context.Background(), tt.pvzID, testUserID, tt.userRole)
 
 			if tt.expectedError != "" {
 				assert.Error(t, err)
@@ -163,6 +207,8 @@ func TestCloseReception_Execute(t *testing.T) {
 			assert.Equal(t, tt.expected.Id, result.Id)
 			assert.Equal(t, tt.expected.PvzId, result.PvzId)
 			assert.Equal(t, tt.expected.Status, result.Status)
+			assert.Equal(t, testUserID, result.ClosedBy.String())
+			assert.Equal(t, testClosedAt, *result.ClosedAt)
 		})
 	}
 }
@@ -174,12 +220,14 @@ func TestCloseReception_validateInput(t *testing.T) {
 		name          string
 		setupMocks    func(*mockLogger)
 		pvzID         uuid.UUID
+		userID        string
 		userRole      string
 		expectedError string
 	}{
 		{
 			name:     "Valid input",
 			pvzID:    validPVZID,
+			userID:   testUserID,
 			userRole: model.RoleEmployee.Get(),
 		},
 		{
@@ -188,6 +236,7 @@ func TestCloseReception_validateInput(t *testing.T) {
 				ml.On("Warn", mock.Anything, mock.Anything)
 			},
 			pvzID:         uuid.Nil,
+			userID:        testUserID,
 			userRole:      model.RoleEmployee.Get(),
 			expectedError: model.ErrAccessDenied,
 		},
@@ -197,9 +246,20 @@ func TestCloseReception_validateInput(t *testing.T) {
 				ml.On("Warn", mock.Anything, mock.Anything)
 			},
 			pvzID:         validPVZID,
+			userID:        testUserID,
 			userRole:      "invalid-role",
 			expectedError: model.ErrInvalidRole,
 		},
+		{
+			name: "Invalid user ID",
+			setupMocks: func(ml *mockLogger) {
+				ml.On("Warn", mock.Anything, mock.Anything)
+			},
+			pvzID:         validPVZID,
+			userID:        "not-a-uuid",
+			userRole:      model.RoleEmployee.Get(),
+			expectedError: model.ErrAccessDenied,
+		},
 	}
 
 	for _, tt := range tests {
@@ -212,7 +272,7 @@ func TestCloseReception_validateInput(t *testing.T) {
 			}
 			uc := &CloseReception{logger: ml}
 
-			_, _, err := uc.validateInput(tt.pvzID, tt.userRole)
+			_, _, _, err := uc.validateInput(tt.pvzID, tt.userID, tt.userRole)
 
 			if tt.expectedError != "" {
 				assert.Error(t, err)
@@ -224,3 +284,29 @@ func TestCloseReception_validateInput(t *testing.T) {
 		})
 	}
 }
+
+func TestCloseReception_Transaction(t *testing.T) {
+	validPVZID := uuid.New()
+	mr := &mockReceptionRepo{}
+	mz := &mockPVZRepo{}
+	mt := &mockTimeService{}
+	ml := &mockLogger{}
+	mz.On("FindByIDForUpdate", mock.MatchedBy(inTx), validPVZID.String()).Return(&dao.PVZ{ID: validPVZID.String()}, nil)
+	mr.On("FindLast", mock.MatchedBy(inTx), mock.Anything, mock.Anything).Run(func(args mock.Arguments) {
+		rec := args.Get(1).(*dao.Reception)
+		rec.ID = uuid.NewString()
+		rec.Status = model.ReceptionInProgress.ToInt()
+	}).Return(nil)
+	mr.On("ChangeStatus", mock.MatchedBy(inTx), mock.Anything, model.ReceptionInProgress.ToInt()).Return(nil)
+	mt.On("GetTime").Return(time.Now())
+	ml.On("Error", mock.Anything, mock.Anything).Once()
+	tx := &mockTxManager{commitErr: errors.New("commit failed")}
+
+	uc := NewUseCaseCloseReception(mr, mz, newTestAssignmentRepo(), tx, mt, newTestAuthorizer(), ml)
+	_, err := uc.Execute(context.Background(), validPVZID, testUserID, model.RoleEmployee.Get())
+
+	assert.EqualError(t, err, model.ErrInternal)
+	mz.AssertExpectations(t)
+	mr.AssertExpectations(t)
+	ml.AssertExpectations(t)
+}
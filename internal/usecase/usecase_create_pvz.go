@@ -13,16 +13,26 @@ import (
 // NewUseCaseCreatePVZ конструктор
 func NewUseCaseCreatePVZ(
 	pvzRepo repo.PVZRepo,
+	cityRepo repo.CityRepo,
 	timeService TimeService,
+	authorizer Authorizer,
 	logger Logger,
 ) *CreatePVZ {
 	if pvzRepo == nil {
 		log.Fatalf("CreatePVZ usecase pvzRepo nil")
 
+	}
+	if cityRepo == nil {
+		log.Fatalf("CreatePVZ usecase cityRepo nil")
+
 	}
 	if timeService == nil {
 		log.Fatalf("CreatePVZ usecase timeService nil")
 
+	}
+	if authorizer == nil {
+		log.Fatalf("CreatePVZ usecase authorizer nil")
+
 	}
 	if logger == nil {
 		log.Fatalf("CreatePVZ usecase logger nil")
@@ -31,7 +41,9 @@ func NewUseCaseCreatePVZ(
 
 	return &CreatePVZ{
 		pvzRepo:     pvzRepo,
+		cityRepo:    cityRepo,
 		timeService: timeService,
+		authorizer:  authorizer,
 		logger:      logger,
 	}
 }
@@ -39,13 +51,15 @@ func NewUseCaseCreatePVZ(
 // CreatePVZ юзкейс
 type CreatePVZ struct {
 	pvzRepo     repo.PVZRepo
+	cityRepo    repo.CityRepo
 	timeService TimeService
+	authorizer  Authorizer
 	logger      Logger
 }
 
 // Execute создаёт пвз
 func (uc *CreatePVZ) Execute(ctx context.Context, request *onlymodels.PVZ, userRole string) (*onlymodels.PVZ, error) {
-	pvz, role, err := uc.validateInput(request, userRole)
+	cityName, role, err := uc.validateInput(request, userRole)
 	if err != nil {
 		uc.logger.Error("validation failed",
 			"usecase", "CreatePVZ",
@@ -55,15 +69,21 @@ func (uc *CreatePVZ) Execute(ctx context.Context, request *onlymodels.PVZ, userR
 		return nil, err
 	}
 
-	if model.RoleModerator != role {
-		uc.logger.Warn("access denied",
-			"usecase", "CreatePVZ",
-			"method", "Execute",
-			"required_role", model.RoleModerator,
-			"user_role", role)
-		return nil, errors.New(model.ErrAccessDenied)
+	if err = checkPermission(uc.authorizer, uc.logger, "CreatePVZ", "Execute", role, model.PermissionCreatePVZ); err != nil {
+		return nil, err
 	}
 
+	pvz, err := uc.validateProfile(request)
+	if err != nil {
+		return nil, err
+	}
+
+	city, err := uc.validateCity(ctx, cityName)
+	if err != nil {
+		return nil, err
+	}
+
+	pvz.City = *city
 	pvzDao := pvz.ToDao()
 	pvzDao.RegistrationDate = uc.timeService.GetTime()
 
@@ -77,7 +97,7 @@ func (uc *CreatePVZ) Execute(ctx context.Context, request *onlymodels.PVZ, userR
 		return nil, errors.New(model.ErrInternal)
 	}
 
-	pvzDto, err := pvzDaoToDto(pvzDao)
+	pvzDto, err := pvzDaoToDto(pvzDao, cityCatalog{city.ID: city.Name})
 	if err != nil {
 		uc.logger.Error("failed to convert PVZ DAO to DTO",
 			"usecase", "CreatePVZ",
@@ -93,7 +113,7 @@ func (uc *CreatePVZ) Execute(ctx context.Context, request *onlymodels.PVZ, userR
 	return pvzDto, nil
 }
 
-func (uc *CreatePVZ) validateInput(request *onlymodels.PVZ, userRole string) (pvz *model.PVZ, role model.Role, err error) {
+func (uc *CreatePVZ) validateInput(request *onlymodels.PVZ, userRole string) (cityName string, role model.Role, err error) {
 	if role, err = validateRole(userRole); err != nil {
 		uc.logger.Warn("invalid user role",
 			"usecase", "CreatePVZ",
@@ -102,27 +122,74 @@ func (uc *CreatePVZ) validateInput(request *onlymodels.PVZ, userRole string) (pv
 		return
 	}
 
-	pvz = &model.PVZ{}
-
-	if pvz.City, err = uc.validateCity(string(request.City)); err != nil {
+	var ok bool
+	if cityName, ok = normalizeCityName(request.City); !ok {
 		uc.logger.Warn("invalid city",
 			"usecase", "CreatePVZ",
-			"method", "validateCity",
+			"method", "validateInput",
 			"city", request.City)
+		err = errors.New(model.ErrInvalidCityName)
 		return
 	}
 	return
 }
 
-func (uc *CreatePVZ) validateCity(city string) (model.City, error) {
-	switch city {
-	case model.CityMoscow.Get():
-		return model.CityMoscow, nil
-	case model.CitySPB.Get():
-		return model.CitySPB, nil
-	case model.CityKazan.Get():
-		return model.CityKazan, nil
-	default:
-		return model.CityDefault, errors.New(model.ErrInvalidCityName)
+// validateProfile проверяет необязательные поля профиля ПВЗ
+func (uc *CreatePVZ) validateProfile(request *onlymodels.PVZ) (pvz *model.PVZ, err error) {
+	pvz = &model.PVZ{Latitude: request.Latitude, Longitude: request.Longitude}
+	if request.Name != nil {
+		if pvz.Name, err = normalizePVZName(*request.Name); err != nil {
+			uc.logger.Warn("invalid PVZ name",
+				"usecase", "CreatePVZ",
+				"method", "validateProfile",
+				"name", *request.Name)
+			return nil, err
+		}
+	}
+	if request.Address != nil {
+		if pvz.Address, err = normalizePVZAddress(*request.Address); err != nil {
+			uc.logger.Warn("invalid PVZ address",
+				"usecase", "CreatePVZ",
+				"method", "validateProfile",
+				"address", *request.Address)
+			return nil, err
+		}
+	}
+	if err = validateCoordinates(pvz.Latitude, pvz.Longitude); err != nil {
+		uc.logger.Warn("invalid PVZ coordinates",
+			"usecase", "CreatePVZ",
+			"method", "validateProfile",
+			"latitude", pvz.Latitude,
+			"longitude", pvz.Longitude)
+		return nil, err
+	}
+	if pvz.WorkingHours, err = workingHoursDtoToModel(request.WorkingHours); err != nil {
+		uc.logger.Warn("invalid PVZ working hours",
+			"usecase", "CreatePVZ",
+			"method", "validateProfile",
+			"working_hours", request.WorkingHours)
+		return nil, err
+	}
+	return pvz, nil
+}
+
+// validateCity ищет город в справочнике городов
+func (uc *CreatePVZ) validateCity(ctx context.Context, cityName string) (*model.City, error) {
+	city, err := uc.cityRepo.FindByName(ctx, cityName)
+	if err != nil {
+		uc.logger.Error("failed to find city",
+			"usecase", "CreatePVZ",
+			"method", "cityRepo.FindByName",
+			"city", cityName,
+			"error", err)
+		return nil, errors.New(model.ErrInternal)
+	}
+	if city == nil {
+		uc.logger.Warn("city is missing in catalog",
+			"usecase", "CreatePVZ",
+			"method", "validateCity",
+			"city", cityName)
+		return nil, errors.New(model.ErrInvalidCityName)
 	}
+	return &model.City{ID: city.ID, Name: city.Name}, nil
 }
@@ -18,10 +18,11 @@ import (
 func TestCreatePVZ_Execute(t *testing.T) {
 	validPVZID := uuid.New()
 	testTime := time.Now()
+	testLatitude := 55.75
 
 	tests := []struct {
 		name          string
-		setupMocks    func(*mockPVZRepo, *mockTimeService, *mockLogger)
+		setupMocks    func(*mockPVZRepo, *mockCityRepo, *mockTimeService, *mockLogger)
 		request       *onlymodels.PVZ
 		userRole      string
 		expected      *onlymodels.PVZ
@@ -29,7 +30,8 @@ func TestCreatePVZ_Execute(t *testing.T) {
 	}{
 		{
 			name: "Success - create PVZ with moderator role",
-			setupMocks: func(mz *mockPVZRepo, mt *mockTimeService, ml *mockLogger) {
+			setupMocks: func(mz *mockPVZRepo, mc *mockCityRepo, mt *mockTimeService, ml *mockLogger) {
+				mc.On("FindByName", mock.Anything, "Москва").Return(testCities()[testCityMoscowID], nil)
 				mt.On("GetTime").Return(testTime)
 				mz.On("Create", mock.Anything, mock.Anything).Run(func(args mock.Arguments) {
 					pvz := args.Get(1).(*dao.PVZ)
@@ -38,43 +40,55 @@ func TestCreatePVZ_Execute(t *testing.T) {
 				ml.On("Info", mock.Anything, mock.Anything)
 			},
 			request: &onlymodels.PVZ{
-				City: onlymodels.PVZCity(model.CityMoscow.Get()),
+				City: "Москва",
 			},
 			userRole: model.RoleModerator.Get(),
 			expected: &onlymodels.PVZ{
 				Id:               &validPVZID,
-				City:             onlymodels.PVZCity(model.CityMoscow.Get()),
+				City:             "Москва",
 				RegistrationDate: &testTime,
 			},
 		},
 		{
 			name: "Invalid user role",
-			setupMocks: func(_ *mockPVZRepo, _ *mockTimeService, ml *mockLogger) {
+			setupMocks: func(_ *mockPVZRepo, _ *mockCityRepo, _ *mockTimeService, ml *mockLogger) {
 				ml.On("Warn", mock.Anything, mock.Anything)
 				ml.On("Error", mock.Anything, mock.Anything)
 			},
 			request: &onlymodels.PVZ{
-				City: onlymodels.PVZCity(model.CityMoscow.Get()),
+				City: "Москва",
 			},
 			userRole:      "invalid-role",
 			expectedError: model.ErrInvalidRole,
 		},
 		{
 			name: "Non-moderator role",
-			setupMocks: func(_ *mockPVZRepo, _ *mockTimeService, ml *mockLogger) {
+			setupMocks: func(_ *mockPVZRepo, _ *mockCityRepo, _ *mockTimeService, ml *mockLogger) {
 				ml.On("Warn", mock.Anything, mock.Anything)
 			},
 			request: &onlymodels.PVZ{
-				City: onlymodels.PVZCity(model.CityMoscow.Get()),
+				City: "Москва",
 			},
 			userRole:      model.RoleEmployee.Get(),
 			expectedError: model.ErrAccessDenied,
 		},
 		{
-			name: "Invalid city",
-			setupMocks: func(_ *mockPVZRepo, _ *mockTimeService, ml *mockLogger) {
+			name: "Latitude without longitude",
+			setupMocks: func(_ *mockPVZRepo, _ *mockCityRepo, _ *mockTimeService, ml *mockLogger) {
+				ml.On("Warn", mock.Anything, mock.Anything)
+			},
+			request: &onlymodels.PVZ{
+				City:     "Москва",
+				Latitude: &testLatitude,
+			},
+			userRole:      model.RoleModerator.Get(),
+			expectedError: model.ErrInvalidCoordinates,
+		},
+		{
+			name: "City missing in catalog",
+			setupMocks: func(_ *mockPVZRepo, mc *mockCityRepo, _ *mockTimeService, ml *mockLogger) {
+				mc.On("FindByName", mock.Anything, "invalid-city").Return(nil, nil)
 				ml.On("Warn", mock.Anything, mock.Anything)
-				ml.On("Error", mock.Anything, mock.Anything)
 			},
 			request: &onlymodels.PVZ{
 				City: "invalid-city",
@@ -84,13 +98,14 @@ func TestCreatePVZ_Execute(t *testing.T) {
 		},
 		{
 			name: "Database error",
-			setupMocks: func(mz *mockPVZRepo, mt *mockTimeService, ml *mockLogger) {
+			setupMocks: func(mz *mockPVZRepo, mc *mockCityRepo, mt *mockTimeService, ml *mockLogger) {
+				mc.On("FindByName", mock.Anything, "Москва").Return(testCities()[testCityMoscowID], nil)
 				mt.On("GetTime").Return(testTime)
 				mz.On("Create", mock.Anything, mock.Anything).Return(errors.New("db error"))
 				ml.On("Error", mock.Anything, mock.Anything)
 			},
 			request: &onlymodels.PVZ{
-				City: onlymodels.PVZCity(model.CityMoscow.Get()),
+				City: "Москва",
 			},
 			userRole:      model.RoleModerator.Get(),
 			expectedError: model.ErrInternal,
@@ -100,14 +115,15 @@ func TestCreatePVZ_Execute(t *testing.T) {
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
 			mz := &mockPVZRepo{}
+			mc := &mockCityRepo{}
 			mt := &mockTimeService{}
 			ml := &mockLogger{}
 
 			if tt.setupMocks != nil {
-				tt.setupMocks(mz, mt, ml)
+				tt.setupMocks(mz, mc, mt, ml)
 			}
 
-			uc := NewUseCaseCreatePVZ(mz, mt, ml)
+			uc := NewUseCaseCreatePVZ(mz, mc, mt, newTestAuthorizer(), ml)
 			result, err := uc.Execute(context.Background(), tt.request, tt.userRole)
 
 			if tt.expectedError != "" {
@@ -130,26 +146,26 @@ func TestCreatePVZ_validateInput(t *testing.T) {
 		setupMocks    func(*mockLogger)
 		request       *onlymodels.PVZ
 		userRole      string
-		expectedCity  model.City
+		expectedCity  string
 		expectedRole  model.Role
 		expectedError string
 	}{
 		{
 			name: "Valid input - Moscow",
 			request: &onlymodels.PVZ{
-				City: onlymodels.PVZCity(model.CityMoscow.Get()),
+				City: "Москва",
 			},
 			userRole:     model.RoleModerator.Get(),
-			expectedCity: model.CityMoscow,
+			expectedCity: "Москва",
 			expectedRole: model.RoleModerator,
 		},
 		{
-			name: "Valid input - SPB",
+			name: "Valid input - city name is trimmed",
 			request: &onlymodels.PVZ{
-				City: onlymodels.PVZCity(model.CitySPB.Get()),
+				City: " Санкт-Петербург ",
 			},
 			userRole:     model.RoleModerator.Get(),
-			expectedCity: model.CitySPB,
+			expectedCity: "Санкт-Петербург",
 			expectedRole: model.RoleModerator,
 		},
 		{
@@ -159,18 +175,18 @@ func TestCreatePVZ_validateInput(t *testing.T) {
 				ml.On("Warn", mock.Anything, mock.Anything)
 			},
 			request: &onlymodels.PVZ{
-				City: onlymodels.PVZCity(model.CityMoscow.Get()),
+				City: "Москва",
 			},
 			userRole:      "invalid-role",
 			expectedError: model.ErrInvalidRole,
 		},
 		{
-			name: "Invalid city",
+			name: "Empty city",
 			setupMocks: func(ml *mockLogger) {
 				ml.On("Warn", mock.Anything, mock.Anything)
 			},
 			request: &onlymodels.PVZ{
-				City: "invalid-city",
+				City: "  ",
 			},
 			userRole:      model.RoleModerator.Get(),
 			expectedError: model.ErrInvalidCityName,
@@ -186,7 +202,7 @@ func TestCreatePVZ_validateInput(t *testing.T) {
 			}
 			uc := &CreatePVZ{logger: ml}
 
-			pvz, role, err := uc.validateInput(tt.request, tt.userRole)
+			city, role, err := uc.validateInput(tt.request, tt.userRole)
 
 			if tt.expectedError != "" {
 				assert.Error(t, err)
@@ -195,7 +211,7 @@ func TestCreatePVZ_validateInput(t *testing.T) {
 			}
 
 			assert.NoError(t, err)
-			assert.Equal(t, tt.expectedCity, pvz.City)
+			assert.Equal(t, tt.expectedCity, city)
 			assert.Equal(t, tt.expectedRole, role)
 		})
 	}
@@ -204,38 +220,58 @@ func TestCreatePVZ_validateInput(t *testing.T) {
 func TestCreatePVZ_validateCity(t *testing.T) {
 	tests := []struct {
 		name          string
+		setupMocks    func(*mockCityRepo, *mockLogger)
 		city          string
-		expected      model.City
+		expected      *model.City
 		expectedError string
 	}{
 		{
-			name:     "Valid city - Moscow",
-			city:     model.CityMoscow.Get(),
-			expected: model.CityMoscow,
-		},
-		{
-			name:     "Valid city - SPB",
-			city:     model.CitySPB.Get(),
-			expected: model.CitySPB,
+			name: "Valid city - Moscow",
+			setupMocks: func(mc *mockCityRepo, _ *mockLogger) {
+				mc.On("FindByName", mock.Anything, "Москва").Return(testCities()[testCityMoscowID], nil)
+			},
+			city:     "Москва",
+			expected: &model.City{ID: testCityMoscowID, Name: "Москва"},
 		},
 		{
-			name:     "Valid city - Kazan",
-			city:     model.CityKazan.Get(),
-			expected: model.CityKazan,
+			name: "Valid city - Kazan",
+			setupMocks: func(mc *mockCityRepo, _ *mockLogger) {
+				mc.On("FindByName", mock.Anything, "Казань").Return(testCities()[testCityKazanID], nil)
+			},
+			city:     "Казань",
+			expected: &model.City{ID: testCityKazanID, Name: "Казань"},
 		},
 		{
-			name:          "Invalid city",
+			name: "City missing in catalog",
+			setupMocks: func(mc *mockCityRepo, ml *mockLogger) {
+				mc.On("FindByName", mock.Anything, "invalid-city").Return(nil, nil)
+				ml.On("Warn", mock.Anything, mock.Anything)
+			},
 			city:          "invalid-city",
 			expectedError: model.ErrInvalidCityName,
 		},
+		{
+			name: "Database error",
+			setupMocks: func(mc *mockCityRepo, ml *mockLogger) {
+				mc.On("FindByName", mock.Anything, "Москва").Return(nil, errors.New("db error"))
+				ml.On("Error", mock.Anything, mock.Anything)
+			},
+			city:          "Москва",
+			expectedError: model.ErrInternal,
+		},
 	}
 
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
+			mc := &mockCityRepo{}
 			ml := &mockLogger{}
-			uc := &CreatePVZ{logger: ml}
 
-			result, err := uc.validateCity(tt.city)
+			if tt.setupMocks != nil {
+				tt.setupMocks(mc, ml)
+			}
+			uc := &CreatePVZ{cityRepo: mc, logger: ml}
+
+			result, err := uc.validateCity(context.Background(), tt.city)
 
 			if tt.expectedError != "" {
 				assert.Error(t, err)
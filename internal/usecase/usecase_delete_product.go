@@ -15,6 +15,9 @@ func NewUseCaseDeleteProduct(
 	productRepo repo.ProductRepo,
 	receptionRepo repo.ReceptionRepo,
 	pvzRepo repo.PVZRepo,
+	assignmentRepo repo.PVZAssignmentRepo,
+	txManager repo.TxManager,
+	authorizer Authorizer,
 	logger Logger,
 ) *DeleteProduct {
 	if productRepo == nil {
@@ -28,6 +31,18 @@ func NewUseCaseDeleteProduct(
 	if pvzRepo == nil {
 		log.Fatalf("DeleteProduct usecase pvzRepo nil")
 
+	}
+	if assignmentRepo == nil {
+		log.Fatalf("DeleteProduct usecase assignmentRepo nil")
+
+	}
+	if txManager == nil {
+		log.Fatalf("DeleteProduct usecase txManager nil")
+
+	}
+	if authorizer == nil {
+		log.Fatalf("DeleteProduct usecase authorizer nil")
+
 	}
 	if logger == nil {
 		log.Fatalf("DeleteProduct usecase logger nil")
@@ -35,24 +50,30 @@ func NewUseCaseDeleteProduct(
 	}
 
 	return &DeleteProduct{
-		productRepo:   productRepo,
-		receptionRepo: receptionRepo,
-		pvzRepo:       pvzRepo,
-		logger:        logger,
+		productRepo:    productRepo,
+		receptionRepo:  receptionRepo,
+		pvzRepo:        pvzRepo,
+		assignmentRepo: assignmentRepo,
+		txManager:      txManager,
+		authorizer:     authorizer,
+		logger:         logger,
 	}
 }
 
 // DeleteProduct юзкейс
 type DeleteProduct struct {
-	productRepo   repo.ProductRepo
-	receptionRepo repo.ReceptionRepo
-	pvzRepo       repo.PVZRepo
-	logger        Logger
+	productRepo    repo.ProductRepo
+	receptionRepo  repo.ReceptionRepo
+	pvzRepo        repo.PVZRepo
+	assignmentRepo repo.PVZAssignmentRepo
+	txManager      repo.TxManager
+	authorizer     Authorizer
+	logger         Logger
 }
 
 // Execute удаляет продукт
-func (uc *DeleteProduct) Execute(ctx context.Context, PVZID uuid.UUID, userRole string) error {
-	pvzID, role, err := uc.validateInput(PVZID, userRole)
+func (uc *DeleteProduct) Execute(ctx context.Context, PVZID uuid.UUID, userID, userRole string) error {
+	pvzID, userUUID, role, err := uc.validateInput(PVZID, userID, userRole)
 	if err != nil {
 		uc.logger.Error("validation failed",
 			"usecase", "DeleteProduct",
@@ -62,101 +83,100 @@ func (uc *DeleteProduct) Execute(ctx context.Context, PVZID uuid.UUID, userRole
 		return err
 	}
 
-	if model.RoleEmployee != role {
-		uc.logger.Warn("access denied",
-			"usecase", "DeleteProduct",
-			"method", "Execute",
-			"required_role", model.RoleEmployee,
-			"user_role", role)
-		return errors.New(model.ErrAccessDenied)
+	if err = checkPermission(uc.authorizer, uc.logger, "DeleteProduct", "Execute", role, model.PermissionDeleteProduct); err != nil {
+		return err
 	}
 
 	rec := &model.Reception{PVZID: pvzID, Status: model.ReceptionInProgress}
 	recDao := rec.ToDao()
 
-	exists, err := uc.pvzRepo.CheckIfExists(ctx, recDao.PVZID)
+	err = runInTx(ctx, uc.txManager, uc.logger, "DeleteProduct", func(ctx context.Context) error {
+		if err := checkPVZActive(ctx, uc.pvzRepo, uc.logger, "DeleteProduct", recDao.PVZID); err != nil {
+			return err
+		}
+
+		if err := checkPVZAssignment(ctx, uc.assignmentRepo, uc.logger, "DeleteProduct", userUUID.String(), recDao.PVZID); err != nil {
+			return err
+		}
+
+		recDao.ID = ""
+		err := uc.receptionRepo.FindOpened(ctx, recDao)
+		if err != nil {
+			uc.logger.Error("failed to find opened reception",
+				"usecase", "DeleteProduct",
+				"method", "receptionRepo.FindOpened",
+				"pvz_id", recDao.PVZID,
+				"error", err)
+			return errors.New(model.ErrInternal)
+		}
+
+		if recDao.ID == "" {
+			uc.logger.Warn("no active reception found",
+				"usecase", "DeleteProduct",
+				"method", "Execute",
+				"pvz_id", recDao.PVZID)
+			return errors.New(model.ErrNoActiveReception)
+		}
+
+		_, err = validateRawID(recDao.ID)
+		if err != nil {
+			uc.logger.Error("invalid reception ID format",
+				"usecase", "DeleteProduct",
+				"method", "validateRawID",
+				"reception_id", recDao.ID,
+				"error", err)
+			return errors.New(model.ErrInternal)
+		}
+
+		numOfProducts, err := uc.productRepo.CountProducts(ctx, recDao.ID)
+		if err != nil {
+			uc.logger.Error("failed to count products",
+				"usecase", "DeleteProduct",
+				"method", "productRepo.CountProducts",
+				"reception_id", recDao.ID,
+				"error", err)
+			return errors.New(model.ErrInternal)
+		}
+
+		if numOfProducts == 0 {
+			uc.logger.Warn("no products left to delete",
+				"usecase", "DeleteProduct",
+				"method", "Execute",
+				"reception_id", recDao.ID)
+			return errors.New(model.ErrNoProductsLeftToDelete)
+		}
+
+		err = uc.productRepo.DeleteLastFromReception(ctx, recDao.ID)
+		if err != nil {
+			if err.Error() == model.ErrNoActiveReception {
+				uc.logger.Warn("reception is no longer in progress",
+					"usecase", "DeleteProduct",
+					"method", "productRepo.DeleteLastFromReception",
+					"reception_id", recDao.ID)
+				return err
+			}
+			uc.logger.Error("failed to delete last product from reception",
+				"usecase", "DeleteProduct",
+				"method", "productRepo.DeleteLastFromReception",
+				"reception_id", recDao.ID,
+				"error", err)
+			return errors.New(model.ErrInternal)
+		}
+		return nil
+	})
 	if err != nil {
-		uc.logger.Error("failed to check PVZ existence",
-			"usecase", "DeleteProduct",
-			"method", "pvzRepo.CheckIfExists",
-			"pvz_id", recDao.PVZID,
-			"error", err)
-		return errors.New(model.ErrInternal)
-	}
-
-	if !exists {
-		uc.logger.Warn("invalid PVZ ID",
-			"usecase", "DeleteProduct",
-			"method", "Execute",
-			"pvz_id", recDao.PVZID)
-		return errors.New(model.ErrInvalidPVZID)
-	}
-
-	recDao.ID = ""
-	err = uc.receptionRepo.FindOpened(ctx, recDao)
-	if err != nil {
-		uc.logger.Error("failed to find opened reception",
-			"usecase", "DeleteProduct",
-			"method", "receptionRepo.FindOpened",
-			"pvz_id", recDao.PVZID,
-			"error", err)
-		return errors.New(model.ErrInternal)
-	}
-
-	if recDao.ID == "" {
-		uc.logger.Warn("no active reception found",
-			"usecase", "DeleteProduct",
-			"method", "Execute",
-			"pvz_id", recDao.PVZID)
-		return errors.New(model.ErrNoActiveReception)
-	}
-
-	_, err = validateRawID(recDao.ID)
-	if err != nil {
-		uc.logger.Error("invalid reception ID format",
-			"usecase", "DeleteProduct",
-			"method", "validateRawID",
-			"reception_id", recDao.ID,
-			"error", err)
-		return errors.New(model.ErrInternal)
-	}
-
-	numOfProducts, err := uc.productRepo.CountProducts(ctx, recDao.ID)
-	if err != nil {
-		uc.logger.Error("failed to count products",
-			"usecase", "DeleteProduct",
-			"method", "productRepo.CountProducts",
-			"reception_id", recDao.ID,
-			"error", err)
-		return errors.New(model.ErrInternal)
-	}
-
-	if numOfProducts == 0 {
-		uc.logger.Warn("no products left to delete",
-			"usecase", "DeleteProduct",
-			"method", "Execute",
-			"reception_id", recDao.ID)
-		return errors.New(model.ErrNoProductsLeftToDelete)
-	}
-
-	err = uc.productRepo.DeleteLastFromReception(ctx, recDao.ID)
-	if err != nil {
-		uc.logger.Error("failed to delete last product from reception",
-			"usecase", "DeleteProduct",
-			"method", "productRepo.DeleteLastFromReception",
-			"reception_id", recDao.ID,
-			"error", err)
-		return errors.New(model.ErrInternal)
+		return err
 	}
 
 	uc.logger.Info("product deleted successfully",
 		"usecase", "DeleteProduct",
 		"reception_id", recDao.ID,
-		"pvz_id", recDao.PVZID)
+		"pvz_id", recDao.PVZID,
+		"user_id", userUUID)
 	return nil
 }
 
-func (uc *DeleteProduct) validateInput(PVZID uuid.UUID, userRole string) (pvzID uuid.UUID, role model.Role, err error) {
+func (uc *DeleteProduct) validateInput(PVZID uuid.UUID, userID, userRole string) (pvzID, userUUID uuid.UUID, role model.Role, err error) {
 	if pvzID, err = validateID(PVZID); err != nil {
 		uc.logger.Warn("invalid PVZ ID format",
 			"usecase", "DeleteProduct",
@@ -165,6 +185,14 @@ func (uc *DeleteProduct) validateInput(PVZID uuid.UUID, userRole string) (pvzID
 		return
 	}
 
+	if userUUID, err = validateRawID(userID); err != nil {
+		uc.logger.Warn("invalid user ID in token",
+			"usecase", "DeleteProduct",
+			"method", "validateInput",
+			"user_id", userID)
+		return
+	}
+
 	if role, err = validateRole(userRole); err != nil {
 		uc.logger.Warn("invalid user role",
 			"usecase", "DeleteProduct",
@@ -3,8 +3,10 @@ package usecase
 
 import (
 	"context"
+	"database/sql"
 	"errors"
 	"testing"
+	"time"
 
 	"github.com/google/uuid"
 	"github.com/stretchr/testify/assert"
@@ -27,7 +29,7 @@ func TestDeleteProduct_Execute(t *testing.T) {
 		{
 			name: "Success - delete product",
 			setupMocks: func(mp *mockProductRepo, mr *mockReceptionRepo, mz *mockPVZRepo, ml *mockLogger) {
-				mz.On("CheckIfExists", mock.Anything, validPVZID.String()).Return(true, nil)
+				mz.On("FindByIDForUpdate", mock.Anything, validPVZID.String()).Return(&dao.PVZ{ID: validPVZID.String()}, nil)
 				mr.On("FindOpened", mock.Anything, mock.Anything).Run(func(args mock.Arguments) {
 					rec := args.Get(1).(*dao.Reception)
 					rec.ID = validReceptionID.String()
@@ -71,7 +73,7 @@ func TestDeleteProduct_Execute(t *testing.T) {
 		{
 			name: "PVZ not found",
 			setupMocks: func(_ *mockProductRepo, _ *mockReceptionRepo, mz *mockPVZRepo, ml *mockLogger) {
-				mz.On("CheckIfExists", mock.Anything, validPVZID.String()).Return(false, nil)
+				mz.On("FindByIDForUpdate", mock.Anything, validPVZID.String()).Return(nil, nil)
 				ml.On("Warn", mock.Anything, mock.Anything)
 			},
 			pvzID:         validPVZID,
@@ -79,9 +81,19 @@ func TestDeleteProduct_Execute(t *testing.T) {
 			expectedError: model.ErrInvalidPVZID,
 		},
 		{
-			name: "Error checking PVZ existence",
+			name: "PVZ not assigned to user",
 			setupMocks: func(_ *mockProductRepo, _ *mockReceptionRepo, mz *mockPVZRepo, ml *mockLogger) {
-				mz.On("CheckIfExists", mock.Anything, validPVZID.String()).Return(false, errors.New("db error"))
+				mz.On("FindByIDForUpdate", mock.Anything, testForeignPVZID.String()).Return(&dao.PVZ{ID: testForeignPVZID.String()}, nil)
+				ml.On("Warn", mock.Anything, mock.Anything)
+			},
+			pvzID:         testForeignPVZID,
+			userRole:      model.RoleEmployee.Get(),
+			expectedError: model.ErrAccessDenied,
+		},
+		{
+			name: "Error locking PVZ",
+			setupMocks: func(_ *mockProductRepo, _ *mockReceptionRepo, mz *mockPVZRepo, ml *mockLogger) {
+				mz.On("FindByIDForUpdate", mock.Anything, validPVZID.String()).Return(nil, errors.New("db error"))
 				ml.On("Error", mock.Anything, mock.Anything)
 			},
 			pvzID:         validPVZID,
@@ -91,7 +103,7 @@ func TestDeleteProduct_Execute(t *testing.T) {
 		{
 			name: "No active reception found",
 			setupMocks: func(_ *mockProductRepo, mr *mockReceptionRepo, mz *mockPVZRepo, ml *mockLogger) {
-				mz.On("CheckIfExists", mock.Anything, validPVZID.String()).Return(true, nil)
+				mz.On("FindByIDForUpdate", mock.Anything, validPVZID.String()).Return(&dao.PVZ{ID: validPVZID.String()}, nil)
 				mr.On("FindOpened", mock.Anything, mock.Anything).Return(nil)
 				ml.On("Warn", mock.Anything, mock.Anything)
 			},
@@ -102,7 +114,7 @@ func TestDeleteProduct_Execute(t *testing.T) {
 		{
 			name: "Error finding opened reception",
 			setupMocks: func(_ *mockProductRepo, mr *mockReceptionRepo, mz *mockPVZRepo, ml *mockLogger) {
-				mz.On("CheckIfExists", mock.Anything, validPVZID.String()).Return(true, nil)
+				mz.On("FindByIDForUpdate", mock.Anything, validPVZID.String()).Return(&dao.PVZ{ID: validPVZID.String()}, nil)
 				mr.On("FindOpened", mock.Anything, mock.Anything).Return(errors.New("db error"))
 				ml.On("Error", mock.Anything, mock.Anything)
 			},
@@ -113,7 +125,7 @@ func TestDeleteProduct_Execute(t *testing.T) {
 		{
 			name: "No products left to delete",
 			setupMocks: func(mp *mockProductRepo, mr *mockReceptionRepo, mz *mockPVZRepo, ml *mockLogger) {
-				mz.On("CheckIfExists", mock.Anything, validPVZID.String()).Return(true, nil)
+				mz.On("FindByIDForUpdate", mock.Anything, validPVZID.String()).Return(&dao.PVZ{ID: validPVZID.String()}, nil)
 				mr.On("FindOpened", mock.Anything, mock.Anything).Run(func(args mock.Arguments) {
 					rec := args.Get(1).(*dao.Reception)
 					rec.ID = validReceptionID.String()
@@ -128,7 +140,7 @@ func TestDeleteProduct_Execute(t *testing.T) {
 		{
 			name: "Error counting products",
 			setupMocks: func(mp *mockProductRepo, mr *mockReceptionRepo, mz *mockPVZRepo, ml *mockLogger) {
-				mz.On("CheckIfExists", mock.Anything, validPVZID.String()).Return(true, nil)
+				mz.On("FindByIDForUpdate", mock.Anything, validPVZID.String()).Return(&dao.PVZ{ID: validPVZID.String()}, nil)
 				mr.On("FindOpened", mock.Anything, mock.Anything).Run(func(args mock.Arguments) {
 					rec := args.Get(1).(*dao.Reception)
 					rec.ID = validReceptionID.String()
@@ -140,10 +152,37 @@ func TestDeleteProduct_Execute(t *testing.T) {
 			userRole:      model.RoleEmployee.Get(),
 			expectedError: model.ErrInternal,
 		},
+		{
+			name: "PVZ is archived",
+			setupMocks: func(_ *mockProductRepo, _ *mockReceptionRepo, mz *mockPVZRepo, ml *mockLogger) {
+				mz.On("FindByIDForUpdate", mock.Anything, validPVZID.String()).
+					Return(&dao.PVZ{ID: validPVZID.String(), ArchivedAt: sql.NullTime{Time: time.Now(), Valid: true}}, nil)
+				ml.On("Warn", mock.Anything, mock.Anything)
+			},
+			pvzID:         validPVZID,
+			userRole:      model.RoleEmployee.Get(),
+			expectedError: model.ErrPVZArchived,
+		},
+		{
+			name: "Reception closed before delete",
+			setupMocks: func(mp *mockProductRepo, mr *mockReceptionRepo, mz *mockPVZRepo, ml *mockLogger) {
+				mz.On("FindByIDForUpdate", mock.Anything, validPVZID.String()).Return(&dao.PVZ{ID: validPVZID.String()}, nil)
+				mr.On("FindOpened", mock.Anything, mock.Anything).Run(func(args mock.Arguments) {
+					rec := args.Get(1).(*dao.Reception)
+					rec.ID = validReceptionID.String()
+				}).Return(nil)
+				mp.On("CountProducts", mock.Anything, validReceptionID.String()).Return(1, nil)
+				mp.On("DeleteLastFromReception", mock.Anything, validReceptionID.String()).Return(errors.New(model.ErrNoActiveReception))
+				ml.On("Warn", mock.Anything, mock.Anything)
+			},
+			pvzID:         validPVZID,
+			userRole:      model.RoleEmployee.Get(),
+			expectedError: model.ErrNoActiveReception,
+		},
 		{
 			name: "Error deleting product",
 			setupMocks: func(mp *mockProductRepo, mr *mockReceptionRepo, mz *mockPVZRepo, ml *mockLogger) {
-				mz.On("CheckIfExists", mock.Anything, validPVZID.String()).Return(true, nil)
+				mz.On("FindByIDForUpdate", mock.Anything, validPVZID.String()).Return(&dao.PVZ{ID: validPVZID.String()}, nil)
 				mr.On("FindOpened", mock.Anything, mock.Anything).Run(func(args mock.Arguments) {
 					rec := args.Get(1).(*dao.Reception)
 					rec.ID = validReceptionID.String()
@@ -169,8 +208,8 @@ func TestDeleteProduct_Execute(t *testing.T) {
 				tt.setupMocks(mp, mr, mz, ml)
 			}
 
-			uc := NewUseCaseDeleteProduct(mp, mr, mz, ml)
-			err := uc.Execute(context.Background(), tt.pvzID, tt.userRole)
+			uc := NewUseCaseDeleteProduct(mp, mr, mz, newTestAssignmentRepo(), newTestTxManager(), newTestAuthorizer(), ml)
+			err := uc.Execute(context.Background(), tt.pvzID, testUserID, tt.userRole)
 
 			if tt.expectedError != "" {
 				assert.Error(t, err)
@@ -183,6 +222,75 @@ func TestDeleteProduct_Execute(t *testing.T) {
 	}
 }
 
+func TestDeleteProduct_Transaction(t *testing.T) {
+	validPVZID := uuid.New()
+	validReceptionID := uuid.New()
+
+	t.Run("Lock, lookup and delete run in one transaction", func(t *testing.T) {
+		mp := &mockProductRepo{}
+		mr := &mockReceptionRepo{}
+		mz := &mockPVZRepo{}
+		ml := &mockLogger{}
+		mz.On("FindByIDForUpdate", mock.MatchedBy(inTx), validPVZID.String()).Return(&dao.PVZ{ID: validPVZID.String()}, nil)
+		mr.On("FindOpened", mock.MatchedBy(inTx), mock.Anything).Run(func(args mock.Arguments) {
+			args.Get(1).(*dao.Reception).ID = validReceptionID.String()
+		}).Return(nil)
+		mp.On("CountProducts", mock.MatchedBy(inTx), validReceptionID.String()).Return(1, nil)
+		mp.On("DeleteLastFromReception", mock.MatchedBy(inTx), validReceptionID.String()).Return(nil)
+		ml.On("Info", mock.Anything, mock.Anything)
+		tx := newTestTxManager()
+
+		uc := NewUseCaseDeleteProduct(mp, mr, mz, newTestAssignmentRepo(), tx, newTestAuthorizer(), ml)
+		err := uc.Execute(context.Background(), validPVZID, testUserID, model.RoleEmployee.Get())
+
+		assert.NoError(t, err)
+		assert.Equal(t, 1, tx.calls)
+		mz.AssertExpectations(t)
+		mr.AssertExpectations(t)
+		mp.AssertExpectations(t)
+	})
+
+	t.Run("Reception closed by the time the lock is taken", func(t *testing.T) {
+		mp := &mockProductRepo{}
+		mr := &mockReceptionRepo{}
+		mz := &mockPVZRepo{}
+		ml := &mockLogger{}
+		mz.On("FindByIDForUpdate", mock.MatchedBy(inTx), validPVZID.String()).Return(&dao.PVZ{ID: validPVZID.String()}, nil)
+		// под блокировкой открытой приёмки уже нет: её закрыли до начала транзакции
+		mr.On("FindOpened", mock.MatchedBy(inTx), mock.Anything).Return(nil)
+		ml.On("Warn", mock.Anything, mock.Anything)
+		tx := newTestTxManager()
+
+		uc := NewUseCaseDeleteProduct(mp, mr, mz, newTestAssignmentRepo(), tx, newTestAuthorizer(), ml)
+		err := uc.Execute(context.Background(), validPVZID, testUserID, model.RoleEmployee.Get())
+
+		assert.EqualError(t, err, model.ErrNoActiveReception)
+		assert.Equal(t, 1, tx.calls)
+		mp.AssertNotCalled(t, "DeleteLastFromReception", mock.Anything, mock.Anything)
+	})
+
+	t.Run("Commit error", func(t *testing.T) {
+		mp := &mockProductRepo{}
+		mr := &mockReceptionRepo{}
+		mz := &mockPVZRepo{}
+		ml := &mockLogger{}
+		mz.On("FindByIDForUpdate", mock.Anything, validPVZID.String()).Return(&dao.PVZ{ID: validPVZID.String()}, nil)
+		mr.On("FindOpened", mock.Anything, mock.Anything).Run(func(args mock.Arguments) {
+			args.Get(1).(*dao.Reception).ID = validReceptionID.String()
+		}).Return(nil)
+		mp.On("CountProducts", mock.Anything, validReceptionID.String()).Return(1, nil)
+		mp.On("DeleteLastFromReception", mock.Anything, validReceptionID.String()).Return(nil)
+		ml.On("Error", mock.Anything, mock.Anything).Once()
+		tx := &mockTxManager{commitErr: errors.New("commit failed")}
+
+		uc := NewUseCaseDeleteProduct(mp, mr, mz, newTestAssignmentRepo(), tx, newTestAuthorizer(), ml)
+		err := uc.Execute(context.Background(), validPVZID, testUserID, model.RoleEmployee.Get())
+
+		assert.EqualError(t, err, model.ErrInternal)
+		ml.AssertExpectations(t)
+	})
+}
+
 func TestDeleteProduct_validateInput(t *testing.T) {
 	validPVZID := uuid.New()
 
@@ -190,12 +298,14 @@ func TestDeleteProduct_validateInput(t *testing.T) {
 		name          string
 		setupMocks    func(*mockLogger)
 		pvzID         uuid.UUID
+		userID        string
 		userRole      string
 		expectedError string
 	}{
 		{
 			name:     "Valid input",
 			pvzID:    validPVZID,
+			userID:   testUserID,
 			userRole: model.RoleEmployee.Get(),
 		},
 		{
@@ -204,6 +314,7 @@ func TestDeleteProduct_validateInput(t *testing.T) {
 			},
 			name:          "Invalid PVZ ID",
 			pvzID:         uuid.Nil,
+			userID:        testUserID,
 			userRole:      model.RoleEmployee.Get(),
 			expectedError: model.ErrAccessDenied,
 		},
@@ -213,9 +324,20 @@ func TestDeleteProduct_validateInput(t *testing.T) {
 			},
 			name:          "Invalid user role",
 			pvzID:         validPVZID,
+			userID:        testUserID,
 			userRole:      "invalid-role",
 			expectedError: model.ErrInvalidRole,
 		},
+		{
+			name: "Invalid user ID",
+			setupMocks: func(ml *mockLogger) {
+				ml.On("Warn", mock.Anything, mock.Anything)
+			},
+			pvzID:         validPVZID,
+			userID:        "not-a-uuid",
+			userRole:      model.RoleEmployee.Get(),
+			expectedError: model.ErrAccessDenied,
+		},
 	}
 
 	for _, tt := range tests {
@@ -227,7 +349,7 @@ func TestDeleteProduct_validateInput(t *testing.T) {
 			}
 			uc := &DeleteProduct{logger: ml}
 
-			_, _, err := uc.validateInput(tt.pvzID, tt.userRole)
+			_, _, _, err := uc.validateInput(tt.pvzID, tt.userID, tt.userRole)
 
 			if tt.expectedError != "" {
 				assert.Error(t, err)
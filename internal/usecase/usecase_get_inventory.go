@@ -0,0 +1,175 @@
+// Package usecase это юзкейсы и вспомогательная логика
+package usecase
+
+import (
+	"context"
+	"errors"
+	"github.com/google/uuid"
+	"internshipPVZ/internal/domain/model"
+	repo "internshipPVZ/internal/domain/repository"
+	"internshipPVZ/internal/http/onlymodels"
+	"log"
+)
+
+// inventoryReceptionStatuses статусы приёмок, продукты которых числятся в ПВЗ. Продукты отменённых приёмок не учитываются
+var inventoryReceptionStatuses = []model.ReceptionStatus{
+	model.ReceptionInProgress,
+	model.ReceptionPaused,
+	model.ReceptionClosed,
+}
+
+// NewUseCaseGetInventory конструктор
+func NewUseCaseGetInventory(
+	productRepo repo.ProductRepo,
+	pvzRepo repo.PVZRepo,
+	productTypeRepo repo.ProductTypeRepo,
+	authorizer Authorizer,
+	logger Logger,
+) *GetInventory {
+	if productRepo == nil {
+		log.Fatalf("GetInventory usecase productRepo nil")
+
+	}
+	if pvzRepo == nil {
+		log.Fatalf("GetInventory usecase pvzRepo nil")
+
+	}
+	if productTypeRepo == nil {
+		log.Fatalf("GetInventory usecase productTypeRepo nil")
+
+	}
+	if authorizer == nil {
+		log.Fatalf("GetInventory usecase authorizer nil")
+
+	}
+	if logger == nil {
+		log.Fatalf("GetInventory usecase logger nil")
+
+	}
+
+	return &GetInventory{
+		productRepo:     productRepo,
+		pvzRepo:         pvzRepo,
+		productTypeRepo: productTypeRepo,
+		authorizer:      authorizer,
+		logger:          logger,
+	}
+}
+
+// GetInventory юзкейс
+type GetInventory struct {
+	productRepo     repo.ProductRepo
+	pvzRepo         repo.PVZRepo
+	productTypeRepo repo.ProductTypeRepo
+	authorizer      Authorizer
+	logger          Logger
+}
+
+// Execute выдаёт продукты, которые сейчас физически находятся в ПВЗ
+func (uc *GetInventory) Execute(ctx context.Context, PVZID uuid.UUID, userRole string) ([]onlymodels.Product, error) {
+	pvzID, role, err := uc.validateInput(PVZID, userRole)
+	if err != nil {
+		uc.logger.Error("validation failed",
+			"usecase", "GetInventory",
+			"method", "validateInput",
+			"pvz_id", PVZID,
+			"error", err)
+		return nil, err
+	}
+
+	if err = checkPermission(uc.authorizer, uc.logger, "GetInventory", "Execute", role, model.PermissionReadInventory); err != nil {
+		return nil, err
+	}
+
+	exists, err := uc.pvzRepo.CheckIfExists(ctx, pvzID.String())
+	if err != nil {
+		uc.logger.Error("failed to check PVZ existence",
+			"usecase", "GetInventory",
+			"method", "pvzRepo.CheckIfExists",
+			"pvz_id", pvzID,
+			"error", err)
+		return nil, errors.New(model.ErrInternal)
+	}
+	if !exists {
+		uc.logger.Warn("invalid PVZ ID",
+			"usecase", "GetInventory",
+			"method", "Execute",
+			"pvz_id", pvzID)
+		return nil, errors.New(model.ErrInvalidPVZID)
+	}
+
+	products, err := uc.productRepo.GetInventory(ctx, pvzID.String(),
+		productStatusesToInt(model.OnHandProductStatuses),
+		receptionStatusesToInt(inventoryReceptionStatuses))
+	if err != nil {
+		uc.logger.Error("failed to get inventory",
+			"usecase", "GetInventory",
+			"method", "productRepo.GetInventory",
+			"pvz_id", pvzID,
+			"error", err)
+		return nil, errors.New(model.ErrInternal)
+	}
+
+	productTypes, err := uc.productTypeRepo.GetAll(ctx)
+	if err != nil {
+		uc.logger.Error("failed to get product types",
+			"usecase", "GetInventory",
+			"method", "productTypeRepo.GetAll",
+			"error", err)
+		return nil, errors.New(model.ErrInternal)
+	}
+	catalog, err := newProductTypeCatalog(productTypes)
+	if err != nil {
+		uc.logger.Error("failed to build product type catalog",
+			"usecase", "GetInventory",
+			"method", "newProductTypeCatalog",
+			"error", err)
+		return nil, errors.New(model.ErrInternal)
+	}
+
+	result := make([]onlymodels.Product, 0, len(products))
+	for _, product := range products {
+		productTypeName, err := catalog.name(product.Type)
+		if err != nil {
+			uc.logger.Error("failed to resolve product type",
+				"usecase", "GetInventory",
+				"method", "productTypeCatalog.name",
+				"error", err)
+			return nil, errors.New(model.ErrInternal)
+		}
+		productDto, err := productDaoToDto(product, productTypeName)
+		if err != nil {
+			uc.logger.Error("failed to convert product DAO to DTO",
+				"usecase", "GetInventory",
+				"method", "productDaoToDto",
+				"error", err)
+			return nil, errors.New(model.ErrInternal)
+		}
+		result = append(result, *productDto)
+	}
+
+	uc.logger.Info("inventory retrieved successfully",
+		"usecase", "GetInventory",
+		"pvz_id", pvzID,
+		"count", len(result))
+	return result, nil
+}
+
+func (uc *GetInventory) validateInput(PVZID uuid.UUID, userRole string) (pvzID uuid.UUID, role model.Role, err error) {
+	if pvzID, err = validateID(PVZID); err != nil {
+		uc.logger.Warn("invalid PVZ ID format",
+			"usecase", "GetInventory",
+			"method", "validateInput",
+			"pvz_id", PVZID)
+		err = errors.New(model.ErrInvalidPVZID)
+		return
+	}
+	if role, err = validateRole(userRole); err != nil {
+		uc.logger.Warn("invalid user role",
+			"usecase", "GetInventory",
+			"method", "validateInput",
+			"user_role", userRole)
+		return
+	}
+	return
+}
@@ -0,0 +1,118 @@
+// Package usecase это юзкейсы и вспомогательная логика
+package usecase
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"internshipPVZ/internal/domain/model"
+	"internshipPVZ/internal/domain/repository/dao"
+	"internshipPVZ/internal/http/onlymodels"
+)
+
+func TestGetInventory_Execute(t *testing.T) {
+	validPVZID := uuid.New()
+	validReceptionID := uuid.New()
+	receivedID := uuid.New()
+	storedID := uuid.New()
+	onHand := []int8{model.ProductReceived.ToInt(), model.ProductStored.ToInt()}
+	notCancelled := []int8{model.ReceptionInProgress.ToInt(), model.ReceptionPaused.ToInt(), model.ReceptionClosed.ToInt()}
+
+	tests := []struct {
+		name          string
+		setupMocks    func(*mockProductRepo, *mockPVZRepo, *mockLogger)
+		pvzID         uuid.UUID
+		userRole      string
+		expected      []onlymodels.ProductStatus
+		expectedError string
+	}{
+		{
+			name: "Inventory for moderator",
+			setupMocks: func(mp *mockProductRepo, mz *mockPVZRepo, ml *mockLogger) {
+				mz.On("CheckIfExists", mock.Anything, validPVZID.String()).Return(true, nil)
+				mp.On("GetInventory", mock.Anything, validPVZID.String(), onHand, notCancelled).Return([]*dao.Product{
+					testProductDao(receivedID, validReceptionID, model.ProductReceived),
+					testProductDao(storedID, validReceptionID, model.ProductStored),
+				}, nil)
+				ml.On("Info", mock.Anything, mock.Anything)
+			},
+			pvzID:    validPVZID,
+			userRole: model.RoleModerator.Get(),
+			expected: []onlymodels.ProductStatus{onlymodels.Received, onlymodels.Stored},
+		},
+		{
+			name: "Empty inventory",
+			setupMocks: func(mp *mockProductRepo, mz *mockPVZRepo, ml *mockLogger) {
+				mz.On("CheckIfExists", mock.Anything, validPVZID.String()).Return(true, nil)
+				mp.On("GetInventory", mock.Anything, validPVZID.String(), onHand, notCancelled).Return(nil, nil)
+				ml.On("Info", mock.Anything, mock.Anything)
+			},
+			pvzID:    validPVZID,
+			userRole: model.RoleEmployee.Get(),
+			expected: []onlymodels.ProductStatus{},
+		},
+		{
+			name: "PVZ does not exist",
+			setupMocks: func(_ *mockProductRepo, mz *mockPVZRepo, ml *mockLogger) {
+				mz.On("CheckIfExists", mock.Anything, validPVZID.String()).Return(false, nil)
+				ml.On("Warn", mock.Anything, mock.Anything)
+			},
+			pvzID:         validPVZID,
+			userRole:      model.RoleEmployee.Get(),
+			expectedError: model.ErrInvalidPVZID,
+		},
+		{
+			name: "Error getting inventory",
+			setupMocks: func(mp *mockProductRepo, mz *mockPVZRepo, ml *mockLogger) {
+				mz.On("CheckIfExists", mock.Anything, validPVZID.String()).Return(true, nil)
+				mp.On("GetInventory", mock.Anything, mock.Anything, mock.Anything, mock.Anything).Return(nil, errors.New("db error"))
+				ml.On("Error", mock.Anything, mock.Anything)
+			},
+			pvzID:         validPVZID,
+			userRole:      model.RoleEmployee.Get(),
+			expectedError: model.ErrInternal,
+		},
+		{
+			name: "Invalid role",
+			setupMocks: func(_ *mockProductRepo, _ *mockPVZRepo, ml *mockLogger) {
+				ml.On("Warn", mock.Anything, mock.Anything)
+				ml.On("Error", mock.Anything, mock.Anything)
+			},
+			pvzID:         validPVZID,
+			userRole:      "client",
+			expectedError: model.ErrInvalidRole,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mp := &mockProductRepo{}
+			mz := &mockPVZRepo{}
+			ml := &mockLogger{}
+
+			if tt.setupMocks != nil {
+				tt.setupMocks(mp, mz, ml)
+			}
+
+			uc := NewUseCaseGetInventory(mp, mz, newTestProductTypeRepo(), newTestAuthorizer(), ml)
+			result, err := uc.Execute(context.Background(), tt.pvzID, tt.userRole)
+
+			if tt.expectedError != "" {
+				assert.Error(t, err)
+				assert.Contains(t, err.Error(), tt.expectedError)
+				return
+			}
+
+			assert.NoError(t, err)
+			statuses := make([]onlymodels.ProductStatus, 0, len(result))
+			for _, product := range result {
+				statuses = append(statuses, *product.Status)
+			}
+			assert.Equal(t, tt.expected, statuses)
+		})
+	}
+}
@@ -3,8 +3,10 @@ package usecase
 
 import (
 	"context"
+	"errors"
 	"internshipPVZ/internal/domain/model"
 	repo "internshipPVZ/internal/domain/repository"
+	"internshipPVZ/internal/domain/repository/dao"
 	pb "internshipPVZ/internal/grpc/models"
 	"internshipPVZ/internal/http/onlymodels"
 	"log"
@@ -15,11 +17,26 @@ import (
 // NewUseCaseGetPvz конструктор
 func NewUseCaseGetPvz(
 	pvzRepo repo.PVZRepo,
+	cityRepo repo.CityRepo,
+	productTypeRepo repo.ProductTypeRepo,
+	authorizer Authorizer,
 	logger Logger,
 ) *GetPvz {
 	if pvzRepo == nil {
 		log.Fatalf("GetPvz usecase pvzRepo nil")
 
+	}
+	if cityRepo == nil {
+		log.Fatalf("GetPvz usecase cityRepo nil")
+
+	}
+	if productTypeRepo == nil {
+		log.Fatalf("GetPvz usecase productTypeRepo nil")
+
+	}
+	if authorizer == nil {
+		log.Fatalf("GetPvz usecase authorizer nil")
+
 	}
 	if logger == nil {
 		log.Fatalf("GetPvz usecase logger nil")
@@ -27,19 +44,28 @@ func NewUseCaseGetPvz(
 	}
 
 	return &GetPvz{
-		pvzRepo: pvzRepo,
-		logger:  logger,
+		pvzRepo:         pvzRepo,
+		cityRepo:        cityRepo,
+		productTypeRepo: productTypeRepo,
+		authorizer:      authorizer,
+		logger:          logger,
 	}
 }
 
 // GetPvz юзкейс
 type GetPvz struct {
-	pvzRepo repo.PVZRepo
-	logger  Logger
+	pvzRepo         repo.PVZRepo
+	cityRepo        repo.CityRepo
+	productTypeRepo repo.ProductTypeRepo
+	authorizer      Authorizer
+	logger          Logger
 }
 
-// GetFiltered выдаёт фильтрованные пвз со всей информацией
-func (uc *GetPvz) GetFiltered(ctx context.Context, startDate, endDate string, pageR, limitR int, userRole string) *onlymodels.GetFilteredResponse {
+// GetFiltered выдаёт фильтрованные пвз со всей информацией, архивные только при includeArchived.
+// Даты всегда отбирают приёмки, а mode решает, остаются ли в списке пвз без приёмок в диапазоне.
+// Страница начинается после cursor, а без него берётся по устаревшему page.
+// Вторым значением возвращает курсор следующей страницы, пустой на последней
+func (uc *GetPvz) GetFiltered(ctx context.Context, startDate, endDate, modeR string, pageR, limitR int, cursor string, includeArchived bool, userRole string) (*onlymodels.GetFilteredResponse, string, error) {
 	page, limit, role, err := uc.validateInput(pageR, limitR, userRole)
 	if err != nil {
 		uc.logger.Error("validation failed",
@@ -49,16 +75,29 @@ func (uc *GetPvz) GetFiltered(ctx context.Context, startDate, endDate string, pa
 			"limit", limitR,
 			"user_role", userRole,
 			"error", err)
-		return nil
+		return nil, "", err
 	}
 
-	if model.RoleEmployee != role && model.RoleModerator != role {
-		uc.logger.Warn("access denied",
+	if err = checkPermission(uc.authorizer, uc.logger, "GetPvz", "GetFiltered", role, model.PermissionReadPVZ); err != nil {
+		return nil, "", err
+	}
+
+	mode, err := model.NewPVZListMode(modeR)
+	if err != nil {
+		uc.logger.Warn("invalid list mode",
 			"usecase", "GetPvz",
 			"method", "GetFiltered",
-			"required_role", "RoleEmployee or RoleModerator",
-			"user_role", role)
-		return nil
+			"mode", modeR)
+		return nil, "", err
+	}
+
+	after, err := decodePVZCursor(cursor)
+	if err != nil {
+		uc.logger.Warn("invalid cursor",
+			"usecase", "GetPvz",
+			"method", "decodePVZCursor",
+			"cursor", cursor)
+		return nil, "", err
 	}
 
 	if startDate != "" {
@@ -68,7 +107,7 @@ func (uc *GetPvz) GetFiltered(ctx context.Context, startDate, endDate string, pa
 				"usecase", "GetPvz",
 				"method", "validateTime",
 				"start_date", startDate)
-			return nil
+			return nil, "", errors.New(model.ErrInvalidDateFilter)
 		}
 		startDate = strings.Join([]string{startDate, " 00:00:00"}, "")
 	}
@@ -80,66 +119,151 @@ func (uc *GetPvz) GetFiltered(ctx context.Context, startDate, endDate string, pa
 				"usecase", "GetPvz",
 				"method", "validateTime",
 				"end_date", endDate)
-			return nil
+			return nil, "", errors.New(model.ErrInvalidDateFilter)
 		}
 		endDate = strings.Join([]string{endDate, " 23:59:59"}, "")
 	}
 
-	response, err := uc.pvzRepo.GetAllWithFilter(ctx, startDate, endDate, page, limit)
+	response, next, err := uc.pvzRepo.GetAllWithFilter(ctx, dao.PVZListFilter{
+		StartDate:          startDate,
+		EndDate:            endDate,
+		OnlyWithReceptions: mode == model.PVZListWithReceptions,
+		Page:               page,
+		Limit:              limit,
+		After:              after,
+		IncludeArchived:    includeArchived,
+	})
 	if err != nil {
 		uc.logger.Error("failed to get filtered PVZs",
 			"usecase", "GetPvz",
 			"method", "pvzRepo.GetAllWithFilter",
 			"start_date", startDate,
 			"end_date", endDate,
+			"mode", mode,
 			"page", page,
 			"limit", limit,
+			"cursor", cursor,
+			"include_archived", includeArchived,
 			"error", err)
-		return nil
+		return nil, "", errors.New(model.ErrInternal)
+	}
+	// пустая страница не ошибка, клиент получает пустой массив вместо null
+	if len(response) == 0 {
+		return &onlymodels.GetFilteredResponse{}, "", nil
+	}
+
+	cities, err := uc.getCityCatalog(ctx)
+	if err != nil {
+		return nil, "", errors.New(model.ErrInternal)
+	}
+	productTypes, err := uc.getProductTypeCatalog(ctx)
+	if err != nil {
+		return nil, "", errors.New(model.ErrInternal)
 	}
 
-	pvzFiltered, err := pvzListToDto(response)
+	pvzFiltered, err := pvzListToDto(response, cities, productTypes)
 	if err != nil {
 		uc.logger.Error("failed to convert PVZ list to DTO",
 			"usecase", "GetPvz",
 			"method", "pvzListToDto",
 			"error", err)
-		return nil
+		return nil, "", errors.New(model.ErrInternal)
 	}
 
 	uc.logger.Info("filtered PVZs retrieved successfully",
 		"usecase", "GetPvz",
 		"start_date", startDate,
 		"end_date", endDate,
+		"mode", mode,
 		"page", page,
-		"limit", limit)
-	return pvzFiltered
+		"limit", limit,
+		"cursor", cursor)
+	return pvzFiltered, encodePVZCursor(next), nil
 }
 
-// Get выдаёт все пвз с базовой информацией
-func (uc *GetPvz) Get(ctx context.Context) *pb.GetPVZListResponse {
-	repoResponse, err := uc.pvzRepo.Get(ctx)
+// Get выдаёт пвз с базовой информацией, архивные только при includeArchived.
+// Без cursor и limit возвращает все пвз, иначе страницу с тем же курсором, что и GetFiltered
+func (uc *GetPvz) Get(ctx context.Context, cursor string, limit int, includeArchived bool) (*pb.GetPVZListResponse, error) {
+	after, err := decodePVZCursor(cursor)
+	if err != nil {
+		uc.logger.Warn("invalid cursor",
+			"usecase", "GetPvz",
+			"method", "decodePVZCursor",
+			"cursor", cursor)
+		return nil, err
+	}
+	if (cursor != "" || limit != 0) && (limit < 1 || limit > maxPVZLimit) {
+		limit = defaultPVZLimit
+	}
+
+	repoResponse, next, err := uc.pvzRepo.Get(ctx, after, limit, includeArchived)
 	if err != nil {
 		uc.logger.Error("failed to get PVZs",
 			"usecase", "GetPvz",
 			"method", "pvzRepo.Get",
 			"error", err)
-		return nil
+		return nil, errors.New(model.ErrInternal)
 	}
 
-	pvzs, err := pvzsToGrpcDto(repoResponse)
+	cities, err := uc.getCityCatalog(ctx)
+	if err != nil {
+		return nil, errors.New(model.ErrInternal)
+	}
+
+	pvzs, err := pvzsToGrpcDto(repoResponse, cities)
 	if err != nil {
 		uc.logger.Error("failed to get PVZs",
 			"usecase", "GetPvz",
 			"method", "pvzsToGrpcDto",
 			"error", err)
-		return nil
+		return nil, errors.New(model.ErrInternal)
 	}
-	response := &pb.GetPVZListResponse{Pvzs: pvzs}
+	response := &pb.GetPVZListResponse{Pvzs: pvzs, NextCursor: encodePVZCursor(next)}
 
 	uc.logger.Info("PVZs retrieved successfully",
 		"usecase", "GetPvz")
-	return response
+	return response, nil
+}
+
+func (uc *GetPvz) getCityCatalog(ctx context.Context) (cityCatalog, error) {
+	cities, err := uc.cityRepo.GetAll(ctx)
+	if err != nil {
+		uc.logger.Error("failed to get cities",
+			"usecase", "GetPvz",
+			"method", "cityRepo.GetAll",
+			"error", err)
+		return nil, err
+	}
+	catalog, err := newCityCatalog(cities)
+	if err != nil {
+		uc.logger.Error("failed to build city catalog",
+			"usecase", "GetPvz",
+			"method", "newCityCatalog",
+			"error", err)
+		return nil, err
+	}
+	return catalog, nil
+}
+
+// getProductTypeCatalog загружает и деактивированные типы, т.к. они остаются у ранее принятых товаров
+func (uc *GetPvz) getProductTypeCatalog(ctx context.Context) (productTypeCatalog, error) {
+	productTypes, err := uc.productTypeRepo.GetAll(ctx)
+	if err != nil {
+		uc.logger.Error("failed to get product types",
+			"usecase", "GetPvz",
+			"method", "productTypeRepo.GetAll",
+			"error", err)
+		return nil, err
+	}
+	catalog, err := newProductTypeCatalog(productTypes)
+	if err != nil {
+		uc.logger.Error("failed to build product type catalog",
+			"usecase", "GetPvz",
+			"method", "newProductTypeCatalog",
+			"error", err)
+		return nil, err
+	}
+	return catalog, nil
 }
 
 func (uc *GetPvz) validateInput(pageR, limitR int, userRole string) (page, limit int, role model.Role, err error) {
@@ -156,8 +280,8 @@ func (uc *GetPvz) validateInput(pageR, limitR int, userRole string) (page, limit
 	}
 	page = pageR
 
-	if limitR < 1 || limitR > 30 {
-		limitR = 10
+	if limitR < 1 || limitR > maxPVZLimit {
+		limitR = defaultPVZLimit
 	}
 	limit = limitR
 
@@ -3,6 +3,8 @@ package usecase
 
 import (
 	"context"
+	"database/sql"
+	"encoding/base64"
 	"errors"
 	"google.golang.org/protobuf/types/known/timestamppb"
 	"testing"
@@ -20,31 +22,53 @@ import (
 func TestGetPvz_GetFiltered(t *testing.T) {
 	validPVZID := uuid.New()
 	testTime := time.Now()
+	after := &dao.PVZCursor{RegistrationDate: time.Date(2023, 1, 10, 0, 0, 0, 0, time.UTC), ID: uuid.NewString()}
+	next := &dao.PVZCursor{RegistrationDate: time.Date(2023, 1, 12, 0, 0, 0, 0, time.UTC), ID: validPVZID.String()}
+	pvzList := []*dao.PVZList{
+		{
+			PvzID:             validPVZID.String(),
+			RegistrationDate:  testTime,
+			City:              testCityMoscowID,
+			ReceptionID:       sql.NullString{String: validPVZID.String(), Valid: true},
+			ReceptionDateTime: sql.NullTime{Time: testTime, Valid: true},
+			Status:            sql.NullInt16{Valid: true},
+		},
+	}
 
 	tests := []struct {
-		name          string
-		setupMocks    func(*mockPVZRepo, *mockLogger)
-		startDate     string
-		endDate       string
-		page          int
-		limit         int
-		userRole      string
-		expected      *onlymodels.GetFilteredResponse
-		expectedError bool
+		name            string
+		setupMocks      func(*mockPVZRepo, *mockLogger)
+		startDate       string
+		endDate         string
+		mode            string
+		page            int
+		limit           int
+		cursor          string
+		includeArchived bool
+		userRole        string
+		expected        *onlymodels.GetFilteredResponse
+		expectedNext    string
+		expectedError   bool
+		errorMessage    string
 	}{
 		{
 			name: "Success - employee role with valid dates",
 			setupMocks: func(mz *mockPVZRepo, ml *mockLogger) {
-				mz.On("GetAllWithFilter", mock.Anything, "2023-01-01 00:00:00", "2023-01-31 23:59:59", 1, 10).Return([]*dao.PVZList{
+				mz.On("GetAllWithFilter", mock.Anything, dao.PVZListFilter{
+					StartDate: "2023-01-01 00:00:00",
+					EndDate:   "2023-01-31 23:59:59",
+					Page:      1,
+					Limit:     10,
+				}).Return([]*dao.PVZList{
 					{
 						PvzID:             validPVZID.String(),
 						RegistrationDate:  testTime,
-						City:              model.CityMoscow.ToInt(),
-						ReceptionID:       validPVZID.String(),
-						ReceptionDateTime: testTime,
-						Status:            int8(0),
+						City:              testCityMoscowID,
+						ReceptionID:       sql.NullString{String: validPVZID.String(), Valid: true},
+						ReceptionDateTime: sql.NullTime{Time: testTime, Valid: true},
+						Status:            sql.NullInt16{Valid: true},
 					},
-				}, nil)
+				}, nil, nil)
 				ml.On("Info", mock.Anything, mock.Anything)
 				ml.On("Error", mock.Anything, mock.Anything)
 			},
@@ -58,7 +82,7 @@ func TestGetPvz_GetFiltered(t *testing.T) {
 					Pvz: &onlymodels.PVZ{
 						Id:               &validPVZID,
 						RegistrationDate: &testTime,
-						City:             onlymodels.PVZCity(model.CityMoscow.Get()),
+						City:             "Москва",
 					},
 				},
 			},
@@ -66,32 +90,103 @@ func TestGetPvz_GetFiltered(t *testing.T) {
 		{
 			name: "Success - moderator role with no dates",
 			setupMocks: func(mz *mockPVZRepo, ml *mockLogger) {
-				mz.On("GetAllWithFilter", mock.Anything, "", "", 2, 20).Return([]*dao.PVZList{
+				mz.On("GetAllWithFilter", mock.Anything, dao.PVZListFilter{Page: 2, Limit: 20, IncludeArchived: true}).Return([]*dao.PVZList{
 					{
 						PvzID:             validPVZID.String(),
 						RegistrationDate:  testTime,
-						City:              model.CitySPB.ToInt(),
-						ReceptionID:       validPVZID.String(),
-						ReceptionDateTime: testTime,
-						Status:            int8(0),
+						City:              testCitySPBID,
+						ReceptionID:       sql.NullString{String: validPVZID.String(), Valid: true},
+						ReceptionDateTime: sql.NullTime{Time: testTime, Valid: true},
+						Status:            sql.NullInt16{Valid: true},
 					},
-				}, nil)
+				}, nil, nil)
 				ml.On("Info", mock.Anything, mock.Anything)
 				ml.On("Error", mock.Anything, mock.Anything)
 			},
-			page:     2,
-			limit:    20,
-			userRole: model.RoleModerator.Get(),
+			page:            2,
+			limit:           20,
+			includeArchived: true,
+			userRole:        model.RoleModerator.Get(),
 			expected: &onlymodels.GetFilteredResponse{
 				{
 					Pvz: &onlymodels.PVZ{
 						Id:               &validPVZID,
 						RegistrationDate: &testTime,
-						City:             onlymodels.PVZCity(model.CitySPB.Get()),
+						City:             "Санкт-Петербург",
 					},
 				},
 			},
 		},
+		{
+			name: "Success - cursor page with next cursor",
+			setupMocks: func(mz *mockPVZRepo, ml *mockLogger) {
+				mz.On("GetAllWithFilter", mock.Anything, dao.PVZListFilter{Page: 1, Limit: 10, After: after}).Return(pvzList, next, nil)
+				ml.On("Info", mock.Anything, mock.Anything)
+			},
+			cursor:   encodePVZCursor(after),
+			userRole: model.RoleEmployee.Get(),
+			expected: &onlymodels.GetFilteredResponse{
+				{
+					Pvz: &onlymodels.PVZ{
+						Id:   &validPVZID,
+						City: "Москва",
+					},
+				},
+			},
+			expectedNext: encodePVZCursor(next),
+		},
+		{
+			name: "Success - only PVZs with receptions in range",
+			setupMocks: func(mz *mockPVZRepo, ml *mockLogger) {
+				mz.On("GetAllWithFilter", mock.Anything, dao.PVZListFilter{
+					StartDate:          "2023-01-01 00:00:00",
+					OnlyWithReceptions: true,
+					Page:               1,
+					Limit:              10,
+				}).Return(pvzList, nil, nil)
+				ml.On("Info", mock.Anything, mock.Anything)
+			},
+			startDate: "2023-01-01",
+			mode:      string(model.PVZListWithReceptions),
+			userRole:  model.RoleEmployee.Get(),
+			expected: &onlymodels.GetFilteredResponse{
+				{
+					Pvz: &onlymodels.PVZ{
+						Id:   &validPVZID,
+						City: "Москва",
+					},
+				},
+			},
+		},
+		{
+			name: "Success - empty page",
+			setupMocks: func(mz *mockPVZRepo, _ *mockLogger) {
+				mz.On("GetAllWithFilter", mock.Anything, dao.PVZListFilter{Page: 1, Limit: 10}).Return(nil, nil, nil)
+			},
+			mode:     string(model.PVZListAll),
+			userRole: model.RoleEmployee.Get(),
+			expected: &onlymodels.GetFilteredResponse{},
+		},
+		{
+			name: "Invalid mode",
+			setupMocks: func(_ *mockPVZRepo, ml *mockLogger) {
+				ml.On("Warn", mock.Anything, mock.Anything)
+			},
+			mode:          "withoutReceptions",
+			userRole:      model.RoleEmployee.Get(),
+			expectedError: true,
+			errorMessage:  model.ErrInvalidPVZListMode,
+		},
+		{
+			name: "Invalid cursor",
+			setupMocks: func(_ *mockPVZRepo, ml *mockLogger) {
+				ml.On("Warn", mock.Anything, mock.Anything)
+			},
+			cursor:        "not-a-cursor",
+			userRole:      model.RoleEmployee.Get(),
+			expectedError: true,
+			errorMessage:  model.ErrInvalidPVZCursor,
+		},
 		{
 			name: "Invalid user role",
 			setupMocks: func(_ *mockPVZRepo, ml *mockLogger) {
@@ -109,6 +204,7 @@ func TestGetPvz_GetFiltered(t *testing.T) {
 			startDate:     "invalid-date",
 			userRole:      model.RoleEmployee.Get(),
 			expectedError: true,
+			errorMessage:  model.ErrInvalidDateFilter,
 		},
 		{
 			name: "Invalid end date format",
@@ -118,11 +214,12 @@ func TestGetPvz_GetFiltered(t *testing.T) {
 			endDate:       "invalid-date",
 			userRole:      model.RoleEmployee.Get(),
 			expectedError: true,
+			errorMessage:  model.ErrInvalidDateFilter,
 		},
 		{
 			name: "Database error",
 			setupMocks: func(mz *mockPVZRepo, ml *mockLogger) {
-				mz.On("GetAllWithFilter", mock.Anything, "", "", 1, 10).Return(nil, errors.New("db error"))
+				mz.On("GetAllWithFilter", mock.Anything, dao.PVZListFilter{Page: 1, Limit: 10}).Return(nil, nil, errors.New("db error"))
 				ml.On("Error", mock.Anything, mock.Anything)
 			},
 			userRole:      model.RoleEmployee.Get(),
@@ -139,15 +236,26 @@ func TestGetPvz_GetFiltered(t *testing.T) {
 				tt.setupMocks(mz, ml)
 			}
 
-			uc := NewUseCaseGetPvz(mz, ml)
-			result := uc.GetFiltered(context.Background(), tt.startDate, tt.endDate, tt.page, tt.limit, tt.userRole)
+			mc := &mockCityRepo{}
+			mc.On("GetAll", mock.Anything).Return(testCities(), nil)
+
+			uc := NewUseCaseGetPvz(mz, mc, newTestProductTypeRepo(), newTestAuthorizer(), ml)
+			result, nextCursor, err := uc.GetFiltered(context.Background(), tt.startDate, tt.endDate, tt.mode, tt.page, tt.limit, tt.cursor, tt.includeArchived, tt.userRole)
 
+			mz.AssertExpectations(t)
 			if tt.expectedError {
+				assert.Error(t, err)
+				if tt.errorMessage != "" {
+					assert.EqualError(t, err, tt.errorMessage)
+				}
 				assert.Nil(t, result)
+				assert.Empty(t, nextCursor)
 				return
 			}
 
+			assert.NoError(t, err)
 			assert.NotNil(t, result)
+			assert.Equal(t, tt.expectedNext, nextCursor)
 			assert.Equal(t, len(*tt.expected), len(*result))
 			if len(*result) > 0 {
 				assert.Equal(t, (*tt.expected)[0].Pvz.Id, (*result)[0].Pvz.Id)
@@ -160,23 +268,28 @@ func TestGetPvz_GetFiltered(t *testing.T) {
 func TestGetPvz_Get(t *testing.T) {
 	validPVZID := uuid.New()
 	testTime := time.Now()
+	after := &dao.PVZCursor{RegistrationDate: time.Date(2023, 1, 10, 0, 0, 0, 0, time.UTC), ID: uuid.NewString()}
+	next := &dao.PVZCursor{RegistrationDate: time.Date(2023, 1, 12, 0, 0, 0, 0, time.UTC), ID: validPVZID.String()}
+	pvzs := []*dao.PVZ{
+		{
+			ID:               validPVZID.String(),
+			RegistrationDate: testTime,
+			City:             testCityMoscowID,
+		},
+	}
 
 	tests := []struct {
 		name          string
 		setupMocks    func(*mockPVZRepo, *mockLogger)
+		cursor        string
+		limit         int
 		expected      *pb.GetPVZListResponse
 		expectedError bool
 	}{
 		{
 			name: "Success - get PVZs",
 			setupMocks: func(mz *mockPVZRepo, ml *mockLogger) {
-				mz.On("Get", mock.Anything).Return([]*dao.PVZ{
-					{
-						ID:               validPVZID.String(),
-						RegistrationDate: testTime,
-						City:             model.CityMoscow.ToInt(),
-					},
-				}, nil)
+				mz.On("Get", mock.Anything, (*dao.PVZCursor)(nil), 0, false).Return(pvzs, nil, nil)
 				ml.On("Info", mock.Anything, mock.Anything)
 			},
 			expected: &pb.GetPVZListResponse{
@@ -184,15 +297,56 @@ func TestGetPvz_Get(t *testing.T) {
 					{
 						Id:               validPVZID.String(),
 						RegistrationDate: timestamppb.New(testTime),
-						City:             model.CityMoscow.Get(),
+						City:             "Москва",
 					},
 				},
 			},
 		},
+		{
+			name: "Success - cursor page with default limit",
+			setupMocks: func(mz *mockPVZRepo, ml *mockLogger) {
+				mz.On("Get", mock.Anything, after, defaultPVZLimit, false).Return(pvzs, next, nil)
+				ml.On("Info", mock.Anything, mock.Anything)
+			},
+			cursor: encodePVZCursor(after),
+			expected: &pb.GetPVZListResponse{
+				Pvzs: []*pb.PVZ{
+					{
+						Id:   validPVZID.String(),
+						City: "Москва",
+					},
+				},
+				NextCursor: encodePVZCursor(next),
+			},
+		},
+		{
+			name: "Success - first page",
+			setupMocks: func(mz *mockPVZRepo, ml *mockLogger) {
+				mz.On("Get", mock.Anything, (*dao.PVZCursor)(nil), 5, false).Return(pvzs, nil, nil)
+				ml.On("Info", mock.Anything, mock.Anything)
+			},
+			limit: 5,
+			expected: &pb.GetPVZListResponse{
+				Pvzs: []*pb.PVZ{
+					{
+						Id:   validPVZID.String(),
+						City: "Москва",
+					},
+				},
+			},
+		},
+		{
+			name: "Invalid cursor",
+			setupMocks: func(_ *mockPVZRepo, ml *mockLogger) {
+				ml.On("Warn", mock.Anything, mock.Anything)
+			},
+			cursor:        "not-a-cursor",
+			expectedError: true,
+		},
 		{
 			name: "Database error",
 			setupMocks: func(mz *mockPVZRepo, ml *mockLogger) {
-				mz.On("Get", mock.Anything).Return(nil, errors.New("db error"))
+				mz.On("Get", mock.Anything, (*dao.PVZCursor)(nil), 0, false).Return(nil, nil, errors.New("db error"))
 				ml.On("Error", mock.Anything, mock.Anything)
 			},
 			expectedError: true,
@@ -208,15 +362,22 @@ func TestGetPvz_Get(t *testing.T) {
 				tt.setupMocks(mz, ml)
 			}
 
-			uc := NewUseCaseGetPvz(mz, ml)
-			result := uc.Get(context.Background())
+			mc := &mockCityRepo{}
+			mc.On("GetAll", mock.Anything).Return(testCities(), nil)
+
+			uc := NewUseCaseGetPvz(mz, mc, newTestProductTypeRepo(), newTestAuthorizer(), ml)
+			result, err := uc.Get(context.Background(), tt.cursor, tt.limit, false)
 
+			mz.AssertExpectations(t)
 			if tt.expectedError {
+				assert.Error(t, err)
 				assert.Nil(t, result)
 				return
 			}
 
+			assert.NoError(t, err)
 			assert.NotNil(t, result)
+			assert.Equal(t, tt.expected.NextCursor, result.NextCursor)
 			assert.Equal(t, len(tt.expected.Pvzs), len(result.Pvzs))
 			if len(result.Pvzs) > 0 {
 				assert.Equal(t, tt.expected.Pvzs[0].Id, result.Pvzs[0].Id)
@@ -358,3 +519,30 @@ func TestGetPvz_validateTime(t *testing.T) {
 		})
 	}
 }
+
+func TestPVZCursor(t *testing.T) {
+	cursor := &dao.PVZCursor{RegistrationDate: time.Date(2024, 3, 5, 0, 0, 0, 0, time.UTC), ID: uuid.NewString()}
+
+	decoded, err := decodePVZCursor(encodePVZCursor(cursor))
+	assert.NoError(t, err)
+	assert.Equal(t, cursor, decoded)
+
+	decoded, err = decodePVZCursor("")
+	assert.NoError(t, err)
+	assert.Nil(t, decoded)
+	assert.Empty(t, encodePVZCursor(nil))
+
+	for _, invalid := range []string{
+		"not base64!",
+		"bm90IGpzb24",
+		encodeRawCursor(`{"d":"05.03.2024","id":"` + cursor.ID + `"}`),
+		encodeRawCursor(`{"d":"2024-03-05","id":"not-uuid"}`),
+	} {
+		_, err = decodePVZCursor(invalid)
+		assert.EqualError(t, err, model.ErrInvalidPVZCursor, invalid)
+	}
+}
+
+func encodeRawCursor(raw string) string {
+	return base64.RawURLEncoding.EncodeToString([]byte(raw))
+}
@@ -0,0 +1,122 @@
+// Package usecase это юзкейсы и вспомогательная логика
+package usecase
+
+import (
+	"context"
+	"errors"
+	"github.com/google/uuid"
+	"internshipPVZ/internal/domain/model"
+	repo "internshipPVZ/internal/domain/repository"
+	"internshipPVZ/internal/http/onlymodels"
+	"log"
+)
+
+// NewUseCaseIssueProduct конструктор
+func NewUseCaseIssueProduct(
+	productRepo repo.ProductRepo,
+	receptionRepo repo.ReceptionRepo,
+	productTypeRepo repo.ProductTypeRepo,
+	assignmentRepo repo.PVZAssignmentRepo,
+	authorizer Authorizer,
+	logger Logger,
+) *IssueProduct {
+	if productRepo == nil {
+		log.Fatalf("IssueProduct usecase productRepo nil")
+
+	}
+	if receptionRepo == nil {
+		log.Fatalf("IssueProduct usecase receptionRepo nil")
+
+	}
+	if productTypeRepo == nil {
+		log.Fatalf("IssueProduct usecase productTypeRepo nil")
+
+	}
+	if assignmentRepo == nil {
+		log.Fatalf("IssueProduct usecase assignmentRepo nil")
+
+	}
+	if authorizer == nil {
+		log.Fatalf("IssueProduct usecase authorizer nil")
+
+	}
+	if logger == nil {
+		log.Fatalf("IssueProduct usecase logger nil")
+
+	}
+
+	return &IssueProduct{
+		productRepo:     productRepo,
+		receptionRepo:   receptionRepo,
+		productTypeRepo: productTypeRepo,
+		assignmentRepo:  assignmentRepo,
+		authorizer:      authorizer,
+		logger:          logger,
+	}
+}
+
+// IssueProduct юзкейс
+type IssueProduct struct {
+	productRepo     repo.ProductRepo
+	receptionRepo   repo.ReceptionRepo
+	productTypeRepo repo.ProductTypeRepo
+	assignmentRepo  repo.PVZAssignmentRepo
+	authorizer      Authorizer
+	logger          Logger
+}
+
+// Execute выдаёт продукт покупателю
+func (uc *IssueProduct) Execute(ctx context.Context, productID uuid.UUID, userID, userRole string) (*onlymodels.Product, error) {
+	validProductID, userUUID, role, err := uc.validateInput(productID, userID, userRole)
+	if err != nil {
+		uc.logger.Error("validation failed",
+			"usecase", "IssueProduct",
+			"method", "validateInput",
+			"product_id", productID,
+			"error", err)
+		return nil, err
+	}
+
+	if err = checkPermission(uc.authorizer, uc.logger, "IssueProduct", "Execute", role, model.PermissionIssueProduct); err != nil {
+		return nil, err
+	}
+
+	repos := productStatusRepos{
+		productRepo:     uc.productRepo,
+		receptionRepo:   uc.receptionRepo,
+		productTypeRepo: uc.productTypeRepo,
+		assignmentRepo:  uc.assignmentRepo,
+	}
+	return changeProductStatus(ctx, repos, uc.logger, productStatusChange{
+		usecase:   "IssueProduct",
+		productID: validProductID,
+		userID:    userUUID,
+		target:    model.ProductIssued,
+	})
+}
+
+func (uc *IssueProduct) validateInput(productID uuid.UUID, userID, userRole string) (validProductID, userUUID uuid.UUID, role model.Role, err error) {
+	if validProductID, err = validateID(productID); err != nil {
+		uc.logger.Warn("invalid product ID format",
+			"usecase", "IssueProduct",
+			"method", "validateInput",
+			"product_id", productID)
+		err = errors.New(model.ErrInvalidProductID)
+		return
+	}
+	if userUUID, err = validateRawID(userID); err != nil {
+		uc.logger.Warn("invalid user ID in token",
+			"usecase", "IssueProduct",
+			"method", "validateInput",
+			"user_id", userID)
+		return
+	}
+	if role, err = validateRole(userRole); err != nil {
+		uc.logger.Warn("invalid user role",
+			"usecase", "IssueProduct",
+			"method", "validateInput",
+			"user_role", userRole)
+		return
+	}
+	return
+}
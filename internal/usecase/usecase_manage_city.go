@@ -0,0 +1,209 @@
+// Package usecase это юзкейсы и вспомогательная логика
+package usecase
+
+import (
+	"context"
+	"errors"
+	"internshipPVZ/internal/domain/model"
+	repo "internshipPVZ/internal/domain/repository"
+	"internshipPVZ/internal/http/onlymodels"
+	"log"
+)
+
+// NewUseCaseManageCity конструктор
+func NewUseCaseManageCity(
+	cityRepo repo.CityRepo,
+	authorizer Authorizer,
+	logger Logger,
+) *ManageCity {
+	if cityRepo == nil {
+		log.Fatalf("ManageCity usecase cityRepo nil")
+
+	}
+	if authorizer == nil {
+		log.Fatalf("ManageCity usecase authorizer nil")
+
+	}
+	if logger == nil {
+		log.Fatalf("ManageCity usecase logger nil")
+
+	}
+
+	return &ManageCity{
+		cityRepo:   cityRepo,
+		authorizer: authorizer,
+		logger:     logger,
+	}
+}
+
+// ManageCity юзкейс
+type ManageCity struct {
+	cityRepo   repo.CityRepo
+	authorizer Authorizer
+	logger     Logger
+}
+
+// GetAll выдаёт справочник городов
+func (uc *ManageCity) GetAll(ctx context.Context, userRole string) ([]onlymodels.City, error) {
+	if err := uc.checkAccess("GetAll", userRole, model.PermissionReadCities); err != nil {
+		return nil, err
+	}
+
+	cities, err := uc.cityRepo.GetAll(ctx)
+	if err != nil {
+		uc.logger.Error("failed to get cities",
+			"usecase", "ManageCity",
+			"method", "cityRepo.GetAll",
+			"error", err)
+		return nil, errors.New(model.ErrInternal)
+	}
+
+	result := make([]onlymodels.City, 0, len(cities))
+	for _, city := range cities {
+		cityDto, err := cityDaoToDto(city)
+		if err != nil {
+			uc.logger.Error("failed to convert city DAO to DTO",
+				"usecase", "ManageCity",
+				"method", "cityDaoToDto",
+				"error", err)
+			return nil, errors.New(model.ErrInternal)
+		}
+		result = append(result, *cityDto)
+	}
+
+	uc.logger.Info("cities retrieved successfully",
+		"usecase", "ManageCity",
+		"count", len(result))
+	return result, nil
+}
+
+// Create добавляет город в справочник
+func (uc *ManageCity) Create(ctx context.Context, request *onlymodels.City, userRole string) (*onlymodels.City, error) {
+	if err := uc.checkAccess("Create", userRole, model.PermissionManageCities); err != nil {
+		return nil, err
+	}
+
+	city, err := uc.validateCity(request)
+	if err != nil {
+		return nil, err
+	}
+
+	cityDao := city.ToDao()
+	err = uc.cityRepo.Create(ctx, cityDao)
+	if err != nil {
+		if err.Error() == model.ErrCityAlreadyExists {
+			uc.logger.Warn("city already exists",
+				"usecase", "ManageCity",
+				"method", "cityRepo.Create",
+				"city", cityDao.Name)
+			return nil, err
+		}
+		uc.logger.Error("failed to create city",
+			"usecase", "ManageCity",
+			"method", "cityRepo.Create",
+			"city", cityDao.Name,
+			"error", err)
+		return nil, errors.New(model.ErrInternal)
+	}
+
+	uc.logger.Info("city created successfully",
+		"usecase", "ManageCity",
+		"city_id", cityDao.ID,
+		"city", cityDao.Name)
+	return cityDaoToDto(cityDao)
+}
+
+// Update переименовывает город
+func (uc *ManageCity) Update(ctx context.Context, cityID int32, request *onlymodels.City, userRole string) (*onlymodels.City, error) {
+	if err := uc.checkAccess("Update", userRole, model.PermissionManageCities); err != nil {
+		return nil, err
+	}
+
+	city, err := uc.validateCity(request)
+	if err != nil {
+		return nil, err
+	}
+	city.ID = cityID
+
+	cityDao := city.ToDao()
+	err = uc.cityRepo.Update(ctx, cityDao)
+	if err != nil {
+		if err.Error() == model.ErrCityAlreadyExists || err.Error() == model.ErrCityNotFound {
+			uc.logger.Warn("failed to rename city",
+				"usecase", "ManageCity",
+				"method", "cityRepo.Update",
+				"city_id", cityDao.ID,
+				"error", err)
+			return nil, err
+		}
+		uc.logger.Error("failed to rename city",
+			"usecase", "ManageCity",
+			"method", "cityRepo.Update",
+			"city_id", cityDao.ID,
+			"error", err)
+		return nil, errors.New(model.ErrInternal)
+	}
+
+	uc.logger.Info("city renamed successfully",
+		"usecase", "ManageCity",
+		"city_id", cityDao.ID,
+		"city", cityDao.Name)
+	return cityDaoToDto(cityDao)
+}
+
+// Delete удаляет город из справочника
+func (uc *ManageCity) Delete(ctx context.Context, cityID int32, userRole string) error {
+	if err := uc.checkAccess("Delete", userRole, model.PermissionManageCities); err != nil {
+		return err
+	}
+
+	err := uc.cityRepo.Delete(ctx, cityID)
+	if err != nil {
+		if err.Error() == model.ErrCityIsInUse || err.Error() == model.ErrCityNotFound {
+			uc.logger.Warn("failed to delete city",
+				"usecase", "ManageCity",
+				"method", "cityRepo.Delete",
+				"city_id", cityID,
+				"error", err)
+			return err
+		}
+		uc.logger.Error("failed to delete city",
+			"usecase", "ManageCity",
+			"method", "cityRepo.Delete",
+			"city_id", cityID,
+			"error", err)
+		return errors.New(model.ErrInternal)
+	}
+
+	uc.logger.Info("city deleted successfully",
+		"usecase", "ManageCity",
+		"city_id", cityID)
+	return nil
+}
+
+func (uc *ManageCity) checkAccess(method, userRole string, permission model.Permission) error {
+	role, err := validateRole(userRole)
+	if err != nil {
+		uc.logger.Warn("invalid user role",
+			"usecase", "ManageCity",
+			"method", method,
+			"user_role", userRole)
+		return err
+	}
+	return checkPermission(uc.authorizer, uc.logger, "ManageCity", method, role, permission)
+}
+
+func (uc *ManageCity) validateCity(request *onlymodels.City) (*model.City, error) {
+	if request == nil {
+		return nil, errors.New(model.ErrInvalidRequest)
+	}
+	name, ok := normalizeCityName(request.Name)
+	if !ok {
+		uc.logger.Warn("invalid city name",
+			"usecase", "ManageCity",
+			"method", "validateCity",
+			"city", request.Name)
+		return nil, errors.New(model.ErrInvalidCityName)
+	}
+	return &model.City{Name: name}, nil
+}
@@ -0,0 +1,351 @@
+// Package usecase это юзкейсы и вспомогательная логика
+package usecase
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"internshipPVZ/internal/domain/model"
+	"internshipPVZ/internal/domain/repository/dao"
+	"internshipPVZ/internal/http/onlymodels"
+)
+
+const (
+	testCitySPBID    int32 = 0
+	testCityKazanID  int32 = 1
+	testCityMoscowID int32 = 2
+)
+
+func testCities() []*dao.City {
+	return []*dao.City{
+		{ID: testCitySPBID, Name: "Санкт-Петербург"},
+		{ID: testCityKazanID, Name: "Казань"},
+		{ID: testCityMoscowID, Name: "Москва"},
+	}
+}
+
+func testCityCatalog() cityCatalog {
+	catalog, _ := newCityCatalog(testCities())
+	return catalog
+}
+
+type mockCityRepo struct{ mock.Mock }
+
+func (m *mockCityRepo) Create(ctx context.Context, city *dao.City) error {
+	args := m.Called(ctx, city)
+	return args.Error(0)
+}
+
+func (m *mockCityRepo) GetAll(ctx context.Context) ([]*dao.City, error) {
+	args := m.Called(ctx)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]*dao.City), args.Error(1)
+}
+
+func (m *mockCityRepo) FindByName(ctx context.Context, name string) (*dao.City, error) {
+	args := m.Called(ctx, name)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*dao.City), args.Error(1)
+}
+
+func (m *mockCityRepo) Update(ctx context.Context, city *dao.City) error {
+	args := m.Called(ctx, city)
+	return args.Error(0)
+}
+
+func (m *mockCityRepo) Delete(ctx context.Context, id int32) error {
+	args := m.Called(ctx, id)
+	return args.Error(0)
+}
+
+func TestManageCity_GetAll(t *testing.T) {
+	tests := []struct {
+		name          string
+		setupMocks    func(*mockCityRepo, *mockLogger)
+		userRole      string
+		expectedCount int
+		expectedError string
+	}{
+		{
+			name: "Success - moderator lists cities",
+			setupMocks: func(mc *mockCityRepo, ml *mockLogger) {
+				mc.On("GetAll", mock.Anything).Return(testCities(), nil)
+				ml.On("Info", mock.Anything, mock.Anything)
+			},
+			userRole:      model.RoleModerator.Get(),
+			expectedCount: 3,
+		},
+		{
+			name: "Employee is denied",
+			setupMocks: func(_ *mockCityRepo, ml *mockLogger) {
+				ml.On("Warn", mock.Anything, mock.Anything)
+			},
+			userRole:      model.RoleEmployee.Get(),
+			expectedError: model.ErrAccessDenied,
+		},
+		{
+			name: "Database error",
+			setupMocks: func(mc *mockCityRepo, ml *mockLogger) {
+				mc.On("GetAll", mock.Anything).Return(nil, errors.New("db error"))
+				ml.On("Error", mock.Anything, mock.Anything)
+			},
+			userRole:      model.RoleModerator.Get(),
+			expectedError: model.ErrInternal,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mc := &mockCityRepo{}
+			ml := &mockLogger{}
+
+			if tt.setupMocks != nil {
+				tt.setupMocks(mc, ml)
+			}
+
+			uc := NewUseCaseManageCity(mc, newTestAuthorizer(), ml)
+			result, err := uc.GetAll(context.Background(), tt.userRole)
+
+			if tt.expectedError != "" {
+				assert.Error(t, err)
+				assert.Equal(t, tt.expectedError, err.Error())
+				return
+			}
+
+			assert.NoError(t, err)
+			assert.Len(t, result, tt.expectedCount)
+		})
+	}
+}
+
+func TestManageCity_Create(t *testing.T) {
+	tests := []struct {
+		name          string
+		setupMocks    func(*mockCityRepo, *mockLogger)
+		request       *onlymodels.City
+		userRole      string
+		expectedName  string
+		expectedError string
+	}{
+		{
+			name: "Success - name is trimmed",
+			setupMocks: func(mc *mockCityRepo, ml *mockLogger) {
+				mc.On("Create", mock.Anything, &dao.City{Name: "Новосибирск"}).Run(func(args mock.Arguments) {
+					args.Get(1).(*dao.City).ID = 3
+				}).Return(nil)
+				ml.On("Info", mock.Anything, mock.Anything)
+			},
+			request:      &onlymodels.City{Name: "  Новосибирск "},
+			userRole:     model.RoleModerator.Get(),
+			expectedName: "Новосибирск",
+		},
+		{
+			name: "Employee is denied",
+			setupMocks: func(_ *mockCityRepo, ml *mockLogger) {
+				ml.On("Warn", mock.Anything, mock.Anything)
+			},
+			request:       &onlymodels.City{Name: "Новосибирск"},
+			userRole:      model.RoleEmployee.Get(),
+			expectedError: model.ErrAccessDenied,
+		},
+		{
+			name: "Empty name",
+			setupMocks: func(_ *mockCityRepo, ml *mockLogger) {
+				ml.On("Warn", mock.Anything, mock.Anything)
+			},
+			request:       &onlymodels.City{Name: "   "},
+			userRole:      model.RoleModerator.Get(),
+			expectedError: model.ErrInvalidCityName,
+		},
+		{
+			name: "Duplicate name",
+			setupMocks: func(mc *mockCityRepo, ml *mockLogger) {
+				mc.On("Create", mock.Anything, mock.Anything).Return(errors.New(model.ErrCityAlreadyExists))
+				ml.On("Warn", mock.Anything, mock.Anything)
+			},
+			request:       &onlymodels.City{Name: "Москва"},
+			userRole:      model.RoleModerator.Get(),
+			expectedError: model.ErrCityAlreadyExists,
+		},
+		{
+			name: "Database error",
+			setupMocks: func(mc *mockCityRepo, ml *mockLogger) {
+				mc.On("Create", mock.Anything, mock.Anything).Return(errors.New("db error"))
+				ml.On("Error", mock.Anything, mock.Anything)
+			},
+			request:       &onlymodels.City{Name: "Новосибирск"},
+			userRole:      model.RoleModerator.Get(),
+			expectedError: model.ErrInternal,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mc := &mockCityRepo{}
+			ml := &mockLogger{}
+
+			if tt.setupMocks != nil {
+				tt.setupMocks(mc, ml)
+			}
+
+			uc := NewUseCaseManageCity(mc, newTestAuthorizer(), ml)
+			result, err := uc.Create(context.Background(), tt.request, tt.userRole)
+
+			if tt.expectedError != "" {
+				assert.Error(t, err)
+				assert.Equal(t, tt.expectedError, err.Error())
+				return
+			}
+
+			assert.NoError(t, err)
+			assert.NotNil(t, result.Id)
+			assert.Equal(t, tt.expectedName, result.Name)
+		})
+	}
+}
+
+func TestManageCity_Update(t *testing.T) {
+	tests := []struct {
+		name          string
+		setupMocks    func(*mockCityRepo, *mockLogger)
+		cityID        int32
+		request       *onlymodels.City
+		userRole      string
+		expectedError string
+	}{
+		{
+			name: "Success - rename city",
+			setupMocks: func(mc *mockCityRepo, ml *mockLogger) {
+				mc.On("Update", mock.Anything, &dao.City{ID: testCitySPBID, Name: "Петербург"}).Return(nil)
+				ml.On("Info", mock.Anything, mock.Anything)
+			},
+			cityID:   testCitySPBID,
+			request:  &onlymodels.City{Name: "Петербург"},
+			userRole: model.RoleModerator.Get(),
+		},
+		{
+			name: "City not found",
+			setupMocks: func(mc *mockCityRepo, ml *mockLogger) {
+				mc.On("Update", mock.Anything, mock.Anything).Return(errors.New(model.ErrCityNotFound))
+				ml.On("Warn", mock.Anything, mock.Anything)
+			},
+			cityID:        42,
+			request:       &onlymodels.City{Name: "Петербург"},
+			userRole:      model.RoleModerator.Get(),
+			expectedError: model.ErrCityNotFound,
+		},
+		{
+			name: "Employee is denied",
+			setupMocks: func(_ *mockCityRepo, ml *mockLogger) {
+				ml.On("Warn", mock.Anything, mock.Anything)
+			},
+			cityID:        testCitySPBID,
+			request:       &onlymodels.City{Name: "Петербург"},
+			userRole:      model.RoleEmployee.Get(),
+			expectedError: model.ErrAccessDenied,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mc := &mockCityRepo{}
+			ml := &mockLogger{}
+
+			if tt.setupMocks != nil {
+				tt.setupMocks(mc, ml)
+			}
+
+			uc := NewUseCaseManageCity(mc, newTestAuthorizer(), ml)
+			result, err := uc.Update(context.Background(), tt.cityID, tt.request, tt.userRole)
+
+			if tt.expectedError != "" {
+				assert.Error(t, err)
+				assert.Equal(t, tt.expectedError, err.Error())
+				return
+			}
+
+			assert.NoError(t, err)
+			assert.Equal(t, tt.cityID, *result.Id)
+			assert.Equal(t, tt.request.Name, result.Name)
+		})
+	}
+}
+
+func TestManageCity_Delete(t *testing.T) {
+	tests := []struct {
+		name          string
+		setupMocks    func(*mockCityRepo, *mockLogger)
+		cityID        int32
+		userRole      string
+		expectedError string
+	}{
+		{
+			name: "Success - delete unused city",
+			setupMocks: func(mc *mockCityRepo, ml *mockLogger) {
+				mc.On("Delete", mock.Anything, int32(3)).Return(nil)
+				ml.On("Info", mock.Anything, mock.Anything)
+			},
+			cityID:   3,
+			userRole: model.RoleModerator.Get(),
+		},
+		{
+			name: "City is used by PVZ",
+			setupMocks: func(mc *mockCityRepo, ml *mockLogger) {
+				mc.On("Delete", mock.Anything, testCityMoscowID).Return(errors.New(model.ErrCityIsInUse))
+				ml.On("Warn", mock.Anything, mock.Anything)
+			},
+			cityID:        testCityMoscowID,
+			userRole:      model.RoleModerator.Get(),
+			expectedError: model.ErrCityIsInUse,
+		},
+		{
+			name: "Database error",
+			setupMocks: func(mc *mockCityRepo, ml *mockLogger) {
+				mc.On("Delete", mock.Anything, int32(3)).Return(errors.New("db error"))
+				ml.On("Error", mock.Anything, mock.Anything)
+			},
+			cityID:        3,
+			userRole:      model.RoleModerator.Get(),
+			expectedError: model.ErrInternal,
+		},
+		{
+			name: "Employee is denied",
+			setupMocks: func(_ *mockCityRepo, ml *mockLogger) {
+				ml.On("Warn", mock.Anything, mock.Anything)
+			},
+			cityID:        3,
+			userRole:      model.RoleEmployee.Get(),
+			expectedError: model.ErrAccessDenied,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mc := &mockCityRepo{}
+			ml := &mockLogger{}
+
+			if tt.setupMocks != nil {
+				tt.setupMocks(mc, ml)
+			}
+
+			uc := NewUseCaseManageCity(mc, newTestAuthorizer(), ml)
+			err := uc.Delete(context.Background(), tt.cityID, tt.userRole)
+
+			if tt.expectedError != "" {
+				assert.Error(t, err)
+				assert.Equal(t, tt.expectedError, err.Error())
+				return
+			}
+
+			assert.NoError(t, err)
+			mc.AssertExpectations(t)
+		})
+	}
+}
@@ -0,0 +1,138 @@
+// Package usecase это юзкейсы и вспомогательная логика
+package usecase
+
+import (
+	"context"
+	"errors"
+	"internshipPVZ/internal/domain/model"
+	repo "internshipPVZ/internal/domain/repository"
+	"internshipPVZ/internal/domain/repository/dao"
+	"internshipPVZ/internal/http/onlymodels"
+	"log"
+	"time"
+)
+
+// moderatorInviteTTL время, за которое приглашённый должен зарегистрироваться
+const moderatorInviteTTL = 72 * time.Hour
+
+// NewUseCaseManageModeratorInvite конструктор
+func NewUseCaseManageModeratorInvite(
+	inviteRepo repo.ModeratorInviteRepo,
+	tokenService RefreshTokenService,
+	timeService TimeService,
+	authorizer Authorizer,
+	logger Logger,
+) *ManageModeratorInvite {
+	if inviteRepo == nil {
+		log.Fatalf("ManageModeratorInvite usecase inviteRepo nil")
+
+	}
+	if tokenService == nil {
+		log.Fatalf("ManageModeratorInvite usecase tokenService nil")
+
+	}
+	if timeService == nil {
+		log.Fatalf("ManageModeratorInvite usecase timeService nil")
+
+	}
+	if authorizer == nil {
+		log.Fatalf("ManageModeratorInvite usecase authorizer nil")
+
+	}
+	if logger == nil {
+		log.Fatalf("ManageModeratorInvite usecase logger nil")
+
+	}
+
+	return &ManageModeratorInvite{
+		inviteRepo:   inviteRepo,
+		tokenService: tokenService,
+		timeService:  timeService,
+		authorizer:   authorizer,
+		logger:       logger,
+	}
+}
+
+// ManageModeratorInvite юзкейс
+type ManageModeratorInvite struct {
+	inviteRepo   repo.ModeratorInviteRepo
+	tokenService RefreshTokenService
+	timeService  TimeService
+	authorizer   Authorizer
+	logger       Logger
+}
+
+// Create выдаёт одноразовое приглашение модератора в организацию текущего модератора.
+// В базе хранится только хэш кода, сам код возвращается один раз
+func (uc *ManageModeratorInvite) Create(ctx context.Context, userID, userRole string) (*onlymodels.ModeratorInvite, error) {
+	if err := uc.checkAccess("Create", userRole, model.PermissionInviteModerator); err != nil {
+		return nil, err
+	}
+	moderatorID, err := validateRawID(userID)
+	if err != nil {
+		uc.logger.Warn("invalid user ID in token",
+			"usecase", "ManageModeratorInvite",
+			"method", "Create",
+			"user_id", userID)
+		return nil, err
+	}
+
+	code, err := uc.tokenService.GenerateRefreshToken()
+	if err != nil {
+		uc.logger.Error("failed to generate invite code",
+			"usecase", "ManageModeratorInvite",
+			"method", "tokenService.GenerateRefreshToken",
+			"error", err)
+		return nil, errors.New(model.ErrInternal)
+	}
+
+	now := uc.timeService.GetTime()
+	invite := &dao.ModeratorInvite{
+		CodeHash:  uc.tokenService.HashRefreshToken(code),
+		CreatedBy: moderatorID.String(),
+		CreatedAt: now,
+		ExpiresAt: now.Add(moderatorInviteTTL),
+	}
+	err = uc.inviteRepo.Create(ctx, invite)
+	if err != nil {
+		uc.logger.Error("failed to create moderator invite",
+			"usecase", "ManageModeratorInvite",
+			"method", "inviteRepo.Create",
+			"user_id", moderatorID,
+			"error", err)
+		return nil, errors.New(model.ErrInternal)
+	}
+
+	organizationID, err := validateRawID(invite.OrganizationID)
+	if err != nil {
+		uc.logger.Error("failed to parse organization ID",
+			"usecase", "ManageModeratorInvite",
+			"method", "Create",
+			"organization_id", invite.OrganizationID,
+			"error", err)
+		return nil, errors.New(model.ErrInternal)
+	}
+
+	uc.logger.Info("moderator invite created successfully",
+		"usecase", "ManageModeratorInvite",
+		"invite_id", invite.ID,
+		"created_by", moderatorID,
+		"organization_id", organizationID)
+	return &onlymodels.ModeratorInvite{
+		Code:           code,
+		OrganizationId: organizationID,
+		ExpiresAt:      invite.ExpiresAt,
+	}, nil
+}
+
+func (uc *ManageModeratorInvite) checkAccess(method, userRole string, permission model.Permission) error {
+	role, err := validateRole(userRole)
+	if err != nil {
+		uc.logger.Warn("invalid user role",
+			"usecase", "ManageModeratorInvite",
+			"method", method,
+			"user_role", userRole)
+		return err
+	}
+	return checkPermission(uc.authorizer, uc.logger, "ManageModeratorInvite", method, role, permission)
+}
@@ -0,0 +1,99 @@
+// Package usecase это юзкейсы и вспомогательная логика
+package usecase
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"internshipPVZ/internal/domain/model"
+	"internshipPVZ/internal/domain/repository/dao"
+)
+
+func TestManageModeratorInvite_Create(t *testing.T) {
+	testTime := time.Now()
+	moderatorID := uuid.New()
+
+	tests := []struct {
+		name          string
+		setupMocks    func(*mockModeratorInviteRepo, *mockLogger)
+		userID        string
+		userRole      string
+		expectedError string
+	}{
+		{
+			name: "Success - only code hash stored",
+			setupMocks: func(mi *mockModeratorInviteRepo, ml *mockLogger) {
+				mi.On("Create", mock.Anything, mock.MatchedBy(func(invite *dao.ModeratorInvite) bool {
+					return invite.CodeHash == testRefreshTokenHash &&
+						invite.CreatedBy == moderatorID.String() &&
+						invite.ExpiresAt.Equal(testTime.Add(moderatorInviteTTL))
+				})).Run(func(args mock.Arguments) {
+					invite := args.Get(1).(*dao.ModeratorInvite)
+					invite.ID = uuid.NewString()
+					invite.OrganizationID = testOrganizationID.String()
+				}).Return(nil)
+				ml.On("Info", mock.Anything, mock.Anything)
+			},
+			userID:   moderatorID.String(),
+			userRole: model.RoleModerator.Get(),
+		},
+		{
+			name: "Employee is denied",
+			setupMocks: func(_ *mockModeratorInviteRepo, ml *mockLogger) {
+				ml.On("Warn", mock.Anything, mock.Anything)
+			},
+			userID:        moderatorID.String(),
+			userRole:      model.RoleEmployee.Get(),
+			expectedError: model.ErrAccessDenied,
+		},
+		{
+			name: "Invalid user ID in token",
+			setupMocks: func(_ *mockModeratorInviteRepo, ml *mockLogger) {
+				ml.On("Warn", mock.Anything, mock.Anything)
+			},
+			userID:        "not-a-uuid",
+			userRole:      model.RoleModerator.Get(),
+			expectedError: model.ErrAccessDenied,
+		},
+		{
+			name: "Database error",
+			setupMocks: func(mi *mockModeratorInviteRepo, ml *mockLogger) {
+				mi.On("Create", mock.Anything, mock.Anything).Return(errors.New("db error"))
+				ml.On("Error", mock.Anything, mock.Anything)
+			},
+			userID:        moderatorID.String(),
+			userRole:      model.RoleModerator.Get(),
+			expectedError: model.ErrInternal,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mi := &mockModeratorInviteRepo{}
+			mt := &mockTimeService{}
+			ml := &mockLogger{}
+
+			tt.setupMocks(mi, ml)
+			mt.On("GetTime").Return(testTime).Maybe()
+
+			uc := NewUseCaseManageModeratorInvite(mi, newTestRefreshTokenService(), mt, newTestAuthorizer(), ml)
+			invite, err := uc.Create(context.Background(), tt.userID, tt.userRole)
+
+			if tt.expectedError != "" {
+				assert.Error(t, err)
+				assert.Equal(t, tt.expectedError, err.Error())
+				return
+			}
+
+			assert.NoError(t, err)
+			assert.Equal(t, testRefreshToken, invite.Code)
+			assert.Equal(t, testOrganizationID, invite.OrganizationId)
+			mi.AssertExpectations(t)
+		})
+	}
+}
@@ -0,0 +1,321 @@
+// Package usecase это юзкейсы и вспомогательная логика
+package usecase
+
+import (
+	"context"
+	"errors"
+	"internshipPVZ/internal/domain/model"
+	repo "internshipPVZ/internal/domain/repository"
+	"internshipPVZ/internal/domain/repository/dao"
+	"internshipPVZ/internal/http/onlymodels"
+	"log"
+	"regexp"
+	"time"
+)
+
+// passwordResetTokenTTL время жизни токена сброса пароля
+const passwordResetTokenTTL = time.Hour
+
+// Notifier для доставки уведомлений пользователю
+type Notifier interface {
+	SendPasswordReset(ctx context.Context, notification model.PasswordResetNotification) error
+}
+
+// NewUseCaseManagePassword конструктор.
+// Токены сброса выпускает тот же сервис, что и refresh токены: это такие же случайные непрозрачные строки
+func NewUseCaseManagePassword(
+	userRepo repo.UserRepo,
+	sessionRepo repo.SessionRepo,
+	resetTokenRepo repo.PasswordResetTokenRepo,
+	hashService HashService,
+	tokenService RefreshTokenService,
+	notifier Notifier,
+	timeService TimeService,
+	logger Logger,
+) *ManagePassword {
+	if userRepo == nil {
+		log.Fatalf("ManagePassword usecase userRepo nil")
+
+	}
+	if sessionRepo == nil {
+		log.Fatalf("ManagePassword usecase sessionRepo nil")
+
+	}
+	if resetTokenRepo == nil {
+		log.Fatalf("ManagePassword usecase resetTokenRepo nil")
+
+	}
+	if hashService == nil {
+		log.Fatalf("ManagePassword usecase hashService nil")
+
+	}
+	if tokenService == nil {
+		log.Fatalf("ManagePassword usecase tokenService nil")
+
+	}
+	if notifier == nil {
+		log.Fatalf("ManagePassword usecase notifier nil")
+
+	}
+	if timeService == nil {
+		log.Fatalf("ManagePassword usecase timeService nil")
+
+	}
+	if logger == nil {
+		log.Fatalf("ManagePassword usecase logger nil")
+
+	}
+
+	return &ManagePassword{
+		userRepo:       userRepo,
+		sessionRepo:    sessionRepo,
+		resetTokenRepo: resetTokenRepo,
+		hashService:    hashService,
+		tokenService:   tokenService,
+		notifier:       notifier,
+		timeService:    timeService,
+		emailRegex:     regexp.MustCompile(emailPattern),
+		logger:         logger,
+	}
+}
+
+// ManagePassword юзкейс
+type ManagePassword struct {
+	userRepo       repo.UserRepo
+	sessionRepo    repo.SessionRepo
+	resetTokenRepo repo.PasswordResetTokenRepo
+	hashService    HashService
+	tokenService   RefreshTokenService
+	notifier       Notifier
+	timeService    TimeService
+	emailRegex     *regexp.Regexp
+	logger         Logger
+}
+
+// Change меняет пароль по текущему паролю и отзывает все сессии пользователя, кроме текущей
+func (uc *ManagePassword) Change(ctx context.Context, userID, sessionID string, request *onlymodels.PostPasswordChangeJSONBody) error {
+	id, err := validateRawID(userID)
+	if err != nil {
+		uc.logger.Warn("invalid user ID",
+			"usecase", "ManagePassword",
+			"method", "Change",
+			"user_id", userID)
+		return err
+	}
+	if request == nil {
+		return errors.New(model.ErrInvalidRequest)
+	}
+	newPassword, err := validatePassword(request.NewPassword)
+	if err != nil {
+		uc.logger.Warn("invalid new password",
+			"usecase", "ManagePassword",
+			"method", "Change",
+			"user_id", id)
+		return err
+	}
+
+	user, err := uc.userRepo.FindByID(ctx, id.String())
+	if err != nil {
+		uc.logger.Error("failed to find user by ID",
+			"usecase", "ManagePassword",
+			"method", "userRepo.FindByID",
+			"user_id", id,
+			"error", err)
+		return errors.New(model.ErrInternal)
+	}
+	if user == nil {
+		uc.logger.Warn("user not found",
+			"usecase", "ManagePassword",
+			"method", "Change",
+			"user_id", id)
+		return errors.New(model.ErrUserNotFound)
+	}
+
+	if !uc.hashService.HashAndComparePassword(request.CurrentPassword, user.Password) {
+		uc.logger.Warn("wrong current password",
+			"usecase", "ManagePassword",
+			"method", "Change",
+			"user_id", id)
+		return errors.New(model.ErrWrongCurrentPassword)
+	}
+
+	if err := uc.setPassword(ctx, "Change", user.ID, newPassword, sessionID); err != nil {
+		return err
+	}
+
+	uc.logger.Info("password changed successfully",
+		"usecase", "ManagePassword",
+		"user_id", id)
+	return nil
+}
+
+// RequestReset отправляет токен сброса пароля. Для неизвестного email ничего не отправляет,
+// но отвечает так же, чтобы по ответу нельзя было узнать, зарегистрирован ли адрес
+func (uc *ManagePassword) RequestReset(ctx context.Context, request *onlymodels.PostPasswordResetRequestJSONBody) error {
+	if request == nil {
+		return errors.New(model.ErrInvalidRequest)
+	}
+	email := string(request.Email)
+	if !uc.emailRegex.MatchString(email) {
+		uc.logger.Warn("invalid email in password reset request",
+			"usecase", "ManagePassword",
+			"method", "RequestReset",
+			"email", email)
+		return errors.New(model.ErrInvalidEmail)
+	}
+
+	user, err := uc.userRepo.FindByEmail(ctx, email)
+	if err != nil {
+		uc.logger.Error("failed to find user by email",
+			"usecase", "ManagePassword",
+			"method", "userRepo.FindByEmail",
+			"email", email,
+			"error", err)
+		return errors.New(model.ErrInternal)
+	}
+	if user == nil {
+		uc.logger.Warn("password reset requested for unknown email",
+			"usecase", "ManagePassword",
+			"method", "RequestReset",
+			"email", email)
+		return nil
+	}
+
+	token, err := uc.tokenService.GenerateRefreshToken()
+	if err != nil {
+		uc.logger.Error("failed to generate password reset token",
+			"usecase", "ManagePassword",
+			"method", "tokenService.GenerateRefreshToken",
+			"error", err)
+		return errors.New(model.ErrInternal)
+	}
+	now := uc.timeService.GetTime()
+	resetToken := &dao.PasswordResetToken{
+		UserID:    user.ID,
+		TokenHash: uc.tokenService.HashRefreshToken(token),
+		CreatedAt: now,
+		ExpiresAt: now.Add(passwordResetTokenTTL),
+	}
+	err = uc.resetTokenRepo.Create(ctx, resetToken)
+	if err != nil {
+		uc.logger.Error("failed to create password reset token",
+			"usecase", "ManagePassword",
+			"method", "resetTokenRepo.Create",
+			"user_id", user.ID,
+			"error", err)
+		return errors.New(model.ErrInternal)
+	}
+
+	err = uc.notifier.SendPasswordReset(ctx, model.PasswordResetNotification{
+		Email:     user.Email,
+		Token:     token,
+		ExpiresAt: resetToken.ExpiresAt,
+	})
+	if err != nil {
+		// ошибку отправки не показываем, иначе ответ для известного email отличался бы от ответа для неизвестного
+		uc.logger.Error("failed to send password reset token",
+			"usecase", "ManagePassword",
+			"method", "notifier.SendPasswordReset",
+			"user_id", user.ID,
+			"error", err)
+		return nil
+	}
+
+	uc.logger.Info("password reset token sent",
+		"usecase", "ManagePassword",
+		"user_id", user.ID,
+		"expires_at", resetToken.ExpiresAt)
+	return nil
+}
+
+// Reset устанавливает новый пароль по токену сброса. Токен одноразовый,
+// после сброса остальные токены пользователя удаляются, а все его сессии отзываются
+func (uc *ManagePassword) Reset(ctx context.Context, request *onlymodels.PostPasswordResetJSONBody) error {
+	if request == nil {
+		return errors.New(model.ErrInvalidRequest)
+	}
+	newPassword, err := validatePassword(request.NewPassword)
+	if err != nil {
+		uc.logger.Warn("invalid new password",
+			"usecase", "ManagePassword",
+			"method", "Reset")
+		return err
+	}
+	if request.Token == "" {
+		return errors.New(model.ErrInvalidResetToken)
+	}
+
+	resetToken, err := uc.resetTokenRepo.Consume(ctx, uc.tokenService.HashRefreshToken(request.Token), uc.timeService.GetTime())
+	if err != nil {
+		uc.logger.Error("failed to consume password reset token",
+			"usecase", "ManagePassword",
+			"method", "resetTokenRepo.Consume",
+			"error", err)
+		return errors.New(model.ErrInternal)
+	}
+	if resetToken == nil {
+		uc.logger.Warn("password reset token not found, used or expired",
+			"usecase", "ManagePassword",
+			"method", "Reset")
+		return errors.New(model.ErrInvalidResetToken)
+	}
+
+	if err := uc.setPassword(ctx, "Reset", resetToken.UserID, newPassword, ""); err != nil {
+		return err
+	}
+
+	err = uc.resetTokenRepo.DeleteByUser(ctx, resetToken.UserID)
+	if err != nil {
+		uc.logger.Error("failed to delete password reset tokens",
+			"usecase", "ManagePassword",
+			"method", "resetTokenRepo.DeleteByUser",
+			"user_id", resetToken.UserID,
+			"error", err)
+		return errors.New(model.ErrInternal)
+	}
+
+	uc.logger.Info("password reset successfully",
+		"usecase", "ManagePassword",
+		"user_id", resetToken.UserID)
+	return nil
+}
+
+// setPassword сохраняет хэш нового пароля и отзывает сессии пользователя, кроме keepSessionID
+func (uc *ManagePassword) setPassword(ctx context.Context, method, userID, password, keepSessionID string) error {
+	hash, err := uc.hashService.HashPassword(password)
+	if err != nil {
+		uc.logger.Error("failed to hash password",
+			"usecase", "ManagePassword",
+			"method", "hashService.HashPassword",
+			"error", err)
+		return errors.New(model.ErrInternal)
+	}
+
+	err = uc.userRepo.UpdatePassword(ctx, userID, hash)
+	if err != nil {
+		if err.Error() == model.ErrUserNotFound {
+			uc.logger.Warn("user not found",
+				"usecase", "ManagePassword",
+				"method", method,
+				"user_id", userID)
+			return err
+		}
+		uc.logger.Error("failed to update password",
+			"usecase", "ManagePassword",
+			"method", "userRepo.UpdatePassword",
+			"user_id", userID,
+			"error", err)
+		return errors.New(model.ErrInternal)
+	}
+
+	err = uc.sessionRepo.RevokeByUser(ctx, userID, keepSessionID, uc.timeService.GetTime())
+	if err != nil {
+		uc.logger.Error("failed to revoke user sessions",
+			"usecase", "ManagePassword",
+			"method", "sessionRepo.RevokeByUser",
+			"user_id", userID,
+			"error", err)
+		return errors.New(model.ErrInternal)
+	}
+	return nil
+}
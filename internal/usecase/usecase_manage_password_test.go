@@ -0,0 +1,307 @@
+// Package usecase это юзкейсы и вспомогательная логика
+package usecase
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/oapi-codegen/runtime/types"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"internshipPVZ/internal/domain/model"
+	"internshipPVZ/internal/domain/repository/dao"
+	"internshipPVZ/internal/http/onlymodels"
+)
+
+type mockPasswordResetTokenRepo struct{ mock.Mock }
+
+func (m *mockPasswordResetTokenRepo) Create(ctx context.Context, token *dao.PasswordResetToken) error {
+	args := m.Called(ctx, token)
+	return args.Error(0)
+}
+
+func (m *mockPasswordResetTokenRepo) Consume(ctx context.Context, tokenHash string, now time.Time) (*dao.PasswordResetToken, error) {
+	args := m.Called(ctx, tokenHash, now)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*dao.PasswordResetToken), args.Error(1)
+}
+
+func (m *mockPasswordResetTokenRepo) DeleteByUser(ctx context.Context, userID string) error {
+	args := m.Called(ctx, userID)
+	return args.Error(0)
+}
+
+type mockNotifier struct{ mock.Mock }
+
+func (m *mockNotifier) SendPasswordReset(ctx context.Context, notification model.PasswordResetNotification) error {
+	args := m.Called(ctx, notification)
+	return args.Error(0)
+}
+
+type passwordMocks struct {
+	users    *mockUserRepo
+	sessions *mockSessionRepo
+	tokens   *mockPasswordResetTokenRepo
+	hash     *mockHashService
+	notifier *mockNotifier
+	logger   *mockLogger
+}
+
+func newPasswordUseCase(testTime time.Time, setupMocks func(*passwordMocks)) *ManagePassword {
+	m := &passwordMocks{
+		users:    &mockUserRepo{},
+		sessions: &mockSessionRepo{},
+		tokens:   &mockPasswordResetTokenRepo{},
+		hash:     &mockHashService{},
+		notifier: &mockNotifier{},
+		logger:   &mockLogger{},
+	}
+	setupMocks(m)
+	mt := &mockTimeService{}
+	mt.On("GetTime").Return(testTime).Maybe()
+	return NewUseCaseManagePassword(m.users, m.sessions, m.tokens, m.hash, newTestRefreshTokenService(), m.notifier, mt, m.logger)
+}
+
+func TestManagePassword_Change(t *testing.T) {
+	testTime := time.Now()
+	userID := uuid.New()
+	user := &dao.User{ID: userID.String(), Email: "test@example.com", Password: "oldHash"}
+	validRequest := &onlymodels.PostPasswordChangeJSONBody{CurrentPassword: "oldPassword1", NewPassword: "newPassword1"}
+
+	tests := []struct {
+		name          string
+		setupMocks    func(*passwordMocks)
+		userID        string
+		request       *onlymodels.PostPasswordChangeJSONBody
+		expectedError string
+	}{
+		{
+			name: "Success - other sessions revoked",
+			setupMocks: func(m *passwordMocks) {
+				m.users.On("FindByID", mock.Anything, userID.String()).Return(user, nil)
+				m.hash.On("HashAndComparePassword", "oldPassword1", "oldHash").Return(true)
+				m.hash.On("HashPassword", "newPassword1").Return("newHash", nil)
+				m.users.On("UpdatePassword", mock.Anything, userID.String(), "newHash").Return(nil)
+				m.sessions.On("RevokeByUser", mock.Anything, userID.String(), testSessionID, testTime).Return(nil)
+				m.logger.On("Info", mock.Anything, mock.Anything)
+			},
+			userID:  userID.String(),
+			request: validRequest,
+		},
+		{
+			name: "Invalid user ID",
+			setupMocks: func(m *passwordMocks) {
+				m.logger.On("Warn", mock.Anything, mock.Anything)
+			},
+			userID:        "not-a-uuid",
+			request:       validRequest,
+			expectedError: model.ErrAccessDenied,
+		},
+		{
+			name: "Too short new password",
+			setupMocks: func(m *passwordMocks) {
+				m.logger.On("Warn", mock.Anything, mock.Anything)
+			},
+			userID:        userID.String(),
+			request:       &onlymodels.PostPasswordChangeJSONBody{CurrentPassword: "oldPassword1", NewPassword: "short"},
+			expectedError: model.ErrInvalidPassword,
+		},
+		{
+			name: "User not found",
+			setupMocks: func(m *passwordMocks) {
+				m.users.On("FindByID", mock.Anything, userID.String()).Return(nil, nil)
+				m.logger.On("Warn", mock.Anything, mock.Anything)
+			},
+			userID:        userID.String(),
+			request:       validRequest,
+			expectedError: model.ErrUserNotFound,
+		},
+		{
+			name: "Wrong current password",
+			setupMocks: func(m *passwordMocks) {
+				m.users.On("FindByID", mock.Anything, userID.String()).Return(user, nil)
+				m.hash.On("HashAndComparePassword", "oldPassword1", "oldHash").Return(false)
+				m.logger.On("Warn", mock.Anything, mock.Anything)
+			},
+			userID:        userID.String(),
+			request:       validRequest,
+			expectedError: model.ErrWrongCurrentPassword,
+		},
+		{
+			name: "Session revocation error",
+			setupMocks: func(m *passwordMocks) {
+				m.users.On("FindByID", mock.Anything, userID.String()).Return(user, nil)
+				m.hash.On("HashAndComparePassword", "oldPassword1", "oldHash").Return(true)
+				m.hash.On("HashPassword", "newPassword1").Return("newHash", nil)
+				m.users.On("UpdatePassword", mock.Anything, userID.String(), "newHash").Return(nil)
+				m.sessions.On("RevokeByUser", mock.Anything, userID.String(), testSessionID, testTime).Return(errors.New("db error"))
+				m.logger.On("Error", mock.Anything, mock.Anything)
+			},
+			userID:        userID.String(),
+			request:       validRequest,
+			expectedError: model.ErrInternal,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			uc := newPasswordUseCase(testTime, tt.setupMocks)
+			err := uc.Change(context.Background(), tt.userID, testSessionID, tt.request)
+
+			if tt.expectedError != "" {
+				assert.Error(t, err)
+				assert.Equal(t, tt.expectedError, err.Error())
+				return
+			}
+			assert.NoError(t, err)
+		})
+	}
+}
+
+func TestManagePassword_RequestReset(t *testing.T) {
+	testTime := time.Now()
+	user := &dao.User{ID: uuid.NewString(), Email: "test@example.com", Password: "hash"}
+
+	tests := []struct {
+		name          string
+		setupMocks    func(*passwordMocks)
+		email         string
+		expectedError string
+	}{
+		{
+			name: "Success - token stored hashed and sent",
+			setupMocks: func(m *passwordMocks) {
+				m.users.On("FindByEmail", mock.Anything, user.Email).Return(user, nil)
+				m.tokens.On("Create", mock.Anything, mock.MatchedBy(func(token *dao.PasswordResetToken) bool {
+					return token.UserID == user.ID &&
+						token.TokenHash == testRefreshTokenHash &&
+						token.ExpiresAt.Equal(testTime.Add(passwordResetTokenTTL))
+				})).Return(nil)
+				m.notifier.On("SendPasswordReset", mock.Anything, model.PasswordResetNotification{
+					Email:     user.Email,
+					Token:     testRefreshToken,
+					ExpiresAt: testTime.Add(passwordResetTokenTTL),
+				}).Return(nil)
+				m.logger.On("Info", mock.Anything, mock.Anything)
+			},
+			email: user.Email,
+		},
+		{
+			name: "Unknown email - same answer, nothing sent",
+			setupMocks: func(m *passwordMocks) {
+				m.users.On("FindByEmail", mock.Anything, "nobody@example.com").Return(nil, nil)
+				m.logger.On("Warn", mock.Anything, mock.Anything)
+			},
+			email: "nobody@example.com",
+		},
+		{
+			name: "Invalid email",
+			setupMocks: func(m *passwordMocks) {
+				m.logger.On("Warn", mock.Anything, mock.Anything)
+			},
+			email:         "invalid-email",
+			expectedError: model.ErrInvalidEmail,
+		},
+		{
+			name: "Notifier error is not revealed",
+			setupMocks: func(m *passwordMocks) {
+				m.users.On("FindByEmail", mock.Anything, user.Email).Return(user, nil)
+				m.tokens.On("Create", mock.Anything, mock.Anything).Return(nil)
+				m.notifier.On("SendPasswordReset", mock.Anything, mock.Anything).Return(errors.New("smtp error"))
+				m.logger.On("Error", mock.Anything, mock.Anything)
+			},
+			email: user.Email,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			uc := newPasswordUseCase(testTime, tt.setupMocks)
+			err := uc.RequestReset(context.Background(), &onlymodels.PostPasswordResetRequestJSONBody{Email: types.Email(tt.email)})
+
+			if tt.expectedError != "" {
+				assert.Error(t, err)
+				assert.Equal(t, tt.expectedError, err.Error())
+				return
+			}
+			assert.NoError(t, err)
+		})
+	}
+}
+
+func TestManagePassword_Reset(t *testing.T) {
+	testTime := time.Now()
+	userID := uuid.NewString()
+	resetToken := &dao.PasswordResetToken{ID: uuid.NewString(), UserID: userID, TokenHash: testOldRefreshTokenHash}
+
+	tests := []struct {
+		name          string
+		setupMocks    func(*passwordMocks)
+		request       *onlymodels.PostPasswordResetJSONBody
+		expectedError string
+	}{
+		{
+			name: "Success - all sessions and other tokens revoked",
+			setupMocks: func(m *passwordMocks) {
+				m.tokens.On("Consume", mock.Anything, testOldRefreshTokenHash, testTime).Return(resetToken, nil)
+				m.hash.On("HashPassword", "newPassword1").Return("newHash", nil)
+				m.users.On("UpdatePassword", mock.Anything, userID, "newHash").Return(nil)
+				m.sessions.On("RevokeByUser", mock.Anything, userID, "", testTime).Return(nil)
+				m.tokens.On("DeleteByUser", mock.Anything, userID).Return(nil)
+				m.logger.On("Info", mock.Anything, mock.Anything)
+			},
+			request: &onlymodels.PostPasswordResetJSONBody{Token: testOldRefreshToken, NewPassword: "newPassword1"},
+		},
+		{
+			name: "Used or expired token",
+			setupMocks: func(m *passwordMocks) {
+				m.tokens.On("Consume", mock.Anything, testOldRefreshTokenHash, testTime).Return(nil, nil)
+				m.logger.On("Warn", mock.Anything, mock.Anything)
+			},
+			request:       &onlymodels.PostPasswordResetJSONBody{Token: testOldRefreshToken, NewPassword: "newPassword1"},
+			expectedError: model.ErrInvalidResetToken,
+		},
+		{
+			name:          "Empty token",
+			setupMocks:    func(_ *passwordMocks) {},
+			request:       &onlymodels.PostPasswordResetJSONBody{NewPassword: "newPassword1"},
+			expectedError: model.ErrInvalidResetToken,
+		},
+		{
+			name: "Too short new password",
+			setupMocks: func(m *passwordMocks) {
+				m.logger.On("Warn", mock.Anything, mock.Anything)
+			},
+			request:       &onlymodels.PostPasswordResetJSONBody{Token: testOldRefreshToken, NewPassword: "short"},
+			expectedError: model.ErrInvalidPassword,
+		},
+		{
+			name: "Database error",
+			setupMocks: func(m *passwordMocks) {
+				m.tokens.On("Consume", mock.Anything, testOldRefreshTokenHash, testTime).Return(nil, errors.New("db error"))
+				m.logger.On("Error", mock.Anything, mock.Anything)
+			},
+			request:       &onlymodels.PostPasswordResetJSONBody{Token: testOldRefreshToken, NewPassword: "newPassword1"},
+			expectedError: model.ErrInternal,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			uc := newPasswordUseCase(testTime, tt.setupMocks)
+			err := uc.Reset(context.Background(), tt.request)
+
+			if tt.expectedError != "" {
+				assert.Error(t, err)
+				assert.Equal(t, tt.expectedError, err.Error())
+				return
+			}
+			assert.NoError(t, err)
+		})
+	}
+}
@@ -0,0 +1,212 @@
+// Package usecase это юзкейсы и вспомогательная логика
+package usecase
+
+import (
+	"context"
+	"errors"
+	"internshipPVZ/internal/domain/model"
+	repo "internshipPVZ/internal/domain/repository"
+	"internshipPVZ/internal/http/onlymodels"
+	"log"
+)
+
+// NewUseCaseManageProductType конструктор
+func NewUseCaseManageProductType(
+	productTypeRepo repo.ProductTypeRepo,
+	authorizer Authorizer,
+	logger Logger,
+) *ManageProductType {
+	if productTypeRepo == nil {
+		log.Fatalf("ManageProductType usecase productTypeRepo nil")
+
+	}
+	if authorizer == nil {
+		log.Fatalf("ManageProductType usecase authorizer nil")
+
+	}
+	if logger == nil {
+		log.Fatalf("ManageProductType usecase logger nil")
+
+	}
+
+	return &ManageProductType{
+		productTypeRepo: productTypeRepo,
+		authorizer:      authorizer,
+		logger:          logger,
+	}
+}
+
+// ManageProductType юзкейс
+type ManageProductType struct {
+	productTypeRepo repo.ProductTypeRepo
+	authorizer      Authorizer
+	logger          Logger
+}
+
+// GetAll выдаёт справочник типов продуктов, включая деактивированные
+func (uc *ManageProductType) GetAll(ctx context.Context, userRole string) ([]onlymodels.ProductType, error) {
+	if err := uc.checkAccess("GetAll", userRole, model.PermissionReadProductTypes); err != nil {
+		return nil, err
+	}
+
+	productTypes, err := uc.productTypeRepo.GetAll(ctx)
+	if err != nil {
+		uc.logger.Error("failed to get product types",
+			"usecase", "ManageProductType",
+			"method", "productTypeRepo.GetAll",
+			"error", err)
+		return nil, errors.New(model.ErrInternal)
+	}
+
+	result := make([]onlymodels.ProductType, 0, len(productTypes))
+	for _, productType := range productTypes {
+		productTypeDto, err := productTypeDaoToDto(productType)
+		if err != nil {
+			uc.logger.Error("failed to convert product type DAO to DTO",
+				"usecase", "ManageProductType",
+				"method", "productTypeDaoToDto",
+				"error", err)
+			return nil, errors.New(model.ErrInternal)
+		}
+		result = append(result, *productTypeDto)
+	}
+
+	uc.logger.Info("product types retrieved successfully",
+		"usecase", "ManageProductType",
+		"count", len(result))
+	return result, nil
+}
+
+// Create добавляет активный тип продукта в справочник
+func (uc *ManageProductType) Create(ctx context.Context, request *onlymodels.ProductType, userRole string) (*onlymodels.ProductType, error) {
+	if err := uc.checkAccess("Create", userRole, model.PermissionManageProductTypes); err != nil {
+		return nil, err
+	}
+
+	productType, err := uc.validateProductType(request)
+	if err != nil {
+		return nil, err
+	}
+
+	productTypeDao := productType.ToDao()
+	err = uc.productTypeRepo.Create(ctx, productTypeDao)
+	if err != nil {
+		if err.Error() == model.ErrProductTypeAlreadyExists {
+			uc.logger.Warn("product type already exists",
+				"usecase", "ManageProductType",
+				"method", "productTypeRepo.Create",
+				"product_type", productTypeDao.Name)
+			return nil, err
+		}
+		uc.logger.Error("failed to create product type",
+			"usecase", "ManageProductType",
+			"method", "productTypeRepo.Create",
+			"product_type", productTypeDao.Name,
+			"error", err)
+		return nil, errors.New(model.ErrInternal)
+	}
+
+	uc.logger.Info("product type created successfully",
+		"usecase", "ManageProductType",
+		"product_type_id", productTypeDao.ID,
+		"product_type", productTypeDao.Name)
+	return productTypeDaoToDto(productTypeDao)
+}
+
+// Rename переименовывает тип продукта, в том числе у ранее принятых товаров
+func (uc *ManageProductType) Rename(ctx context.Context, productTypeID int16, request *onlymodels.ProductType, userRole string) (*onlymodels.ProductType, error) {
+	if err := uc.checkAccess("Rename", userRole, model.PermissionManageProductTypes); err != nil {
+		return nil, err
+	}
+
+	productType, err := uc.validateProductType(request)
+	if err != nil {
+		return nil, err
+	}
+	productType.ID = productTypeID
+
+	productTypeDao := productType.ToDao()
+	err = uc.productTypeRepo.Rename(ctx, productTypeDao)
+	if err != nil {
+		if err.Error() == model.ErrProductTypeAlreadyExists || err.Error() == model.ErrProductTypeNotFound {
+			uc.logger.Warn("failed to rename product type",
+				"usecase", "ManageProductType",
+				"method", "productTypeRepo.Rename",
+				"product_type_id", productTypeDao.ID,
+				"error", err)
+			return nil, err
+		}
+		uc.logger.Error("failed to rename product type",
+			"usecase", "ManageProductType",
+			"method", "productTypeRepo.Rename",
+			"product_type_id", productTypeDao.ID,
+			"error", err)
+		return nil, errors.New(model.ErrInternal)
+	}
+
+	uc.logger.Info("product type renamed successfully",
+		"usecase", "ManageProductType",
+		"product_type_id", productTypeDao.ID,
+		"product_type", productTypeDao.Name)
+	return productTypeDaoToDto(productTypeDao)
+}
+
+// Deactivate запрещает указывать тип у новых продуктов, ранее принятые продукты его сохраняют
+func (uc *ManageProductType) Deactivate(ctx context.Context, productTypeID int16, userRole string) (*onlymodels.ProductType, error) {
+	if err := uc.checkAccess("Deactivate", userRole, model.PermissionManageProductTypes); err != nil {
+		return nil, err
+	}
+
+	productType := &model.ProductType{ID: productTypeID}
+	productTypeDao := productType.ToDao()
+	err := uc.productTypeRepo.Deactivate(ctx, productTypeDao)
+	if err != nil {
+		if err.Error() == model.ErrProductTypeNotFound {
+			uc.logger.Warn("failed to deactivate product type",
+				"usecase", "ManageProductType",
+				"method", "productTypeRepo.Deactivate",
+				"product_type_id", productTypeID,
+				"error", err)
+			return nil, err
+		}
+		uc.logger.Error("failed to deactivate product type",
+			"usecase", "ManageProductType",
+			"method", "productTypeRepo.Deactivate",
+			"product_type_id", productTypeID,
+			"error", err)
+		return nil, errors.New(model.ErrInternal)
+	}
+
+	uc.logger.Info("product type deactivated successfully",
+		"usecase", "ManageProductType",
+		"product_type_id", productTypeDao.ID,
+		"product_type", productTypeDao.Name)
+	return productTypeDaoToDto(productTypeDao)
+}
+
+func (uc *ManageProductType) checkAccess(method, userRole string, permission model.Permission) error {
+	role, err := validateRole(userRole)
+	if err != nil {
+		uc.logger.Warn("invalid user role",
+			"usecase", "ManageProductType",
+			"method", method,
+			"user_role", userRole)
+		return err
+	}
+	return checkPermission(uc.authorizer, uc.logger, "ManageProductType", method, role, permission)
+}
+
+func (uc *ManageProductType) validateProductType(request *onlymodels.ProductType) (*model.ProductType, error) {
+	if request == nil {
+		return nil, errors.New(model.ErrInvalidRequest)
+	}
+	name, ok := normalizeProductTypeName(request.Name)
+	if !ok {
+		uc.logger.Warn("invalid product type name",
+			"usecase", "ManageProductType",
+			"method", "validateProductType",
+			"product_type", request.Name)
+		return nil, errors.New(model.ErrInvalidProductType)
+	}
+	return &model.ProductType{Name: name, Active: true}, nil
+}
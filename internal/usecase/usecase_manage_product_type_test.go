@@ -0,0 +1,350 @@
+// Package usecase это юзкейсы и вспомогательная логика
+package usecase
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"internshipPVZ/internal/domain/model"
+	"internshipPVZ/internal/domain/repository/dao"
+	"internshipPVZ/internal/http/onlymodels"
+)
+
+func testDeactivatedProductType() *dao.ProductType {
+	return &dao.ProductType{ID: 3, Name: "пластинки", Active: false}
+}
+
+func testProductTypes() []*dao.ProductType {
+	return []*dao.ProductType{
+		{ID: 0, Name: "электроника", Active: true},
+		{ID: 1, Name: "обувь", Active: true},
+		{ID: 2, Name: "одежда", Active: true},
+		testDeactivatedProductType(),
+	}
+}
+
+func testProductTypeCatalog() productTypeCatalog {
+	catalog, _ := newProductTypeCatalog(testProductTypes())
+	return catalog
+}
+
+// newTestProductTypeRepo мок справочника с тремя исходными и одним деактивированным типом
+func newTestProductTypeRepo() *mockProductTypeRepo {
+	m := &mockProductTypeRepo{}
+	m.On("GetAll", mock.Anything).Return(testProductTypes(), nil).Maybe()
+	for _, productType := range testProductTypes() {
+		m.On("FindByName", mock.Anything, productType.Name).Return(productType, nil).Maybe()
+	}
+	m.On("FindByName", mock.Anything, mock.Anything).Return(nil, nil).Maybe()
+	return m
+}
+
+type mockProductTypeRepo struct{ mock.Mock }
+
+func (m *mockProductTypeRepo) Create(ctx context.Context, productType *dao.ProductType) error {
+	args := m.Called(ctx, productType)
+	return args.Error(0)
+}
+
+func (m *mockProductTypeRepo) GetAll(ctx context.Context) ([]*dao.ProductType, error) {
+	args := m.Called(ctx)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]*dao.ProductType), args.Error(1)
+}
+
+func (m *mockProductTypeRepo) FindByName(ctx context.Context, name string) (*dao.ProductType, error) {
+	args := m.Called(ctx, name)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*dao.ProductType), args.Error(1)
+}
+
+func (m *mockProductTypeRepo) Rename(ctx context.Context, productType *dao.ProductType) error {
+	args := m.Called(ctx, productType)
+	return args.Error(0)
+}
+
+func (m *mockProductTypeRepo) Deactivate(ctx context.Context, productType *dao.ProductType) error {
+	args := m.Called(ctx, productType)
+	return args.Error(0)
+}
+
+func TestManageProductType_GetAll(t *testing.T) {
+	tests := []struct {
+		name          string
+		setupMocks    func(*mockProductTypeRepo, *mockLogger)
+		userRole      string
+		expectedCount int
+		expectedError string
+	}{
+		{
+			name: "Success - employee sees deactivated types too",
+			setupMocks: func(mpt *mockProductTypeRepo, ml *mockLogger) {
+				mpt.On("GetAll", mock.Anything).Return(testProductTypes(), nil)
+				ml.On("Info", mock.Anything, mock.Anything)
+			},
+			userRole:      model.RoleEmployee.Get(),
+			expectedCount: 4,
+		},
+		{
+			name: "Invalid user role",
+			setupMocks: func(_ *mockProductTypeRepo, ml *mockLogger) {
+				ml.On("Warn", mock.Anything, mock.Anything)
+			},
+			userRole:      "invalid-role",
+			expectedError: model.ErrInvalidRole,
+		},
+		{
+			name: "Database error",
+			setupMocks: func(mpt *mockProductTypeRepo, ml *mockLogger) {
+				mpt.On("GetAll", mock.Anything).Return(nil, errors.New("db error"))
+				ml.On("Error", mock.Anything, mock.Anything)
+			},
+			userRole:      model.RoleModerator.Get(),
+			expectedError: model.ErrInternal,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mpt := &mockProductTypeRepo{}
+			ml := &mockLogger{}
+
+			if tt.setupMocks != nil {
+				tt.setupMocks(mpt, ml)
+			}
+
+			uc := NewUseCaseManageProductType(mpt, newTestAuthorizer(), ml)
+			result, err := uc.GetAll(context.Background(), tt.userRole)
+
+			if tt.expectedError != "" {
+				assert.Error(t, err)
+				assert.Equal(t, tt.expectedError, err.Error())
+				return
+			}
+
+			assert.NoError(t, err)
+			assert.Len(t, result, tt.expectedCount)
+			assert.False(t, *result[len(result)-1].Active)
+		})
+	}
+}
+
+func TestManageProductType_Create(t *testing.T) {
+	tests := []struct {
+		name          string
+		setupMocks    func(*mockProductTypeRepo, *mockLogger)
+		request       *onlymodels.ProductType
+		userRole      string
+		expectedError string
+	}{
+		{
+			name: "Success - new type is active",
+			setupMocks: func(mpt *mockProductTypeRepo, ml *mockLogger) {
+				mpt.On("Create", mock.Anything, &dao.ProductType{Name: "мебель", Active: true}).Run(func(args mock.Arguments) {
+					args.Get(1).(*dao.ProductType).ID = 4
+				}).Return(nil)
+				ml.On("Info", mock.Anything, mock.Anything)
+			},
+			request:  &onlymodels.ProductType{Name: " мебель "},
+			userRole: model.RoleModerator.Get(),
+		},
+		{
+			name: "Employee is denied",
+			setupMocks: func(_ *mockProductTypeRepo, ml *mockLogger) {
+				ml.On("Warn", mock.Anything, mock.Anything)
+			},
+			request:       &onlymodels.ProductType{Name: "мебель"},
+			userRole:      model.RoleEmployee.Get(),
+			expectedError: model.ErrAccessDenied,
+		},
+		{
+			name: "Empty name",
+			setupMocks: func(_ *mockProductTypeRepo, ml *mockLogger) {
+				ml.On("Warn", mock.Anything, mock.Anything)
+			},
+			request:       &onlymodels.ProductType{Name: ""},
+			userRole:      model.RoleModerator.Get(),
+			expectedError: model.ErrInvalidProductType,
+		},
+		{
+			name: "Duplicate name",
+			setupMocks: func(mpt *mockProductTypeRepo, ml *mockLogger) {
+				mpt.On("Create", mock.Anything, mock.Anything).Return(errors.New(model.ErrProductTypeAlreadyExists))
+				ml.On("Warn", mock.Anything, mock.Anything)
+			},
+			request:       &onlymodels.ProductType{Name: "обувь"},
+			userRole:      model.RoleModerator.Get(),
+			expectedError: model.ErrProductTypeAlreadyExists,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mpt := &mockProductTypeRepo{}
+			ml := &mockLogger{}
+
+			if tt.setupMocks != nil {
+				tt.setupMocks(mpt, ml)
+			}
+
+			uc := NewUseCaseManageProductType(mpt, newTestAuthorizer(), ml)
+			result, err := uc.Create(context.Background(), tt.request, tt.userRole)
+
+			if tt.expectedError != "" {
+				assert.Error(t, err)
+				assert.Equal(t, tt.expectedError, err.Error())
+				return
+			}
+
+			assert.NoError(t, err)
+			assert.Equal(t, int32(4), *result.Id)
+			assert.Equal(t, "мебель", result.Name)
+			assert.True(t, *result.Active)
+		})
+	}
+}
+
+func TestManageProductType_Rename(t *testing.T) {
+	tests := []struct {
+		name          string
+		setupMocks    func(*mockProductTypeRepo, *mockLogger)
+		productTypeID int16
+		request       *onlymodels.ProductType
+		userRole      string
+		expectedError string
+	}{
+		{
+			name: "Success - rename deactivated type keeps it deactivated",
+			setupMocks: func(mpt *mockProductTypeRepo, ml *mockLogger) {
+				mpt.On("Rename", mock.Anything, mock.Anything).Run(func(args mock.Arguments) {
+					args.Get(1).(*dao.ProductType).Active = false
+				}).Return(nil)
+				ml.On("Info", mock.Anything, mock.Anything)
+			},
+			productTypeID: 3,
+			request:       &onlymodels.ProductType{Name: "винил"},
+			userRole:      model.RoleModerator.Get(),
+		},
+		{
+			name: "Type not found",
+			setupMocks: func(mpt *mockProductTypeRepo, ml *mockLogger) {
+				mpt.On("Rename", mock.Anything, mock.Anything).Return(errors.New(model.ErrProductTypeNotFound))
+				ml.On("Warn", mock.Anything, mock.Anything)
+			},
+			productTypeID: 42,
+			request:       &onlymodels.ProductType{Name: "винил"},
+			userRole:      model.RoleModerator.Get(),
+			expectedError: model.ErrProductTypeNotFound,
+		},
+		{
+			name: "Database error",
+			setupMocks: func(mpt *mockProductTypeRepo, ml *mockLogger) {
+				mpt.On("Rename", mock.Anything, mock.Anything).Return(errors.New("db error"))
+				ml.On("Error", mock.Anything, mock.Anything)
+			},
+			productTypeID: 3,
+			request:       &onlymodels.ProductType{Name: "винил"},
+			userRole:      model.RoleModerator.Get(),
+			expectedError: model.ErrInternal,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mpt := &mockProductTypeRepo{}
+			ml := &mockLogger{}
+
+			if tt.setupMocks != nil {
+				tt.setupMocks(mpt, ml)
+			}
+
+			uc := NewUseCaseManageProductType(mpt, newTestAuthorizer(), ml)
+			result, err := uc.Rename(context.Background(), tt.productTypeID, tt.request, tt.userRole)
+
+			if tt.expectedError != "" {
+				assert.Error(t, err)
+				assert.Equal(t, tt.expectedError, err.Error())
+				return
+			}
+
+			assert.NoError(t, err)
+			assert.Equal(t, int32(tt.productTypeID), *result.Id)
+			assert.Equal(t, tt.request.Name, result.Name)
+			assert.False(t, *result.Active)
+		})
+	}
+}
+
+func TestManageProductType_Deactivate(t *testing.T) {
+	tests := []struct {
+		name          string
+		setupMocks    func(*mockProductTypeRepo, *mockLogger)
+		productTypeID int16
+		userRole      string
+		expectedError string
+	}{
+		{
+			name: "Success - deactivate type",
+			setupMocks: func(mpt *mockProductTypeRepo, ml *mockLogger) {
+				mpt.On("Deactivate", mock.Anything, &dao.ProductType{ID: 1}).Run(func(args mock.Arguments) {
+					productType := args.Get(1).(*dao.ProductType)
+					productType.Name = "обувь"
+					productType.Active = false
+				}).Return(nil)
+				ml.On("Info", mock.Anything, mock.Anything)
+			},
+			productTypeID: 1,
+			userRole:      model.RoleModerator.Get(),
+		},
+		{
+			name: "Type not found",
+			setupMocks: func(mpt *mockProductTypeRepo, ml *mockLogger) {
+				mpt.On("Deactivate", mock.Anything, mock.Anything).Return(errors.New(model.ErrProductTypeNotFound))
+				ml.On("Warn", mock.Anything, mock.Anything)
+			},
+			productTypeID: 42,
+			userRole:      model.RoleModerator.Get(),
+			expectedError: model.ErrProductTypeNotFound,
+		},
+		{
+			name: "Employee is denied",
+			setupMocks: func(_ *mockProductTypeRepo, ml *mockLogger) {
+				ml.On("Warn", mock.Anything, mock.Anything)
+			},
+			productTypeID: 1,
+			userRole:      model.RoleEmployee.Get(),
+			expectedError: model.ErrAccessDenied,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mpt := &mockProductTypeRepo{}
+			ml := &mockLogger{}
+
+			if tt.setupMocks != nil {
+				tt.setupMocks(mpt, ml)
+			}
+
+			uc := NewUseCaseManageProductType(mpt, newTestAuthorizer(), ml)
+			result, err := uc.Deactivate(context.Background(), tt.productTypeID, tt.userRole)
+
+			if tt.expectedError != "" {
+				assert.Error(t, err)
+				assert.Equal(t, tt.expectedError, err.Error())
+				return
+			}
+
+			assert.NoError(t, err)
+			assert.Equal(t, "обувь", result.Name)
+			assert.False(t, *result.Active)
+		})
+	}
+}
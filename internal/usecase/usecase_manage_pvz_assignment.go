@@ -0,0 +1,271 @@
+// Package usecase это юзкейсы и вспомогательная логика
+package usecase
+
+import (
+	"context"
+	"errors"
+	"github.com/google/uuid"
+	"internshipPVZ/internal/domain/model"
+	repo "internshipPVZ/internal/domain/repository"
+	"internshipPVZ/internal/domain/repository/dao"
+	"internshipPVZ/internal/http/onlymodels"
+	"log"
+)
+
+// NewUseCaseManagePVZAssignment конструктор
+func NewUseCaseManagePVZAssignment(
+	assignmentRepo repo.PVZAssignmentRepo,
+	pvzRepo repo.PVZRepo,
+	timeService TimeService,
+	authorizer Authorizer,
+	logger Logger,
+) *ManagePVZAssignment {
+	if assignmentRepo == nil {
+		log.Fatalf("ManagePVZAssignment usecase assignmentRepo nil")
+
+	}
+	if pvzRepo == nil {
+		log.Fatalf("ManagePVZAssignment usecase pvzRepo nil")
+
+	}
+	if timeService == nil {
+		log.Fatalf("ManagePVZAssignment usecase timeService nil")
+
+	}
+	if authorizer == nil {
+		log.Fatalf("ManagePVZAssignment usecase authorizer nil")
+
+	}
+	if logger == nil {
+		log.Fatalf("ManagePVZAssignment usecase logger nil")
+
+	}
+
+	return &ManagePVZAssignment{
+		assignmentRepo: assignmentRepo,
+		pvzRepo:        pvzRepo,
+		timeService:    timeService,
+		authorizer:     authorizer,
+		logger:         logger,
+	}
+}
+
+// ManagePVZAssignment юзкейс
+type ManagePVZAssignment struct {
+	assignmentRepo repo.PVZAssignmentRepo
+	pvzRepo        repo.PVZRepo
+	timeService    TimeService
+	authorizer     Authorizer
+	logger         Logger
+}
+
+// GetByPVZ выдаёт сотрудников, закреплённых за ПВЗ
+func (uc *ManagePVZAssignment) GetByPVZ(ctx context.Context, PVZID uuid.UUID, userRole string) ([]onlymodels.PVZAssignment, error) {
+	if err := uc.checkAccess("GetByPVZ", userRole, model.PermissionManageAssignments); err != nil {
+		return nil, err
+	}
+	pvzID, err := uc.validatePVZID(PVZID)
+	if err != nil {
+		return nil, err
+	}
+	if _, err = uc.findPVZ(ctx, "GetByPVZ", pvzID); err != nil {
+		return nil, err
+	}
+
+	assignments, err := uc.assignmentRepo.GetByPVZ(ctx, pvzID.String())
+	if err != nil {
+		uc.logger.Error("failed to get PVZ assignments",
+			"usecase", "ManagePVZAssignment",
+			"method", "assignmentRepo.GetByPVZ",
+			"pvz_id", pvzID,
+			"error", err)
+		return nil, errors.New(model.ErrInternal)
+	}
+
+	result := make([]onlymodels.PVZAssignment, 0, len(assignments))
+	for _, assignment := range assignments {
+		assignmentDto, err := pvzAssignmentDaoToDto(assignment)
+		if err != nil {
+			uc.logger.Error("failed to convert PVZ assignment DAO to DTO",
+				"usecase", "ManagePVZAssignment",
+				"method", "pvzAssignmentDaoToDto",
+				"error", err)
+			return nil, errors.New(model.ErrInternal)
+		}
+		result = append(result, *assignmentDto)
+	}
+
+	uc.logger.Info("PVZ assignments retrieved successfully",
+		"usecase", "ManagePVZAssignment",
+		"pvz_id", pvzID,
+		"count", len(result))
+	return result, nil
+}
+
+// Assign закрепляет сотрудника за ПВЗ, архивные ПВЗ не принимают новых сотрудников
+func (uc *ManagePVZAssignment) Assign(ctx context.Context, PVZID uuid.UUID, request *onlymodels.PVZAssignment, userID, userRole string) (*onlymodels.PVZAssignment, error) {
+	if err := uc.checkAccess("Assign", userRole, model.PermissionManageAssignments); err != nil {
+		return nil, err
+	}
+	pvzID, err := uc.validatePVZID(PVZID)
+	if err != nil {
+		return nil, err
+	}
+	if request == nil {
+		return nil, errors.New(model.ErrInvalidRequest)
+	}
+	employeeID, err := validateID(request.UserId)
+	if err != nil {
+		uc.logger.Warn("invalid user ID",
+			"usecase", "ManagePVZAssignment",
+			"method", "Assign",
+			"user_id", request.UserId)
+		return nil, errors.New(model.ErrInvalidUserID)
+	}
+	moderatorID, err := validateRawID(userID)
+	if err != nil {
+		uc.logger.Warn("invalid user ID in token",
+			"usecase", "ManagePVZAssignment",
+			"method", "Assign",
+			"user_id", userID)
+		return nil, err
+	}
+
+	pvzDao, err := uc.findPVZ(ctx, "Assign", pvzID)
+	if err != nil {
+		return nil, err
+	}
+	if pvzDao.ArchivedAt.Valid {
+		uc.logger.Warn("PVZ is archived",
+			"usecase", "ManagePVZAssignment",
+			"method", "Assign",
+			"pvz_id", pvzID)
+		return nil, errors.New(model.ErrPVZArchived)
+	}
+
+	assignment := &model.PVZAssignment{
+		UserID:     employeeID,
+		PVZID:      pvzID,
+		AssignedBy: moderatorID,
+		AssignedAt: uc.timeService.GetTime(),
+	}
+	assignmentDao := assignment.ToDao()
+	err = uc.assignmentRepo.Assign(ctx, assignmentDao)
+	if err != nil {
+		switch err.Error() {
+		case model.ErrAssignmentAlreadyExists, model.ErrUserNotFound, model.ErrPVZNotFound:
+			uc.logger.Warn("failed to assign user to PVZ",
+				"usecase", "ManagePVZAssignment",
+				"method", "assignmentRepo.Assign",
+				"user_id", employeeID,
+				"pvz_id", pvzID,
+				"error", err)
+			return nil, err
+		}
+		uc.logger.Error("failed to assign user to PVZ",
+			"usecase", "ManagePVZAssignment",
+			"method", "assignmentRepo.Assign",
+			"user_id", employeeID,
+			"pvz_id", pvzID,
+			"error", err)
+		return nil, errors.New(model.ErrInternal)
+	}
+
+	uc.logger.Info("user assigned to PVZ successfully",
+		"usecase", "ManagePVZAssignment",
+		"user_id", employeeID,
+		"pvz_id", pvzID,
+		"assigned_by", moderatorID)
+	return pvzAssignmentDaoToDto(assignmentDao)
+}
+
+// Unassign открепляет сотрудника от ПВЗ
+func (uc *ManagePVZAssignment) Unassign(ctx context.Context, PVZID, employeeID uuid.UUID, userRole string) error {
+	if err := uc.checkAccess("Unassign", userRole, model.PermissionManageAssignments); err != nil {
+		return err
+	}
+	pvzID, err := uc.validatePVZID(PVZID)
+	if err != nil {
+		return err
+	}
+	if _, err = validateID(employeeID); err != nil {
+		uc.logger.Warn("invalid user ID",
+			"usecase", "ManagePVZAssignment",
+			"method", "Unassign",
+			"user_id", employeeID)
+		return errors.New(model.ErrInvalidUserID)
+	}
+	if _, err = uc.findPVZ(ctx, "Unassign", pvzID); err != nil {
+		return err
+	}
+
+	err = uc.assignmentRepo.Unassign(ctx, employeeID.String(), pvzID.String())
+	if err != nil {
+		if err.Error() == model.ErrAssignmentNotFound {
+			uc.logger.Warn("user is not assigned to PVZ",
+				"usecase", "ManagePVZAssignment",
+				"method", "assignmentRepo.Unassign",
+				"user_id", employeeID,
+				"pvz_id", pvzID)
+			return err
+		}
+		uc.logger.Error("failed to unassign user from PVZ",
+			"usecase", "ManagePVZAssignment",
+			"method", "assignmentRepo.Unassign",
+			"user_id", employeeID,
+			"pvz_id", pvzID,
+			"error", err)
+		return errors.New(model.ErrInternal)
+	}
+
+	uc.logger.Info("user unassigned from PVZ successfully",
+		"usecase", "ManagePVZAssignment",
+		"user_id", employeeID,
+		"pvz_id", pvzID)
+	return nil
+}
+
+func (uc *ManagePVZAssignment) checkAccess(method, userRole string, permission model.Permission) error {
+	role, err := validateRole(userRole)
+	if err != nil {
+		uc.logger.Warn("invalid user role",
+			"usecase", "ManagePVZAssignment",
+			"method", method,
+			"user_role", userRole)
+		return err
+	}
+	return checkPermission(uc.authorizer, uc.logger, "ManagePVZAssignment", method, role, permission)
+}
+
+// findPVZ ищет ПВЗ в организации модератора, чужие ПВЗ для него не существуют
+func (uc *ManagePVZAssignment) findPVZ(ctx context.Context, method string, pvzID uuid.UUID) (*dao.PVZ, error) {
+	pvzDao, err := uc.pvzRepo.FindByID(ctx, pvzID.String())
+	if err != nil {
+		uc.logger.Error("failed to find PVZ",
+			"usecase", "ManagePVZAssignment",
+			"method", "pvzRepo.FindByID",
+			"pvz_id", pvzID,
+			"error", err)
+		return nil, errors.New(model.ErrInternal)
+	}
+	if pvzDao == nil {
+		uc.logger.Warn("PVZ not found",
+			"usecase", "ManagePVZAssignment",
+			"method", method,
+			"pvz_id", pvzID)
+		return nil, errors.New(model.ErrPVZNotFound)
+	}
+	return pvzDao, nil
+}
+
+func (uc *ManagePVZAssignment) validatePVZID(PVZID uuid.UUID) (uuid.UUID, error) {
+	pvzID, err := validateID(PVZID)
+	if err != nil {
+		uc.logger.Warn("invalid PVZ ID format",
+			"usecase", "ManagePVZAssignment",
+			"method", "validatePVZID",
+			"pvz_id", PVZID)
+		return pvzID, errors.New(model.ErrInvalidPVZID)
+	}
+	return pvzID, nil
+}
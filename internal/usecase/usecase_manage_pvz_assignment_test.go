@@ -0,0 +1,358 @@
+// Package usecase это юзкейсы и вспомогательная логика
+package usecase
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"internshipPVZ/internal/domain/model"
+	"internshipPVZ/internal/domain/repository/dao"
+	"internshipPVZ/internal/http/onlymodels"
+)
+
+// testForeignPVZID ПВЗ, за которым testUserID не закреплён
+var testForeignPVZID = uuid.MustParse("7f1c3c1e-2c1d-4a8e-9d43-5a1e0c6f9b21")
+
+func newTestAssignmentRepo() *mockPVZAssignmentRepo {
+	m := &mockPVZAssignmentRepo{}
+	m.On("CheckIfAssigned", mock.Anything, testUserID, testForeignPVZID.String()).Return(false, nil).Maybe()
+	m.On("CheckIfAssigned", mock.Anything, testUserID, mock.Anything).Return(true, nil).Maybe()
+	return m
+}
+
+type mockPVZAssignmentRepo struct{ mock.Mock }
+
+func (m *mockPVZAssignmentRepo) Assign(ctx context.Context, assignment *dao.PVZAssignment) error {
+	args := m.Called(ctx, assignment)
+	return args.Error(0)
+}
+
+func (m *mockPVZAssignmentRepo) Unassign(ctx context.Context, userID, pvzID string) error {
+	args := m.Called(ctx, userID, pvzID)
+	return args.Error(0)
+}
+
+func (m *mockPVZAssignmentRepo) GetByPVZ(ctx context.Context, pvzID string) ([]*dao.PVZAssignment, error) {
+	args := m.Called(ctx, pvzID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]*dao.PVZAssignment), args.Error(1)
+}
+
+func (m *mockPVZAssignmentRepo) CheckIfAssigned(ctx context.Context, userID, pvzID string) (bool, error) {
+	args := m.Called(ctx, userID, pvzID)
+	return args.Bool(0), args.Error(1)
+}
+
+func TestManagePVZAssignment_GetByPVZ(t *testing.T) {
+	validPVZID := uuid.New()
+	employeeID := uuid.New()
+	testTime := time.Now()
+
+	tests := []struct {
+		name          string
+		setupMocks    func(*mockPVZAssignmentRepo, *mockPVZRepo, *mockLogger)
+		pvzID         uuid.UUID
+		userRole      string
+		expectedCount int
+		expectedError string
+	}{
+		{
+			name: "Success - moderator lists assignments",
+			setupMocks: func(ma *mockPVZAssignmentRepo, mz *mockPVZRepo, ml *mockLogger) {
+				mz.On("FindByID", mock.Anything, validPVZID.String()).Return(&dao.PVZ{ID: validPVZID.String()}, nil)
+				ma.On("GetByPVZ", mock.Anything, validPVZID.String()).Return([]*dao.PVZAssignment{
+					{UserID: employeeID.String(), PVZID: validPVZID.String(), AssignedBy: sql.NullString{String: testUserID, Valid: true}, AssignedAt: testTime},
+				}, nil)
+				ml.On("Info", mock.Anything, mock.Anything)
+			},
+			pvzID:         validPVZID,
+			userRole:      model.RoleModerator.Get(),
+			expectedCount: 1,
+		},
+		{
+			name: "Employee is denied",
+			setupMocks: func(_ *mockPVZAssignmentRepo, _ *mockPVZRepo, ml *mockLogger) {
+				ml.On("Warn", mock.Anything, mock.Anything)
+			},
+			pvzID:         validPVZID,
+			userRole:      model.RoleEmployee.Get(),
+			expectedError: model.ErrAccessDenied,
+		},
+		{
+			name: "Invalid PVZ ID",
+			setupMocks: func(_ *mockPVZAssignmentRepo, _ *mockPVZRepo, ml *mockLogger) {
+				ml.On("Warn", mock.Anything, mock.Anything)
+			},
+			pvzID:         uuid.Nil,
+			userRole:      model.RoleModerator.Get(),
+			expectedError: model.ErrInvalidPVZID,
+		},
+		{
+			name: "PVZ of another organization",
+			setupMocks: func(_ *mockPVZAssignmentRepo, mz *mockPVZRepo, ml *mockLogger) {
+				mz.On("FindByID", mock.Anything, validPVZID.String()).Return(nil, nil)
+				ml.On("Warn", mock.Anything, mock.Anything)
+			},
+			pvzID:         validPVZID,
+			userRole:      model.RoleModerator.Get(),
+			expectedError: model.ErrPVZNotFound,
+		},
+		{
+			name: "Repository error",
+			setupMocks: func(ma *mockPVZAssignmentRepo, mz *mockPVZRepo, ml *mockLogger) {
+				mz.On("FindByID", mock.Anything, validPVZID.String()).Return(&dao.PVZ{ID: validPVZID.String()}, nil)
+				ma.On("GetByPVZ", mock.Anything, validPVZID.String()).Return(nil, errors.New("db error"))
+				ml.On("Error", mock.Anything, mock.Anything)
+			},
+			pvzID:         validPVZID,
+			userRole:      model.RoleModerator.Get(),
+			expectedError: model.ErrInternal,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ma := &mockPVZAssignmentRepo{}
+			mz := &mockPVZRepo{}
+			mt := &mockTimeService{}
+			ml := &mockLogger{}
+			tt.setupMocks(ma, mz, ml)
+
+			uc := NewUseCaseManagePVZAssignment(ma, mz, mt, newTestAuthorizer(), ml)
+			result, err := uc.GetByPVZ(context.Background(), tt.pvzID, tt.userRole)
+
+			if tt.expectedError != "" {
+				assert.Error(t, err)
+				assert.Equal(t, tt.expectedError, err.Error())
+				return
+			}
+
+			assert.NoError(t, err)
+			assert.Len(t, result, tt.expectedCount)
+			assert.Equal(t, employeeID, result[0].UserId)
+			assert.Equal(t, testUserID, result[0].AssignedBy.String())
+		})
+	}
+}
+
+func TestManagePVZAssignment_Assign(t *testing.T) {
+	validPVZID := uuid.New()
+	employeeID := uuid.New()
+	testTime := time.Now()
+
+	tests := []struct {
+		name          string
+		setupMocks    func(*mockPVZAssignmentRepo, *mockPVZRepo, *mockTimeService, *mockLogger)
+		request       *onlymodels.PVZAssignment
+		userRole      string
+		expectedError string
+	}{
+		{
+			name: "Success - moderator assigns employee",
+			setupMocks: func(ma *mockPVZAssignmentRepo, mz *mockPVZRepo, mt *mockTimeService, ml *mockLogger) {
+				mz.On("FindByID", mock.Anything, validPVZID.String()).Return(&dao.PVZ{ID: validPVZID.String()}, nil)
+				mt.On("GetTime").Return(testTime)
+				ma.On("Assign", mock.Anything, mock.MatchedBy(func(assignment *dao.PVZAssignment) bool {
+					return assignment.UserID == employeeID.String() &&
+						assignment.PVZID == validPVZID.String() &&
+						assignment.AssignedBy.String == testUserID
+				})).Return(nil)
+				ml.On("Info", mock.Anything, mock.Anything)
+			},
+			request:  &onlymodels.PVZAssignment{UserId: employeeID},
+			userRole: model.RoleModerator.Get(),
+		},
+		{
+			name: "Employee is denied",
+			setupMocks: func(_ *mockPVZAssignmentRepo, _ *mockPVZRepo, _ *mockTimeService, ml *mockLogger) {
+				ml.On("Warn", mock.Anything, mock.Anything)
+			},
+			request:       &onlymodels.PVZAssignment{UserId: employeeID},
+			userRole:      model.RoleEmployee.Get(),
+			expectedError: model.ErrAccessDenied,
+		},
+		{
+			name: "Invalid user ID",
+			setupMocks: func(_ *mockPVZAssignmentRepo, _ *mockPVZRepo, _ *mockTimeService, ml *mockLogger) {
+				ml.On("Warn", mock.Anything, mock.Anything)
+			},
+			request:       &onlymodels.PVZAssignment{UserId: uuid.Nil},
+			userRole:      model.RoleModerator.Get(),
+			expectedError: model.ErrInvalidUserID,
+		},
+		{
+			name: "PVZ not found",
+			setupMocks: func(_ *mockPVZAssignmentRepo, mz *mockPVZRepo, _ *mockTimeService, ml *mockLogger) {
+				mz.On("FindByID", mock.Anything, validPVZID.String()).Return(nil, nil)
+				ml.On("Warn", mock.Anything, mock.Anything)
+			},
+			request:       &onlymodels.PVZAssignment{UserId: employeeID},
+			userRole:      model.RoleModerator.Get(),
+			expectedError: model.ErrPVZNotFound,
+		},
+		{
+			name: "Archived PVZ",
+			setupMocks: func(_ *mockPVZAssignmentRepo, mz *mockPVZRepo, _ *mockTimeService, ml *mockLogger) {
+				mz.On("FindByID", mock.Anything, validPVZID.String()).Return(&dao.PVZ{
+					ID:         validPVZID.String(),
+					ArchivedAt: sql.NullTime{Time: testTime, Valid: true},
+				}, nil)
+				ml.On("Warn", mock.Anything, mock.Anything)
+			},
+			request:       &onlymodels.PVZAssignment{UserId: employeeID},
+			userRole:      model.RoleModerator.Get(),
+			expectedError: model.ErrPVZArchived,
+		},
+		{
+			name: "User not found",
+			setupMocks: func(ma *mockPVZAssignmentRepo, mz *mockPVZRepo, mt *mockTimeService, ml *mockLogger) {
+				mz.On("FindByID", mock.Anything, validPVZID.String()).Return(&dao.PVZ{ID: validPVZID.String()}, nil)
+				mt.On("GetTime").Return(testTime)
+				ma.On("Assign", mock.Anything, mock.Anything).Return(errors.New(model.ErrUserNotFound))
+				ml.On("Warn", mock.Anything, mock.Anything)
+			},
+			request:       &onlymodels.PVZAssignment{UserId: employeeID},
+			userRole:      model.RoleModerator.Get(),
+			expectedError: model.ErrUserNotFound,
+		},
+		{
+			name: "Already assigned",
+			setupMocks: func(ma *mockPVZAssignmentRepo, mz *mockPVZRepo, mt *mockTimeService, ml *mockLogger) {
+				mz.On("FindByID", mock.Anything, validPVZID.String()).Return(&dao.PVZ{ID: validPVZID.String()}, nil)
+				mt.On("GetTime").Return(testTime)
+				ma.On("Assign", mock.Anything, mock.Anything).Return(errors.New(model.ErrAssignmentAlreadyExists))
+				ml.On("Warn", mock.Anything, mock.Anything)
+			},
+			request:       &onlymodels.PVZAssignment{UserId: employeeID},
+			userRole:      model.RoleModerator.Get(),
+			expectedError: model.ErrAssignmentAlreadyExists,
+		},
+		{
+			name: "Repository error",
+			setupMocks: func(ma *mockPVZAssignmentRepo, mz *mockPVZRepo, mt *mockTimeService, ml *mockLogger) {
+				mz.On("FindByID", mock.Anything, validPVZID.String()).Return(&dao.PVZ{ID: validPVZID.String()}, nil)
+				mt.On("GetTime").Return(testTime)
+				ma.On("Assign", mock.Anything, mock.Anything).Return(errors.New("db error"))
+				ml.On("Error", mock.Anything, mock.Anything)
+			},
+			request:       &onlymodels.PVZAssignment{UserId: employeeID},
+			userRole:      model.RoleModerator.Get(),
+			expectedError: model.ErrInternal,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ma := &mockPVZAssignmentRepo{}
+			mz := &mockPVZRepo{}
+			mt := &mockTimeService{}
+			ml := &mockLogger{}
+			tt.setupMocks(ma, mz, mt, ml)
+
+			uc := NewUseCaseManagePVZAssignment(ma, mz, mt, newTestAuthorizer(), ml)
+			result, err := uc.Assign(context.Background(), validPVZID, tt.request, testUserID, tt.userRole)
+
+			if tt.expectedError != "" {
+				assert.Error(t, err)
+				assert.Equal(t, tt.expectedError, err.Error())
+				return
+			}
+
+			assert.NoError(t, err)
+			assert.Equal(t, employeeID, result.UserId)
+			assert.Equal(t, validPVZID, *result.PvzId)
+			assert.Equal(t, testUserID, result.AssignedBy.String())
+			assert.Equal(t, testTime, *result.AssignedAt)
+			ma.AssertExpectations(t)
+		})
+	}
+}
+
+func TestManagePVZAssignment_Unassign(t *testing.T) {
+	validPVZID := uuid.New()
+	employeeID := uuid.New()
+
+	tests := []struct {
+		name          string
+		setupMocks    func(*mockPVZAssignmentRepo, *mockPVZRepo, *mockLogger)
+		userRole      string
+		expectedError string
+	}{
+		{
+			name: "Success - moderator unassigns employee",
+			setupMocks: func(ma *mockPVZAssignmentRepo, mz *mockPVZRepo, ml *mockLogger) {
+				mz.On("FindByID", mock.Anything, validPVZID.String()).Return(&dao.PVZ{ID: validPVZID.String()}, nil)
+				ma.On("Unassign", mock.Anything, employeeID.String(), validPVZID.String()).Return(nil)
+				ml.On("Info", mock.Anything, mock.Anything)
+			},
+			userRole: model.RoleModerator.Get(),
+		},
+		{
+			name: "Employee is denied",
+			setupMocks: func(_ *mockPVZAssignmentRepo, _ *mockPVZRepo, ml *mockLogger) {
+				ml.On("Warn", mock.Anything, mock.Anything)
+			},
+			userRole:      model.RoleEmployee.Get(),
+			expectedError: model.ErrAccessDenied,
+		},
+		{
+			name: "Assignment not found",
+			setupMocks: func(ma *mockPVZAssignmentRepo, mz *mockPVZRepo, ml *mockLogger) {
+				mz.On("FindByID", mock.Anything, validPVZID.String()).Return(&dao.PVZ{ID: validPVZID.String()}, nil)
+				ma.On("Unassign", mock.Anything, employeeID.String(), validPVZID.String()).Return(errors.New(model.ErrAssignmentNotFound))
+				ml.On("Warn", mock.Anything, mock.Anything)
+			},
+			userRole:      model.RoleModerator.Get(),
+			expectedError: model.ErrAssignmentNotFound,
+		},
+		{
+			name: "PVZ of another organization",
+			setupMocks: func(_ *mockPVZAssignmentRepo, mz *mockPVZRepo, ml *mockLogger) {
+				mz.On("FindByID", mock.Anything, validPVZID.String()).Return(nil, nil)
+				ml.On("Warn", mock.Anything, mock.Anything)
+			},
+			userRole:      model.RoleModerator.Get(),
+			expectedError: model.ErrPVZNotFound,
+		},
+		{
+			name: "Repository error",
+			setupMocks: func(ma *mockPVZAssignmentRepo, mz *mockPVZRepo, ml *mockLogger) {
+				mz.On("FindByID", mock.Anything, validPVZID.String()).Return(&dao.PVZ{ID: validPVZID.String()}, nil)
+				ma.On("Unassign", mock.Anything, employeeID.String(), validPVZID.String()).Return(errors.New("db error"))
+				ml.On("Error", mock.Anything, mock.Anything)
+			},
+			userRole:      model.RoleModerator.Get(),
+			expectedError: model.ErrInternal,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ma := &mockPVZAssignmentRepo{}
+			mz := &mockPVZRepo{}
+			mt := &mockTimeService{}
+			ml := &mockLogger{}
+			tt.setupMocks(ma, mz, ml)
+
+			uc := NewUseCaseManagePVZAssignment(ma, mz, mt, newTestAuthorizer(), ml)
+			err := uc.Unassign(context.Background(), validPVZID, employeeID, tt.userRole)
+
+			if tt.expectedError != "" {
+				assert.Error(t, err)
+				assert.Equal(t, tt.expectedError, err.Error())
+				return
+			}
+
+			assert.NoError(t, err)
+			ma.AssertExpectations(t)
+		})
+	}
+}
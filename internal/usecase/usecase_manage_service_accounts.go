@@ -0,0 +1,262 @@
+// Package usecase это юзкейсы и вспомогательная логика
+package usecase
+
+import (
+	"context"
+	"errors"
+	"github.com/google/uuid"
+	"internshipPVZ/internal/domain/model"
+	repo "internshipPVZ/internal/domain/repository"
+	"internshipPVZ/internal/domain/repository/dao"
+	"internshipPVZ/internal/http/onlymodels"
+	"log"
+)
+
+// NewUseCaseManageServiceAccounts конструктор
+func NewUseCaseManageServiceAccounts(
+	accountRepo repo.ServiceAccountRepo,
+	tokenService RefreshTokenService,
+	timeService TimeService,
+	authorizer Authorizer,
+	logger Logger,
+) *ManageServiceAccounts {
+	if accountRepo == nil {
+		log.Fatalf("ManageServiceAccounts usecase accountRepo nil")
+
+	}
+	if tokenService == nil {
+		log.Fatalf("ManageServiceAccounts usecase tokenService nil")
+
+	}
+	if timeService == nil {
+		log.Fatalf("ManageServiceAccounts usecase timeService nil")
+
+	}
+	if authorizer == nil {
+		log.Fatalf("ManageServiceAccounts usecase authorizer nil")
+
+	}
+	if logger == nil {
+		log.Fatalf("ManageServiceAccounts usecase logger nil")
+
+	}
+
+	return &ManageServiceAccounts{
+		accountRepo:  accountRepo,
+		tokenService: tokenService,
+		timeService:  timeService,
+		authorizer:   authorizer,
+		logger:       logger,
+	}
+}
+
+// ManageServiceAccounts юзкейс
+type ManageServiceAccounts struct {
+	accountRepo  repo.ServiceAccountRepo
+	tokenService RefreshTokenService
+	timeService  TimeService
+	authorizer   Authorizer
+	logger       Logger
+}
+
+// Create заводит сервисный аккаунт в организации модератора.
+// В базе хранится только хэш ключа, сам ключ возвращается один раз
+func (uc *ManageServiceAccounts) Create(ctx context.Context, request *onlymodels.PostServiceAccountsJSONBody, userID, userRole string) (*onlymodels.ServiceAccount, error) {
+	if err := uc.checkAccess("Create", userRole, model.PermissionManageServiceAccounts); err != nil {
+		return nil, err
+	}
+	moderatorID, err := validateRawID(userID)
+	if err != nil {
+		uc.logger.Warn("invalid user ID in token",
+			"usecase", "ManageServiceAccounts",
+			"method", "Create",
+			"user_id", userID)
+		return nil, err
+	}
+	if request == nil {
+		uc.logger.Warn("empty request",
+			"usecase", "ManageServiceAccounts",
+			"method", "Create")
+		return nil, errors.New(model.ErrInvalidRequest)
+	}
+	name, ok := normalizeCatalogName(request.Name, maxServiceAccountNameLength)
+	if !ok {
+		uc.logger.Warn("invalid service account name",
+			"usecase", "ManageServiceAccounts",
+			"method", "Create",
+			"name", request.Name)
+		return nil, errors.New(model.ErrInvalidServiceAccountName)
+	}
+	scopes, ok := normalizeAPIKeyScopes(request.Scopes)
+	if !ok {
+		uc.logger.Warn("invalid API key scopes",
+			"usecase", "ManageServiceAccounts",
+			"method", "Create",
+			"scopes", request.Scopes)
+		return nil, errors.New(model.ErrInvalidAPIKeyScope)
+	}
+
+	key, err := uc.tokenService.GenerateRefreshToken()
+	if err != nil {
+		uc.logger.Error("failed to generate API key",
+			"usecase", "ManageServiceAccounts",
+			"method", "tokenService.GenerateRefreshToken",
+			"error", err)
+		return nil, errors.New(model.ErrInternal)
+	}
+
+	account := &dao.ServiceAccount{
+		Name:      name,
+		KeyHash:   uc.tokenService.HashRefreshToken(key),
+		Scopes:    scopes,
+		CreatedBy: moderatorID.String(),
+		CreatedAt: uc.timeService.GetTime(),
+	}
+	err = uc.accountRepo.Create(ctx, account)
+	if err != nil {
+		uc.logger.Error("failed to create service account",
+			"usecase", "ManageServiceAccounts",
+			"method", "accountRepo.Create",
+			"user_id", moderatorID,
+			"error", err)
+		return nil, errors.New(model.ErrInternal)
+	}
+
+	accountDto, err := uc.toDto("Create", account)
+	if err != nil {
+		return nil, err
+	}
+	accountDto.ApiKey = &key
+
+	uc.logger.Info("service account created successfully",
+		"usecase", "ManageServiceAccounts",
+		"service_account_id", account.ID,
+		"created_by", moderatorID,
+		"scopes", scopes)
+	return accountDto, nil
+}
+
+// List выдаёт сервисные аккаунты организации модератора, ключи не возвращаются
+func (uc *ManageServiceAccounts) List(ctx context.Context, userRole string) ([]onlymodels.ServiceAccount, error) {
+	if err := uc.checkAccess("List", userRole, model.PermissionManageServiceAccounts); err != nil {
+		return nil, err
+	}
+
+	accounts, err := uc.accountRepo.List(ctx)
+	if err != nil {
+		uc.logger.Error("failed to list service accounts",
+			"usecase", "ManageServiceAccounts",
+			"method", "accountRepo.List",
+			"error", err)
+		return nil, errors.New(model.ErrInternal)
+	}
+
+	result := make([]onlymodels.ServiceAccount, 0, len(accounts))
+	for _, account := range accounts {
+		accountDto, err := uc.toDto("List", account)
+		if err != nil {
+			return nil, err
+		}
+		result = append(result, *accountDto)
+	}
+
+	uc.logger.Info("service accounts listed successfully",
+		"usecase", "ManageServiceAccounts",
+		"count", len(result))
+	return result, nil
+}
+
+// Revoke отзывает ключ сервисного аккаунта, запросы с ним сразу перестают проходить
+func (uc *ManageServiceAccounts) Revoke(ctx context.Context, accountID uuid.UUID, userRole string) (*onlymodels.ServiceAccount, error) {
+	if err := uc.checkAccess("Revoke", userRole, model.PermissionManageServiceAccounts); err != nil {
+		return nil, err
+	}
+	id, err := validateID(accountID)
+	if err != nil {
+		uc.logger.Warn("invalid service account ID",
+			"usecase", "ManageServiceAccounts",
+			"method", "Revoke",
+			"service_account_id", accountID)
+		return nil, errors.New(model.ErrInvalidServiceAccountID)
+	}
+
+	account, err := uc.accountRepo.Revoke(ctx, id.String(), uc.timeService.GetTime())
+	if err != nil {
+		if err.Error() == model.ErrServiceAccountNotFound {
+			uc.logger.Warn("service account not found",
+				"usecase", "ManageServiceAccounts",
+				"method", "Revoke",
+				"service_account_id", id)
+			return nil, err
+		}
+		uc.logger.Error("failed to revoke service account",
+			"usecase", "ManageServiceAccounts",
+			"method", "accountRepo.Revoke",
+			"service_account_id", id,
+			"error", err)
+		return nil, errors.New(model.ErrInternal)
+	}
+
+	uc.logger.Info("service account revoked successfully",
+		"usecase", "ManageServiceAccounts",
+		"service_account_id", id)
+	return uc.toDto("Revoke", account)
+}
+
+// AuthenticateAPIKey находит действующий сервисный аккаунт по ключу из заголовка X-API-Key
+func (uc *ManageServiceAccounts) AuthenticateAPIKey(ctx context.Context, key string) (*model.ServiceAccountClaims, error) {
+	if key == "" {
+		return nil, errors.New(model.ErrAccessDenied)
+	}
+	account, err := uc.accountRepo.FindActiveByKeyHash(ctx, uc.tokenService.HashRefreshToken(key))
+	if err != nil {
+		uc.logger.Error("failed to find service account",
+			"usecase", "ManageServiceAccounts",
+			"method", "accountRepo.FindActiveByKeyHash",
+			"error", err)
+		return nil, errors.New(model.ErrInternal)
+	}
+	if account == nil {
+		uc.logger.Warn("unknown or revoked API key",
+			"usecase", "ManageServiceAccounts",
+			"method", "AuthenticateAPIKey")
+		return nil, errors.New(model.ErrAccessDenied)
+	}
+
+	claims := &model.ServiceAccountClaims{
+		ID:             account.ID,
+		OrganizationID: account.OrganizationID,
+		Scopes:         make([]model.APIKeyScope, 0, len(account.Scopes)),
+	}
+	for _, raw := range account.Scopes {
+		// области, которые убрали из кода после выдачи ключа, просто перестают действовать
+		if scope, ok := model.NewAPIKeyScope(raw); ok {
+			claims.Scopes = append(claims.Scopes, scope)
+		}
+	}
+	return claims, nil
+}
+
+func (uc *ManageServiceAccounts) toDto(method string, account *dao.ServiceAccount) (*onlymodels.ServiceAccount, error) {
+	accountDto, err := serviceAccountDaoToDto(account)
+	if err != nil {
+		uc.logger.Error("failed to convert service account DAO to DTO",
+			"usecase", "ManageServiceAccounts",
+			"method", method,
+			"error", err)
+		return nil, errors.New(model.ErrInternal)
+	}
+	return accountDto, nil
+}
+
+func (uc *ManageServiceAccounts) checkAccess(method, userRole string, permission model.Permission) error {
+	role, err := validateRole(userRole)
+	if err != nil {
+		uc.logger.Warn("invalid user role",
+			"usecase", "ManageServiceAccounts",
+			"method", method,
+			"user_role", userRole)
+		return err
+	}
+	return checkPermission(uc.authorizer, uc.logger, "ManageServiceAccounts", method, role, permission)
+}
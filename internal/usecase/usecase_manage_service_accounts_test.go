@@ -0,0 +1,282 @@
+// Package usecase это юзкейсы и вспомогательная логика
+package usecase
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"internshipPVZ/internal/domain/model"
+	"internshipPVZ/internal/domain/repository/dao"
+	"internshipPVZ/internal/http/onlymodels"
+)
+
+type mockServiceAccountRepo struct{ mock.Mock }
+
+func (m *mockServiceAccountRepo) Create(ctx context.Context, account *dao.ServiceAccount) error {
+	args := m.Called(ctx, account)
+	return args.Error(0)
+}
+
+func (m *mockServiceAccountRepo) List(ctx context.Context) ([]*dao.ServiceAccount, error) {
+	args := m.Called(ctx)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+
+	return args.Get(0).([]*dao.ServiceAccount), args.Error(1)
+}
+
+func (m *mockServiceAccountRepo) Revoke(ctx context.Context, id string, now time.Time) (*dao.ServiceAccount, error) {
+	args := m.Called(ctx, id, now)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+
+	return args.Get(0).(*dao.ServiceAccount), args.Error(1)
+}
+
+func (m *mockServiceAccountRepo) FindActiveByKeyHash(ctx context.Context, keyHash string) (*dao.ServiceAccount, error) {
+	args := m.Called(ctx, keyHash)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+
+	return args.Get(0).(*dao.ServiceAccount), args.Error(1)
+}
+
+func newManageServiceAccountsUseCase(testTime time.Time, setupMocks func(*mockServiceAccountRepo, *mockLogger)) (*ManageServiceAccounts, *mockServiceAccountRepo) {
+	ma := &mockServiceAccountRepo{}
+	ml := &mockLogger{}
+	setupMocks(ma, ml)
+	mt := &mockTimeService{}
+	mt.On("GetTime").Return(testTime).Maybe()
+	return NewUseCaseManageServiceAccounts(ma, newTestRefreshTokenService(), mt, newTestAuthorizer(), ml), ma
+}
+
+func TestManageServiceAccounts_Create(t *testing.T) {
+	testTime := time.Now()
+	moderatorID := uuid.New()
+
+	tests := []struct {
+		name           string
+		setupMocks     func(*mockServiceAccountRepo, *mockLogger)
+		request        *onlymodels.PostServiceAccountsJSONBody
+		userRole       string
+		expectedScopes []string
+		expectedError  string
+	}{
+		{
+			name: "Success - only key hash stored, duplicate scopes dropped",
+			setupMocks: func(ma *mockServiceAccountRepo, ml *mockLogger) {
+				ma.On("Create", mock.Anything, mock.MatchedBy(func(account *dao.ServiceAccount) bool {
+					return account.KeyHash == testRefreshTokenHash &&
+						account.Name == "Сканер склада" &&
+						account.CreatedBy == moderatorID.String() &&
+						account.CreatedAt.Equal(testTime)
+				})).Run(func(args mock.Arguments) {
+					account := args.Get(1).(*dao.ServiceAccount)
+					account.ID = uuid.NewString()
+					account.OrganizationID = testOrganizationID.String()
+				}).Return(nil)
+				ml.On("Info", mock.Anything, mock.Anything)
+			},
+			request: &onlymodels.PostServiceAccountsJSONBody{
+				Name:   "  Сканер склада ",
+				Scopes: []string{"products:add", "pvz:read", "products:add"},
+			},
+			userRole:       model.RoleModerator.Get(),
+			expectedScopes: []string{"products:add", "pvz:read"},
+		},
+		{
+			name: "Employee is denied",
+			setupMocks: func(_ *mockServiceAccountRepo, ml *mockLogger) {
+				ml.On("Warn", mock.Anything, mock.Anything)
+			},
+			request:       &onlymodels.PostServiceAccountsJSONBody{Name: "scanner", Scopes: []string{"products:add"}},
+			userRole:      model.RoleEmployee.Get(),
+			expectedError: model.ErrAccessDenied,
+		},
+		{
+			name: "Empty name",
+			setupMocks: func(_ *mockServiceAccountRepo, ml *mockLogger) {
+				ml.On("Warn", mock.Anything, mock.Anything)
+			},
+			request:       &onlymodels.PostServiceAccountsJSONBody{Name: "   ", Scopes: []string{"products:add"}},
+			userRole:      model.RoleModerator.Get(),
+			expectedError: model.ErrInvalidServiceAccountName,
+		},
+		{
+			name: "No scopes",
+			setupMocks: func(_ *mockServiceAccountRepo, ml *mockLogger) {
+				ml.On("Warn", mock.Anything, mock.Anything)
+			},
+			request:       &onlymodels.PostServiceAccountsJSONBody{Name: "scanner"},
+			userRole:      model.RoleModerator.Get(),
+			expectedError: model.ErrInvalidAPIKeyScope,
+		},
+		{
+			name: "Unknown scope",
+			setupMocks: func(_ *mockServiceAccountRepo, ml *mockLogger) {
+				ml.On("Warn", mock.Anything, mock.Anything)
+			},
+			request:       &onlymodels.PostServiceAccountsJSONBody{Name: "scanner", Scopes: []string{"products:add", "pvz:create"}},
+			userRole:      model.RoleModerator.Get(),
+			expectedError: model.ErrInvalidAPIKeyScope,
+		},
+		{
+			name: "Database error",
+			setupMocks: func(ma *mockServiceAccountRepo, ml *mockLogger) {
+				ma.On("Create", mock.Anything, mock.Anything).Return(errors.New("db error"))
+				ml.On("Error", mock.Anything, mock.Anything)
+			},
+			request:       &onlymodels.PostServiceAccountsJSONBody{Name: "scanner", Scopes: []string{"products:add"}},
+			userRole:      model.RoleModerator.Get(),
+			expectedError: model.ErrInternal,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			uc, ma := newManageServiceAccountsUseCase(testTime, tt.setupMocks)
+			account, err := uc.Create(context.Background(), tt.request, moderatorID.String(), tt.userRole)
+
+			if tt.expectedError != "" {
+				assert.Error(t, err)
+				assert.Equal(t, tt.expectedError, err.Error())
+				return
+			}
+
+			assert.NoError(t, err)
+			ma.AssertExpectations(t)
+			assert.Equal(t, testRefreshToken, *account.ApiKey)
+			assert.Equal(t, tt.expectedScopes, account.Scopes)
+			assert.Equal(t, testOrganizationID, account.OrganizationId)
+			assert.True(t, account.Active)
+		})
+	}
+}
+
+func TestManageServiceAccounts_ListRevoke(t *testing.T) {
+	testTime := time.Now()
+	accountID := uuid.New()
+	revoked := &dao.ServiceAccount{
+		ID:             accountID.String(),
+		OrganizationID: testOrganizationID.String(),
+		Name:           "scanner",
+		Scopes:         []string{"products:add"},
+		RevokedAt:      sql.NullTime{Time: testTime, Valid: true},
+	}
+
+	t.Run("List hides keys", func(t *testing.T) {
+		uc, _ := newManageServiceAccountsUseCase(testTime, func(ma *mockServiceAccountRepo, ml *mockLogger) {
+			ma.On("List", mock.Anything).Return([]*dao.ServiceAccount{revoked}, nil)
+			ml.On("Info", mock.Anything, mock.Anything)
+		})
+		accounts, err := uc.List(context.Background(), model.RoleModerator.Get())
+
+		assert.NoError(t, err)
+		assert.Len(t, accounts, 1)
+		assert.Nil(t, accounts[0].ApiKey)
+		assert.False(t, accounts[0].Active)
+	})
+
+	t.Run("List by employee", func(t *testing.T) {
+		uc, _ := newManageServiceAccountsUseCase(testTime, func(_ *mockServiceAccountRepo, ml *mockLogger) {
+			ml.On("Warn", mock.Anything, mock.Anything)
+		})
+		_, err := uc.List(context.Background(), model.RoleEmployee.Get())
+
+		assert.EqualError(t, err, model.ErrAccessDenied)
+	})
+
+	t.Run("Revoke", func(t *testing.T) {
+		uc, ma := newManageServiceAccountsUseCase(testTime, func(ma *mockServiceAccountRepo, ml *mockLogger) {
+			ma.On("Revoke", mock.Anything, accountID.String(), testTime).Return(revoked, nil)
+			ml.On("Info", mock.Anything, mock.Anything)
+		})
+		account, err := uc.Revoke(context.Background(), accountID, model.RoleModerator.Get())
+
+		assert.NoError(t, err)
+		assert.False(t, account.Active)
+		ma.AssertExpectations(t)
+	})
+
+	t.Run("Revoke invalid ID", func(t *testing.T) {
+		uc, ma := newManageServiceAccountsUseCase(testTime, func(_ *mockServiceAccountRepo, ml *mockLogger) {
+			ml.On("Warn", mock.Anything, mock.Anything)
+		})
+		_, err := uc.Revoke(context.Background(), uuid.Nil, model.RoleModerator.Get())
+
+		assert.EqualError(t, err, model.ErrInvalidServiceAccountID)
+		ma.AssertNotCalled(t, "Revoke", mock.Anything, mock.Anything, mock.Anything)
+	})
+
+	t.Run("Revoke account of another organization", func(t *testing.T) {
+		uc, _ := newManageServiceAccountsUseCase(testTime, func(ma *mockServiceAccountRepo, ml *mockLogger) {
+			ma.On("Revoke", mock.Anything, accountID.String(), testTime).Return(nil, errors.New(model.ErrServiceAccountNotFound))
+			ml.On("Warn", mock.Anything, mock.Anything)
+		})
+		_, err := uc.Revoke(context.Background(), accountID, model.RoleModerator.Get())
+
+		assert.EqualError(t, err, model.ErrServiceAccountNotFound)
+	})
+}
+
+func TestManageServiceAccounts_AuthenticateAPIKey(t *testing.T) {
+	testTime := time.Now()
+	active := &dao.ServiceAccount{
+		ID:             uuid.NewString(),
+		OrganizationID: testOrganizationID.String(),
+		Scopes:         []string{"products:add", "legacy:scope"},
+	}
+
+	t.Run("Active key", func(t *testing.T) {
+		uc, _ := newManageServiceAccountsUseCase(testTime, func(ma *mockServiceAccountRepo, _ *mockLogger) {
+			ma.On("FindActiveByKeyHash", mock.Anything, testRefreshTokenHash).Return(active, nil)
+		})
+		claims, err := uc.AuthenticateAPIKey(context.Background(), testRefreshToken)
+
+		assert.NoError(t, err)
+		assert.Equal(t, active.ID, claims.ID)
+		assert.Equal(t, active.OrganizationID, claims.OrganizationID)
+		assert.Equal(t, []model.APIKeyScope{model.ScopeAddProduct}, claims.Scopes)
+		assert.True(t, claims.HasScope(model.ScopeAddProduct))
+		assert.False(t, claims.HasScope(model.ScopeReadPVZ))
+	})
+
+	t.Run("Unknown or revoked key", func(t *testing.T) {
+		uc, _ := newManageServiceAccountsUseCase(testTime, func(ma *mockServiceAccountRepo, ml *mockLogger) {
+			ma.On("FindActiveByKeyHash", mock.Anything, testOldRefreshTokenHash).Return(nil, nil)
+			ml.On("Warn", mock.Anything, mock.Anything)
+		})
+		_, err := uc.AuthenticateAPIKey(context.Background(), testOldRefreshToken)
+
+		assert.EqualError(t, err, model.ErrAccessDenied)
+	})
+
+	t.Run("Database error", func(t *testing.T) {
+		uc, _ := newManageServiceAccountsUseCase(testTime, func(ma *mockServiceAccountRepo, ml *mockLogger) {
+			ma.On("FindActiveByKeyHash", mock.Anything, mock.Anything).Return(nil, errors.New("db error"))
+			ml.On("Error", mock.Anything, mock.Anything)
+		})
+		_, err := uc.AuthenticateAPIKey(context.Background(), testRefreshToken)
+
+		assert.EqualError(t, err, model.ErrInternal)
+	})
+}
+
+func TestCheckPVZAssignment_ServiceAccount(t *testing.T) {
+	ma := &mockPVZAssignmentRepo{}
+	ctx := model.ContextWithServiceAccountID(context.Background(), uuid.NewString())
+
+	err := checkPVZAssignment(ctx, ma, &mockLogger{}, "AddProduct", testUserID, testForeignPVZID.String())
+
+	assert.NoError(t, err)
+	ma.AssertNotCalled(t, "CheckIfAssigned", mock.Anything, mock.Anything, mock.Anything)
+}
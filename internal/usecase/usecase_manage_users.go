@@ -0,0 +1,277 @@
+// Package usecase это юзкейсы и вспомогательная логика
+package usecase
+
+import (
+	"context"
+	"errors"
+	"github.com/google/uuid"
+	"internshipPVZ/internal/domain/model"
+	repo "internshipPVZ/internal/domain/repository"
+	"internshipPVZ/internal/domain/repository/dao"
+	"internshipPVZ/internal/http/onlymodels"
+	"log"
+	"strings"
+)
+
+// NewUseCaseManageUsers конструктор
+func NewUseCaseManageUsers(
+	userRepo repo.UserRepo,
+	sessionRepo repo.SessionRepo,
+	txManager repo.TxManager,
+	timeService TimeService,
+	authorizer Authorizer,
+	logger Logger,
+) *ManageUsers {
+	if userRepo == nil {
+		log.Fatalf("ManageUsers usecase userRepo nil")
+
+	}
+	if sessionRepo == nil {
+		log.Fatalf("ManageUsers usecase sessionRepo nil")
+
+	}
+	if txManager == nil {
+		log.Fatalf("ManageUsers usecase txManager nil")
+
+	}
+	if timeService == nil {
+		log.Fatalf("ManageUsers usecase timeService nil")
+
+	}
+	if authorizer == nil {
+		log.Fatalf("ManageUsers usecase authorizer nil")
+
+	}
+	if logger == nil {
+		log.Fatalf("ManageUsers usecase logger nil")
+
+	}
+
+	return &ManageUsers{
+		userRepo:    userRepo,
+		sessionRepo: sessionRepo,
+		txManager:   txManager,
+		timeService: timeService,
+		authorizer:  authorizer,
+		logger:      logger,
+	}
+}
+
+// ManageUsers юзкейс
+type ManageUsers struct {
+	userRepo    repo.UserRepo
+	sessionRepo repo.SessionRepo
+	txManager   repo.TxManager
+	timeService TimeService
+	authorizer  Authorizer
+	logger      Logger
+}
+
+// List выдаёт страницу пользователей организации модератора, search ищет по части email
+func (uc *ManageUsers) List(ctx context.Context, search string, page, limit int, userRole string) ([]onlymodels.User, error) {
+	if err := uc.checkAccess("List", userRole, model.PermissionManageUsers); err != nil {
+		return nil, err
+	}
+	page, limit = normalizeUsersPage(page, limit)
+	search = strings.TrimSpace(search)
+
+	users, err := uc.userRepo.List(ctx, search, page, limit)
+	if err != nil {
+		uc.logger.Error("failed to list users",
+			"usecase", "ManageUsers",
+			"method", "userRepo.List",
+			"error", err)
+		return nil, errors.New(model.ErrInternal)
+	}
+
+	result := make([]onlymodels.User, 0, len(users))
+	for _, user := range users {
+		userDto, err := userDaoToDto(user)
+		if err != nil {
+			uc.logger.Error("failed to convert user DAO to DTO",
+				"usecase", "ManageUsers",
+				"method", "userDaoToDto",
+				"error", err)
+			return nil, errors.New(model.ErrInternal)
+		}
+		result = append(result, *userDto)
+	}
+
+	uc.logger.Info("users retrieved successfully",
+		"usecase", "ManageUsers",
+		"page", page,
+		"limit", limit,
+		"count", len(result))
+	return result, nil
+}
+
+// ChangeRole меняет роль пользователя, его сессии отзываются, чтобы новая роль действовала сразу.
+// Смена роли и отзыв сессий идут в одной транзакции: роль не сменится, если сессии отозвать не удалось
+func (uc *ManageUsers) ChangeRole(ctx context.Context, targetID uuid.UUID, request *onlymodels.PutUsersUserIdRoleJSONBody, userID, userRole string) (*onlymodels.User, error) {
+	id, err := uc.validateTarget("ChangeRole", targetID, userID, userRole)
+	if err != nil {
+		return nil, err
+	}
+	if request == nil {
+		return nil, errors.New(model.ErrInvalidRequest)
+	}
+	role, err := validateRole(request.Role)
+	if err != nil {
+		uc.logger.Warn("invalid requested role",
+			"usecase", "ManageUsers",
+			"method", "ChangeRole",
+			"requested_role", request.Role)
+		return nil, err
+	}
+
+	var userDao *dao.User
+	err = runInTx(ctx, uc.txManager, uc.logger, "ManageUsers", func(ctx context.Context) error {
+		var err error
+		userDao, err = uc.userRepo.UpdateRole(ctx, id.String(), role.ToInt())
+		if err != nil {
+			return uc.repoError("ChangeRole", "userRepo.UpdateRole", id, err)
+		}
+		return uc.revokeSessions(ctx, "ChangeRole", id)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	uc.logger.Info("user role changed successfully",
+		"usecase", "ManageUsers",
+		"user_id", id,
+		"role", role,
+		"changed_by", userID)
+	return uc.toDto("ChangeRole", userDao)
+}
+
+// Deactivate закрывает пользователю вход и отзывает все его сессии в одной транзакции
+func (uc *ManageUsers) Deactivate(ctx context.Context, targetID uuid.UUID, userID, userRole string) (*onlymodels.User, error) {
+	id, err := uc.validateTarget("Deactivate", targetID, userID, userRole)
+	if err != nil {
+		return nil, err
+	}
+
+	var userDao *dao.User
+	err = runInTx(ctx, uc.txManager, uc.logger, "ManageUsers", func(ctx context.Context) error {
+		var err error
+		userDao, err = uc.userRepo.Deactivate(ctx, id.String(), uc.timeService.GetTime())
+		if err != nil {
+			return uc.repoError("Deactivate", "userRepo.Deactivate", id, err)
+		}
+		return uc.revokeSessions(ctx, "Deactivate", id)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	uc.logger.Info("user deactivated successfully",
+		"usecase", "ManageUsers",
+		"user_id", id,
+		"deactivated_by", userID)
+	return uc.toDto("Deactivate", userDao)
+}
+
+// Reactivate возвращает пользователю вход, старые сессии остаются отозванными
+func (uc *ManageUsers) Reactivate(ctx context.Context, targetID uuid.UUID, userID, userRole string) (*onlymodels.User, error) {
+	id, err := uc.validateTarget("Reactivate", targetID, userID, userRole)
+	if err != nil {
+		return nil, err
+	}
+
+	userDao, err := uc.userRepo.Reactivate(ctx, id.String())
+	if err != nil {
+		return nil, uc.repoError("Reactivate", "userRepo.Reactivate", id, err)
+	}
+
+	uc.logger.Info("user reactivated successfully",
+		"usecase", "ManageUsers",
+		"user_id", id,
+		"reactivated_by", userID)
+	return uc.toDto("Reactivate", userDao)
+}
+
+// validateTarget проверяет права модератора и не даёт ему менять собственный аккаунт,
+// иначе последний модератор организации мог бы случайно лишить её управления
+func (uc *ManageUsers) validateTarget(method string, targetID uuid.UUID, userID, userRole string) (uuid.UUID, error) {
+	if err := uc.checkAccess(method, userRole, model.PermissionManageUsers); err != nil {
+		return uuid.Nil, err
+	}
+	id, err := validateID(targetID)
+	if err != nil {
+		uc.logger.Warn("invalid user ID",
+			"usecase", "ManageUsers",
+			"method", method,
+			"user_id", targetID)
+		return uuid.Nil, errors.New(model.ErrInvalidUserID)
+	}
+	moderatorID, err := validateRawID(userID)
+	if err != nil {
+		uc.logger.Warn("invalid user ID in token",
+			"usecase", "ManageUsers",
+			"method", method,
+			"user_id", userID)
+		return uuid.Nil, err
+	}
+	if moderatorID == id {
+		uc.logger.Warn("moderator tried to manage own account",
+			"usecase", "ManageUsers",
+			"method", method,
+			"user_id", id)
+		return uuid.Nil, errors.New(model.ErrCannotManageSelf)
+	}
+	return id, nil
+}
+
+func (uc *ManageUsers) revokeSessions(ctx context.Context, method string, id uuid.UUID) error {
+	err := uc.sessionRepo.RevokeByUser(ctx, id.String(), "", uc.timeService.GetTime())
+	if err != nil {
+		uc.logger.Error("failed to revoke user sessions",
+			"usecase", "ManageUsers",
+			"method", method,
+			"user_id", id,
+			"error", err)
+		return errors.New(model.ErrInternal)
+	}
+	return nil
+}
+
+func (uc *ManageUsers) repoError(method, repoMethod string, id uuid.UUID, err error) error {
+	if err.Error() == model.ErrUserNotFound {
+		uc.logger.Warn("user not found",
+			"usecase", "ManageUsers",
+			"method", method,
+			"user_id", id)
+		return err
+	}
+	uc.logger.Error("failed to update user",
+		"usecase", "ManageUsers",
+		"method", repoMethod,
+		"user_id", id,
+		"error", err)
+	return errors.New(model.ErrInternal)
+}
+
+func (uc *ManageUsers) toDto(method string, userDao *dao.User) (*onlymodels.User, error) {
+	userDto, err := userDaoToDto(userDao)
+	if err != nil {
+		uc.logger.Error("failed to convert user DAO to DTO",
+			"usecase", "ManageUsers",
+			"method", method,
+			"error", err)
+		return nil, errors.New(model.ErrInternal)
+	}
+	return userDto, nil
+}
+
+func (uc *ManageUsers) checkAccess(method, userRole string, permission model.Permission) error {
+	role, err := validateRole(userRole)
+	if err != nil {
+		uc.logger.Warn("invalid user role",
+			"usecase", "ManageUsers",
+			"method", method,
+			"user_role", userRole)
+		return err
+	}
+	return checkPermission(uc.authorizer, uc.logger, "ManageUsers", method, role, permission)
+}
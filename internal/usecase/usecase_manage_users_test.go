@@ -0,0 +1,334 @@
+// Package usecase это юзкейсы и вспомогательная логика
+package usecase
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"internshipPVZ/internal/domain/model"
+	"internshipPVZ/internal/domain/repository/dao"
+	"internshipPVZ/internal/http/onlymodels"
+)
+
+type userAdminMocks struct {
+	users    *mockUserRepo
+	sessions *mockSessionRepo
+	tx       *mockTxManager
+	logger   *mockLogger
+}
+
+func newManageUsersUseCase(testTime time.Time, setupMocks func(*userAdminMocks)) (*ManageUsers, *userAdminMocks) {
+	m := &userAdminMocks{
+		users:    &mockUserRepo{},
+		sessions: &mockSessionRepo{},
+		tx:       newTestTxManager(),
+		logger:   &mockLogger{},
+	}
+	setupMocks(m)
+	mt := &mockTimeService{}
+	mt.On("GetTime").Return(testTime).Maybe()
+	return NewUseCaseManageUsers(m.users, m.sessions, m.tx, mt, newTestAuthorizer(), m.logger), m
+}
+
+func TestManageUsers_List(t *testing.T) {
+	testTime := time.Now()
+	activeUser := &dao.User{ID: uuid.NewString(), Email: "a@example.com", OrganizationID: testOrganizationID.String()}
+	deactivatedUser := &dao.User{
+		ID:             uuid.NewString(),
+		Email:          "b@example.com",
+		Role:           model.RoleModerator.ToInt(),
+		OrganizationID: testOrganizationID.String(),
+		DeactivatedAt:  sql.NullTime{Time: testTime, Valid: true},
+	}
+
+	tests := []struct {
+		name          string
+		setupMocks    func(*userAdminMocks)
+		search        string
+		page          int
+		limit         int
+		userRole      string
+		expectedCount int
+		expectedError string
+	}{
+		{
+			name: "Success - defaults applied",
+			setupMocks: func(m *userAdminMocks) {
+				m.users.On("List", mock.Anything, "", defaultUsersPage, defaultUsersLimit).Return([]*dao.User{activeUser, deactivatedUser}, nil)
+				m.logger.On("Info", mock.Anything, mock.Anything)
+			},
+			userRole:      model.RoleModerator.Get(),
+			expectedCount: 2,
+		},
+		{
+			name: "Success - search trimmed and too large limit replaced",
+			setupMocks: func(m *userAdminMocks) {
+				m.users.On("List", mock.Anything, "example", 3, defaultUsersLimit).Return([]*dao.User{activeUser}, nil)
+				m.logger.On("Info", mock.Anything, mock.Anything)
+			},
+			search:        "  example ",
+			page:          3,
+			limit:         maxUsersLimit + 1,
+			userRole:      model.RoleModerator.Get(),
+			expectedCount: 1,
+		},
+		{
+			name: "Employee is denied",
+			setupMocks: func(m *userAdminMocks) {
+				m.logger.On("Warn", mock.Anything, mock.Anything)
+			},
+			userRole:      model.RoleEmployee.Get(),
+			expectedError: model.ErrAccessDenied,
+		},
+		{
+			name: "Database error",
+			setupMocks: func(m *userAdminMocks) {
+				m.users.On("List", mock.Anything, mock.Anything, mock.Anything, mock.Anything).Return(nil, errors.New("db error"))
+				m.logger.On("Error", mock.Anything, mock.Anything)
+			},
+			userRole:      model.RoleModerator.Get(),
+			expectedError: model.ErrInternal,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			uc, m := newManageUsersUseCase(testTime, tt.setupMocks)
+			users, err := uc.List(context.Background(), tt.search, tt.page, tt.limit, tt.userRole)
+
+			m.users.AssertExpectations(t)
+			if tt.expectedError != "" {
+				assert.Error(t, err)
+				assert.Equal(t, tt.expectedError, err.Error())
+				return
+			}
+
+			assert.NoError(t, err)
+			assert.Len(t, users, tt.expectedCount)
+			if tt.expectedCount == 2 {
+				assert.True(t, *users[0].Active)
+				assert.False(t, *users[1].Active)
+				assert.Equal(t, onlymodels.UserRole(model.RoleModerator), users[1].Role)
+			}
+		})
+	}
+}
+
+func TestManageUsers_ChangeRole(t *testing.T) {
+	testTime := time.Now()
+	moderatorID := uuid.New()
+	targetID := uuid.New()
+	demoted := &dao.User{ID: targetID.String(), Email: "m@example.com", Role: model.RoleEmployee.ToInt(), OrganizationID: testOrganizationID.String()}
+	validRequest := &onlymodels.PutUsersUserIdRoleJSONBody{Role: model.RoleEmployee.Get()}
+
+	tests := []struct {
+		name          string
+		setupMocks    func(*userAdminMocks)
+		targetID      uuid.UUID
+		request       *onlymodels.PutUsersUserIdRoleJSONBody
+		userRole      string
+		expectedError string
+	}{
+		{
+			name: "Success - sessions revoked",
+			setupMocks: func(m *userAdminMocks) {
+				m.users.On("UpdateRole", mock.MatchedBy(inTx), targetID.String(), model.RoleEmployee.ToInt()).Return(demoted, nil)
+				m.sessions.On("RevokeByUser", mock.MatchedBy(inTx), targetID.String(), "", testTime).Return(nil).Once()
+				m.logger.On("Info", mock.Anything, mock.Anything)
+			},
+			targetID: targetID,
+			request:  validRequest,
+			userRole: model.RoleModerator.Get(),
+		},
+		{
+			name: "Employee is denied",
+			setupMocks: func(m *userAdminMocks) {
+				m.logger.On("Warn", mock.Anything, mock.Anything)
+			},
+			targetID:      targetID,
+			request:       validRequest,
+			userRole:      model.RoleEmployee.Get(),
+			expectedError: model.ErrAccessDenied,
+		},
+		{
+			name: "Own account",
+			setupMocks: func(m *userAdminMocks) {
+				m.logger.On("Warn", mock.Anything, mock.Anything)
+			},
+			targetID:      moderatorID,
+			request:       validRequest,
+			userRole:      model.RoleModerator.Get(),
+			expectedError: model.ErrCannotManageSelf,
+		},
+		{
+			name: "Invalid target ID",
+			setupMocks: func(m *userAdminMocks) {
+				m.logger.On("Warn", mock.Anything, mock.Anything)
+			},
+			targetID:      uuid.Nil,
+			request:       validRequest,
+			userRole:      model.RoleModerator.Get(),
+			expectedError: model.ErrInvalidUserID,
+		},
+		{
+			name: "Invalid role",
+			setupMocks: func(m *userAdminMocks) {
+				m.logger.On("Warn", mock.Anything, mock.Anything)
+			},
+			targetID:      targetID,
+			request:       &onlymodels.PutUsersUserIdRoleJSONBody{Role: "admin"},
+			userRole:      model.RoleModerator.Get(),
+			expectedError: model.ErrInvalidRole,
+		},
+		{
+			name: "User from another organization",
+			setupMocks: func(m *userAdminMocks) {
+				m.users.On("UpdateRole", mock.Anything, targetID.String(), model.RoleEmployee.ToInt()).Return(nil, errors.New(model.ErrUserNotFound))
+				m.logger.On("Warn", mock.Anything, mock.Anything)
+			},
+			targetID:      targetID,
+			request:       validRequest,
+			userRole:      model.RoleModerator.Get(),
+			expectedError: model.ErrUserNotFound,
+		},
+		{
+			name: "Session revocation error",
+			setupMocks: func(m *userAdminMocks) {
+				m.users.On("UpdateRole", mock.Anything, targetID.String(), model.RoleEmployee.ToInt()).Return(demoted, nil)
+				m.sessions.On("RevokeByUser", mock.Anything, targetID.String(), "", testTime).Return(errors.New("db error"))
+				m.logger.On("Error", mock.Anything, mock.Anything)
+			},
+			targetID:      targetID,
+			request:       validRequest,
+			userRole:      model.RoleModerator.Get(),
+			expectedError: model.ErrInternal,
+		},
+		{
+			name: "Commit error",
+			setupMocks: func(m *userAdminMocks) {
+				m.tx.commitErr = errors.New("commit failed")
+				m.users.On("UpdateRole", mock.Anything, targetID.String(), model.RoleEmployee.ToInt()).Return(demoted, nil)
+				m.sessions.On("RevokeByUser", mock.Anything, targetID.String(), "", testTime).Return(nil)
+				m.logger.On("Error", mock.Anything, mock.Anything)
+			},
+			targetID:      targetID,
+			request:       validRequest,
+			userRole:      model.RoleModerator.Get(),
+			expectedError: model.ErrInternal,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			uc, m := newManageUsersUseCase(testTime, tt.setupMocks)
+			user, err := uc.ChangeRole(context.Background(), tt.targetID, tt.request, moderatorID.String(), tt.userRole)
+
+			m.users.AssertExpectations(t)
+			m.sessions.AssertExpectations(t)
+			if tt.expectedError != "" {
+				assert.Error(t, err)
+				assert.Equal(t, tt.expectedError, err.Error())
+				return
+			}
+
+			assert.NoError(t, err)
+			assert.Equal(t, onlymodels.UserRole(model.RoleEmployee), user.Role)
+		})
+	}
+}
+
+func TestManageUsers_DeactivateReactivate(t *testing.T) {
+	testTime := time.Now()
+	moderatorID := uuid.New()
+	targetID := uuid.New()
+	deactivated := &dao.User{
+		ID:             targetID.String(),
+		Email:          "e@example.com",
+		OrganizationID: testOrganizationID.String(),
+		DeactivatedAt:  sql.NullTime{Time: testTime, Valid: true},
+	}
+	reactivated := &dao.User{ID: targetID.String(), Email: "e@example.com", OrganizationID: testOrganizationID.String()}
+
+	t.Run("Deactivate revokes sessions", func(t *testing.T) {
+		uc, m := newManageUsersUseCase(testTime, func(m *userAdminMocks) {
+			m.users.On("Deactivate", mock.MatchedBy(inTx), targetID.String(), testTime).Return(deactivated, nil)
+			m.sessions.On("RevokeByUser", mock.MatchedBy(inTx), targetID.String(), "", testTime).Return(nil).Once()
+			m.logger.On("Info", mock.Anything, mock.Anything)
+		})
+		user, err := uc.Deactivate(context.Background(), targetID, moderatorID.String(), model.RoleModerator.Get())
+
+		assert.NoError(t, err)
+		assert.False(t, *user.Active)
+		m.sessions.AssertExpectations(t)
+	})
+
+	t.Run("Deactivate session revocation error", func(t *testing.T) {
+		uc, m := newManageUsersUseCase(testTime, func(m *userAdminMocks) {
+			m.users.On("Deactivate", mock.MatchedBy(inTx), targetID.String(), testTime).Return(deactivated, nil)
+			m.sessions.On("RevokeByUser", mock.MatchedBy(inTx), targetID.String(), "", testTime).Return(errors.New("db error"))
+			m.logger.On("Error", mock.Anything, mock.Anything)
+		})
+		_, err := uc.Deactivate(context.Background(), targetID, moderatorID.String(), model.RoleModerator.Get())
+
+		assert.EqualError(t, err, model.ErrInternal)
+		assert.Equal(t, 1, m.tx.calls)
+	})
+
+	t.Run("Deactivate own account", func(t *testing.T) {
+		uc, m := newManageUsersUseCase(testTime, func(m *userAdminMocks) {
+			m.logger.On("Warn", mock.Anything, mock.Anything)
+		})
+		_, err := uc.Deactivate(context.Background(), moderatorID, moderatorID.String(), model.RoleModerator.Get())
+
+		assert.EqualError(t, err, model.ErrCannotManageSelf)
+		m.users.AssertNotCalled(t, "Deactivate", mock.Anything, mock.Anything, mock.Anything)
+	})
+
+	t.Run("Deactivate unknown user", func(t *testing.T) {
+		uc, m := newManageUsersUseCase(testTime, func(m *userAdminMocks) {
+			m.users.On("Deactivate", mock.Anything, targetID.String(), testTime).Return(nil, errors.New(model.ErrUserNotFound))
+			m.logger.On("Warn", mock.Anything, mock.Anything)
+		})
+		_, err := uc.Deactivate(context.Background(), targetID, moderatorID.String(), model.RoleModerator.Get())
+
+		assert.EqualError(t, err, model.ErrUserNotFound)
+		m.sessions.AssertNotCalled(t, "RevokeByUser", mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+	})
+
+	t.Run("Reactivate", func(t *testing.T) {
+		uc, m := newManageUsersUseCase(testTime, func(m *userAdminMocks) {
+			m.users.On("Reactivate", mock.Anything, targetID.String()).Return(reactivated, nil)
+			m.logger.On("Info", mock.Anything, mock.Anything)
+		})
+		user, err := uc.Reactivate(context.Background(), targetID, moderatorID.String(), model.RoleModerator.Get())
+
+		assert.NoError(t, err)
+		assert.True(t, *user.Active)
+		m.sessions.AssertNotCalled(t, "RevokeByUser", mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+	})
+
+	t.Run("Reactivate by employee", func(t *testing.T) {
+		uc, _ := newManageUsersUseCase(testTime, func(m *userAdminMocks) {
+			m.logger.On("Warn", mock.Anything, mock.Anything)
+		})
+		_, err := uc.Reactivate(context.Background(), targetID, moderatorID.String(), model.RoleEmployee.Get())
+
+		assert.EqualError(t, err, model.ErrAccessDenied)
+	})
+
+	t.Run("Reactivate database error", func(t *testing.T) {
+		uc, _ := newManageUsersUseCase(testTime, func(m *userAdminMocks) {
+			m.users.On("Reactivate", mock.Anything, targetID.String()).Return(nil, errors.New("db error"))
+			m.logger.On("Error", mock.Anything, mock.Anything)
+		})
+		_, err := uc.Reactivate(context.Background(), targetID, moderatorID.String(), model.RoleModerator.Get())
+
+		assert.EqualError(t, err, model.ErrInternal)
+	})
+}
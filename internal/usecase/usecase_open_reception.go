@@ -4,10 +4,10 @@ package usecase
 import (
 	"context"
 	"errors"
-	"fmt"
 	"github.com/google/uuid"
 	"internshipPVZ/internal/domain/model"
 	repo "internshipPVZ/internal/domain/repository"
+	"internshipPVZ/internal/domain/repository/dao"
 	"internshipPVZ/internal/http/onlymodels"
 	"log"
 )
@@ -16,7 +16,10 @@ import (
 func NewUseCaseOpenReception(
 	receptionRepo repo.ReceptionRepo,
 	pvzRepo repo.PVZRepo,
+	assignmentRepo repo.PVZAssignmentRepo,
+	txManager repo.TxManager,
 	timeService TimeService,
+	authorizer Authorizer,
 	logger Logger,
 ) *OpenReception {
 	if receptionRepo == nil {
@@ -26,10 +29,22 @@ func NewUseCaseOpenReception(
 	if pvzRepo == nil {
 		log.Fatalf("OpenReception usecase pvzRepo nil")
 
+	}
+	if assignmentRepo == nil {
+		log.Fatalf("OpenReception usecase assignmentRepo nil")
+
+	}
+	if txManager == nil {
+		log.Fatalf("OpenReception usecase txManager nil")
+
 	}
 	if timeService == nil {
 		log.Fatalf("OpenReception usecase timeService nil")
 
+	}
+	if authorizer == nil {
+		log.Fatalf("OpenReception usecase authorizer nil")
+
 	}
 	if logger == nil {
 		log.Fatalf("OpenReception usecase logger nil")
@@ -37,24 +52,30 @@ func NewUseCaseOpenReception(
 	}
 
 	return &OpenReception{
-		receptionRepo: receptionRepo,
-		pvzRepo:       pvzRepo,
-		timeService:   timeService,
-		logger:        logger,
+		receptionRepo:  receptionRepo,
+		pvzRepo:        pvzRepo,
+		assignmentRepo: assignmentRepo,
+		txManager:      txManager,
+		timeService:    timeService,
+		authorizer:     authorizer,
+		logger:         logger,
 	}
 }
 
 // OpenReception юзкейс
 type OpenReception struct {
-	receptionRepo repo.ReceptionRepo
-	timeService   TimeService
-	pvzRepo       repo.PVZRepo
-	logger        Logger
+	receptionRepo  repo.ReceptionRepo
+	timeService    TimeService
+	pvzRepo        repo.PVZRepo
+	assignmentRepo repo.PVZAssignmentRepo
+	txManager      repo.TxManager
+	authorizer     Authorizer
+	logger         Logger
 }
 
 // Execute открывает приёмку
-func (uc *OpenReception) Execute(ctx context.Context, PVZID uuid.UUID, userRole string) (*onlymodels.Reception, error) {
-	pvzID, role, err := uc.validateInput(PVZID, userRole)
+func (uc *OpenReception) Execute(ctx context.Context, PVZID uuid.UUID, userID, userRole string) (*onlymodels.Reception, error) {
+	pvzID, userUUID, role, err := uc.validateInput(PVZID, userID, userRole)
 	if err != nil {
 		uc.logger.Error("validation failed",
 			"usecase", "OpenReception",
@@ -64,62 +85,62 @@ func (uc *OpenReception) Execute(ctx context.Context, PVZID uuid.UUID, userRole
 		return nil, err
 	}
 
-	if model.RoleEmployee != role {
-		uc.logger.Warn("access denied",
-			"usecase", "OpenReception",
-			"method", "Execute",
-			"required_role", model.RoleEmployee,
-			"user_role", role)
-		return nil, errors.New(model.ErrAccessDenied)
+	if err = checkPermission(uc.authorizer, uc.logger, "OpenReception", "Execute", role, model.PermissionOpenReception); err != nil {
+		return nil, err
 	}
 
-	rec := &model.Reception{PVZID: pvzID, DateTime: uc.timeService.GetTime(), Status: model.ReceptionInProgress}
+	rec := &model.Reception{PVZID: pvzID, DateTime: uc.timeService.GetTime(), Status: model.ReceptionInProgress, OpenedBy: userUUID}
 	recDao := rec.ToDao()
 
-	exists, err := uc.pvzRepo.CheckIfExists(ctx, recDao.PVZID)
-	if err != nil {
-		uc.logger.Error("failed to check PVZ existence",
-			"usecase", "OpenReception",
-			"method", "pvzRepo.CheckIfExists",
-			"pvz_id", recDao.PVZID,
-			"error", err)
-		return nil, errors.New(model.ErrInternal)
-	}
-
-	if !exists {
-		uc.logger.Warn("invalid PVZ ID",
-			"usecase", "OpenReception",
-			"method", "Execute",
-			"pvz_id", recDao.PVZID)
-		return nil, errors.New(model.ErrInvalidPVZID)
-	}
-
-	exists, err = uc.receptionRepo.CheckIfExists(ctx, recDao)
+	// проверка незавершённой приёмки и вставка идут под блокировкой ПВЗ, иначе параллельные запросы откроют две приёмки
+	err = runInTx(ctx, uc.txManager, uc.logger, "OpenReception", func(ctx context.Context) error {
+		if err := checkPVZActive(ctx, uc.pvzRepo, uc.logger, "OpenReception", recDao.PVZID); err != nil {
+			return err
+		}
+
+		if err := checkPVZAssignment(ctx, uc.assignmentRepo, uc.logger, "OpenReception", userUUID.String(), recDao.PVZID); err != nil {
+			return err
+		}
+
+		unfinished := &dao.Reception{PVZID: recDao.PVZID}
+		err := uc.receptionRepo.FindLast(ctx, unfinished, receptionStatusesToInt(model.UnfinishedReceptionStatuses)...)
+		if err != nil {
+			uc.logger.Error("failed to find unfinished reception",
+				"usecase", "OpenReception",
+				"method", "receptionRepo.FindLast",
+				"pvz_id", recDao.PVZID,
+				"error", err)
+			return errors.New(model.ErrInternal)
+		}
+
+		if unfinished.ID != "" {
+			uc.logger.Warn("reception already opened",
+				"usecase", "OpenReception",
+				"method", "Execute",
+				"pvz_id", recDao.PVZID)
+			return errors.New(model.ErrReceptionAlreadyOpened)
+		}
+
+		err = uc.receptionRepo.Create(ctx, recDao)
+		if err != nil {
+			if err.Error() == model.ErrReceptionAlreadyOpened {
+				uc.logger.Warn("reception was opened concurrently",
+					"usecase", "OpenReception",
+					"method", "receptionRepo.Create",
+					"pvz_id", recDao.PVZID)
+				return err
+			}
+			uc.logger.Error("failed to create reception",
+				"usecase", "OpenReception",
+				"method", "receptionRepo.Create",
+				"pvz_id", recDao.PVZID,
+				"error", err)
+			return errors.New(model.ErrInternal)
+		}
+		return nil
+	})
 	if err != nil {
-		uc.logger.Error("failed to check if reception exists",
-			"usecase", "OpenReception",
-			"method", "receptionRepo.CheckIfExists",
-			"pvz_id", recDao.PVZID,
-			"error", err)
-		return nil, fmt.Errorf(model.ErrInternal)
-	}
-
-	if exists {
-		uc.logger.Warn("reception already opened",
-			"usecase", "OpenReception",
-			"method", "Execute",
-			"pvz_id", recDao.PVZID)
-		return nil, errors.New(model.ErrReceptionAlreadyOpened)
-	}
-
-	err = uc.receptionRepo.Create(ctx, recDao)
-	if err != nil {
-		uc.logger.Error("failed to create reception",
-			"usecase", "OpenReception",
-			"method", "receptionRepo.Create",
-			"pvz_id", recDao.PVZID,
-			"error", err)
-		return nil, errors.New(model.ErrInternal)
+		return nil, err
 	}
 
 	recDto, err := receptionDaoToDto(recDao)
@@ -135,11 +156,12 @@ func (uc *OpenReception) Execute(ctx context.Context, PVZID uuid.UUID, userRole
 	uc.logger.Info("reception opened successfully",
 		"usecase", "OpenReception",
 		"reception_id", recDao.ID,
-		"pvz_id", recDao.PVZID)
+		"pvz_id", recDao.PVZID,
+		"user_id", userUUID)
 	return recDto, nil
 }
 
-func (uc *OpenReception) validateInput(PVZID uuid.UUID, userRole string) (pvzID uuid.UUID, role model.Role, err error) {
+func (uc *OpenReception) validateInput(PVZID uuid.UUID, userID, userRole string) (pvzID, userUUID uuid.UUID, role model.Role, err error) {
 	if pvzID, err = validateID(PVZID); err != nil {
 		uc.logger.Warn("invalid PVZ ID format",
 			"usecase", "OpenReception",
@@ -148,6 +170,14 @@ func (uc *OpenReception) validateInput(PVZID uuid.UUID, userRole string) (pvzID
 		return
 	}
 
+	if userUUID, err = validateRawID(userID); err != nil {
+		uc.logger.Warn("invalid user ID in token",
+			"usecase", "OpenReception",
+			"method", "validateInput",
+			"user_id", userID)
+		return
+	}
+
 	if role, err = validateRole(userRole); err != nil {
 		uc.logger.Warn("invalid user role",
 			"usecase", "OpenReception",
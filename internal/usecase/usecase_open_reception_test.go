@@ -3,6 +3,7 @@ package usecase
 
 import (
 	"context"
+	"database/sql"
 	"errors"
 	"testing"
 	"time"
@@ -31,8 +32,8 @@ func TestOpenReception_Execute(t *testing.T) {
 		{
 			name: "Success - open reception",
 			setupMocks: func(mr *mockReceptionRepo, mz *mockPVZRepo, mt *mockTimeService, ml *mockLogger) {
-				mz.On("CheckIfExists", mock.Anything, validPVZID.String()).Return(true, nil)
-				mr.On("CheckIfExists", mock.Anything, mock.Anything).Return(false, nil)
+				mz.On("FindByIDForUpdate", mock.Anything, validPVZID.String()).Return(&dao.PVZ{ID: validPVZID.String()}, nil)
+				mr.On("FindLast", mock.Anything, mock.Anything, mock.Anything).Return(nil)
 				mt.On("GetTime").Return(testTime)
 				mr.On("Create", mock.Anything, mock.Anything).Run(func(args mock.Arguments) {
 					rec := args.Get(1).(*dao.Reception)
@@ -81,7 +82,7 @@ func TestOpenReception_Execute(t *testing.T) {
 		{
 			name: "PVZ not found",
 			setupMocks: func(_ *mockReceptionRepo, mz *mockPVZRepo, mt *mockTimeService, ml *mockLogger) {
-				mz.On("CheckIfExists", mock.Anything, validPVZID.String()).Return(false, nil)
+				mz.On("FindByIDForUpdate", mock.Anything, validPVZID.String()).Return(nil, nil)
 				ml.On("Warn", mock.Anything, mock.Anything)
 				mt.On("GetTime", mock.Anything, mock.Anything).Return(testTime)
 			},
@@ -89,10 +90,35 @@ func TestOpenReception_Execute(t *testing.T) {
 			userRole:      model.RoleEmployee.Get(),
 			expectedError: model.ErrInvalidPVZID,
 		},
+		{
+			name: "Archived PVZ",
+			setupMocks: func(_ *mockReceptionRepo, mz *mockPVZRepo, mt *mockTimeService, ml *mockLogger) {
+				mz.On("FindByIDForUpdate", mock.Anything, validPVZID.String()).Return(&dao.PVZ{
+					ID:         validPVZID.String(),
+					ArchivedAt: sql.NullTime{Time: testTime, Valid: true},
+				}, nil)
+				ml.On("Warn", mock.Anything, mock.Anything)
+				mt.On("GetTime", mock.Anything, mock.Anything).Return(testTime)
+			},
+			pvzID:         validPVZID,
+			userRole:      model.RoleEmployee.Get(),
+			expectedError: model.ErrPVZArchived,
+		},
+		{
+			name: "PVZ not assigned to user",
+			setupMocks: func(_ *mockReceptionRepo, mz *mockPVZRepo, mt *mockTimeService, ml *mockLogger) {
+				mz.On("FindByIDForUpdate", mock.Anything, testForeignPVZID.String()).Return(&dao.PVZ{ID: testForeignPVZID.String()}, nil)
+				ml.On("Warn", mock.Anything, mock.Anything)
+				mt.On("GetTime", mock.Anything, mock.Anything).Return(testTime)
+			},
+			pvzID:         testForeignPVZID,
+			userRole:      model.RoleEmployee.Get(),
+			expectedError: model.ErrAccessDenied,
+		},
 		{
 			name: "Error checking PVZ existence",
 			setupMocks: func(_ *mockReceptionRepo, mz *mockPVZRepo, mt *mockTimeService, ml *mockLogger) {
-				mz.On("CheckIfExists", mock.Anything, validPVZID.String()).Return(false, errors.New("db error"))
+				mz.On("FindByIDForUpdate", mock.Anything, validPVZID.String()).Return(nil, errors.New("db error"))
 				ml.On("Error", mock.Anything, mock.Anything)
 				mt.On("GetTime", mock.Anything, mock.Anything).Return(testTime)
 			},
@@ -103,8 +129,28 @@ func TestOpenReception_Execute(t *testing.T) {
 		{
 			name: "Reception already exists",
 			setupMocks: func(mr *mockReceptionRepo, mz *mockPVZRepo, mt *mockTimeService, ml *mockLogger) {
-				mz.On("CheckIfExists", mock.Anything, validPVZID.String()).Return(true, nil)
-				mr.On("CheckIfExists", mock.Anything, mock.Anything).Return(true, nil)
+				mz.On("FindByIDForUpdate", mock.Anything, validPVZID.String()).Return(&dao.PVZ{ID: validPVZID.String()}, nil)
+				mr.On("FindLast", mock.Anything, mock.Anything, mock.Anything).Run(func(args mock.Arguments) {
+					rec := args.Get(1).(*dao.Reception)
+					rec.ID = validReceptionID.String()
+					rec.Status = model.ReceptionInProgress.ToInt()
+				}).Return(nil)
+				ml.On("Warn", mock.Anything, mock.Anything)
+				mt.On("GetTime", mock.Anything, mock.Anything).Return(testTime)
+			},
+			pvzID:         validPVZID,
+			userRole:      model.RoleEmployee.Get(),
+			expectedError: model.ErrReceptionAlreadyOpened,
+		},
+		{
+			name: "Paused reception blocks opening",
+			setupMocks: func(mr *mockReceptionRepo, mz *mockPVZRepo, mt *mockTimeService, ml *mockLogger) {
+				mz.On("FindByIDForUpdate", mock.Anything, validPVZID.String()).Return(&dao.PVZ{ID: validPVZID.String()}, nil)
+				mr.On("FindLast", mock.Anything, mock.Anything, receptionStatusesToInt(model.UnfinishedReceptionStatuses)).Run(func(args mock.Arguments) {
+					rec := args.Get(1).(*dao.Reception)
+					rec.ID = validReceptionID.String()
+					rec.Status = model.ReceptionPaused.ToInt()
+				}).Return(nil)
 				ml.On("Warn", mock.Anything, mock.Anything)
 				mt.On("GetTime", mock.Anything, mock.Anything).Return(testTime)
 			},
@@ -115,8 +161,8 @@ func TestOpenReception_Execute(t *testing.T) {
 		{
 			name: "Error checking reception existence",
 			setupMocks: func(mr *mockReceptionRepo, mz *mockPVZRepo, mt *mockTimeService, ml *mockLogger) {
-				mz.On("CheckIfExists", mock.Anything, validPVZID.String()).Return(true, nil)
-				mr.On("CheckIfExists", mock.Anything, mock.Anything).Return(false, errors.New("db error"))
+				mz.On("FindByIDForUpdate", mock.Anything, validPVZID.String()).Return(&dao.PVZ{ID: validPVZID.String()}, nil)
+				mr.On("FindLast", mock.Anything, mock.Anything, mock.Anything).Return(errors.New("db error"))
 				ml.On("Error", mock.Anything, mock.Anything)
 				mt.On("GetTime", mock.Anything, mock.Anything).Return(testTime)
 			},
@@ -124,11 +170,24 @@ func TestOpenReception_Execute(t *testing.T) {
 			userRole:      model.RoleEmployee.Get(),
 			expectedError: model.ErrInternal,
 		},
+		{
+			name: "Unique index rejects concurrent reception",
+			setupMocks: func(mr *mockReceptionRepo, mz *mockPVZRepo, mt *mockTimeService, ml *mockLogger) {
+				mz.On("FindByIDForUpdate", mock.Anything, validPVZID.String()).Return(&dao.PVZ{ID: validPVZID.String()}, nil)
+				mr.On("FindLast", mock.Anything, mock.Anything, mock.Anything).Return(nil)
+				mt.On("GetTime").Return(testTime)
+				mr.On("Create", mock.Anything, mock.Anything).Return(errors.New(model.ErrReceptionAlreadyOpened))
+				ml.On("Warn", mock.Anything, mock.Anything)
+			},
+			pvzID:         validPVZID,
+			userRole:      model.RoleEmployee.Get(),
+			expectedError: model.ErrReceptionAlreadyOpened,
+		},
 		{
 			name: "Error creating reception",
 			setupMocks: func(mr *mockReceptionRepo, mz *mockPVZRepo, mt *mockTimeService, ml *mockLogger) {
-				mz.On("CheckIfExists", mock.Anything, validPVZID.String()).Return(true, nil)
-				mr.On("CheckIfExists", mock.Anything, mock.Anything).Return(false, nil)
+				mz.On("FindByIDForUpdate", mock.Anything, validPVZID.String()).Return(&dao.PVZ{ID: validPVZID.String()}, nil)
+				mr.On("FindLast", mock.Anything, mock.Anything, mock.Anything).Return(nil)
 				mt.On("GetTime").Return(testTime)
 				mr.On("Create", mock.Anything, mock.Anything).Return(errors.New("db error"))
 				ml.On("Error", mock.Anything, mock.Anything)
@@ -150,8 +209,8 @@ func TestOpenReception_Execute(t *testing.T) {
 				tt.setupMocks(mr, mz, mt, ml)
 			}
 
-			uc := NewUseCaseOpenReception(mr, mz, mt, ml)
-			result, err := uc.Execute(context.Background(), tt.pvzID, tt.userRole)
+			uc := NewUseCaseOpenReception(mr, mz, newTestAssignmentRepo(), newTestTxManager(), mt, newTestAuthorizer(), ml)
+			result, err := uc.Execute(context.Background(), tt.pvzID, testUserID, tt.userRole)
 
 			if tt.expectedError != "" {
 				assert.Error(t, err)
@@ -164,6 +223,7 @@ func TestOpenReception_Execute(t *testing.T) {
 			assert.Equal(t, tt.expected.PvzId, result.PvzId)
 			assert.Equal(t, tt.expected.DateTime, result.DateTime)
 			assert.Equal(t, tt.expected.Status, result.Status)
+			assert.Equal(t, testUserID, result.OpenedBy.String())
 		})
 	}
 }
@@ -175,12 +235,14 @@ func TestOpenReception_validateInput(t *testing.T) {
 		name          string
 		setupMocks    func(*mockLogger)
 		pvzID         uuid.UUID
+		userID        string
 		userRole      string
 		expectedError string
 	}{
 		{
 			name:     "Valid input",
 			pvzID:    validPVZID,
+			userID:   testUserID,
 			userRole: model.RoleEmployee.Get(),
 		},
 		{
@@ -189,6 +251,7 @@ func TestOpenReception_validateInput(t *testing.T) {
 			},
 			name:          "Invalid PVZ ID",
 			pvzID:         uuid.Nil,
+			userID:        testUserID,
 			userRole:      model.RoleEmployee.Get(),
 			expectedError: model.ErrAccessDenied,
 		},
@@ -198,9 +261,20 @@ func TestOpenReception_validateInput(t *testing.T) {
 			},
 			name:          "Invalid user role",
 			pvzID:         validPVZID,
+			userID:        testUserID,
 			userRole:      "invalid-role",
 			expectedError: model.ErrInvalidRole,
 		},
+		{
+			name: "Invalid user ID",
+			setupMocks: func(ml *mockLogger) {
+				ml.On("Warn", mock.Anything, mock.Anything)
+			},
+			pvzID:         validPVZID,
+			userID:        "not-a-uuid",
+			userRole:      model.RoleEmployee.Get(),
+			expectedError: model.ErrAccessDenied,
+		},
 	}
 
 	for _, tt := range tests {
@@ -212,7 +286,7 @@ func TestOpenReception_validateInput(t *testing.T) {
 			}
 			uc := &OpenReception{logger: ml}
 
-			_, _, err := uc.validateInput(tt.pvzID, tt.userRole)
+			_, _, _, err := uc.validateInput(tt.pvzID, tt.userID, tt.userRole)
 
 			if tt.expectedError != "" {
 				assert.Error(t, err)
@@ -224,3 +298,50 @@ func TestOpenReception_validateInput(t *testing.T) {
 		})
 	}
 }
+
+func TestOpenReception_Transaction(t *testing.T) {
+	validPVZID := uuid.New()
+
+	t.Run("Unfinished reception check and insert run in one transaction", func(t *testing.T) {
+		mr := &mockReceptionRepo{}
+		mz := &mockPVZRepo{}
+		mt := &mockTimeService{}
+		ml := &mockLogger{}
+		mz.On("FindByIDForUpdate", mock.MatchedBy(inTx), validPVZID.String()).Return(&dao.PVZ{ID: validPVZID.String()}, nil)
+		mr.On("FindLast", mock.MatchedBy(inTx), mock.Anything, mock.Anything).Return(nil)
+		mr.On("Create", mock.MatchedBy(inTx), mock.Anything).Run(func(args mock.Arguments) {
+			args.Get(1).(*dao.Reception).ID = uuid.NewString()
+		}).Return(nil)
+		mt.On("GetTime").Return(time.Now())
+		ml.On("Info", mock.Anything, mock.Anything)
+		tx := newTestTxManager()
+
+		uc := NewUseCaseOpenReception(mr, mz, newTestAssignmentRepo(), tx, mt, newTestAuthorizer(), ml)
+		_, err := uc.Execute(context.Background(), validPVZID, testUserID, model.RoleEmployee.Get())
+
+		assert.NoError(t, err)
+		assert.Equal(t, 1, tx.calls)
+		mz.AssertExpectations(t)
+		mr.AssertExpectations(t)
+	})
+
+	t.Run("Error inside transaction is returned as is", func(t *testing.T) {
+		mr := &mockReceptionRepo{}
+		mz := &mockPVZRepo{}
+		mt := &mockTimeService{}
+		ml := &mockLogger{}
+		mz.On("FindByIDForUpdate", mock.MatchedBy(inTx), validPVZID.String()).Return(&dao.PVZ{ID: validPVZID.String()}, nil)
+		mr.On("FindLast", mock.MatchedBy(inTx), mock.Anything, mock.Anything).Run(func(args mock.Arguments) {
+			args.Get(1).(*dao.Reception).ID = uuid.NewString()
+		}).Return(nil)
+		mt.On("GetTime").Return(time.Now())
+		ml.On("Warn", mock.Anything, mock.Anything)
+		tx := &mockTxManager{commitErr: errors.New("must not be reached")}
+
+		uc := NewUseCaseOpenReception(mr, mz, newTestAssignmentRepo(), tx, mt, newTestAuthorizer(), ml)
+		_, err := uc.Execute(context.Background(), validPVZID, testUserID, model.RoleEmployee.Get())
+
+		assert.EqualError(t, err, model.ErrReceptionAlreadyOpened)
+		mr.AssertNotCalled(t, "Create", mock.Anything, mock.Anything)
+	})
+}
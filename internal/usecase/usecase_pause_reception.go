@@ -0,0 +1,114 @@
+// Package usecase это юзкейсы и вспомогательная логика
+package usecase
+
+import (
+	"context"
+	"github.com/google/uuid"
+	"internshipPVZ/internal/domain/model"
+	repo "internshipPVZ/internal/domain/repository"
+	"internshipPVZ/internal/http/onlymodels"
+	"log"
+)
+
+// NewUseCasePauseReception конструктор
+func NewUseCasePauseReception(
+	receptionRepo repo.ReceptionRepo,
+	pvzRepo repo.PVZRepo,
+	assignmentRepo repo.PVZAssignmentRepo,
+	txManager repo.TxManager,
+	authorizer Authorizer,
+	logger Logger,
+) *PauseReception {
+	if receptionRepo == nil {
+		log.Fatalf("PauseReception usecase receptionRepo nil")
+
+	}
+	if pvzRepo == nil {
+		log.Fatalf("PauseReception usecase pvzRepo nil")
+
+	}
+	if assignmentRepo == nil {
+		log.Fatalf("PauseReception usecase assignmentRepo nil")
+
+	}
+	if txManager == nil {
+		log.Fatalf("PauseReception usecase txManager nil")
+
+	}
+	if authorizer == nil {
+		log.Fatalf("PauseReception usecase authorizer nil")
+
+	}
+	if logger == nil {
+		log.Fatalf("PauseReception usecase logger nil")
+
+	}
+
+	return &PauseReception{
+		receptionRepo:  receptionRepo,
+		pvzRepo:        pvzRepo,
+		assignmentRepo: assignmentRepo,
+		txManager:      txManager,
+		authorizer:     authorizer,
+		logger:         logger,
+	}
+}
+
+// PauseReception юзкейс
+type PauseReception struct {
+	receptionRepo  repo.ReceptionRepo
+	pvzRepo        repo.PVZRepo
+	assignmentRepo repo.PVZAssignmentRepo
+	txManager      repo.TxManager
+	authorizer     Authorizer
+	logger         Logger
+}
+
+// Execute приостанавливает открытую приёмку
+func (uc *PauseReception) Execute(ctx context.Context, PVZID uuid.UUID, userID, userRole string) (*onlymodels.Reception, error) {
+	pvzID, userUUID, role, err := uc.validateInput(PVZID, userID, userRole)
+	if err != nil {
+		uc.logger.Error("validation failed",
+			"usecase", "PauseReception",
+			"method", "validateInput",
+			"pvz_id", PVZID,
+			"error", err)
+		return nil, err
+	}
+
+	if err = checkPermission(uc.authorizer, uc.logger, "PauseReception", "Execute", role, model.PermissionPauseReception); err != nil {
+		return nil, err
+	}
+
+	return changeLastReceptionStatus(ctx, uc.receptionRepo, uc.pvzRepo, uc.assignmentRepo, uc.txManager, uc.logger, receptionStatusChange{
+		usecase: "PauseReception",
+		pvzID:   pvzID,
+		userID:  userUUID,
+		target:  model.ReceptionPaused,
+	})
+}
+
+func (uc *PauseReception) validateInput(PVZID uuid.UUID, userID, userRole string) (pvzID, userUUID uuid.UUID, role model.Role, err error) {
+	if pvzID, err = validateID(PVZID); err != nil {
+		uc.logger.Warn("invalid PVZ ID format",
+			"usecase", "PauseReception",
+			"method", "validateInput",
+			"pvz_id", PVZID)
+		return
+	}
+	if userUUID, err = validateRawID(userID); err != nil {
+		uc.logger.Warn("invalid user ID in token",
+			"usecase", "PauseReception",
+			"method", "validateInput",
+			"user_id", userID)
+		return
+	}
+	if role, err = validateRole(userRole); err != nil {
+		uc.logger.Warn("invalid user role",
+			"usecase", "PauseReception",
+			"method", "validateInput",
+			"user_role", userRole)
+		return
+	}
+	return
+}
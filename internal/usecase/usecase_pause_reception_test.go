@@ -0,0 +1,126 @@
+// Package usecase это юзкейсы и вспомогательная логика
+package usecase
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"internshipPVZ/internal/domain/model"
+	"internshipPVZ/internal/domain/repository/dao"
+	"internshipPVZ/internal/http/onlymodels"
+)
+
+func TestPauseReception_Execute(t *testing.T) {
+	validPVZID := uuid.New()
+	validReceptionID := uuid.New()
+
+	tests := []struct {
+		name          string
+		setupMocks    func(*mockReceptionRepo, *mockPVZRepo, *mockLogger)
+		pvzID         uuid.UUID
+		userRole      string
+		expected      onlymodels.ReceptionStatus
+		expectedError string
+	}{
+		{
+			name: "Pause reception in progress",
+			setupMocks: func(mr *mockReceptionRepo, mz *mockPVZRepo, ml *mockLogger) {
+				mz.On("FindByIDForUpdate", mock.Anything, validPVZID.String()).Return(&dao.PVZ{ID: validPVZID.String()}, nil)
+				mr.On("FindLast", mock.Anything, mock.Anything, mock.Anything).Run(func(args mock.Arguments) {
+					rec := args.Get(1).(*dao.Reception)
+					rec.ID = validReceptionID.String()
+					rec.PVZID = validPVZID.String()
+					rec.Status = model.ReceptionInProgress.ToInt()
+				}).Return(nil)
+				mr.On("ChangeStatus", mock.Anything, mock.Anything, model.ReceptionInProgress.ToInt()).Return(nil)
+				ml.On("Info", mock.Anything, mock.Anything)
+			},
+			pvzID:    validPVZID,
+			userRole: model.RoleEmployee.Get(),
+			expected: onlymodels.Paused,
+		},
+		{
+			name: "Pause already paused reception",
+			setupMocks: func(mr *mockReceptionRepo, mz *mockPVZRepo, ml *mockLogger) {
+				mz.On("FindByIDForUpdate", mock.Anything, validPVZID.String()).Return(&dao.PVZ{ID: validPVZID.String()}, nil)
+				mr.On("FindLast", mock.Anything, mock.Anything, mock.Anything).Run(func(args mock.Arguments) {
+					rec := args.Get(1).(*dao.Reception)
+					rec.ID = validReceptionID.String()
+					rec.PVZID = validPVZID.String()
+					rec.Status = model.ReceptionPaused.ToInt()
+				}).Return(nil)
+				ml.On("Warn", mock.Anything, mock.Anything)
+			},
+			pvzID:         validPVZID,
+			userRole:      model.RoleEmployee.Get(),
+			expectedError: model.ErrInvalidReceptionTransition,
+		},
+		{
+			name: "No unfinished reception",
+			setupMocks: func(mr *mockReceptionRepo, mz *mockPVZRepo, ml *mockLogger) {
+				mz.On("FindByIDForUpdate", mock.Anything, validPVZID.String()).Return(&dao.PVZ{ID: validPVZID.String()}, nil)
+				mr.On("FindLast", mock.Anything, mock.Anything, mock.Anything).Return(nil)
+				ml.On("Warn", mock.Anything, mock.Anything)
+			},
+			pvzID:         validPVZID,
+			userRole:      model.RoleEmployee.Get(),
+			expectedError: model.ErrNoActiveReception,
+		},
+		{
+			name: "Error changing status",
+			setupMocks: func(mr *mockReceptionRepo, mz *mockPVZRepo, ml *mockLogger) {
+				mz.On("FindByIDForUpdate", mock.Anything, validPVZID.String()).Return(&dao.PVZ{ID: validPVZID.String()}, nil)
+				mr.On("FindLast", mock.Anything, mock.Anything, mock.Anything).Run(func(args mock.Arguments) {
+					rec := args.Get(1).(*dao.Reception)
+					rec.ID = validReceptionID.String()
+					rec.PVZID = validPVZID.String()
+					rec.Status = model.ReceptionInProgress.ToInt()
+				}).Return(nil)
+				mr.On("ChangeStatus", mock.Anything, mock.Anything, mock.Anything).Return(errors.New("db error"))
+				ml.On("Error", mock.Anything, mock.Anything)
+			},
+			pvzID:         validPVZID,
+			userRole:      model.RoleEmployee.Get(),
+			expectedError: model.ErrInternal,
+		},
+		{
+			name: "Non-employee role",
+			setupMocks: func(_ *mockReceptionRepo, _ *mockPVZRepo, ml *mockLogger) {
+				ml.On("Warn", mock.Anything, mock.Anything)
+			},
+			pvzID:         validPVZID,
+			userRole:      model.RoleModerator.Get(),
+			expectedError: model.ErrAccessDenied,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mr := &mockReceptionRepo{}
+			mz := &mockPVZRepo{}
+			ml := &mockLogger{}
+
+			if tt.setupMocks != nil {
+				tt.setupMocks(mr, mz, ml)
+			}
+
+			uc := NewUseCasePauseReception(mr, mz, newTestAssignmentRepo(), newTestTxManager(), newTestAuthorizer(), ml)
+			result, err := uc.Execute(context.Background(), tt.pvzID, testUserID, tt.userRole)
+
+			if tt.expectedError != "" {
+				assert.Error(t, err)
+				assert.Contains(t, err.Error(), tt.expectedError)
+				return
+			}
+
+			assert.NoError(t, err)
+			assert.Equal(t, &validReceptionID, result.Id)
+			assert.Equal(t, tt.expected, result.Status)
+			assert.Nil(t, result.ClosedBy)
+		})
+	}
+}
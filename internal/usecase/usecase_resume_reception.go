@@ -0,0 +1,114 @@
+// Package usecase это юзкейсы и вспомогательная логика
+package usecase
+
+import (
+	"context"
+	"github.com/google/uuid"
+	"internshipPVZ/internal/domain/model"
+	repo "internshipPVZ/internal/domain/repository"
+	"internshipPVZ/internal/http/onlymodels"
+	"log"
+)
+
+// NewUseCaseResumeReception конструктор
+func NewUseCaseResumeReception(
+	receptionRepo repo.ReceptionRepo,
+	pvzRepo repo.PVZRepo,
+	assignmentRepo repo.PVZAssignmentRepo,
+	txManager repo.TxManager,
+	authorizer Authorizer,
+	logger Logger,
+) *ResumeReception {
+	if receptionRepo == nil {
+		log.Fatalf("ResumeReception usecase receptionRepo nil")
+
+	}
+	if pvzRepo == nil {
+		log.Fatalf("ResumeReception usecase pvzRepo nil")
+
+	}
+	if assignmentRepo == nil {
+		log.Fatalf("ResumeReception usecase assignmentRepo nil")
+
+	}
+	if txManager == nil {
+		log.Fatalf("ResumeReception usecase txManager nil")
+
+	}
+	if authorizer == nil {
+		log.Fatalf("ResumeReception usecase authorizer nil")
+
+	}
+	if logger == nil {
+		log.Fatalf("ResumeReception usecase logger nil")
+
+	}
+
+	return &ResumeReception{
+		receptionRepo:  receptionRepo,
+		pvzRepo:        pvzRepo,
+		assignmentRepo: assignmentRepo,
+		txManager:      txManager,
+		authorizer:     authorizer,
+		logger:         logger,
+	}
+}
+
+// ResumeReception юзкейс
+type ResumeReception struct {
+	receptionRepo  repo.ReceptionRepo
+	pvzRepo        repo.PVZRepo
+	assignmentRepo repo.PVZAssignmentRepo
+	txManager      repo.TxManager
+	authorizer     Authorizer
+	logger         Logger
+}
+
+// Execute возобновляет приостановленную приёмку
+func (uc *ResumeReception) Execute(ctx context.Context, PVZID uuid.UUID, userID, userRole string) (*onlymodels.Reception, error) {
+	pvzID, userUUID, role, err := uc.validateInput(PVZID, userID, userRole)
+	if err != nil {
+		uc.logger.Error("validation failed",
+			"usecase", "ResumeReception",
+			"method", "validateInput",
+			"pvz_id", PVZID,
+			"error", err)
+		return nil, err
+	}
+
+	if err = checkPermission(uc.authorizer, uc.logger, "ResumeReception", "Execute", role, model.PermissionResumeReception); err != nil {
+		return nil, err
+	}
+
+	return changeLastReceptionStatus(ctx, uc.receptionRepo, uc.pvzRepo, uc.assignmentRepo, uc.txManager, uc.logger, receptionStatusChange{
+		usecase: "ResumeReception",
+		pvzID:   pvzID,
+		userID:  userUUID,
+		target:  model.ReceptionInProgress,
+	})
+}
+
+func (uc *ResumeReception) validateInput(PVZID uuid.UUID, userID, userRole string) (pvzID, userUUID uuid.UUID, role model.Role, err error) {
+	if pvzID, err = validateID(PVZID); err != nil {
+		uc.logger.Warn("invalid PVZ ID format",
+			"usecase", "ResumeReception",
+			"method", "validateInput",
+			"pvz_id", PVZID)
+		return
+	}
+	if userUUID, err = validateRawID(userID); err != nil {
+		uc.logger.Warn("invalid user ID in token",
+			"usecase", "ResumeReception",
+			"method", "validateInput",
+			"user_id", userID)
+		return
+	}
+	if role, err = validateRole(userRole); err != nil {
+		uc.logger.Warn("invalid user role",
+			"usecase", "ResumeReception",
+			"method", "validateInput",
+			"user_role", userRole)
+		return
+	}
+	return
+}
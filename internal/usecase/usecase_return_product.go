@@ -0,0 +1,122 @@
+// Package usecase это юзкейсы и вспомогательная логика
+package usecase
+
+import (
+	"context"
+	"errors"
+	"github.com/google/uuid"
+	"internshipPVZ/internal/domain/model"
+	repo "internshipPVZ/internal/domain/repository"
+	"internshipPVZ/internal/http/onlymodels"
+	"log"
+)
+
+// NewUseCaseReturnProduct конструктор
+func NewUseCaseReturnProduct(
+	productRepo repo.ProductRepo,
+	receptionRepo repo.ReceptionRepo,
+	productTypeRepo repo.ProductTypeRepo,
+	assignmentRepo repo.PVZAssignmentRepo,
+	authorizer Authorizer,
+	logger Logger,
+) *ReturnProduct {
+	if productRepo == nil {
+		log.Fatalf("ReturnProduct usecase productRepo nil")
+
+	}
+	if receptionRepo == nil {
+		log.Fatalf("ReturnProduct usecase receptionRepo nil")
+
+	}
+	if productTypeRepo == nil {
+		log.Fatalf("ReturnProduct usecase productTypeRepo nil")
+
+	}
+	if assignmentRepo == nil {
+		log.Fatalf("ReturnProduct usecase assignmentRepo nil")
+
+	}
+	if authorizer == nil {
+		log.Fatalf("ReturnProduct usecase authorizer nil")
+
+	}
+	if logger == nil {
+		log.Fatalf("ReturnProduct usecase logger nil")
+
+	}
+
+	return &ReturnProduct{
+		productRepo:     productRepo,
+		receptionRepo:   receptionRepo,
+		productTypeRepo: productTypeRepo,
+		assignmentRepo:  assignmentRepo,
+		authorizer:      authorizer,
+		logger:          logger,
+	}
+}
+
+// ReturnProduct юзкейс
+type ReturnProduct struct {
+	productRepo     repo.ProductRepo
+	receptionRepo   repo.ReceptionRepo
+	productTypeRepo repo.ProductTypeRepo
+	assignmentRepo  repo.PVZAssignmentRepo
+	authorizer      Authorizer
+	logger          Logger
+}
+
+// Execute возвращает продукт отправителю
+func (uc *ReturnProduct) Execute(ctx context.Context, productID uuid.UUID, userID, userRole string) (*onlymodels.Product, error) {
+	validProductID, userUUID, role, err := uc.validateInput(productID, userID, userRole)
+	if err != nil {
+		uc.logger.Error("validation failed",
+			"usecase", "ReturnProduct",
+			"method", "validateInput",
+			"product_id", productID,
+			"error", err)
+		return nil, err
+	}
+
+	if err = checkPermission(uc.authorizer, uc.logger, "ReturnProduct", "Execute", role, model.PermissionReturnProduct); err != nil {
+		return nil, err
+	}
+
+	repos := productStatusRepos{
+		productRepo:     uc.productRepo,
+		receptionRepo:   uc.receptionRepo,
+		productTypeRepo: uc.productTypeRepo,
+		assignmentRepo:  uc.assignmentRepo,
+	}
+	return changeProductStatus(ctx, repos, uc.logger, productStatusChange{
+		usecase:   "ReturnProduct",
+		productID: validProductID,
+		userID:    userUUID,
+		target:    model.ProductReturned,
+	})
+}
+
+func (uc *ReturnProduct) validateInput(productID uuid.UUID, userID, userRole string) (validProductID, userUUID uuid.UUID, role model.Role, err error) {
+	if validProductID, err = validateID(productID); err != nil {
+		uc.logger.Warn("invalid product ID format",
+			"usecase", "ReturnProduct",
+			"method", "validateInput",
+			"product_id", productID)
+		err = errors.New(model.ErrInvalidProductID)
+		return
+	}
+	if userUUID, err = validateRawID(userID); err != nil {
+		uc.logger.Warn("invalid user ID in token",
+			"usecase", "ReturnProduct",
+			"method", "validateInput",
+			"user_id", userID)
+		return
+	}
+	if role, err = validateRole(userRole); err != nil {
+		uc.logger.Warn("invalid user role",
+			"usecase", "ReturnProduct",
+			"method", "validateInput",
+			"user_role", userRole)
+		return
+	}
+	return
+}
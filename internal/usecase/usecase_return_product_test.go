@@ -0,0 +1,96 @@
+// Package usecase это юзкейсы и вспомогательная логика
+package usecase
+
+import (
+	"context"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"internshipPVZ/internal/domain/model"
+	"internshipPVZ/internal/http/onlymodels"
+)
+
+func TestReturnProduct_Execute(t *testing.T) {
+	validProductID := uuid.New()
+	validReceptionID := uuid.New()
+	validPVZID := uuid.New()
+
+	tests := []struct {
+		name          string
+		setupMocks    func(*mockProductRepo, *mockReceptionRepo, *mockLogger)
+		userRole      string
+		expectedError string
+	}{
+		{
+			name: "Return product",
+			setupMocks: func(mp *mockProductRepo, mr *mockReceptionRepo, ml *mockLogger) {
+				mp.On("FindByID", mock.Anything, validProductID.String()).
+					Return(testProductDao(validProductID, validReceptionID, model.ProductIssued), nil)
+				mr.On("FindByID", mock.Anything, validReceptionID.String()).
+					Return(testProductReceptionDao(validReceptionID, validPVZID, model.ReceptionClosed), nil)
+				mp.On("ChangeStatus", mock.Anything, mock.Anything, model.ProductIssued.ToInt()).Return(nil)
+				ml.On("Info", mock.Anything, mock.Anything)
+			},
+			userRole: model.RoleEmployee.Get(),
+		},
+		{
+			name: "Transition is not allowed",
+			setupMocks: func(mp *mockProductRepo, mr *mockReceptionRepo, ml *mockLogger) {
+				mp.On("FindByID", mock.Anything, validProductID.String()).
+					Return(testProductDao(validProductID, validReceptionID, model.ProductReturned), nil)
+				mr.On("FindByID", mock.Anything, validReceptionID.String()).
+					Return(testProductReceptionDao(validReceptionID, validPVZID, model.ReceptionClosed), nil)
+				ml.On("Warn", mock.Anything, mock.Anything)
+			},
+			userRole:      model.RoleEmployee.Get(),
+			expectedError: model.ErrInvalidProductTransition,
+		},
+		{
+			name: "Employee is not assigned to reception PVZ",
+			setupMocks: func(mp *mockProductRepo, mr *mockReceptionRepo, ml *mockLogger) {
+				mp.On("FindByID", mock.Anything, validProductID.String()).
+					Return(testProductDao(validProductID, validReceptionID, model.ProductIssued), nil)
+				mr.On("FindByID", mock.Anything, validReceptionID.String()).
+					Return(testProductReceptionDao(validReceptionID, testForeignPVZID, model.ReceptionClosed), nil)
+				ml.On("Warn", mock.Anything, mock.Anything)
+			},
+			userRole:      model.RoleEmployee.Get(),
+			expectedError: model.ErrAccessDenied,
+		},
+		{
+			name: "Non-employee role",
+			setupMocks: func(_ *mockProductRepo, _ *mockReceptionRepo, ml *mockLogger) {
+				ml.On("Warn", mock.Anything, mock.Anything)
+			},
+			userRole:      model.RoleModerator.Get(),
+			expectedError: model.ErrAccessDenied,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mp := &mockProductRepo{}
+			mr := &mockReceptionRepo{}
+			ml := &mockLogger{}
+
+			if tt.setupMocks != nil {
+				tt.setupMocks(mp, mr, ml)
+			}
+
+			uc := NewUseCaseReturnProduct(mp, mr, newTestProductTypeRepo(), newTestAssignmentRepo(), newTestAuthorizer(), ml)
+			result, err := uc.Execute(context.Background(), validProductID, testUserID, tt.userRole)
+
+			if tt.expectedError != "" {
+				assert.Error(t, err)
+				assert.Contains(t, err.Error(), tt.expectedError)
+				return
+			}
+
+			assert.NoError(t, err)
+			assert.Equal(t, &validProductID, result.Id)
+			assert.Equal(t, onlymodels.Returned, *result.Status)
+		})
+	}
+}
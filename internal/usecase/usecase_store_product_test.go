@@ -0,0 +1,183 @@
+// Package usecase это юзкейсы и вспомогательная логика
+package usecase
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"internshipPVZ/internal/domain/model"
+	"internshipPVZ/internal/domain/repository/dao"
+	"internshipPVZ/internal/http/onlymodels"
+)
+
+func testProductDao(productID, receptionID uuid.UUID, status model.ProductStatus) *dao.Product {
+	return &dao.Product{
+		ID:          productID.String(),
+		DateTime:    time.Now(),
+		ReceptionID: receptionID.String(),
+		Type:        0,
+		Barcode:     sql.NullString{String: "4006381333931", Valid: true},
+		Status:      status.ToInt(),
+	}
+}
+
+func testProductReceptionDao(receptionID, pvzID uuid.UUID, status model.ReceptionStatus) *dao.Reception {
+	return &dao.Reception{
+		ID:     receptionID.String(),
+		PVZID:  pvzID.String(),
+		Status: status.ToInt(),
+	}
+}
+
+func TestStoreProduct_Execute(t *testing.T) {
+	validProductID := uuid.New()
+	validReceptionID := uuid.New()
+	validPVZID := uuid.New()
+
+	tests := []struct {
+		name          string
+		setupMocks    func(*mockProductRepo, *mockReceptionRepo, *mockLogger)
+		productID     uuid.UUID
+		userRole      string
+		expectedError string
+	}{
+		{
+			name: "Store product from closed reception",
+			setupMocks: func(mp *mockProductRepo, mr *mockReceptionRepo, ml *mockLogger) {
+				mp.On("FindByID", mock.Anything, validProductID.String()).
+					Return(testProductDao(validProductID, validReceptionID, model.ProductReceived), nil)
+				mr.On("FindByID", mock.Anything, validReceptionID.String()).
+					Return(testProductReceptionDao(validReceptionID, validPVZID, model.ReceptionClosed), nil)
+				mp.On("ChangeStatus", mock.Anything, mock.Anything, model.ProductReceived.ToInt()).Return(nil)
+				ml.On("Info", mock.Anything, mock.Anything)
+			},
+			productID: validProductID,
+			userRole:  model.RoleEmployee.Get(),
+		},
+		{
+			name: "Reception is still in progress",
+			setupMocks: func(mp *mockProductRepo, mr *mockReceptionRepo, ml *mockLogger) {
+				mp.On("FindByID", mock.Anything, validProductID.String()).
+					Return(testProductDao(validProductID, validReceptionID, model.ProductReceived), nil)
+				mr.On("FindByID", mock.Anything, validReceptionID.String()).
+					Return(testProductReceptionDao(validReceptionID, validPVZID, model.ReceptionInProgress), nil)
+				ml.On("Warn", mock.Anything, mock.Anything)
+			},
+			productID:     validProductID,
+			userRole:      model.RoleEmployee.Get(),
+			expectedError: model.ErrReceptionNotClosed,
+		},
+		{
+			name: "Employee is not assigned to reception PVZ",
+			setupMocks: func(mp *mockProductRepo, mr *mockReceptionRepo, ml *mockLogger) {
+				mp.On("FindByID", mock.Anything, validProductID.String()).
+					Return(testProductDao(validProductID, validReceptionID, model.ProductReceived), nil)
+				mr.On("FindByID", mock.Anything, validReceptionID.String()).
+					Return(testProductReceptionDao(validReceptionID, testForeignPVZID, model.ReceptionClosed), nil)
+				ml.On("Warn", mock.Anything, mock.Anything)
+			},
+			productID:     validProductID,
+			userRole:      model.RoleEmployee.Get(),
+			expectedError: model.ErrAccessDenied,
+		},
+		{
+			name: "Product already stored",
+			setupMocks: func(mp *mockProductRepo, mr *mockReceptionRepo, ml *mockLogger) {
+				mp.On("FindByID", mock.Anything, validProductID.String()).
+					Return(testProductDao(validProductID, validReceptionID, model.ProductStored), nil)
+				mr.On("FindByID", mock.Anything, validReceptionID.String()).
+					Return(testProductReceptionDao(validReceptionID, validPVZID, model.ReceptionClosed), nil)
+				ml.On("Warn", mock.Anything, mock.Anything)
+			},
+			productID:     validProductID,
+			userRole:      model.RoleEmployee.Get(),
+			expectedError: model.ErrInvalidProductTransition,
+		},
+		{
+			name: "Product not found",
+			setupMocks: func(mp *mockProductRepo, _ *mockReceptionRepo, ml *mockLogger) {
+				mp.On("FindByID", mock.Anything, validProductID.String()).Return(nil, nil)
+				ml.On("Warn", mock.Anything, mock.Anything)
+			},
+			productID:     validProductID,
+			userRole:      model.RoleEmployee.Get(),
+			expectedError: model.ErrProductNotFound,
+		},
+		{
+			name: "Status changed concurrently",
+			setupMocks: func(mp *mockProductRepo, mr *mockReceptionRepo, ml *mockLogger) {
+				mp.On("FindByID", mock.Anything, validProductID.String()).
+					Return(testProductDao(validProductID, validReceptionID, model.ProductReceived), nil)
+				mr.On("FindByID", mock.Anything, validReceptionID.String()).
+					Return(testProductReceptionDao(validReceptionID, validPVZID, model.ReceptionClosed), nil)
+				mp.On("ChangeStatus", mock.Anything, mock.Anything, mock.Anything).
+					Return(errors.New(model.ErrInvalidProductTransition))
+				ml.On("Warn", mock.Anything, mock.Anything)
+			},
+			productID:     validProductID,
+			userRole:      model.RoleEmployee.Get(),
+			expectedError: model.ErrInvalidProductTransition,
+		},
+		{
+			name: "Error finding product",
+			setupMocks: func(mp *mockProductRepo, _ *mockReceptionRepo, ml *mockLogger) {
+				mp.On("FindByID", mock.Anything, mock.Anything).Return(nil, errors.New("db error"))
+				ml.On("Error", mock.Anything, mock.Anything)
+			},
+			productID:     validProductID,
+			userRole:      model.RoleEmployee.Get(),
+			expectedError: model.ErrInternal,
+		},
+		{
+			name: "Invalid product ID",
+			setupMocks: func(_ *mockProductRepo, _ *mockReceptionRepo, ml *mockLogger) {
+				ml.On("Warn", mock.Anything, mock.Anything)
+				ml.On("Error", mock.Anything, mock.Anything)
+			},
+			productID:     uuid.Nil,
+			userRole:      model.RoleEmployee.Get(),
+			expectedError: model.ErrInvalidProductID,
+		},
+		{
+			name: "Non-employee role",
+			setupMocks: func(_ *mockProductRepo, _ *mockReceptionRepo, ml *mockLogger) {
+				ml.On("Warn", mock.Anything, mock.Anything)
+			},
+			productID:     validProductID,
+			userRole:      model.RoleModerator.Get(),
+			expectedError: model.ErrAccessDenied,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mp := &mockProductRepo{}
+			mr := &mockReceptionRepo{}
+			ml := &mockLogger{}
+
+			if tt.setupMocks != nil {
+				tt.setupMocks(mp, mr, ml)
+			}
+
+			uc := NewUseCaseStoreProduct(mp, mr, newTestProductTypeRepo(), newTestAssignmentRepo(), newTestAuthorizer(), ml)
+			result, err := uc.Execute(context.Background(), tt.productID, testUserID, tt.userRole)
+
+			if tt.expectedError != "" {
+				assert.Error(t, err)
+				assert.Contains(t, err.Error(), tt.expectedError)
+				return
+			}
+
+			assert.NoError(t, err)
+			assert.Equal(t, &validProductID, result.Id)
+			assert.Equal(t, "электроника", result.Type)
+			assert.Equal(t, onlymodels.Stored, *result.Status)
+		})
+	}
+}
@@ -0,0 +1,213 @@
+// Package usecase это юзкейсы и вспомогательная логика
+package usecase
+
+import (
+	"context"
+	"errors"
+	"github.com/google/uuid"
+	"internshipPVZ/internal/domain/model"
+	repo "internshipPVZ/internal/domain/repository"
+	"internshipPVZ/internal/domain/repository/dao"
+	"internshipPVZ/internal/http/onlymodels"
+	"log"
+)
+
+// NewUseCaseUpdatePVZ конструктор
+func NewUseCaseUpdatePVZ(
+	pvzRepo repo.PVZRepo,
+	cityRepo repo.CityRepo,
+	authorizer Authorizer,
+	logger Logger,
+) *UpdatePVZ {
+	if pvzRepo == nil {
+		log.Fatalf("UpdatePVZ usecase pvzRepo nil")
+
+	}
+	if cityRepo == nil {
+		log.Fatalf("UpdatePVZ usecase cityRepo nil")
+
+	}
+	if authorizer == nil {
+		log.Fatalf("UpdatePVZ usecase authorizer nil")
+
+	}
+	if logger == nil {
+		log.Fatalf("UpdatePVZ usecase logger nil")
+
+	}
+
+	return &UpdatePVZ{
+		pvzRepo:    pvzRepo,
+		cityRepo:   cityRepo,
+		authorizer: authorizer,
+		logger:     logger,
+	}
+}
+
+// UpdatePVZ юзкейс
+type UpdatePVZ struct {
+	pvzRepo    repo.PVZRepo
+	cityRepo   repo.CityRepo
+	authorizer Authorizer
+	logger     Logger
+}
+
+// Execute изменяет профиль пвз, поля отсутствующие в запросе не меняются
+func (uc *UpdatePVZ) Execute(ctx context.Context, pvzID uuid.UUID, request *onlymodels.PVZUpdate, userRole string) (*onlymodels.PVZ, error) {
+	role, err := uc.validateInput(pvzID, request, userRole)
+	if err != nil {
+		uc.logger.Error("validation failed",
+			"usecase", "UpdatePVZ",
+			"method", "validateInput",
+			"pvz_id", pvzID,
+			"error", err)
+		return nil, err
+	}
+
+	if err = checkPermission(uc.authorizer, uc.logger, "UpdatePVZ", "Execute", role, model.PermissionUpdatePVZ); err != nil {
+		return nil, err
+	}
+
+	pvzDao, err := uc.pvzRepo.FindByID(ctx, pvzID.String())
+	if err != nil {
+		uc.logger.Error("failed to find PVZ",
+			"usecase", "UpdatePVZ",
+			"method", "pvzRepo.FindByID",
+			"pvz_id", pvzID,
+			"error", err)
+		return nil, errors.New(model.ErrInternal)
+	}
+	if pvzDao == nil {
+		uc.logger.Warn("PVZ not found",
+			"usecase", "UpdatePVZ",
+			"method", "Execute",
+			"pvz_id", pvzID)
+		return nil, errors.New(model.ErrPVZNotFound)
+	}
+
+	pvz, err := uc.applyPatch(pvzID, pvzDao, request)
+	if err != nil {
+		return nil, err
+	}
+
+	updatedDao := pvz.ToDao()
+	err = uc.pvzRepo.Update(ctx, updatedDao)
+	if err != nil {
+		uc.logger.Error("failed to update PVZ",
+			"usecase", "UpdatePVZ",
+			"method", "pvzRepo.Update",
+			"pvz", updatedDao,
+			"error", err)
+		return nil, errors.New(model.ErrInternal)
+	}
+
+	cities, err := uc.cityRepo.GetAll(ctx)
+	if err != nil {
+		uc.logger.Error("failed to get cities",
+			"usecase", "UpdatePVZ",
+			"method", "cityRepo.GetAll",
+			"error", err)
+		return nil, errors.New(model.ErrInternal)
+	}
+	catalog, err := newCityCatalog(cities)
+	if err != nil {
+		uc.logger.Error("failed to build city catalog",
+			"usecase", "UpdatePVZ",
+			"method", "newCityCatalog",
+			"error", err)
+		return nil, errors.New(model.ErrInternal)
+	}
+
+	pvzDto, err := pvzDaoToDto(updatedDao, catalog)
+	if err != nil {
+		uc.logger.Error("failed to convert PVZ DAO to DTO",
+			"usecase", "UpdatePVZ",
+			"method", "pvzDaoToDto",
+			"pvz", updatedDao,
+			"error", err)
+		return nil, errors.New(model.ErrInternal)
+	}
+
+	uc.logger.Info("PVZ updated successfully",
+		"usecase", "UpdatePVZ",
+		"pvz_id", updatedDao.ID)
+	return pvzDto, nil
+}
+
+func (uc *UpdatePVZ) validateInput(pvzID uuid.UUID, request *onlymodels.PVZUpdate, userRole string) (role model.Role, err error) {
+	if role, err = validateRole(userRole); err != nil {
+		uc.logger.Warn("invalid user role",
+			"usecase", "UpdatePVZ",
+			"method", "validateInput",
+			"user_role", userRole)
+		return
+	}
+	if _, err = validateID(pvzID); err != nil {
+		err = errors.New(model.ErrInvalidPVZID)
+		return
+	}
+	if request == nil {
+		err = errors.New(model.ErrInvalidRequest)
+		return
+	}
+	return
+}
+
+// applyPatch накладывает изменения на сохранённый профиль и проверяет результат
+func (uc *UpdatePVZ) applyPatch(pvzID uuid.UUID, pvzDao *dao.PVZ, request *onlymodels.PVZUpdate) (pvz *model.PVZ, err error) {
+	pvz = &model.PVZ{
+		ID:               pvzID,
+		RegistrationDate: pvzDao.RegistrationDate,
+		City:             model.City{ID: pvzDao.City},
+		Name:             pvzDao.Name,
+		Address:          pvzDao.Address,
+		Latitude:         fromNullFloat64(pvzDao.Latitude),
+		Longitude:        fromNullFloat64(pvzDao.Longitude),
+		WorkingHours:     workingHoursDaoToModel(pvzDao.WorkingHours),
+	}
+	if pvzDao.ArchivedAt.Valid {
+		pvz.ArchivedAt = pvzDao.ArchivedAt.Time
+	}
+	if request.Name != nil {
+		if pvz.Name, err = normalizePVZName(*request.Name); err != nil {
+			uc.logger.Warn("invalid PVZ name",
+				"usecase", "UpdatePVZ",
+				"method", "applyPatch",
+				"name", *request.Name)
+			return nil, err
+		}
+	}
+	if request.Address != nil {
+		if pvz.Address, err = normalizePVZAddress(*request.Address); err != nil {
+			uc.logger.Warn("invalid PVZ address",
+				"usecase", "UpdatePVZ",
+				"method", "applyPatch",
+				"address", *request.Address)
+			return nil, err
+		}
+	}
+	if request.Latitude != nil {
+		pvz.Latitude = request.Latitude
+	}
+	if request.Longitude != nil {
+		pvz.Longitude = request.Longitude
+	}
+	if err = validateCoordinates(pvz.Latitude, pvz.Longitude); err != nil {
+		uc.logger.Warn("invalid PVZ coordinates",
+			"usecase", "UpdatePVZ",
+			"method", "applyPatch",
+			"latitude", pvz.Latitude,
+			"longitude", pvz.Longitude)
+		return nil, err
+	}
+	if request.WorkingHours != nil {
+		if pvz.WorkingHours, err = workingHoursDtoToModel(request.WorkingHours); err != nil {
+			uc.logger.Warn("invalid PVZ working hours",
+				"usecase", "UpdatePVZ",
+				"method", "applyPatch",
+				"working_hours", request.WorkingHours)
+			return nil, err
+		}
+	}
+	return pvz, nil
+}
@@ -0,0 +1,169 @@
+// Package usecase это юзкейсы и вспомогательная логика
+package usecase
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"internshipPVZ/internal/domain/model"
+	"internshipPVZ/internal/domain/repository/dao"
+	"internshipPVZ/internal/http/onlymodels"
+)
+
+func TestUpdatePVZ_Execute(t *testing.T) {
+	validPVZID := uuid.New()
+	testTime := time.Now()
+	newName := "ПВЗ на Тверской"
+	tooLongName := string(make([]rune, 101))
+	lat, lon := 55.76, 37.60
+
+	storedPVZ := func() *dao.PVZ {
+		return &dao.PVZ{
+			ID:               validPVZID.String(),
+			RegistrationDate: testTime,
+			City:             testCityMoscowID,
+			Name:             "Старое название",
+			Address:          "Тверская, 1",
+			Latitude:         sql.NullFloat64{Float64: 55.75, Valid: true},
+			Longitude:        sql.NullFloat64{Float64: 37.61, Valid: true},
+			WorkingHours:     []dao.OpeningHours{{Weekday: 1, Opens: "09:00", Closes: "21:00"}},
+		}
+	}
+
+	tests := []struct {
+		name          string
+		setupMocks    func(*mockPVZRepo, *mockLogger)
+		pvzID         uuid.UUID
+		request       *onlymodels.PVZUpdate
+		userRole      string
+		expected      *onlymodels.PVZ
+		expectedError string
+	}{
+		{
+			name: "Success - partial update keeps other fields",
+			setupMocks: func(mz *mockPVZRepo, ml *mockLogger) {
+				mz.On("FindByID", mock.Anything, validPVZID.String()).Return(storedPVZ(), nil)
+				mz.On("Update", mock.Anything, mock.MatchedBy(func(pvz *dao.PVZ) bool {
+					return pvz.Name == newName && pvz.Address == "Тверская, 1" &&
+						pvz.Latitude.Float64 == lat && pvz.Longitude.Float64 == lon &&
+						len(pvz.WorkingHours) == 1 && pvz.City == testCityMoscowID
+				})).Return(nil)
+				ml.On("Info", mock.Anything, mock.Anything)
+			},
+			pvzID: validPVZID,
+			request: &onlymodels.PVZUpdate{
+				Name:      &newName,
+				Latitude:  &lat,
+				Longitude: &lon,
+			},
+			userRole: model.RoleModerator.Get(),
+			expected: &onlymodels.PVZ{
+				Id:   &validPVZID,
+				City: "Москва",
+				Name: &newName,
+			},
+		},
+		{
+			name: "Employee is denied",
+			setupMocks: func(_ *mockPVZRepo, ml *mockLogger) {
+				ml.On("Warn", mock.Anything, mock.Anything)
+			},
+			pvzID:         validPVZID,
+			request:       &onlymodels.PVZUpdate{Name: &newName},
+			userRole:      model.RoleEmployee.Get(),
+			expectedError: model.ErrAccessDenied,
+		},
+		{
+			name: "PVZ not found",
+			setupMocks: func(mz *mockPVZRepo, ml *mockLogger) {
+				mz.On("FindByID", mock.Anything, validPVZID.String()).Return(nil, nil)
+				ml.On("Warn", mock.Anything, mock.Anything)
+			},
+			pvzID:         validPVZID,
+			request:       &onlymodels.PVZUpdate{Name: &newName},
+			userRole:      model.RoleModerator.Get(),
+			expectedError: model.ErrPVZNotFound,
+		},
+		{
+			name: "Invalid PVZ ID",
+			setupMocks: func(_ *mockPVZRepo, ml *mockLogger) {
+				ml.On("Error", mock.Anything, mock.Anything)
+			},
+			pvzID:         uuid.Nil,
+			request:       &onlymodels.PVZUpdate{Name: &newName},
+			userRole:      model.RoleModerator.Get(),
+			expectedError: model.ErrInvalidPVZID,
+		},
+		{
+			name: "Name too long",
+			setupMocks: func(mz *mockPVZRepo, ml *mockLogger) {
+				mz.On("FindByID", mock.Anything, validPVZID.String()).Return(storedPVZ(), nil)
+				ml.On("Warn", mock.Anything, mock.Anything)
+			},
+			pvzID:         validPVZID,
+			request:       &onlymodels.PVZUpdate{Name: &tooLongName},
+			userRole:      model.RoleModerator.Get(),
+			expectedError: model.ErrInvalidPVZName,
+		},
+		{
+			name: "Invalid working hours",
+			setupMocks: func(mz *mockPVZRepo, ml *mockLogger) {
+				mz.On("FindByID", mock.Anything, validPVZID.String()).Return(storedPVZ(), nil)
+				ml.On("Warn", mock.Anything, mock.Anything)
+			},
+			pvzID: validPVZID,
+			request: &onlymodels.PVZUpdate{WorkingHours: &onlymodels.WorkingHours{
+				{Weekday: onlymodels.Monday, Opens: "21:00", Closes: "09:00"},
+			}},
+			userRole:      model.RoleModerator.Get(),
+			expectedError: model.ErrInvalidWorkingHours,
+		},
+		{
+			name: "Database error on update",
+			setupMocks: func(mz *mockPVZRepo, ml *mockLogger) {
+				mz.On("FindByID", mock.Anything, validPVZID.String()).Return(storedPVZ(), nil)
+				mz.On("Update", mock.Anything, mock.Anything).Return(errors.New("db error"))
+				ml.On("Error", mock.Anything, mock.Anything)
+			},
+			pvzID:         validPVZID,
+			request:       &onlymodels.PVZUpdate{Name: &newName},
+			userRole:      model.RoleModerator.Get(),
+			expectedError: model.ErrInternal,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mz := &mockPVZRepo{}
+			mc := &mockCityRepo{}
+			ml := &mockLogger{}
+			mc.On("GetAll", mock.Anything).Return(testCities(), nil)
+
+			if tt.setupMocks != nil {
+				tt.setupMocks(mz, ml)
+			}
+
+			uc := NewUseCaseUpdatePVZ(mz, mc, newTestAuthorizer(), ml)
+			result, err := uc.Execute(context.Background(), tt.pvzID, tt.request, tt.userRole)
+
+			if tt.expectedError != "" {
+				assert.Error(t, err)
+				assert.Equal(t, tt.expectedError, err.Error())
+				return
+			}
+
+			assert.NoError(t, err)
+			assert.Equal(t, tt.expected.Id, result.Id)
+			assert.Equal(t, tt.expected.City, result.City)
+			assert.Equal(t, tt.expected.Name, result.Name)
+			assert.Equal(t, &lat, result.Latitude)
+			mz.AssertExpectations(t)
+		})
+	}
+}
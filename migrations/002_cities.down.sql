@@ -0,0 +1,2 @@
+ALTER TABLE IF EXISTS pvz DROP CONSTRAINT IF EXISTS fk_pvz_city;
+DROP TABLE IF EXISTS cities;
@@ -0,0 +1,15 @@
+CREATE TABLE IF NOT EXISTS cities (
+                        id SERIAL PRIMARY KEY,
+                        name VARCHAR(100) UNIQUE NOT NULL
+);
+
+-- сохраняем ID городов, которые раньше были захардкожены в model.City
+INSERT INTO cities (id, name) VALUES
+                        (0, 'Санкт-Петербург'),
+                        (1, 'Казань'),
+                        (2, 'Москва')
+ON CONFLICT (id) DO NOTHING;
+
+SELECT setval('cities_id_seq', (SELECT MAX(id) FROM cities));
+
+ALTER TABLE pvz ADD CONSTRAINT fk_pvz_city FOREIGN KEY (city) REFERENCES cities (id);
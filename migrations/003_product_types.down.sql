@@ -0,0 +1,2 @@
+ALTER TABLE IF EXISTS products DROP CONSTRAINT IF EXISTS fk_products_type;
+DROP TABLE IF EXISTS product_types;
@@ -0,0 +1,16 @@
+CREATE TABLE IF NOT EXISTS product_types (
+                        id SERIAL PRIMARY KEY,
+                        name VARCHAR(50) UNIQUE NOT NULL,
+                        active BOOLEAN NOT NULL DEFAULT TRUE
+);
+
+-- сохраняем ID типов, которые раньше были захардкожены в model.ProductType
+INSERT INTO product_types (id, name) VALUES
+                        (0, 'электроника'),
+                        (1, 'обувь'),
+                        (2, 'одежда')
+ON CONFLICT (id) DO NOTHING;
+
+SELECT setval('product_types_id_seq', (SELECT MAX(id) FROM product_types));
+
+ALTER TABLE products ADD CONSTRAINT fk_products_type FOREIGN KEY (type) REFERENCES product_types (id);
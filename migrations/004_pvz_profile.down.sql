@@ -0,0 +1,7 @@
+ALTER TABLE IF EXISTS pvz DROP CONSTRAINT IF EXISTS chk_pvz_coordinates;
+ALTER TABLE IF EXISTS pvz
+    DROP COLUMN IF EXISTS working_hours,
+    DROP COLUMN IF EXISTS longitude,
+    DROP COLUMN IF EXISTS latitude,
+    DROP COLUMN IF EXISTS address,
+    DROP COLUMN IF EXISTS name;
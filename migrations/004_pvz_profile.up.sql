@@ -0,0 +1,11 @@
+ALTER TABLE pvz
+    ADD COLUMN IF NOT EXISTS name VARCHAR(100) NOT NULL DEFAULT '',
+    ADD COLUMN IF NOT EXISTS address VARCHAR(255) NOT NULL DEFAULT '',
+    ADD COLUMN IF NOT EXISTS latitude DOUBLE PRECISION,
+    ADD COLUMN IF NOT EXISTS longitude DOUBLE PRECISION,
+    ADD COLUMN IF NOT EXISTS working_hours JSONB NOT NULL DEFAULT '[]'::jsonb;
+
+ALTER TABLE pvz ADD CONSTRAINT chk_pvz_coordinates CHECK (
+    (latitude IS NULL AND longitude IS NULL) OR
+    (latitude BETWEEN -90 AND 90 AND longitude BETWEEN -180 AND 180)
+);
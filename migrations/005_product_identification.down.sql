@@ -0,0 +1,5 @@
+DROP INDEX IF EXISTS uq_products_reception_barcode;
+ALTER TABLE IF EXISTS products
+    DROP COLUMN IF EXISTS order_id,
+    DROP COLUMN IF EXISTS sku,
+    DROP COLUMN IF EXISTS barcode;
@@ -0,0 +1,6 @@
+ALTER TABLE products
+    ADD COLUMN IF NOT EXISTS barcode VARCHAR(80),
+    ADD COLUMN IF NOT EXISTS sku VARCHAR(64),
+    ADD COLUMN IF NOT EXISTS order_id VARCHAR(64);
+
+CREATE UNIQUE INDEX IF NOT EXISTS uq_products_reception_barcode ON products (reception_id, barcode);
@@ -0,0 +1,6 @@
+ALTER TABLE IF EXISTS products
+    DROP COLUMN IF EXISTS added_by;
+ALTER TABLE IF EXISTS receptions
+    DROP COLUMN IF EXISTS closed_at,
+    DROP COLUMN IF EXISTS closed_by,
+    DROP COLUMN IF EXISTS opened_by;
@@ -0,0 +1,7 @@
+ALTER TABLE receptions
+    ADD COLUMN IF NOT EXISTS opened_by UUID,
+    ADD COLUMN IF NOT EXISTS closed_by UUID,
+    ADD COLUMN IF NOT EXISTS closed_at TIMESTAMP;
+
+ALTER TABLE products
+    ADD COLUMN IF NOT EXISTS added_by UUID;
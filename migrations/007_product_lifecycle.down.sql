@@ -0,0 +1,2 @@
+ALTER TABLE IF EXISTS products
+    DROP COLUMN IF EXISTS status;
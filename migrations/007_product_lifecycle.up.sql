@@ -0,0 +1,2 @@
+ALTER TABLE products
+    ADD COLUMN IF NOT EXISTS status INTEGER NOT NULL DEFAULT 0;
@@ -0,0 +1,3 @@
+ALTER TABLE IF EXISTS pvz
+    DROP COLUMN IF EXISTS archived_by,
+    DROP COLUMN IF EXISTS archived_at;
@@ -0,0 +1,3 @@
+ALTER TABLE pvz
+    ADD COLUMN IF NOT EXISTS archived_at TIMESTAMP,
+    ADD COLUMN IF NOT EXISTS archived_by UUID;
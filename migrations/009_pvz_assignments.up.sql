@@ -0,0 +1,11 @@
+CREATE TABLE IF NOT EXISTS pvz_assignments (
+                        user_id UUID NOT NULL,
+                        pvz_id UUID NOT NULL,
+                        assigned_by UUID,
+                        assigned_at TIMESTAMP NOT NULL,
+                        PRIMARY KEY (user_id, pvz_id),
+                        CONSTRAINT fk_pvz_assignments_user FOREIGN KEY (user_id) REFERENCES users (id) ON DELETE CASCADE,
+                        CONSTRAINT fk_pvz_assignments_pvz FOREIGN KEY (pvz_id) REFERENCES pvz (id) ON DELETE CASCADE
+);
+
+CREATE INDEX IF NOT EXISTS idx_pvz_assignments_pvz ON pvz_assignments (pvz_id);
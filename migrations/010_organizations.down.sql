@@ -0,0 +1,6 @@
+DROP INDEX IF EXISTS idx_pvz_organization;
+ALTER TABLE IF EXISTS pvz DROP CONSTRAINT IF EXISTS fk_pvz_organization;
+ALTER TABLE IF EXISTS pvz DROP COLUMN IF EXISTS organization_id;
+ALTER TABLE IF EXISTS users DROP CONSTRAINT IF EXISTS fk_users_organization;
+ALTER TABLE IF EXISTS users DROP COLUMN IF EXISTS organization_id;
+DROP TABLE IF EXISTS organizations;
@@ -0,0 +1,20 @@
+CREATE TABLE IF NOT EXISTS organizations (
+                        id UUID PRIMARY KEY DEFAULT uuid_generate_v4(),
+                        name VARCHAR(100) UNIQUE NOT NULL,
+                        created_at TIMESTAMP NOT NULL DEFAULT now()
+);
+
+-- все существующие пользователи и ПВЗ попадают в организацию по умолчанию (model.DefaultOrganizationID)
+INSERT INTO organizations (id, name) VALUES
+                        ('5e0c9a4d-2f3b-4c8a-9b1d-7e6f5a4c3b2a', 'Основная сеть')
+ON CONFLICT (id) DO NOTHING;
+
+ALTER TABLE users
+    ADD COLUMN IF NOT EXISTS organization_id UUID NOT NULL DEFAULT '5e0c9a4d-2f3b-4c8a-9b1d-7e6f5a4c3b2a';
+ALTER TABLE users ADD CONSTRAINT fk_users_organization FOREIGN KEY (organization_id) REFERENCES organizations (id);
+
+ALTER TABLE pvz
+    ADD COLUMN IF NOT EXISTS organization_id UUID NOT NULL DEFAULT '5e0c9a4d-2f3b-4c8a-9b1d-7e6f5a4c3b2a';
+ALTER TABLE pvz ADD CONSTRAINT fk_pvz_organization FOREIGN KEY (organization_id) REFERENCES organizations (id);
+
+CREATE INDEX IF NOT EXISTS idx_pvz_organization ON pvz (organization_id);
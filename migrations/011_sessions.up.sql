@@ -0,0 +1,13 @@
+CREATE TABLE IF NOT EXISTS sessions (
+                        id UUID PRIMARY KEY DEFAULT uuid_generate_v4(),
+                        user_id UUID NOT NULL,
+                        refresh_token_hash VARCHAR(64) NOT NULL UNIQUE,
+                        previous_token_hash VARCHAR(64),
+                        created_at TIMESTAMP NOT NULL,
+                        expires_at TIMESTAMP NOT NULL,
+                        revoked_at TIMESTAMP,
+                        CONSTRAINT fk_sessions_user FOREIGN KEY (user_id) REFERENCES users (id) ON DELETE CASCADE
+);
+
+CREATE INDEX IF NOT EXISTS idx_sessions_user ON sessions (user_id);
+CREATE INDEX IF NOT EXISTS idx_sessions_previous_token_hash ON sessions (previous_token_hash);
@@ -0,0 +1,10 @@
+CREATE TABLE IF NOT EXISTS login_attempts (
+                        scope VARCHAR(16) NOT NULL,
+                        key VARCHAR(255) NOT NULL,
+                        failures INTEGER NOT NULL DEFAULT 0,
+                        last_failure_at TIMESTAMP NOT NULL,
+                        locked_until TIMESTAMP,
+                        PRIMARY KEY (scope, key)
+);
+
+CREATE INDEX IF NOT EXISTS idx_login_attempts_last_failure_at ON login_attempts (last_failure_at);
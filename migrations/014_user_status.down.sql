@@ -0,0 +1,2 @@
+DROP INDEX IF EXISTS idx_users_organization_email;
+ALTER TABLE IF EXISTS users DROP COLUMN IF EXISTS deactivated_at;
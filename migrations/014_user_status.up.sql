@@ -0,0 +1,3 @@
+ALTER TABLE users ADD COLUMN IF NOT EXISTS deactivated_at TIMESTAMP;
+
+CREATE INDEX IF NOT EXISTS idx_users_organization_email ON users (organization_id, email);
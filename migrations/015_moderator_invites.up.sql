@@ -0,0 +1,10 @@
+CREATE TABLE IF NOT EXISTS moderator_invites (
+                        id UUID PRIMARY KEY DEFAULT uuid_generate_v4(),
+                        organization_id UUID NOT NULL,
+                        code_hash VARCHAR(64) NOT NULL UNIQUE,
+                        created_by UUID NOT NULL,
+                        created_at TIMESTAMP NOT NULL,
+                        expires_at TIMESTAMP NOT NULL,
+                        used_at TIMESTAMP,
+                        CONSTRAINT fk_moderator_invites_organization FOREIGN KEY (organization_id) REFERENCES organizations (id) ON DELETE CASCADE
+);
@@ -0,0 +1,12 @@
+CREATE TABLE IF NOT EXISTS service_accounts (
+                        id UUID PRIMARY KEY DEFAULT uuid_generate_v4(),
+                        organization_id UUID NOT NULL,
+                        name VARCHAR(100) NOT NULL,
+                        key_hash VARCHAR(64) NOT NULL UNIQUE,
+                        scopes TEXT[] NOT NULL,
+                        created_by UUID NOT NULL,
+                        created_at TIMESTAMP NOT NULL,
+                        revoked_at TIMESTAMP,
+                        CONSTRAINT fk_service_accounts_organization FOREIGN KEY (organization_id) REFERENCES organizations (id) ON DELETE CASCADE
+);
+CREATE INDEX IF NOT EXISTS idx_service_accounts_organization ON service_accounts (organization_id, created_at);
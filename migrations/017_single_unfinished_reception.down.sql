@@ -0,0 +1 @@
+DROP INDEX IF EXISTS uq_receptions_pvz_unfinished;
@@ -0,0 +1,4 @@
+-- статусы in_progress (0) и paused (3) из model.UnfinishedReceptionStatuses.
+-- Если у ПВЗ уже несколько незавершённых приёмок, индекс не построится: их показывает go run ./cmd/integrity,
+-- лишние приёмки нужно завершить вручную
+CREATE UNIQUE INDEX IF NOT EXISTS uq_receptions_pvz_unfinished ON receptions (pvz_id) WHERE status IN (0, 3);
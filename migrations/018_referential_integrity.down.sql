@@ -0,0 +1,8 @@
+DROP INDEX IF EXISTS idx_products_reception_date_time;
+DROP INDEX IF EXISTS idx_receptions_pvz_status_date_time;
+DROP INDEX IF EXISTS idx_receptions_date_time;
+ALTER TABLE IF EXISTS users DROP CONSTRAINT IF EXISTS chk_users_role;
+ALTER TABLE IF EXISTS products DROP CONSTRAINT IF EXISTS chk_products_status;
+ALTER TABLE IF EXISTS receptions DROP CONSTRAINT IF EXISTS chk_receptions_status;
+ALTER TABLE IF EXISTS products DROP CONSTRAINT IF EXISTS fk_products_reception;
+ALTER TABLE IF EXISTS receptions DROP CONSTRAINT IF EXISTS fk_receptions_pvz;
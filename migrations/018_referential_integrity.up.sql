@@ -0,0 +1,14 @@
+-- висячие строки и неизвестные статусы не дадут добавить ограничения,
+-- перед миграцией на существующей базе запустите go run ./cmd/integrity (с -fix для удаления висячих строк)
+ALTER TABLE receptions ADD CONSTRAINT fk_receptions_pvz FOREIGN KEY (pvz_id) REFERENCES pvz (id);
+ALTER TABLE products ADD CONSTRAINT fk_products_reception FOREIGN KEY (reception_id) REFERENCES receptions (id);
+
+-- значения model.ReceptionStatus, model.ProductStatus и model.Role
+ALTER TABLE receptions ADD CONSTRAINT chk_receptions_status CHECK (status IN (0, 1, 2, 3));
+ALTER TABLE products ADD CONSTRAINT chk_products_status CHECK (status IN (0, 1, 2, 3));
+ALTER TABLE users ADD CONSTRAINT chk_users_role CHECK (role IN (0, 1));
+
+-- в 001_init индекс по receptions (date_time) получил имя idx_users_email и так и не был создан
+CREATE INDEX IF NOT EXISTS idx_receptions_date_time ON receptions (date_time);
+CREATE INDEX IF NOT EXISTS idx_receptions_pvz_status_date_time ON receptions (pvz_id, status, date_time);
+CREATE INDEX IF NOT EXISTS idx_products_reception_date_time ON products (reception_id, date_time);
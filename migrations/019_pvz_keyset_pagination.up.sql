@@ -0,0 +1,2 @@
+-- список ПВЗ листается курсором по (registration_date, id) внутри организации
+CREATE INDEX IF NOT EXISTS idx_pvz_organization_registration_date_id ON pvz (organization_id, registration_date, id);
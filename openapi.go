@@ -0,0 +1,11 @@
+package internshipPVZ
+
+import (
+	// для встраивания спецификации
+	_ "embed"
+)
+
+// OpenAPISpec спецификация HTTP API, которую отдаёт сервер
+//
+//go:embed swagger.yaml
+var OpenAPISpec []byte
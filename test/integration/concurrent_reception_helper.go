@@ -0,0 +1,81 @@
+// Package integration это интеграционное тестирование
+package integration
+
+import (
+	"bytes"
+	"encoding/json"
+	"github.com/gofiber/fiber/v2"
+	"github.com/stretchr/testify/assert"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+)
+
+// concurrentRequests число одновременных запросов к одному ПВЗ
+const concurrentRequests = 10
+
+// ConcurrentReceptionTest проверяет, что параллельные запросы не открывают у ПВЗ вторую приёмку
+func ConcurrentReceptionTest(t *testing.T, app *fiber.App) {
+	t.Run("concurrent receptions", func(t *testing.T) {
+
+		moderToken := loginModer(t, app)
+		pvzID := createPVZ(t, app, moderToken)
+		employeeID := registerEmployeeInOrganization(t, app, "concurrent.employee@mail.ru", "")
+		assignEmployee(t, app, moderToken, pvzID, employeeID)
+		employeeToken := loginAs(t, app, "concurrent.employee@mail.ru")
+
+		statuses := parallelStatuses(t, app, func() *http.Request {
+			body, _ := json.Marshal(map[string]string{"pvzId": pvzID})
+			req := httptest.NewRequest("POST", "/receptions", bytes.NewReader(body))
+			req.Header.Set("Authorization", "Bearer "+employeeToken)
+			req.Header.Set("Content-Type", "application/json")
+			return req
+		})
+		assert.Equal(t, 1, statuses[http.StatusCreated])
+		assert.Equal(t, concurrentRequests-1, statuses[http.StatusBadRequest])
+
+		t.Logf("opened single reception")
+
+		statuses = parallelStatuses(t, app, func() *http.Request {
+			req := httptest.NewRequest("POST", "/pvz/"+pvzID+"/close_last_reception", nil)
+			req.Header.Set("Authorization", "Bearer "+employeeToken)
+			return req
+		})
+		assert.Equal(t, 1, statuses[http.StatusOK])
+
+		// после закрытия индекс снова пропускает новую приёмку
+		createReception(t, app, employeeToken, pvzID)
+
+		t.Logf("closed and reopened reception")
+	})
+}
+
+// parallelStatuses отправляет concurrentRequests запросов одновременно и считает ответы по статусам
+func parallelStatuses(t *testing.T, app *fiber.App, newRequest func() *http.Request) map[int]int {
+	var (
+		wg       sync.WaitGroup
+		mu       sync.Mutex
+		start    = make(chan struct{})
+		statuses = make(map[int]int)
+	)
+	for i := 0; i < concurrentRequests; i++ {
+		req := newRequest()
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			<-start
+			// без таймаута: запросы ждут друг друга на блокировке ПВЗ
+			resp, err := app.Test(req, -1)
+			if !assert.NoError(t, err) {
+				return
+			}
+			mu.Lock()
+			statuses[resp.StatusCode]++
+			mu.Unlock()
+		}()
+	}
+	close(start)
+	wg.Wait()
+	return statuses
+}
@@ -4,13 +4,14 @@ package integration
 import (
 	_ "github.com/golang-migrate/migrate/v4/source/file"
 	"github.com/jmoiron/sqlx"
+	"internshipPVZ/internal/domain/model"
 )
 
 // NewTestAppConfig конструктор
 func NewTestAppConfig() *TestAppConfig {
 	return &TestAppConfig{
-		logLevel:  "dev",
-		jwtSecret: "07jvv08nv40v3t9t9y9[tvtq",
+		profile:  model.ProfileTest,
+		logLevel: "dev",
 		db: &DB{
 			host:         "localhost",
 			port:         "5434",
@@ -35,9 +36,14 @@ type DB struct {
 
 // TestAppConfig -
 type TestAppConfig struct {
-	logLevel  string
-	db        *DB
-	jwtSecret string
+	profile  model.Profile
+	logLevel string
+	db       *DB
+}
+
+// GetProfile возвращает профиль развёртывания.
+func (ac *TestAppConfig) GetProfile() model.Profile {
+	return ac.profile
 }
 
 // GetLogLevel возвращает уровень логирования.
@@ -85,7 +91,42 @@ func (ac *TestAppConfig) GetMigrationDir() string {
 	return ac.db.migrationDir
 }
 
-// GetJWTSecret возвращает секретный ключ JWT.
-func (ac *TestAppConfig) GetJWTSecret() string {
-	return ac.jwtSecret
+// GetJWTKeysDir в тестах ключи не задаются, сервис подписывает временным ключом.
+func (ac *TestAppConfig) GetJWTKeysDir() string {
+	return ""
+}
+
+// GetJWTActiveKeyID в тестах ключи не задаются, сервис подписывает временным ключом.
+func (ac *TestAppConfig) GetJWTActiveKeyID() string {
+	return ""
+}
+
+// GetNotifierOutboxFile в тестах уведомления перехватывает тестовый Notifier.
+func (ac *TestAppConfig) GetNotifierOutboxFile() string {
+	return ""
+}
+
+// GetPasswordHashAlgorithm в тестах используется алгоритм по умолчанию.
+func (ac *TestAppConfig) GetPasswordHashAlgorithm() string {
+	return ""
+}
+
+// GetArgon2Memory в тестах память уменьшена, чтобы вход не замедлял прогон.
+func (ac *TestAppConfig) GetArgon2Memory() uint32 {
+	return 8 * 1024
+}
+
+// GetArgon2Iterations в тестах достаточно одной итерации.
+func (ac *TestAppConfig) GetArgon2Iterations() uint32 {
+	return 1
+}
+
+// GetArgon2Parallelism в тестах argon2id считается в один поток.
+func (ac *TestAppConfig) GetArgon2Parallelism() uint8 {
+	return 1
+}
+
+// GetBcryptCost в тестах используется минимальный cost.
+func (ac *TestAppConfig) GetBcryptCost() int {
+	return 4
 }
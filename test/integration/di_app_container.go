@@ -7,7 +7,9 @@ import (
 	"github.com/jmoiron/sqlx"
 	"go.uber.org/fx"
 	"internshipPVZ/cmd/initdb"
+	"internshipPVZ/internal/domain/model"
 	repo "internshipPVZ/internal/domain/repository"
+	"internshipPVZ/internal/domain/repository/dao"
 	"internshipPVZ/internal/domain/service"
 	"internshipPVZ/internal/grpc/handler"
 	"internshipPVZ/internal/http"
@@ -28,7 +30,9 @@ func (am *TestAppModule) Invoke(t *testing.T) {
 		ModuleConfig(),
 		fx.Invoke(initializeDatabase),
 		Module(t),
-		fx.Invoke(registerHTTPServer),
+		fx.Invoke(fx.Annotate(
+			registerHTTPServer,
+			fx.ParamTags(``, ``, ``, `name:"prod"`))),
 	)
 
 	ctx, cancel := context.WithTimeout(context.Background(), 3*time.Second)
@@ -55,7 +59,8 @@ type AppConfig interface {
 	SetDbConnection(conn *sqlx.DB)
 	GetDbConnection() *sqlx.DB
 	GetMigrationDir() string
-	GetJWTSecret() string
+	GetJWTKeysDir() string
+	GetJWTActiveKeyID() string
 }
 
 func ModuleConfig() fx.Option {
@@ -64,6 +69,9 @@ func ModuleConfig() fx.Option {
 		fx.Provide(fx.Annotate(
 			NewTestAppConfig,
 			fx.As(new(service.JWTConfig)),
+			fx.As(new(service.HashConfig)),
+			fx.As(new(http.ProfileConfig)),
+			fx.As(new(handlers.ProfileConfig)),
 			fx.As(new(repository.Config)),
 			fx.As(new(AppConfig)),
 			fx.As(new(service.LoggerConfig)))),
@@ -74,10 +82,21 @@ func ModuleConfig() fx.Option {
 		fx.Provide(fx.Annotate(
 			service.NewJWTService,
 			fx.As(new(usecase.JWTService)),
+			fx.As(new(handlers.SigningKeyProvider)),
 			fx.As(new(http.JWTService)))),
 		fx.Provide(fx.Annotate(
 			service.NewHashService,
 			fx.As(new(usecase.HashService)))),
+		fx.Provide(fx.Annotate(
+			service.NewRefreshTokenService,
+			fx.As(new(usecase.RefreshTokenService)))),
+		fx.Provide(fx.Annotate(
+			service.NewRoleAuthorizer,
+			fx.As(new(usecase.Authorizer)))),
+		fx.Provide(fx.Annotate(
+			newRecordingNotifier,
+			fx.As(fx.Self()),
+			fx.As(new(usecase.Notifier)))),
 		fx.Provide(fx.Annotate(
 			service.NewSlogLogger,
 			fx.As(new(usecase.Logger)),
@@ -100,13 +119,50 @@ func Module(t *testing.T) fx.Option {
 		fx.Provide(fx.Annotate(
 			repository.NewReceptionRepo,
 			fx.As(new(repo.ReceptionRepo)))),
+		fx.Provide(fx.Annotate(
+			repository.NewTxManager,
+			fx.As(new(repo.TxManager)))),
+		fx.Provide(fx.Annotate(
+			repository.NewCityRepo,
+			fx.As(new(repo.CityRepo)))),
+		fx.Provide(fx.Annotate(
+			repository.NewProductTypeRepo,
+			fx.As(new(repo.ProductTypeRepo)))),
+		fx.Provide(fx.Annotate(
+			repository.NewPVZAssignmentRepo,
+			fx.As(new(repo.PVZAssignmentRepo)))),
+		fx.Provide(fx.Annotate(
+			repository.NewSessionRepo,
+			fx.As(new(repo.SessionRepo)))),
+		fx.Provide(fx.Annotate(
+			repository.NewLoginAttemptRepo,
+			fx.As(new(repo.LoginAttemptRepo)))),
+		fx.Provide(fx.Annotate(
+			repository.NewPasswordResetTokenRepo,
+			fx.As(new(repo.PasswordResetTokenRepo)))),
+		fx.Provide(fx.Annotate(
+			repository.NewModeratorInviteRepo,
+			fx.As(new(repo.ModeratorInviteRepo)))),
+		fx.Provide(fx.Annotate(
+			repository.NewServiceAccountRepo,
+			fx.As(new(repo.ServiceAccountRepo)))),
+		fx.Provide(fx.Annotate(
+			repository.NewOrganizationRepo,
+			fx.As(new(repo.OrganizationRepo)))),
 		// Регистрируем юзкейсы
 		fx.Provide(fx.Annotate(
 			usecase.NewUseCaseAuth,
-			fx.As(new(handlers.AuthUseCase)))),
+			fx.As(new(handlers.AuthUseCase)),
+			fx.As(new(http.SessionChecker)))),
 		fx.Provide(fx.Annotate(
 			usecase.NewUseCaseCreatePVZ,
 			fx.As(new(handlers.CreatePVZUseCase)))),
+		fx.Provide(fx.Annotate(
+			usecase.NewUseCaseUpdatePVZ,
+			fx.As(new(handlers.UpdatePVZUseCase)))),
+		fx.Provide(fx.Annotate(
+			usecase.NewUseCaseArchivePVZ,
+			fx.As(new(handlers.ArchivePVZUseCase)))),
 		fx.Provide(fx.Annotate(
 			usecase.NewUseCaseGetPvz,
 			fx.As(new(handlers.GetPVZUseCase)),
@@ -123,6 +179,50 @@ func Module(t *testing.T) fx.Option {
 		fx.Provide(fx.Annotate(
 			usecase.NewUseCaseCloseReception,
 			fx.As(new(handlers.CloseReceptionUseCase)))),
+		fx.Provide(fx.Annotate(
+			usecase.NewUseCaseCancelReception,
+			fx.As(new(handlers.CancelReceptionUseCase)))),
+		fx.Provide(fx.Annotate(
+			usecase.NewUseCasePauseReception,
+			fx.As(new(handlers.PauseReceptionUseCase)))),
+		fx.Provide(fx.Annotate(
+			usecase.NewUseCaseResumeReception,
+			fx.As(new(handlers.ResumeReceptionUseCase)))),
+		fx.Provide(fx.Annotate(
+			usecase.NewUseCaseStoreProduct,
+			fx.As(new(handlers.StoreProductUseCase)))),
+		fx.Provide(fx.Annotate(
+			usecase.NewUseCaseIssueProduct,
+			fx.As(new(handlers.IssueProductUseCase)))),
+		fx.Provide(fx.Annotate(
+			usecase.NewUseCaseReturnProduct,
+			fx.As(new(handlers.ReturnProductUseCase)))),
+		fx.Provide(fx.Annotate(
+			usecase.NewUseCaseGetInventory,
+			fx.As(new(handlers.GetInventoryUseCase)))),
+		fx.Provide(fx.Annotate(
+			usecase.NewUseCaseManageCity,
+			fx.As(new(handlers.CityUseCase)))),
+		fx.Provide(fx.Annotate(
+			usecase.NewUseCaseManageProductType,
+			fx.As(new(handlers.ProductTypeUseCase)))),
+		fx.Provide(fx.Annotate(
+			usecase.NewUseCaseManagePVZAssignment,
+			fx.As(new(handlers.PVZAssignmentUseCase)))),
+		fx.Provide(fx.Annotate(
+			usecase.NewUseCaseManagePassword,
+			fx.As(new(handlers.PasswordUseCase)))),
+		fx.Provide(fx.Annotate(
+			usecase.NewUseCaseManageUsers,
+			fx.As(new(handlers.UserUseCase)))),
+		fx.Provide(usecase.NewUseCaseBootstrapModerator),
+		fx.Provide(fx.Annotate(
+			usecase.NewUseCaseManageModeratorInvite,
+			fx.As(new(handlers.ModeratorInviteUseCase)))),
+		fx.Provide(fx.Annotate(
+			usecase.NewUseCaseManageServiceAccounts,
+			fx.As(new(handlers.ServiceAccountUseCase)),
+			fx.As(new(http.APIKeyChecker)))),
 		// Регистрируем http хэндлеры
 		fx.Provide(fx.Annotate(
 			handlers.NewProductController,
@@ -136,15 +236,69 @@ func Module(t *testing.T) fx.Option {
 		fx.Provide(fx.Annotate(
 			handlers.NewReceptionController,
 			fx.As(new(http.ReceptionController)))),
+		fx.Provide(fx.Annotate(
+			handlers.NewCityController,
+			fx.As(new(http.CityController)))),
+		fx.Provide(fx.Annotate(
+			handlers.NewProductTypeController,
+			fx.As(new(http.ProductTypeController)))),
+		fx.Provide(fx.Annotate(
+			handlers.NewPVZAssignmentController,
+			fx.As(new(http.PVZAssignmentController)))),
+		fx.Provide(fx.Annotate(
+			handlers.NewPasswordController,
+			fx.As(new(http.PasswordController)))),
+		fx.Provide(fx.Annotate(
+			handlers.NewUserController,
+			fx.As(new(http.UserController)))),
+		fx.Provide(fx.Annotate(
+			handlers.NewModeratorInviteController,
+			fx.As(new(http.ModeratorInviteController)))),
+		fx.Provide(fx.Annotate(
+			handlers.NewServiceAccountController,
+			fx.As(new(http.ServiceAccountController)))),
+		fx.Provide(fx.Annotate(
+			handlers.NewJWKSController,
+			fx.As(new(http.JWKSController)))),
+		fx.Provide(fx.Annotate(
+			handlers.NewOpenAPIController,
+			fx.As(new(http.OpenAPIController)))),
 		// Регистрируем тест
 		fx.Provide(
 			ProvideTest(t)),
 		// Регистрируем http сервер приложения
 		fx.Provide(fx.Annotate(
 			http.NewHTTPServer)),
+		// Регистрируем второй http сервер с prod профилем, чтобы проверить выключенные в нём эндпоинты
+		fx.Provide(fx.Annotate(
+			newProdProfile,
+			fx.As(new(http.ProfileConfig)),
+			fx.As(new(handlers.ProfileConfig)),
+			fx.ResultTags(`name:"prod"`, `name:"prod"`))),
+		fx.Provide(fx.Annotate(
+			handlers.NewOpenAPIController,
+			fx.ParamTags(`name:"prod"`),
+			fx.As(new(http.OpenAPIController)),
+			fx.ResultTags(`name:"prod"`))),
+		fx.Provide(fx.Annotate(
+			http.NewHTTPServer,
+			fx.ParamTags(`name:"prod"`, ``, ``, ``, ``, ``, ``, ``, ``, ``, ``, ``, ``, `name:"prod"`),
+			fx.ResultTags(`name:"prod"`))),
 	)
 }
 
+// staticProfile профиль, не зависящий от остального конфига
+type staticProfile model.Profile
+
+// GetProfile возвращает профиль развёртывания.
+func (p staticProfile) GetProfile() model.Profile {
+	return model.Profile(p)
+}
+
+func newProdProfile() staticProfile {
+	return staticProfile(model.ProfileProd)
+}
+
 func ProvideTest(t *testing.T) func() *testing.T {
 	return func() *testing.T {
 		return t
@@ -166,18 +320,68 @@ func initializeDatabase(cfg AppConfig) {
 	}
 }
 
-func registerHTTPServer(t *testing.T, lc fx.Lifecycle, testApp *fiber.App, cfg AppConfig) {
+func registerHTTPServer(
+	t *testing.T,
+	lc fx.Lifecycle,
+	testApp *fiber.App,
+	prodApp *fiber.App,
+	cfg AppConfig,
+	organizationRepo repo.OrganizationRepo,
+	notifier *recordingNotifier,
+	bootstrap *usecase.BootstrapModerator,
+) {
 	if testApp == nil {
 		log.Fatalf("registerHTTPServer failed: HttpServer is nil")
 	}
+	if prodApp == nil {
+		log.Fatalf("registerHTTPServer failed: prod HttpServer is nil")
+	}
 	if cfg == nil {
 		log.Fatalf("registerHTTPServer failed: AppConfig is nil")
 	}
+	if organizationRepo == nil {
+		log.Fatalf("registerHTTPServer failed: OrganizationRepo is nil")
+	}
+	if notifier == nil {
+		log.Fatalf("registerHTTPServer failed: Notifier is nil")
+	}
+	if bootstrap == nil {
+		log.Fatalf("registerHTTPServer failed: BootstrapModerator is nil")
+	}
+	// модераторы регистрируются только по приглашениям, первого создаём так же, как утилита cmd/moderator
+	if _, err := bootstrap.Execute(context.Background(), "vl@mail.ru", "123456789", ""); err != nil {
+		t.Fatalf("Failed to bootstrap moderator: %v", err)
+	}
 	FullFlowTest(t, testApp)
 
+	partner := &dao.Organization{Name: "Партнёрская сеть"}
+	if err := organizationRepo.Create(context.Background(), partner); err != nil {
+		t.Fatalf("Failed to create partner organization: %v", err)
+	}
+	if _, err := bootstrap.Execute(context.Background(), "partner@mail.ru", "123456789", partner.ID); err != nil {
+		t.Fatalf("Failed to bootstrap partner moderator: %v", err)
+	}
+	TenantIsolationTest(t, testApp, partner.ID)
+	SessionFlowTest(t, testApp)
+	JWKSTest(t, testApp)
+	LoginLockoutTest(t, testApp)
+	PasswordRehashTest(t, testApp, cfg)
+	PasswordFlowTest(t, testApp, notifier)
+	UserAdminTest(t, testApp)
+	ModeratorInviteTest(t, testApp, partner.ID)
+	ServiceAccountTest(t, testApp)
+	ConcurrentReceptionTest(t, testApp)
+	PVZPaginationTest(t, testApp)
+	PVZDateFilterTest(t, testApp)
+	IntegrityTest(t, cfg)
+	ProfileTest(t, testApp, prodApp)
+
 	if err := testApp.Shutdown(); err != nil {
 		t.Errorf("Failed to shutdown Fiber app: %v", err)
 	}
+	if err := prodApp.Shutdown(); err != nil {
+		t.Errorf("Failed to shutdown prod Fiber app: %v", err)
+	}
 
 	err := initdb.DropDBTables(cfg)
 	if err != nil {
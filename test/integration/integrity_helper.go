@@ -0,0 +1,33 @@
+// Package integration это интеграционное тестирование
+package integration
+
+import (
+	"context"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"internshipPVZ/internal/repository"
+	"testing"
+	"time"
+)
+
+// IntegrityTest проверяет, что после всех сценариев в базе нет висячих строк, а новые не вставляются
+func IntegrityTest(t *testing.T, cfg AppConfig) {
+	t.Run("referential integrity", func(t *testing.T) {
+		violations, err := repository.NewIntegrityRepo(cfg).FindViolations(context.Background())
+		assert.NoError(t, err)
+		assert.NotEmpty(t, violations)
+		for _, violation := range violations {
+			assert.Zero(t, violation.Count, violation.Check)
+		}
+
+		db := cfg.GetDbConnection()
+		_, err = db.Exec(`INSERT INTO receptions (pvz_id, date_time, status) VALUES ($1, $2, 0)`, uuid.NewString(), time.Now())
+		assert.ErrorContains(t, err, "fk_receptions_pvz")
+		_, err = db.Exec(`INSERT INTO products (reception_id, date_time, type) VALUES ($1, $2, 0)`, uuid.NewString(), time.Now())
+		assert.ErrorContains(t, err, "fk_products_reception")
+		_, err = db.Exec(`UPDATE receptions SET status = 42 WHERE id = (SELECT id FROM receptions LIMIT 1)`)
+		assert.ErrorContains(t, err, "chk_receptions_status")
+
+		t.Logf("checked constraints")
+	})
+}
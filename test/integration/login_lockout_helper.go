@@ -0,0 +1,56 @@
+// Package integration это интеграционное тестирование
+package integration
+
+import (
+	"bytes"
+	"encoding/json"
+	"github.com/gofiber/fiber/v2"
+	"github.com/stretchr/testify/assert"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"testing"
+)
+
+// LoginLockoutTest проверяет блокировку входа после серии неверных паролей
+func LoginLockoutTest(t *testing.T, app *fiber.App) {
+	t.Run("login lockout", func(t *testing.T) {
+
+		registerEmployeeInOrganization(t, app, "locked@mail.ru", "")
+
+		for i := 0; i < 5; i++ {
+			resp := loginWithPassword(t, app, "locked@mail.ru", "wrongPassword")
+			assert.Equal(t, http.StatusUnauthorized, resp.StatusCode)
+		}
+
+		t.Logf("failed logins registered")
+
+		// после блокировки не проходит даже верный пароль
+		resp := loginWithPassword(t, app, "LOCKED@mail.ru", "123456789")
+		assert.Equal(t, http.StatusTooManyRequests, resp.StatusCode)
+		retryAfter, err := strconv.Atoi(resp.Header.Get(fiber.HeaderRetryAfter))
+		assert.NoError(t, err)
+		assert.Greater(t, retryAfter, 0)
+
+		t.Logf("login locked out")
+
+		// блокировка по email не задевает другие аккаунты с того же адреса
+		resp = loginWithPassword(t, app, "vl@mail.ru", "123456789")
+		assert.Equal(t, http.StatusOK, resp.StatusCode)
+	})
+}
+
+func loginWithPassword(t *testing.T, app *fiber.App, email, password string) *http.Response {
+	body, _ := json.Marshal(map[string]string{
+		"email":    email,
+		"password": password,
+	})
+
+	req := httptest.NewRequest("POST", "/login", bytes.NewReader(body))
+	req.Header.Set("Content-Type", "application/json")
+	resp, err := app.Test(req)
+	if err != nil {
+		t.Fatalf("Failed to login: %v", err)
+	}
+	return resp
+}
@@ -0,0 +1,125 @@
+// Package integration это интеграционное тестирование
+package integration
+
+import (
+	"bytes"
+	"encoding/json"
+	"github.com/gofiber/fiber/v2"
+	"github.com/stretchr/testify/assert"
+	"internshipPVZ/internal/domain/model"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+// ModeratorInviteTest проверяет, что модератором можно стать только по одноразовому приглашению
+func ModeratorInviteTest(t *testing.T, app *fiber.App, partnerOrganizationID string) {
+	t.Run("moderator invites", func(t *testing.T) {
+
+		assert.Equal(t, http.StatusForbidden, registerStatus(t, app, map[string]string{
+			"email":    "self.moder@mail.ru",
+			"password": "123456789",
+			"role":     "moderator",
+		}))
+		assert.Equal(t, http.StatusBadRequest, registerStatus(t, app, map[string]string{
+			"email":      "self.moder@mail.ru",
+			"password":   "123456789",
+			"role":       "moderator",
+			"inviteCode": "made-up-code",
+		}))
+
+		t.Logf("checked self registration")
+
+		registerEmployeeInOrganization(t, app, "invite.employee@mail.ru", "")
+		employeeToken := loginAs(t, app, "invite.employee@mail.ru")
+		createInviteStatus(t, app, employeeToken, http.StatusForbidden)
+
+		partnerToken := loginAs(t, app, "partner@mail.ru")
+		invite := createInviteStatus(t, app, partnerToken, http.StatusCreated)
+		assert.Equal(t, partnerOrganizationID, invite.OrganizationID)
+
+		// приглашение в чужую организацию не гасится и остаётся рабочим
+		assert.Equal(t, http.StatusBadRequest, registerStatus(t, app, map[string]string{
+			"email":          "invited.partner@mail.ru",
+			"password":       "123456789",
+			"role":           "moderator",
+			"organizationId": model.DefaultOrganizationID,
+			"inviteCode":     invite.Code,
+		}))
+		assert.Equal(t, http.StatusCreated, registerStatus(t, app, map[string]string{
+			"email":      "invited.partner@mail.ru",
+			"password":   "123456789",
+			"role":       "moderator",
+			"inviteCode": invite.Code,
+		}))
+		assert.Equal(t, http.StatusBadRequest, registerStatus(t, app, map[string]string{
+			"email":      "invited.again@mail.ru",
+			"password":   "123456789",
+			"role":       "moderator",
+			"inviteCode": invite.Code,
+		}))
+
+		invitedToken := loginAs(t, app, "invited.partner@mail.ru")
+		partnerPVZID := createPVZ(t, app, invitedToken)
+		assert.Contains(t, getPVZIDs(t, app, partnerToken), partnerPVZID)
+
+		t.Logf("registered invited moderator")
+	})
+}
+
+type moderatorInvite struct {
+	Code           string `json:"code"`
+	OrganizationID string `json:"organizationId"`
+}
+
+// registerModerWithInvite регистрирует модератора по приглашению от inviterToken, возвращает ID
+func registerModerWithInvite(t *testing.T, app *fiber.App, inviterToken, email string) string {
+	invite := createInviteStatus(t, app, inviterToken, http.StatusCreated)
+	body, _ := json.Marshal(map[string]string{
+		"email":      email,
+		"password":   "123456789",
+		"role":       "moderator",
+		"inviteCode": invite.Code,
+	})
+
+	req := httptest.NewRequest("POST", "/register", bytes.NewReader(body))
+	req.Header.Set("Content-Type", "application/json")
+	resp, err := app.Test(req)
+	if err != nil {
+		t.Fatalf("Failed to register moderator: %v", err)
+	}
+	assert.Equal(t, http.StatusCreated, resp.StatusCode)
+
+	var result struct {
+		ID string `json:"id"`
+	}
+	json.NewDecoder(resp.Body).Decode(&result)
+	return result.ID
+}
+
+func createInviteStatus(t *testing.T, app *fiber.App, token string, expectedStatus int) moderatorInvite {
+	req := httptest.NewRequest("POST", "/moderator-invites", nil)
+	req.Header.Set("Authorization", "Bearer "+token)
+
+	resp, err := app.Test(req)
+	assert.NoError(t, err)
+	assert.Equal(t, expectedStatus, resp.StatusCode)
+
+	var invite moderatorInvite
+	if resp.StatusCode == http.StatusCreated {
+		json.NewDecoder(resp.Body).Decode(&invite)
+	}
+	return invite
+}
+
+func registerStatus(t *testing.T, app *fiber.App, payload map[string]string) int {
+	body, _ := json.Marshal(payload)
+
+	req := httptest.NewRequest("POST", "/register", bytes.NewReader(body))
+	req.Header.Set("Content-Type", "application/json")
+	resp, err := app.Test(req)
+	if err != nil {
+		t.Fatalf("Failed to register user: %v", err)
+	}
+	return resp.StatusCode
+}
@@ -0,0 +1,104 @@
+// Package integration это интеграционное тестирование
+package integration
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"github.com/gofiber/fiber/v2"
+	"github.com/stretchr/testify/assert"
+	"internshipPVZ/internal/domain/model"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+)
+
+// recordingNotifier запоминает отправленные токены сброса пароля вместо доставки
+type recordingNotifier struct {
+	mu     sync.Mutex
+	tokens map[string]string
+}
+
+func newRecordingNotifier() *recordingNotifier {
+	return &recordingNotifier{tokens: make(map[string]string)}
+}
+
+// SendPasswordReset запоминает последний токен для email
+func (n *recordingNotifier) SendPasswordReset(_ context.Context, notification model.PasswordResetNotification) error {
+	n.mu.Lock()
+	defer n.mu.Unlock()
+	n.tokens[notification.Email] = notification.Token
+	return nil
+}
+
+func (n *recordingNotifier) lastToken(email string) string {
+	n.mu.Lock()
+	defer n.mu.Unlock()
+	return n.tokens[email]
+}
+
+// PasswordFlowTest проверяет смену пароля и сброс по одноразовому токену
+func PasswordFlowTest(t *testing.T, app *fiber.App, notifier *recordingNotifier) {
+	t.Run("password flow", func(t *testing.T) {
+
+		registerEmployeeInOrganization(t, app, "password@mail.ru", "")
+		accessToken, _ := loginWithSession(t, app, "password@mail.ru")
+		otherToken, _ := loginWithSession(t, app, "password@mail.ru")
+
+		postPasswordStatus(t, app, accessToken, "/password/change", map[string]string{
+			"currentPassword": "wrongPassword",
+			"newPassword":     "newPassword1",
+		}, http.StatusBadRequest)
+		postPasswordStatus(t, app, accessToken, "/password/change", map[string]string{
+			"currentPassword": "123456789",
+			"newPassword":     "newPassword1",
+		}, http.StatusOK)
+
+		// текущая сессия остаётся, остальные отзываются
+		getPVZListStatus(t, app, accessToken, http.StatusOK)
+		getPVZListStatus(t, app, otherToken, http.StatusUnauthorized)
+		assert.Equal(t, http.StatusOK, loginWithPassword(t, app, "password@mail.ru", "newPassword1").StatusCode)
+
+		t.Logf("changed password")
+
+		postPasswordStatus(t, app, "", "/password/reset/request", map[string]string{
+			"email": "nobody@mail.ru",
+		}, http.StatusOK)
+		assert.Empty(t, notifier.lastToken("nobody@mail.ru"))
+
+		postPasswordStatus(t, app, "", "/password/reset/request", map[string]string{
+			"email": "password@mail.ru",
+		}, http.StatusOK)
+		resetToken := notifier.lastToken("password@mail.ru")
+		assert.NotEmpty(t, resetToken)
+
+		postPasswordStatus(t, app, "", "/password/reset", map[string]string{
+			"token":       resetToken,
+			"newPassword": "123456789",
+		}, http.StatusOK)
+		getPVZListStatus(t, app, accessToken, http.StatusUnauthorized)
+		loginWithSession(t, app, "password@mail.ru")
+
+		// токен одноразовый
+		postPasswordStatus(t, app, "", "/password/reset", map[string]string{
+			"token":       resetToken,
+			"newPassword": "anotherPassword1",
+		}, http.StatusBadRequest)
+
+		t.Logf("reset password")
+	})
+}
+
+func postPasswordStatus(t *testing.T, app *fiber.App, token, path string, payload map[string]string, expectedStatus int) {
+	body, _ := json.Marshal(payload)
+
+	req := httptest.NewRequest("POST", path, bytes.NewReader(body))
+	req.Header.Set("Content-Type", "application/json")
+	if token != "" {
+		req.Header.Set("Authorization", "Bearer "+token)
+	}
+	resp, err := app.Test(req)
+	assert.NoError(t, err)
+	assert.Equal(t, expectedStatus, resp.StatusCode)
+}
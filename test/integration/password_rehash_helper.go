@@ -0,0 +1,45 @@
+// Package integration это интеграционное тестирование
+package integration
+
+import (
+	"github.com/gofiber/fiber/v2"
+	"github.com/stretchr/testify/assert"
+	"golang.org/x/crypto/bcrypt"
+	"net/http"
+	"strings"
+	"testing"
+)
+
+// PasswordRehashTest проверяет, что хэш bcrypt, оставшийся от старых версий, после входа пересчитывается в argon2id
+func PasswordRehashTest(t *testing.T, app *fiber.App, cfg AppConfig) {
+	t.Run("password rehash", func(t *testing.T) {
+		email := "rehash.employee@mail.ru"
+		registerEmployeeInOrganization(t, app, email, "")
+		assert.True(t, strings.HasPrefix(storedPasswordHash(t, cfg, email), "$argon2id$"))
+
+		legacyHash, err := bcrypt.GenerateFromPassword([]byte("123456789"), bcrypt.MinCost)
+		assert.NoError(t, err)
+		_, err = cfg.GetDbConnection().Exec(`UPDATE users SET password = $1 WHERE email = $2`, string(legacyHash), email)
+		assert.NoError(t, err)
+
+		assert.Equal(t, http.StatusUnauthorized, loginWithPassword(t, app, email, "987654321").StatusCode)
+		assert.Equal(t, string(legacyHash), storedPasswordHash(t, cfg, email))
+
+		loginAs(t, app, email)
+		upgradedHash := storedPasswordHash(t, cfg, email)
+		assert.True(t, strings.HasPrefix(upgradedHash, "$argon2id$v=19$m=8192,t=1,p=1$"))
+
+		// повторный вход проверяет пароль по новому хэшу и больше его не меняет
+		loginAs(t, app, email)
+		assert.Equal(t, upgradedHash, storedPasswordHash(t, cfg, email))
+
+		t.Logf("upgraded bcrypt hash")
+	})
+}
+
+func storedPasswordHash(t *testing.T, cfg AppConfig, email string) string {
+	var hash string
+	err := cfg.GetDbConnection().Get(&hash, `SELECT password FROM users WHERE email = $1`, email)
+	assert.NoError(t, err)
+	return hash
+}
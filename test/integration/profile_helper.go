@@ -0,0 +1,54 @@
+// Package integration это интеграционное тестирование
+package integration
+
+import (
+	"bytes"
+	"github.com/gofiber/fiber/v2"
+	"github.com/stretchr/testify/assert"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+// ProfileTest проверяет, что /dummyLogin есть только вне prod профиля
+func ProfileTest(t *testing.T, testApp *fiber.App, prodApp *fiber.App) {
+	t.Run("profiles", func(t *testing.T) {
+
+		assert.Equal(t, http.StatusOK, dummyLoginStatus(t, testApp))
+		assert.Contains(t, getOpenAPISpec(t, testApp), "/dummyLogin:")
+
+		t.Logf("dummyLogin enabled in test profile")
+
+		assert.NotEqual(t, http.StatusOK, dummyLoginStatus(t, prodApp))
+		spec := getOpenAPISpec(t, prodApp)
+		assert.NotContains(t, spec, "/dummyLogin:")
+		assert.Contains(t, spec, "/login:")
+
+		t.Logf("dummyLogin disabled in prod profile")
+	})
+}
+
+func dummyLoginStatus(t *testing.T, app *fiber.App) int {
+	req := httptest.NewRequest("POST", "/dummyLogin", bytes.NewReader([]byte(`{"role":"employee"}`)))
+	req.Header.Set("Content-Type", "application/json")
+	resp, err := app.Test(req)
+	if err != nil {
+		t.Fatalf("Failed to call dummyLogin: %v", err)
+	}
+	return resp.StatusCode
+}
+
+func getOpenAPISpec(t *testing.T, app *fiber.App) string {
+	req := httptest.NewRequest("GET", "/openapi.yaml", nil)
+	resp, err := app.Test(req)
+	if err != nil {
+		t.Fatalf("Failed to get openapi spec: %v", err)
+	}
+	assert.Equal(t, http.StatusOK, resp.StatusCode)
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		t.Fatalf("Failed to read response body: %v", err)
+	}
+	return string(body)
+}
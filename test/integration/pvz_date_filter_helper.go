@@ -0,0 +1,136 @@
+// Package integration это интеграционное тестирование
+package integration
+
+import (
+	"encoding/json"
+	"github.com/gofiber/fiber/v2"
+	"github.com/stretchr/testify/assert"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"testing"
+	"time"
+)
+
+// PVZDateFilterTest проверяет оба режима фильтра по датам приёмок: в режиме all ПВЗ без приёмок
+// в диапазоне остаются в списке, в режиме withReceptions пропадают, а страницы при этом не укорачиваются
+func PVZDateFilterTest(t *testing.T, app *fiber.App) {
+	t.Run("pvz date filter modes", func(t *testing.T) {
+
+		moderToken := loginModer(t, app)
+		employeeID := registerEmployeeInOrganization(t, app, "date-filter-employee@mail.ru", "")
+		employeeToken := loginAs(t, app, "date-filter-employee@mail.ru")
+
+		emptyPVZID := createPVZ(t, app, moderToken)
+		busyPVZID := createPVZ(t, app, moderToken)
+		assignEmployee(t, app, moderToken, busyPVZID, employeeID)
+		receptionID := createReception(t, app, employeeToken, busyPVZID)
+
+		t.Logf("created pvz with and without reception")
+
+		// диапазон с запасом в день, чтобы не зависеть от часового пояса базы
+		now := time.Now()
+		current := url.Values{
+			"startDate": {now.AddDate(0, 0, -1).Format(time.DateOnly)},
+			"endDate":   {now.AddDate(0, 0, 1).Format(time.DateOnly)},
+		}
+		past := url.Values{"startDate": {"2000-01-01"}, "endDate": {"2000-01-31"}}
+
+		receptions, _ := listAllPVZReceptions(t, app, moderToken, withMode(current, "all"))
+		assert.Contains(t, receptions, emptyPVZID)
+		assert.Empty(t, receptions[emptyPVZID])
+		assert.Equal(t, []string{receptionID}, receptions[busyPVZID])
+
+		receptions, _ = listAllPVZReceptions(t, app, moderToken, withMode(past, "all"))
+		assert.Contains(t, receptions, emptyPVZID)
+		assert.Contains(t, receptions, busyPVZID)
+		assert.Empty(t, receptions[busyPVZID])
+
+		// без mode действует all
+		receptions, _ = listAllPVZReceptions(t, app, moderToken, past)
+		assert.Contains(t, receptions, busyPVZID)
+
+		t.Logf("checked mode all")
+
+		receptions, pageSizes := listAllPVZReceptions(t, app, moderToken, withMode(current, "withReceptions"))
+		assert.NotContains(t, receptions, emptyPVZID)
+		assert.Equal(t, []string{receptionID}, receptions[busyPVZID])
+		for id, pvzReceptions := range receptions {
+			assert.NotEmpty(t, pvzReceptions, id)
+		}
+		// пагинация после фильтрации: неполной может быть только последняя страница
+		for i, size := range pageSizes {
+			if i < len(pageSizes)-1 {
+				assert.Equal(t, 2, size)
+			}
+		}
+
+		receptions, _ = listAllPVZReceptions(t, app, moderToken, withMode(past, "withReceptions"))
+		assert.Empty(t, receptions)
+
+		t.Logf("checked mode withReceptions")
+
+		invalid := withMode(current, "withoutReceptions")
+		ids, _ := getPVZPage(t, app, moderToken, invalid, http.StatusBadRequest)
+		assert.Empty(t, ids)
+
+		t.Logf("checked invalid mode")
+	})
+}
+
+func withMode(query url.Values, mode string) url.Values {
+	result := url.Values{"mode": {mode}}
+	for key, values := range query {
+		result[key] = values
+	}
+	return result
+}
+
+// listAllPVZReceptions проходит курсором весь список по две записи на странице.
+// Возвращает ID приёмок каждого ПВЗ и размеры страниц
+func listAllPVZReceptions(t *testing.T, app *fiber.App, token string, query url.Values) (map[string][]string, []int) {
+	receptions := make(map[string][]string)
+	var pageSizes []int
+	cursor := ""
+	for pages := 0; ; pages++ {
+		if pages > 100 {
+			t.Fatalf("cursor pagination does not end")
+		}
+		pageQuery := url.Values{"limit": {"2"}, "cursor": {cursor}}
+		for key, values := range query {
+			pageQuery[key] = values
+		}
+
+		req := httptest.NewRequest("GET", "/pvz?"+pageQuery.Encode(), nil)
+		req.Header.Set("Authorization", "Bearer "+token)
+		resp, err := app.Test(req)
+		assert.NoError(t, err)
+		assert.Equal(t, http.StatusOK, resp.StatusCode)
+
+		var result []struct {
+			PVZ struct {
+				ID string `json:"id"`
+			} `json:"pvz"`
+			Receptions []struct {
+				Reception struct {
+					ID string `json:"id"`
+				} `json:"reception"`
+			} `json:"receptions"`
+		}
+		json.NewDecoder(resp.Body).Decode(&result)
+
+		pageSizes = append(pageSizes, len(result))
+		for _, item := range result {
+			ids := make([]string, 0, len(item.Receptions))
+			for _, reception := range item.Receptions {
+				ids = append(ids, reception.Reception.ID)
+			}
+			receptions[item.PVZ.ID] = ids
+		}
+
+		cursor = resp.Header.Get("X-Next-Cursor")
+		if cursor == "" {
+			return receptions, pageSizes
+		}
+	}
+}
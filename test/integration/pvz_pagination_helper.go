@@ -0,0 +1,91 @@
+// Package integration это интеграционное тестирование
+package integration
+
+import (
+	"encoding/json"
+	"github.com/gofiber/fiber/v2"
+	"github.com/stretchr/testify/assert"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"sort"
+	"testing"
+)
+
+// PVZPaginationTest проверяет, что курсор проходит список ПВЗ без пропусков и повторов,
+// а устаревшие page и limit продолжают работать
+func PVZPaginationTest(t *testing.T, app *fiber.App) {
+	t.Run("pvz cursor pagination", func(t *testing.T) {
+
+		moderToken := loginModer(t, app)
+		employeeID := registerEmployeeInOrganization(t, app, "pagination-employee@mail.ru", "")
+		employeeToken := loginAs(t, app, "pagination-employee@mail.ru")
+
+		created := make([]string, 0, 5)
+		for range 5 {
+			pvzID := createPVZ(t, app, moderToken)
+			assignEmployee(t, app, moderToken, pvzID, employeeID)
+			_ = createReception(t, app, employeeToken, pvzID)
+			created = append(created, pvzID)
+		}
+
+		t.Logf("created pvz with receptions")
+
+		seen := make(map[string]int)
+		var listed []string
+		cursor := ""
+		for pages := 0; ; pages++ {
+			if pages > 100 {
+				t.Fatalf("cursor pagination does not end")
+			}
+			ids, next := getPVZPage(t, app, moderToken, url.Values{"limit": {"2"}, "cursor": {cursor}}, http.StatusOK)
+			assert.LessOrEqual(t, len(ids), 2)
+			for _, id := range ids {
+				seen[id]++
+			}
+			listed = append(listed, ids...)
+			if next == "" {
+				break
+			}
+			cursor = next
+		}
+		for _, id := range created {
+			assert.Equal(t, 1, seen[id], id)
+		}
+		// все ПВЗ зарегистрированы сегодня, поэтому порядок задаёт ID
+		assert.True(t, sort.StringsAreSorted(listed))
+
+		t.Logf("walked pvz list by cursor")
+
+		_, next := getPVZPage(t, app, moderToken, url.Values{"page": {"1"}, "limit": {"2"}}, http.StatusOK)
+		assert.NotEmpty(t, next)
+		ids, next := getPVZPage(t, app, moderToken, url.Values{"cursor": {"made-up-cursor"}}, http.StatusBadRequest)
+		assert.Empty(t, ids)
+		assert.Empty(t, next)
+
+		t.Logf("checked deprecated page and invalid cursor")
+	})
+}
+
+// getPVZPage возвращает ID ПВЗ из ответа и курсор следующей страницы
+func getPVZPage(t *testing.T, app *fiber.App, token string, query url.Values, expectedStatus int) ([]string, string) {
+	req := httptest.NewRequest("GET", "/pvz?"+query.Encode(), nil)
+	req.Header.Set("Authorization", "Bearer "+token)
+
+	resp, err := app.Test(req)
+	assert.NoError(t, err)
+	assert.Equal(t, expectedStatus, resp.StatusCode)
+
+	var result []struct {
+		PVZ struct {
+			ID string `json:"id"`
+		} `json:"pvz"`
+	}
+	json.NewDecoder(resp.Body).Decode(&result)
+
+	ids := make([]string, 0, len(result))
+	for _, item := range result {
+		ids = append(ids, item.PVZ.ID)
+	}
+	return ids, resp.Header.Get("X-Next-Cursor")
+}
@@ -0,0 +1,129 @@
+// Package integration это интеграционное тестирование
+package integration
+
+import (
+	"bytes"
+	"encoding/json"
+	"github.com/gofiber/fiber/v2"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+type serviceAccount struct {
+	ID     string   `json:"id"`
+	Scopes []string `json:"scopes"`
+	Active bool     `json:"active"`
+	APIKey string   `json:"apiKey"`
+}
+
+// ServiceAccountTest проверяет, что API-ключ пускает только в маршруты своих областей действия
+func ServiceAccountTest(t *testing.T, app *fiber.App) {
+	t.Run("service accounts", func(t *testing.T) {
+
+		moderToken := loginModer(t, app)
+		employeeID := registerEmployeeInOrganization(t, app, "service.employee@mail.ru", "")
+		employeeToken := loginAs(t, app, "service.employee@mail.ru")
+		pvzID := createPVZ(t, app, moderToken)
+		assignEmployee(t, app, moderToken, pvzID, employeeID)
+		createReception(t, app, employeeToken, pvzID)
+
+		createServiceAccountStatus(t, app, employeeToken, []string{"products:add"}, http.StatusForbidden)
+		createServiceAccountStatus(t, app, moderToken, []string{"pvz:create"}, http.StatusBadRequest)
+		account := createServiceAccountStatus(t, app, moderToken, []string{"products:add"}, http.StatusCreated)
+		assert.NotEmpty(t, account.APIKey)
+		assert.Equal(t, []string{"products:add"}, account.Scopes)
+
+		t.Logf("created service account")
+
+		// ключ не закреплён за ПВЗ, но добавлять товары может в любой ПВЗ организации
+		addProductWithKeyStatus(t, app, account.APIKey, pvzID, http.StatusCreated)
+		apiKeyRequestStatus(t, app, account.APIKey, "GET", "/pvz", http.StatusForbidden)
+		apiKeyRequestStatus(t, app, account.APIKey, "POST", "/pvz/"+pvzID+"/close_last_reception", http.StatusForbidden)
+		apiKeyRequestStatus(t, app, account.APIKey, "GET", "/service-accounts", http.StatusForbidden)
+		addProductWithKeyStatus(t, app, "made-up-key", pvzID, http.StatusUnauthorized)
+
+		t.Logf("checked API key scopes")
+
+		listed := listServiceAccounts(t, app, moderToken)
+		assert.Contains(t, listed, serviceAccount{ID: account.ID, Scopes: account.Scopes, Active: true})
+		partnerToken := loginAs(t, app, "partner@mail.ru")
+		serviceAccountActionStatus(t, app, partnerToken, account.ID, http.StatusNotFound)
+		revoked := serviceAccountActionStatus(t, app, moderToken, account.ID, http.StatusOK)
+		assert.False(t, revoked.Active)
+		addProductWithKeyStatus(t, app, account.APIKey, pvzID, http.StatusUnauthorized)
+
+		t.Logf("revoked service account")
+	})
+}
+
+func createServiceAccountStatus(t *testing.T, app *fiber.App, token string, scopes []string, expectedStatus int) serviceAccount {
+	body, _ := json.Marshal(map[string]any{"name": "Сканер склада", "scopes": scopes})
+
+	req := httptest.NewRequest("POST", "/service-accounts", bytes.NewReader(body))
+	req.Header.Set("Authorization", "Bearer "+token)
+	req.Header.Set("Content-Type", "application/json")
+	resp, err := app.Test(req)
+	assert.NoError(t, err)
+	assert.Equal(t, expectedStatus, resp.StatusCode)
+
+	var account serviceAccount
+	if resp.StatusCode == http.StatusCreated {
+		json.NewDecoder(resp.Body).Decode(&account)
+	}
+	return account
+}
+
+func listServiceAccounts(t *testing.T, app *fiber.App, token string) []serviceAccount {
+	req := httptest.NewRequest("GET", "/service-accounts", nil)
+	req.Header.Set("Authorization", "Bearer "+token)
+
+	resp, err := app.Test(req)
+	assert.NoError(t, err)
+	assert.Equal(t, http.StatusOK, resp.StatusCode)
+
+	var accounts []serviceAccount
+	json.NewDecoder(resp.Body).Decode(&accounts)
+	return accounts
+}
+
+func serviceAccountActionStatus(t *testing.T, app *fiber.App, token, accountID string, expectedStatus int) serviceAccount {
+	req := httptest.NewRequest("POST", "/service-accounts/"+accountID+"/revoke", nil)
+	req.Header.Set("Authorization", "Bearer "+token)
+
+	resp, err := app.Test(req)
+	assert.NoError(t, err)
+	assert.Equal(t, expectedStatus, resp.StatusCode)
+
+	var account serviceAccount
+	if resp.StatusCode == http.StatusOK {
+		json.NewDecoder(resp.Body).Decode(&account)
+	}
+	return account
+}
+
+func addProductWithKeyStatus(t *testing.T, app *fiber.App, apiKey, pvzID string, expectedStatus int) {
+	body, _ := json.Marshal(map[string]string{
+		"type":    "электроника",
+		"pvzId":   pvzID,
+		"barcode": uuid.NewString(),
+	})
+
+	req := httptest.NewRequest("POST", "/products", bytes.NewReader(body))
+	req.Header.Set("X-API-Key", apiKey)
+	req.Header.Set("Content-Type", "application/json")
+	resp, err := app.Test(req)
+	assert.NoError(t, err)
+	assert.Equal(t, expectedStatus, resp.StatusCode)
+}
+
+func apiKeyRequestStatus(t *testing.T, app *fiber.App, apiKey, method, path string, expectedStatus int) {
+	req := httptest.NewRequest(method, path, nil)
+	req.Header.Set("X-API-Key", apiKey)
+
+	resp, err := app.Test(req)
+	assert.NoError(t, err)
+	assert.Equal(t, expectedStatus, resp.StatusCode)
+}
@@ -0,0 +1,150 @@
+// Package integration это интеграционное тестирование
+package integration
+
+import (
+	"bytes"
+	"encoding/base64"
+	"encoding/json"
+	"github.com/gofiber/fiber/v2"
+	"github.com/stretchr/testify/assert"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+// SessionFlowTest проверяет обновление токенов и отзыв сессий
+func SessionFlowTest(t *testing.T, app *fiber.App) {
+	t.Run("session flow", func(t *testing.T) {
+
+		accessToken, refreshToken := loginWithSession(t, app, "vl@mail.ru")
+		getPVZListStatus(t, app, accessToken, http.StatusOK)
+
+		refreshedToken, rotatedRefreshToken := refreshSession(t, app, refreshToken, http.StatusOK)
+		assert.NotEqual(t, refreshToken, rotatedRefreshToken)
+		getPVZListStatus(t, app, refreshedToken, http.StatusOK)
+
+		t.Logf("refreshed session")
+
+		// повторное использование старого refresh токена отзывает всю сессию
+		refreshSession(t, app, refreshToken, http.StatusUnauthorized)
+		refreshSession(t, app, rotatedRefreshToken, http.StatusUnauthorized)
+		getPVZListStatus(t, app, refreshedToken, http.StatusUnauthorized)
+
+		t.Logf("revoked reused session")
+
+		accessToken, refreshToken = loginWithSession(t, app, "vl@mail.ru")
+		logout(t, app, accessToken)
+		getPVZListStatus(t, app, accessToken, http.StatusUnauthorized)
+		refreshSession(t, app, refreshToken, http.StatusUnauthorized)
+
+		t.Logf("logged out")
+	})
+}
+
+// JWKSTest проверяет, что ключ подписи выданного токена опубликован в JWKS
+func JWKSTest(t *testing.T, app *fiber.App) {
+	t.Run("jwks", func(t *testing.T) {
+
+		accessToken, _ := loginWithSession(t, app, "vl@mail.ru")
+
+		req := httptest.NewRequest("GET", "/.well-known/jwks.json", nil)
+		resp, err := app.Test(req)
+		assert.NoError(t, err)
+		assert.Equal(t, http.StatusOK, resp.StatusCode)
+
+		var jwks struct {
+			Keys []struct {
+				Kid string `json:"kid"`
+				Alg string `json:"alg"`
+			} `json:"keys"`
+		}
+		json.NewDecoder(resp.Body).Decode(&jwks)
+
+		var header struct {
+			Kid string `json:"kid"`
+			Alg string `json:"alg"`
+		}
+		headerBytes, err := base64.RawURLEncoding.DecodeString(strings.Split(accessToken, ".")[0])
+		assert.NoError(t, err)
+		json.Unmarshal(headerBytes, &header)
+
+		found := false
+		for _, key := range jwks.Keys {
+			if key.Kid == header.Kid {
+				found = true
+				assert.Equal(t, header.Alg, key.Alg)
+			}
+		}
+		assert.True(t, found, "signing key %q is missing in JWKS", header.Kid)
+	})
+}
+
+func loginWithSession(t *testing.T, app *fiber.App, email string) (string, string) {
+	loginReq := map[string]string{
+		"email":    email,
+		"password": "123456789",
+	}
+	body, _ := json.Marshal(loginReq)
+
+	req := httptest.NewRequest("POST", "/login", bytes.NewReader(body))
+	req.Header.Set("Content-Type", "application/json")
+	resp, err := app.Test(req)
+	if err != nil {
+		t.Fatalf("Failed to get token: %v", err)
+	}
+	assert.Equal(t, http.StatusOK, resp.StatusCode)
+
+	var token string
+	json.NewDecoder(resp.Body).Decode(&token)
+
+	return token, refreshTokenFromCookie(t, resp)
+}
+
+func refreshSession(t *testing.T, app *fiber.App, refreshToken string, expectedStatus int) (string, string) {
+	body, _ := json.Marshal(map[string]string{"refreshToken": refreshToken})
+
+	req := httptest.NewRequest("POST", "/token/refresh", bytes.NewReader(body))
+	req.Header.Set("Content-Type", "application/json")
+	resp, err := app.Test(req)
+	assert.NoError(t, err)
+	assert.Equal(t, expectedStatus, resp.StatusCode)
+	if expectedStatus != http.StatusOK {
+		return "", ""
+	}
+
+	var token string
+	json.NewDecoder(resp.Body).Decode(&token)
+
+	return token, refreshTokenFromCookie(t, resp)
+}
+
+func logout(t *testing.T, app *fiber.App, token string) {
+	req := httptest.NewRequest("POST", "/logout", nil)
+	req.Header.Set("Authorization", "Bearer "+token)
+
+	resp, err := app.Test(req)
+	assert.NoError(t, err)
+	assert.Equal(t, http.StatusOK, resp.StatusCode)
+}
+
+func getPVZListStatus(t *testing.T, app *fiber.App, token string, expectedStatus int) {
+	req := httptest.NewRequest("GET", "/pvz?page=1&limit=10", nil)
+	req.Header.Set("Authorization", "Bearer "+token)
+
+	resp, err := app.Test(req)
+	assert.NoError(t, err)
+	assert.Equal(t, expectedStatus, resp.StatusCode)
+	_, _ = io.Copy(io.Discard, resp.Body)
+}
+
+func refreshTokenFromCookie(t *testing.T, resp *http.Response) string {
+	for _, cookie := range resp.Cookies() {
+		if cookie.Name == "refresh_token" {
+			return cookie.Value
+		}
+	}
+	t.Errorf("refresh_token cookie is missing")
+	return ""
+}
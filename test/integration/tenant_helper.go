@@ -0,0 +1,189 @@
+// Package integration это интеграционное тестирование
+package integration
+
+import (
+	"bytes"
+	"encoding/json"
+	"github.com/gofiber/fiber/v2"
+	"github.com/stretchr/testify/assert"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+// TenantIsolationTest проверяет, что организации не видят и не меняют ПВЗ друг друга.
+// Модератор partner@mail.ru создаётся заранее как первый модератор партнёрской организации
+func TenantIsolationTest(t *testing.T, app *fiber.App, partnerOrganizationID string) {
+	t.Run("tenant isolation", func(t *testing.T) {
+
+		moderToken := loginModer(t, app)
+		employeeID := registerEmployeeInOrganization(t, app, "own-employee@mail.ru", "")
+		employeeToken := loginAs(t, app, "own-employee@mail.ru")
+		partnerToken := loginAs(t, app, "partner@mail.ru")
+		partnerEmployeeID := registerEmployeeInOrganization(t, app, "partner-employee@mail.ru", partnerOrganizationID)
+
+		t.Logf("got tokens")
+
+		ownPVZID := createPVZ(t, app, moderToken)
+		partnerPVZID := createPVZ(t, app, partnerToken)
+
+		t.Logf("created pvz in both organizations")
+
+		ownList := getPVZIDs(t, app, moderToken)
+		assert.Contains(t, ownList, ownPVZID)
+		assert.NotContains(t, ownList, partnerPVZID)
+
+		partnerList := getPVZIDs(t, app, partnerToken)
+		assert.Contains(t, partnerList, partnerPVZID)
+		assert.NotContains(t, partnerList, ownPVZID)
+
+		t.Logf("checked pvz lists")
+
+		updatePVZStatus(t, app, moderToken, partnerPVZID, http.StatusNotFound)
+		archivePVZStatus(t, app, moderToken, partnerPVZID, http.StatusNotFound)
+		assignEmployeeStatus(t, app, moderToken, partnerPVZID, employeeID, http.StatusNotFound)
+		assignEmployeeStatus(t, app, partnerToken, partnerPVZID, employeeID, http.StatusNotFound)
+		assignEmployeeStatus(t, app, moderToken, ownPVZID, partnerEmployeeID, http.StatusNotFound)
+
+		t.Logf("checked foreign pvz management")
+
+		assignEmployee(t, app, moderToken, ownPVZID, employeeID)
+		openReceptionStatus(t, app, employeeToken, partnerPVZID, http.StatusBadRequest)
+		_ = createReception(t, app, employeeToken, ownPVZID)
+
+		t.Logf("checked foreign pvz receptions")
+	})
+}
+
+func registerEmployeeInOrganization(t *testing.T, app *fiber.App, email, organizationID string) string {
+	return registerInOrganization(t, app, email, "employee", organizationID)
+}
+
+// registerInOrganization регистрирует пользователя, пустой organizationID означает организацию по умолчанию
+func registerInOrganization(t *testing.T, app *fiber.App, email, role, organizationID string) string {
+	registerReq := map[string]string{
+		"email":    email,
+		"password": "123456789",
+		"role":     role,
+	}
+	if organizationID != "" {
+		registerReq["organizationId"] = organizationID
+	}
+	body, _ := json.Marshal(registerReq)
+
+	req := httptest.NewRequest("POST", "/register", bytes.NewReader(body))
+	req.Header.Set("Content-Type", "application/json")
+	resp, err := app.Test(req)
+	if err != nil {
+		t.Fatalf("Failed to register user: %v", err)
+	}
+
+	var result struct {
+		ID             string `json:"id"`
+		Email          string `json:"email"`
+		Role           string `json:"role"`
+		OrganizationID string `json:"organizationId"`
+	}
+
+	json.NewDecoder(resp.Body).Decode(&result)
+
+	assert.Equal(t, http.StatusCreated, resp.StatusCode)
+	assert.Equal(t, email, result.Email)
+	assert.Equal(t, role, result.Role)
+	if organizationID != "" {
+		assert.Equal(t, organizationID, result.OrganizationID)
+	}
+
+	return result.ID
+}
+
+func loginAs(t *testing.T, app *fiber.App, email string) string {
+	loginReq := map[string]string{
+		"email":    email,
+		"password": "123456789",
+	}
+	body, _ := json.Marshal(loginReq)
+
+	req := httptest.NewRequest("POST", "/login", bytes.NewReader(body))
+	req.Header.Set("Content-Type", "application/json")
+	resp, err := app.Test(req)
+	if err != nil {
+		t.Fatalf("Failed to get token: %v", err)
+	}
+
+	bodyBytes, err := io.ReadAll(resp.Body)
+	if err != nil {
+		t.Fatalf("Failed to read response body: %v", err)
+	}
+
+	return string(bodyBytes)
+}
+
+func getPVZIDs(t *testing.T, app *fiber.App, token string) []string {
+	req := httptest.NewRequest("GET", "/pvz?page=1&limit=30", nil)
+	req.Header.Set("Authorization", "Bearer "+token)
+
+	resp, err := app.Test(req)
+	assert.NoError(t, err)
+	assert.Equal(t, http.StatusOK, resp.StatusCode)
+
+	var result []struct {
+		PVZ struct {
+			ID string `json:"id"`
+		} `json:"pvz"`
+	}
+
+	json.NewDecoder(resp.Body).Decode(&result)
+
+	ids := make([]string, 0, len(result))
+	for _, item := range result {
+		ids = append(ids, item.PVZ.ID)
+	}
+	return ids
+}
+
+func updatePVZStatus(t *testing.T, app *fiber.App, token string, pvzID string, expectedStatus int) {
+	body, _ := json.Marshal(map[string]string{"name": "Чужой ПВЗ"})
+
+	req := httptest.NewRequest("PATCH", "/pvz/"+pvzID, bytes.NewReader(body))
+	req.Header.Set("Authorization", "Bearer "+token)
+	req.Header.Set("Content-Type", "application/json")
+
+	resp, err := app.Test(req)
+	assert.NoError(t, err)
+	assert.Equal(t, expectedStatus, resp.StatusCode)
+}
+
+func archivePVZStatus(t *testing.T, app *fiber.App, token string, pvzID string, expectedStatus int) {
+	req := httptest.NewRequest("POST", "/pvz/"+pvzID+"/archive", nil)
+	req.Header.Set("Authorization", "Bearer "+token)
+
+	resp, err := app.Test(req)
+	assert.NoError(t, err)
+	assert.Equal(t, expectedStatus, resp.StatusCode)
+}
+
+func assignEmployeeStatus(t *testing.T, app *fiber.App, token string, pvzID, userID string, expectedStatus int) {
+	body, _ := json.Marshal(map[string]string{"userId": userID})
+
+	req := httptest.NewRequest("POST", "/pvz/"+pvzID+"/assignments", bytes.NewReader(body))
+	req.Header.Set("Authorization", "Bearer "+token)
+	req.Header.Set("Content-Type", "application/json")
+
+	resp, err := app.Test(req)
+	assert.NoError(t, err)
+	assert.Equal(t, expectedStatus, resp.StatusCode)
+}
+
+func openReceptionStatus(t *testing.T, app *fiber.App, token string, pvzID string, expectedStatus int) {
+	body, _ := json.Marshal(map[string]string{"pvzId": pvzID})
+
+	req := httptest.NewRequest("POST", "/receptions", bytes.NewReader(body))
+	req.Header.Set("Authorization", "Bearer "+token)
+	req.Header.Set("Content-Type", "application/json")
+
+	resp, err := app.Test(req)
+	assert.NoError(t, err)
+	assert.Equal(t, expectedStatus, resp.StatusCode)
+}
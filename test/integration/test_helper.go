@@ -17,9 +17,10 @@ import (
 func FullFlowTest(t *testing.T, app *fiber.App) {
 	t.Run("full flow", func(t *testing.T) {
 
-		registerModer(t, app)
 		moderToken := loginModer(t, app)
-		employeeToken := dummyLoginEmployee(t, app)
+		employeeID := registerEmployee(t, app)
+		employeeToken := loginEmployee(t, app)
+		strangerToken := dummyLoginEmployee(t, app)
 
 		t.Logf("got tokens")
 
@@ -27,6 +28,12 @@ func FullFlowTest(t *testing.T, app *fiber.App) {
 
 		t.Logf("created pvz")
 
+		assignEmployee(t, app, moderToken, pvzID, employeeID)
+
+		t.Logf("assigned employee")
+
+		createReceptionDenied(t, app, strangerToken, pvzID)
+
 		_ = createReception(t, app, employeeToken, pvzID)
 
 		t.Logf("created reception")
@@ -65,21 +72,44 @@ func dummyLoginModer(t *testing.T, app *fiber.App) string {
 	return token
 }
 
-func registerModer(t *testing.T, app *fiber.App) {
+func loginModer(t *testing.T, app *fiber.App) string {
 	loginReq := map[string]string{
 		"email":    "vl@mail.ru",
 		"password": "123456789",
-		"role":     "moderator",
 	}
 	body, _ := json.Marshal(loginReq)
 
-	req := httptest.NewRequest("POST", "/register", bytes.NewReader(body))
+	req := httptest.NewRequest("POST", "/login", bytes.NewReader(body))
 	req.Header.Set("Content-Type", "application/json")
 	resp, err := app.Test(req)
 	if err != nil {
 		t.Fatalf("Failed to get token: %v", err)
 	}
 
+	bodyBytes, err := io.ReadAll(resp.Body)
+	if err != nil {
+		t.Fatalf("Failed to read response body: %v", err)
+	}
+
+	token := string(bodyBytes)
+	return token
+}
+
+func registerEmployee(t *testing.T, app *fiber.App) string {
+	loginReq := map[string]string{
+		"email":    "employee@mail.ru",
+		"password": "123456789",
+		"role":     "employee",
+	}
+	body, _ := json.Marshal(loginReq)
+
+	req := httptest.NewRequest("POST", "/register", bytes.NewReader(body))
+	req.Header.Set("Content-Type", "application/json")
+	resp, err := app.Test(req)
+	if err != nil {
+		t.Fatalf("Failed to register employee: %v", err)
+	}
+
 	var result struct {
 		ID    string `json:"id"`
 		Email string `json:"email"`
@@ -88,14 +118,16 @@ func registerModer(t *testing.T, app *fiber.App) {
 
 	json.NewDecoder(resp.Body).Decode(&result)
 
-	assert.Equal(t, "vl@mail.ru", result.Email)
+	assert.Equal(t, "employee@mail.ru", result.Email)
+
+	assert.Equal(t, "employee", result.Role)
 
-	assert.Equal(t, "moderator", result.Role)
+	return result.ID
 }
 
-func loginModer(t *testing.T, app *fiber.App) string {
+func loginEmployee(t *testing.T, app *fiber.App) string {
 	loginReq := map[string]string{
-		"email":    "vl@mail.ru",
+		"email":    "employee@mail.ru",
 		"password": "123456789",
 	}
 	body, _ := json.Marshal(loginReq)
@@ -191,6 +223,36 @@ func createReception(t *testing.T, app *fiber.App, token string, pvzID string) s
 	return result.ID
 }
 
+func assignEmployee(t *testing.T, app *fiber.App, token string, pvzID, userID string) {
+	reqBody := map[string]string{
+		"userId": userID,
+	}
+	body, _ := json.Marshal(reqBody)
+
+	req := httptest.NewRequest("POST", "/pvz/"+pvzID+"/assignments", bytes.NewReader(body))
+	req.Header.Set("Authorization", "Bearer "+token)
+	req.Header.Set("Content-Type", "application/json")
+
+	resp, err := app.Test(req)
+	assert.NoError(t, err)
+	assert.Equal(t, http.StatusCreated, resp.StatusCode)
+}
+
+func createReceptionDenied(t *testing.T, app *fiber.App, token string, pvzID string) {
+	reqBody := map[string]string{
+		"pvzId": pvzID,
+	}
+	body, _ := json.Marshal(reqBody)
+
+	req := httptest.NewRequest("POST", "/receptions", bytes.NewReader(body))
+	req.Header.Set("Authorization", "Bearer "+token)
+	req.Header.Set("Content-Type", "application/json")
+
+	resp, err := app.Test(req)
+	assert.NoError(t, err)
+	assert.Equal(t, http.StatusForbidden, resp.StatusCode)
+}
+
 func createProduct(t *testing.T, app *fiber.App, token string, pvzID string) string {
 	productTypes := []string{"электроника", "одежда", "обувь"}
 	productType := productTypes[time.Now().UnixNano()%3]
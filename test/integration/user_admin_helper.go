@@ -0,0 +1,102 @@
+// Package integration это интеграционное тестирование
+package integration
+
+import (
+	"bytes"
+	"encoding/json"
+	"github.com/gofiber/fiber/v2"
+	"github.com/stretchr/testify/assert"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+type adminUser struct {
+	ID     string `json:"id"`
+	Email  string `json:"email"`
+	Role   string `json:"role"`
+	Active bool   `json:"active"`
+}
+
+// UserAdminTest проверяет поиск пользователей, смену роли и деактивацию модератором
+func UserAdminTest(t *testing.T, app *fiber.App) {
+	t.Run("user administration", func(t *testing.T) {
+
+		inviterToken := loginModer(t, app)
+		registerModerWithInvite(t, app, inviterToken, "useradmin.moder@mail.ru")
+		targetID := registerModerWithInvite(t, app, inviterToken, "useradmin.target@mail.ru")
+		moderToken, _ := loginWithSession(t, app, "useradmin.moder@mail.ru")
+		targetToken, targetRefresh := loginWithSession(t, app, "useradmin.target@mail.ru")
+
+		users := listUsers(t, app, moderToken, "/users?search=USERADMIN.&limit=1", http.StatusOK)
+		assert.Len(t, users, 1)
+		assert.Equal(t, "useradmin.moder@mail.ru", users[0].Email)
+		users = listUsers(t, app, moderToken, "/users?search=useradmin.&page=2&limit=1", http.StatusOK)
+		assert.Len(t, users, 1)
+		assert.Equal(t, targetID, users[0].ID)
+		assert.True(t, users[0].Active)
+		listUsers(t, app, targetToken, "/users?search=useradmin_", http.StatusOK)
+		assert.Empty(t, listUsers(t, app, moderToken, "/users?search=useradmin_", http.StatusOK))
+
+		t.Logf("listed users")
+
+		user := userAdminAction(t, app, moderToken, "PUT", "/users/"+targetID+"/role", map[string]string{"role": "employee"}, http.StatusOK)
+		assert.Equal(t, "employee", user.Role)
+		// старые токены несут прежнюю роль, поэтому сессии отозваны
+		getPVZListStatus(t, app, targetToken, http.StatusUnauthorized)
+		targetToken, targetRefresh = loginWithSession(t, app, "useradmin.target@mail.ru")
+		listUsers(t, app, targetToken, "/users", http.StatusForbidden)
+
+		t.Logf("demoted moderator")
+
+		userAdminAction(t, app, targetToken, "POST", "/users/"+targetID+"/deactivate", nil, http.StatusForbidden)
+		user = userAdminAction(t, app, moderToken, "POST", "/users/"+targetID+"/deactivate", nil, http.StatusOK)
+		assert.False(t, user.Active)
+		getPVZListStatus(t, app, targetToken, http.StatusUnauthorized)
+		refreshSession(t, app, targetRefresh, http.StatusUnauthorized)
+		assert.Equal(t, http.StatusForbidden, loginWithPassword(t, app, "useradmin.target@mail.ru", "123456789").StatusCode)
+
+		t.Logf("deactivated user")
+
+		user = userAdminAction(t, app, moderToken, "POST", "/users/"+targetID+"/reactivate", nil, http.StatusOK)
+		assert.True(t, user.Active)
+		loginWithSession(t, app, "useradmin.target@mail.ru")
+
+		t.Logf("reactivated user")
+	})
+}
+
+func listUsers(t *testing.T, app *fiber.App, token, path string, expectedStatus int) []adminUser {
+	req := httptest.NewRequest("GET", path, nil)
+	req.Header.Set("Authorization", "Bearer "+token)
+
+	resp, err := app.Test(req)
+	assert.NoError(t, err)
+	assert.Equal(t, expectedStatus, resp.StatusCode)
+
+	var users []adminUser
+	if resp.StatusCode == http.StatusOK {
+		json.NewDecoder(resp.Body).Decode(&users)
+	}
+	return users
+}
+
+func userAdminAction(t *testing.T, app *fiber.App, token, method, path string, payload map[string]string, expectedStatus int) adminUser {
+	var body []byte
+	if payload != nil {
+		body, _ = json.Marshal(payload)
+	}
+
+	req := httptest.NewRequest(method, path, bytes.NewReader(body))
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("Authorization", "Bearer "+token)
+	resp, err := app.Test(req)
+	assert.NoError(t, err)
+	assert.Equal(t, expectedStatus, resp.StatusCode)
+
+	var user adminUser
+	if resp.StatusCode == http.StatusOK {
+		json.NewDecoder(resp.Body).Decode(&user)
+	}
+	return user
+}